@@ -4,8 +4,10 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"time"
 
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/handler"
@@ -14,6 +16,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/proc"
 	"github.com/zeromicro/go-zero/rest"
 )
 
@@ -28,12 +31,23 @@ func main() {
 
 	var c config.Config
 	conf.MustLoad(*configFile, &c, conf.UseEnv())
-	
+
+	// 收到退出信号后先下线 WebSocket 连接，期间 HTTP 服务继续响应就绪探针，下线完成后再关闭 HTTP 服务
+	if wrapUp := c.Websocket.DrainTimeout + time.Second; c.Shutdown.WrapUpTime < wrapUp {
+		c.Shutdown.WrapUpTime = wrapUp
+	}
+	if wait := c.Shutdown.WrapUpTime + 5*time.Second; c.Shutdown.WaitTime < wait {
+		c.Shutdown.WaitTime = wait
+	}
+
 	server := rest.MustNewServer(c.RestConf)
 	defer server.Stop()
 
 	ctx := svc.NewServiceContext(c)
 	handler.RegisterHandlers(server, ctx)
+	proc.AddWrapUpListener(func() {
+		ctx.WsManager.Drain(context.Background())
+	})
 
 	fmt.Printf("Starting server at %s:%d...\n", c.RestConf.Host, c.RestConf.Port)
 	server.Start()
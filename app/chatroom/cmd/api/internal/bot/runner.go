@@ -0,0 +1,501 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+	"go-zero-voice-agent/pkg/uniqueid"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultSystemPrompt = "你是聊天室中的智能助手。对话记录中每行以发言者开头，请根据上下文简洁地回答最后提及你的用户。"
+	pendingKeyPrefix    = "chatroom:bot:pending:"
+	messageTypeChat     = "chat"
+)
+
+// StreamEvent 机器人回复的增量分片（bot_stream），Done 之后完整回复作为 chat 消息发送，data 中带相同的 streamId
+type StreamEvent struct {
+	StreamID string `json:"streamId"`
+	Bot      string `json:"bot"` // 机器人的用户 id
+	Name     string `json:"name"`
+	Delta    string `json:"delta,omitempty"`
+	Done     bool   `json:"done,omitempty"`
+}
+
+// ToolConfirmEvent 需要用户确认的工具调用（bot_tool_confirm），客户端展示为可操作的消息
+type ToolConfirmEvent struct {
+	ConfirmID string         `json:"confirmId"`
+	Bot       string         `json:"bot"`
+	Name      string         `json:"name"`
+	Requester string         `json:"requester"` // 只有提及机器人的用户可以确认
+	ToolCalls []ToolCallInfo `json:"toolCalls"`
+}
+
+type ToolCallInfo struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Arguments   string `json:"arguments"`
+	Description string `json:"description"`
+}
+
+// ToolReply 客户端 bot_tool_reply 消息的 data
+type ToolReply struct {
+	ConfirmID string `json:"confirmId"`
+	Approved  bool   `json:"approved"`
+}
+
+// pendingConfirm 等待确认的工具调用，保存在 Redis 中，确认消息可以由任意节点处理
+type pendingConfirm struct {
+	BotId          int64          `json:"botId"`
+	Room           string         `json:"room"`
+	Requester      string         `json:"requester"`
+	ConversationId string         `json:"conversationId"`
+	ToolCalls      []*pb.ToolCall `json:"toolCalls"`
+}
+
+// Messenger 机器人向聊天室投递消息，由 WsManager 实现
+type Messenger interface {
+	Publish(msg *types.Message) error
+	Push(msg *types.Message)
+	PushError(userID string, err error)
+}
+
+// ChatStreamer LLM 流式对话
+type ChatStreamer interface {
+	ChatStream(ctx context.Context, in *llmchatservice.ChatStreamReq, opts ...grpc.CallOption) (pb.LlmChatService_ChatStreamClient, error)
+}
+
+// ConfigGetter 查询 LLM 配置
+type ConfigGetter interface {
+	GetConfig(ctx context.Context, in *llmconfigservice.GetConfigReq, opts ...grpc.CallOption) (*llmconfigservice.GetConfigResp, error)
+}
+
+// turn 机器人的一次回复：房间消息 room 非空，私聊时回复给 requester
+type turn struct {
+	bot       *model.Bot
+	room      string
+	requester string
+}
+
+// Runner 在用户私聊机器人或在房间中 @机器人 时调用 LLM 回复
+type Runner struct {
+	ctx       context.Context
+	conf      config.BotConf
+	store     Store
+	messenger Messenger
+	llmChat   ChatStreamer
+	llmConfig ConfigGetter
+	redis     *redis.Redis
+	logx      logx.Logger
+}
+
+func NewRunner(ctx context.Context, conf config.BotConf, store Store, messenger Messenger,
+	llmChat ChatStreamer, llmConfig ConfigGetter, rds *redis.Redis) *Runner {
+	return &Runner{
+		ctx:       ctx,
+		conf:      conf,
+		store:     store,
+		messenger: messenger,
+		llmChat:   llmChat,
+		llmConfig: llmConfig,
+		redis:     rds,
+		logx:      logx.WithContext(ctx),
+	}
+}
+
+// OnMessage 私聊机器人或在房间中提及机器人时异步回复
+func (r *Runner) OnMessage(msg *types.Message) {
+	if msg.Type != messageTypeChat {
+		return
+	}
+	fromUserId, err := strconv.ParseInt(msg.From, 10, 64)
+	if err != nil || model.IsBotUserId(fromUserId) {
+		return
+	}
+	trigger := *msg
+
+	if msg.Room == "" {
+		toUserId, err := strconv.ParseInt(msg.To, 10, 64)
+		if err != nil || !model.IsBotUserId(toUserId) {
+			return
+		}
+		go r.replyDirect(trigger, -toUserId)
+		return
+	}
+
+	text, mentions := messageContent(msg.Data)
+	if !strings.Contains(text, "@") && len(mentions) == 0 {
+		return
+	}
+	go r.replyRoom(trigger, text, mentions)
+}
+
+// OnToolReply 提及机器人的用户确认或拒绝工具调用后继续对话
+func (r *Runner) OnToolReply(userID string, msg *types.Message) error {
+	var reply ToolReply
+	if err := decodeData(msg.Data, &reply); err != nil || reply.ConfirmID == "" {
+		return xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	key := pendingKeyPrefix + reply.ConfirmID
+	val, err := r.redis.GetCtx(r.ctx, key)
+	if err != nil {
+		return fmt.Errorf("get pending confirm %s failed: %w", reply.ConfirmID, err)
+	}
+	if val == "" {
+		return xerr.NewErrCode(xerr.BOT_CONFIRM_EXPIRED_ERROR)
+	}
+	var pending pendingConfirm
+	if err := json.Unmarshal([]byte(val), &pending); err != nil {
+		return fmt.Errorf("decode pending confirm %s failed: %w", reply.ConfirmID, err)
+	}
+	if pending.Requester != userID {
+		return xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+	// 删除成功的节点才继续对话，避免重复确认
+	if n, err := r.redis.DelCtx(r.ctx, key); err != nil || n == 0 {
+		return xerr.NewErrCode(xerr.BOT_CONFIRM_EXPIRED_ERROR)
+	}
+
+	bot, err := r.store.FindBot(r.ctx, pending.BotId)
+	if err != nil {
+		return err
+	}
+	status := chatconsts.TOOL_CALLING_REJECTED
+	if reply.Approved {
+		status = chatconsts.TOOL_CALLING_CONFIRMED
+	}
+	for _, toolCall := range pending.ToolCalls {
+		toolCall.Status = status
+	}
+	go r.resume(turn{bot: bot, room: pending.Room, requester: pending.Requester}, &pending)
+	return nil
+}
+
+func (r *Runner) replyDirect(trigger types.Message, botId int64) {
+	bot, err := r.store.FindBot(r.ctx, botId)
+	if err != nil {
+		r.messenger.PushError(trigger.From, err)
+		return
+	}
+	// 机器人使用创建者的 LLM 配置，只有创建者可以私聊
+	if strconv.FormatInt(bot.OwnerId, 10) != trigger.From {
+		r.messenger.PushError(trigger.From, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR))
+		return
+	}
+	r.reply(turn{bot: bot, requester: trigger.From}, &trigger)
+}
+
+func (r *Runner) replyRoom(trigger types.Message, text string, mentions []string) {
+	roomId, err := strconv.ParseInt(trigger.Room, 10, 64)
+	if err != nil {
+		return
+	}
+	bots, err := r.store.RoomBots(r.ctx, roomId)
+	if err != nil {
+		r.logx.Errorf("failed to load bots of room %s: %v", trigger.Room, err)
+		return
+	}
+	for _, bot := range bots {
+		if mentioned(bot, text, mentions) {
+			r.reply(turn{bot: bot, room: trigger.Room, requester: trigger.From}, &trigger)
+		}
+	}
+}
+
+// reply 以最近的对话为上下文开始新的 LLM 会话
+func (r *Runner) reply(t turn, trigger *types.Message) {
+	ctx, cancel := context.WithTimeout(r.ctx, r.conf.ReplyTimeout)
+	defer cancel()
+
+	llmConfig, err := r.llmConfigOf(ctx, t.bot)
+	if err != nil {
+		r.fail(t, err)
+		return
+	}
+	history, err := r.contextText(ctx, t, trigger)
+	if err != nil {
+		r.fail(t, err)
+		return
+	}
+	systemPrompt := strings.TrimSpace(t.bot.SystemPrompt)
+	if systemPrompt == "" {
+		systemPrompt = defaultSystemPrompt
+	}
+
+	r.stream(ctx, t, &llmchatservice.ChatStreamReq{
+		UserId:    t.bot.OwnerId,
+		LlmConfig: llmConfig,
+		Messages: []*llmchatservice.ChatMsg{
+			{Role: chatconsts.ChatMessageRoleSystem, Content: systemPrompt},
+			{Role: chatconsts.ChatMessageRoleUser, Content: history},
+		},
+		AutoFillHistory: true,
+	})
+}
+
+// resume 把用户的确认结果交回 LLM，在原会话中继续回复
+func (r *Runner) resume(t turn, pending *pendingConfirm) {
+	ctx, cancel := context.WithTimeout(r.ctx, r.conf.ReplyTimeout)
+	defer cancel()
+
+	llmConfig, err := r.llmConfigOf(ctx, t.bot)
+	if err != nil {
+		r.fail(t, err)
+		return
+	}
+	r.stream(ctx, t, &llmchatservice.ChatStreamReq{
+		UserId:         t.bot.OwnerId,
+		ConversationId: pending.ConversationId,
+		LlmConfig:      llmConfig,
+		Messages: []*llmchatservice.ChatMsg{{
+			Role:       chatconsts.ChatMessageRoleTool,
+			ToolCalls:  pending.ToolCalls,
+			ToolCallId: pending.ToolCalls[0].GetInfo().GetId(),
+		}},
+		AutoFillHistory: true,
+	})
+}
+
+// stream 逐片推送回复，结束后发送完整的 chat 消息，需要确认的工具调用作为 bot_tool_confirm 消息发出
+func (r *Runner) stream(ctx context.Context, t turn, req *llmchatservice.ChatStreamReq) {
+	chatStream, err := r.llmChat.ChatStream(ctx, req)
+	if err != nil {
+		r.fail(t, err)
+		return
+	}
+
+	botUser := strconv.FormatInt(model.BotUserId(t.bot.Id), 10)
+	event := StreamEvent{StreamID: uniqueid.GenSn(uniqueid.SN_PREFIX_CHATROOM_BOT), Bot: botUser, Name: t.bot.Name}
+	conversationId := req.ConversationId
+	var content strings.Builder
+	var confirmCalls []*pb.ToolCall
+	for {
+		resp, err := chatStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			r.logx.Errorf("bot %d chat stream recv error: %v", t.bot.Id, err)
+			break
+		}
+		if resp.ConversationId != "" {
+			conversationId = resp.ConversationId
+		}
+		if resp.Error != "" {
+			r.logx.Errorf("bot %d chat resp error: %s", t.bot.Id, resp.Error)
+			continue
+		}
+
+		// 带工具调用的消息会重复携带已输出的文本，这里只累加文本分片
+		respMsg := resp.GetRespMsg()
+		if len(respMsg.GetToolCalls()) > 0 {
+			for _, toolCall := range respMsg.GetToolCalls() {
+				if toolCall.GetStatus() == chatconsts.TOOL_CALLING_WAITING_CONFIRMATION {
+					confirmCalls = append(confirmCalls, toolCall)
+				}
+			}
+			continue
+		}
+		if respMsg.GetContent() == "" {
+			continue
+		}
+		content.WriteString(respMsg.GetContent())
+		event.Delta = respMsg.GetContent()
+		r.messenger.Push(r.message(t, botUser, websocket.MessageTypeBotStream, event))
+	}
+
+	event.Delta, event.Done = "", true
+	r.messenger.Push(r.message(t, botUser, websocket.MessageTypeBotStream, event))
+	if text := strings.TrimSpace(content.String()); text != "" {
+		reply := r.message(t, botUser, messageTypeChat, map[string]interface{}{"text": text, "streamId": event.StreamID})
+		if err := r.messenger.Publish(reply); err != nil {
+			r.fail(t, err)
+			return
+		}
+	}
+	if len(confirmCalls) > 0 {
+		r.requestConfirm(ctx, t, botUser, conversationId, confirmCalls)
+	}
+}
+
+// requestConfirm 保存等待确认的工具调用，并在房间或私聊中发出确认消息
+func (r *Runner) requestConfirm(ctx context.Context, t turn, botUser, conversationId string, toolCalls []*pb.ToolCall) {
+	confirmId := uniqueid.GenSn(uniqueid.SN_PREFIX_CHATROOM_BOT)
+	data, err := json.Marshal(pendingConfirm{
+		BotId:          t.bot.Id,
+		Room:           t.room,
+		Requester:      t.requester,
+		ConversationId: conversationId,
+		ToolCalls:      toolCalls,
+	})
+	if err != nil {
+		r.fail(t, err)
+		return
+	}
+	if err := r.redis.SetexCtx(ctx, pendingKeyPrefix+confirmId, string(data), int(r.conf.ConfirmTTL/time.Second)); err != nil {
+		r.fail(t, err)
+		return
+	}
+
+	event := ToolConfirmEvent{ConfirmID: confirmId, Bot: botUser, Name: t.bot.Name, Requester: t.requester}
+	for _, toolCall := range toolCalls {
+		event.ToolCalls = append(event.ToolCalls, ToolCallInfo{
+			Id:          toolCall.GetInfo().GetId(),
+			Name:        toolCall.GetInfo().GetName(),
+			Arguments:   toolCall.GetInfo().GetArgumentsJson(),
+			Description: toolCall.GetInfo().GetDescription(),
+		})
+	}
+	if err := r.messenger.Publish(r.message(t, botUser, websocket.MessageTypeBotToolConfirm, event)); err != nil {
+		r.fail(t, err)
+	}
+}
+
+// message 机器人发出的消息：房间回复发到房间，私聊回复发给用户
+func (r *Runner) message(t turn, botUser, msgType string, data interface{}) *types.Message {
+	msg := &types.Message{Type: msgType, From: botUser, Data: data, Timestamp: time.Now().Unix()}
+	if t.room != "" {
+		msg.Room = t.room
+	} else {
+		msg.To = t.requester
+	}
+	return msg
+}
+
+// contextText 最近的对话记录，每行以发言者开头，最后一行是提及机器人的消息
+func (r *Runner) contextText(ctx context.Context, t turn, trigger *types.Message) (string, error) {
+	var rows []*model.ChatMessage
+	var err error
+	if t.room != "" {
+		roomId, _ := strconv.ParseInt(t.room, 10, 64)
+		rows, err = r.store.RoomContext(ctx, roomId, r.conf.ContextSize)
+	} else {
+		userId, _ := strconv.ParseInt(t.requester, 10, 64)
+		rows, err = r.store.DirectContext(ctx, userId, model.BotUserId(t.bot.Id), r.conf.ContextSize)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		if row.Type != messageTypeChat || (trigger.ID > 0 && row.Id == trigger.ID) {
+			continue
+		}
+		var data interface{}
+		_ = json.Unmarshal([]byte(row.Data), &data)
+		if text, _ := messageContent(data); text != "" {
+			lines = append(lines, fmt.Sprintf("%s：%s", speaker(t.bot, row.FromUserId), text))
+		}
+	}
+	text, _ := messageContent(trigger.Data)
+	fromUserId, _ := strconv.ParseInt(trigger.From, 10, 64)
+	lines = append(lines, fmt.Sprintf("%s：%s", speaker(t.bot, fromUserId), text))
+	return strings.Join(lines, "\n"), nil
+}
+
+func (r *Runner) llmConfigOf(ctx context.Context, bot *model.Bot) (*llmchatservice.LlmConfig, error) {
+	resp, err := r.llmConfig.GetConfig(ctx, &llmconfigservice.GetConfigReq{Id: bot.LlmConfigId})
+	if err != nil {
+		return nil, err
+	}
+	cfg := resp.Config
+	if cfg.UserId != bot.OwnerId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+	return &llmchatservice.LlmConfig{
+		BaseUrl:           strings.TrimSpace(cfg.BaseUrl),
+		ApiKey:            strings.TrimSpace(cfg.ApiKey),
+		Model:             strings.TrimSpace(cfg.Model),
+		Temperature:       cfg.Temperature,
+		TopP:              cfg.TopP,
+		TopK:              cfg.TopK,
+		EnableThinking:    cfg.EnableThinking > 0,
+		RepetitionPenalty: cfg.RepetitionPenalty,
+		PresencePenalty:   cfg.PresencePenalty,
+		MaxTokens:         cfg.MaxTokens,
+		Seed:              cfg.Seed,
+		EnableSearch:      cfg.EnableSearch > 0,
+		ContentLength:     cfg.ContextLength,
+	}, nil
+}
+
+// fail 回复失败时告知提及机器人的用户
+func (r *Runner) fail(t turn, err error) {
+	r.logx.Errorf("bot %d reply to user %s failed: %v", t.bot.Id, t.requester, err)
+	r.messenger.PushError(t.requester, err)
+}
+
+// mentioned 消息中 @机器人名称，或 mentions 中包含机器人的用户 id
+func mentioned(bot *model.Bot, text string, mentions []string) bool {
+	if bot.Name != "" && strings.Contains(text, "@"+bot.Name) {
+		return true
+	}
+	botUser := strconv.FormatInt(model.BotUserId(bot.Id), 10)
+	for _, mention := range mentions {
+		if mention == botUser {
+			return true
+		}
+	}
+	return false
+}
+
+func speaker(bot *model.Bot, userId int64) string {
+	if !model.IsBotUserId(userId) {
+		return fmt.Sprintf("用户%d", userId)
+	}
+	if -userId == bot.Id {
+		return bot.Name
+	}
+	return fmt.Sprintf("机器人%d", -userId)
+}
+
+// messageContent chat 消息 data 中的 text 与 mentions（被提及的用户 id）
+func messageContent(data interface{}) (string, []string) {
+	fields, ok := data.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	text, _ := fields["text"].(string)
+	var mentions []string
+	if items, ok := fields["mentions"].([]interface{}); ok {
+		for _, item := range items {
+			switch v := item.(type) {
+			case string:
+				mentions = append(mentions, v)
+			case float64:
+				mentions = append(mentions, strconv.FormatInt(int64(v), 10))
+			}
+		}
+	}
+	return text, mentions
+}
+
+func decodeData(data interface{}, v interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,257 @@
+package bot
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"google.golang.org/grpc"
+)
+
+type fakeStore struct {
+	bots    map[int64]*model.Bot
+	history []*model.ChatMessage
+}
+
+func (s *fakeStore) FindBot(ctx context.Context, botId int64) (*model.Bot, error) {
+	return s.bots[botId], nil
+}
+
+func (s *fakeStore) RoomBots(ctx context.Context, roomId int64) ([]*model.Bot, error) {
+	var bots []*model.Bot
+	for _, bot := range s.bots {
+		bots = append(bots, bot)
+	}
+	return bots, nil
+}
+
+func (s *fakeStore) RoomContext(ctx context.Context, roomId, limit int64) ([]*model.ChatMessage, error) {
+	return s.history, nil
+}
+
+func (s *fakeStore) DirectContext(ctx context.Context, userId, botUserId, limit int64) ([]*model.ChatMessage, error) {
+	return s.history, nil
+}
+
+type fakeMessenger struct {
+	mu        sync.Mutex
+	pushed    []*types.Message
+	published []*types.Message
+	errors    []error
+	notify    chan struct{}
+}
+
+func (m *fakeMessenger) Publish(msg *types.Message) error {
+	m.mu.Lock()
+	m.published = append(m.published, msg)
+	m.mu.Unlock()
+	m.notify <- struct{}{}
+	return nil
+}
+
+func (m *fakeMessenger) Push(msg *types.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pushed = append(m.pushed, msg)
+}
+
+func (m *fakeMessenger) PushError(userID string, err error) {
+	m.mu.Lock()
+	m.errors = append(m.errors, err)
+	m.mu.Unlock()
+	m.notify <- struct{}{}
+}
+
+func (m *fakeMessenger) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-m.notify:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for bot message")
+	}
+}
+
+type fakeStream struct {
+	grpc.ClientStream
+	resps []*pb.ChatStreamResp
+}
+
+func (s *fakeStream) Recv() (*pb.ChatStreamResp, error) {
+	if len(s.resps) == 0 {
+		return nil, io.EOF
+	}
+	resp := s.resps[0]
+	s.resps = s.resps[1:]
+	return resp, nil
+}
+
+type fakeLlm struct {
+	mu      sync.Mutex
+	reqs    []*llmchatservice.ChatStreamReq
+	replies [][]*pb.ChatStreamResp
+}
+
+func (f *fakeLlm) ChatStream(ctx context.Context, in *llmchatservice.ChatStreamReq, opts ...grpc.CallOption) (pb.LlmChatService_ChatStreamClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reqs = append(f.reqs, in)
+	resps := f.replies[0]
+	f.replies = f.replies[1:]
+	return &fakeStream{resps: resps}, nil
+}
+
+func (f *fakeLlm) GetConfig(ctx context.Context, in *llmconfigservice.GetConfigReq, opts ...grpc.CallOption) (*llmconfigservice.GetConfigResp, error) {
+	return &llmconfigservice.GetConfigResp{Config: &pb.ChatConfig{Id: in.Id, UserId: 1, Model: " qwen "}}, nil
+}
+
+func deltas(conversationId string, parts ...string) []*pb.ChatStreamResp {
+	resps := make([]*pb.ChatStreamResp, 0, len(parts))
+	for _, part := range parts {
+		resps = append(resps, &pb.ChatStreamResp{ConversationId: conversationId, RespMsg: &pb.ChatMsg{Content: part}})
+	}
+	return resps
+}
+
+func newTestRunner(t *testing.T, llm *fakeLlm) (*Runner, *fakeMessenger) {
+	mr := miniredis.RunT(t)
+	store := &fakeStore{
+		bots: map[int64]*model.Bot{7: {Id: 7, OwnerId: 1, Name: "小助手", LlmConfigId: 3}},
+		history: []*model.ChatMessage{
+			{Id: 11, Type: "chat", FromUserId: 2, RoomId: 100, Data: `{"text":"@小助手 明天天气怎么样"}`},
+			{Id: 10, Type: "chat", FromUserId: 2, RoomId: 100, Data: `{"text":"我明天去杭州"}`},
+		},
+	}
+	messenger := &fakeMessenger{notify: make(chan struct{}, 16)}
+	conf := config.BotConf{ContextSize: 20, ReplyTimeout: time.Second, ConfirmTTL: time.Minute}
+	return NewRunner(context.Background(), conf, store, messenger, llm, llm, redis.New(mr.Addr())), messenger
+}
+
+func TestRunnerRoomMention(t *testing.T) {
+	llm := &fakeLlm{replies: [][]*pb.ChatStreamResp{deltas("conv-1", "杭州明天", "多云")}}
+	runner, messenger := newTestRunner(t, llm)
+
+	// 不提及机器人的消息和机器人自己的消息不触发回复
+	runner.OnMessage(&types.Message{Type: "chat", From: "2", Room: "100", Data: map[string]interface{}{"text": "大家好"}})
+	runner.OnMessage(&types.Message{Type: "chat", From: "-7", Room: "100", Data: map[string]interface{}{"text": "@小助手"}})
+	runner.OnMessage(&types.Message{ID: 11, Type: "chat", From: "2", Room: "100", Data: map[string]interface{}{"text": "@小助手 明天天气怎么样"}})
+	messenger.wait(t)
+
+	messenger.mu.Lock()
+	defer messenger.mu.Unlock()
+	if len(messenger.published) != 1 {
+		t.Fatalf("expected one reply, got %d", len(messenger.published))
+	}
+	reply := messenger.published[0]
+	data := reply.Data.(map[string]interface{})
+	if reply.From != "-7" || reply.Room != "100" || data["text"] != "杭州明天多云" {
+		t.Fatalf("unexpected reply: %+v", reply)
+	}
+
+	if len(messenger.pushed) != 3 {
+		t.Fatalf("expected two deltas and done, got %d frames", len(messenger.pushed))
+	}
+	for i, frame := range messenger.pushed {
+		event := frame.Data.(StreamEvent)
+		if frame.Type != websocket.MessageTypeBotStream || frame.Room != "100" || event.StreamID != data["streamId"] {
+			t.Fatalf("unexpected stream frame %d: %+v", i, frame)
+		}
+		if event.Done != (i == 2) {
+			t.Fatalf("frame %d done = %v", i, event.Done)
+		}
+	}
+
+	req := llm.reqs[0]
+	if req.UserId != 1 || req.LlmConfig.Model != "qwen" {
+		t.Fatalf("unexpected llm request: %+v", req)
+	}
+	history := req.Messages[1].Content
+	if history != "用户2：我明天去杭州\n用户2：@小助手 明天天气怎么样" {
+		t.Fatalf("unexpected context:\n%s", history)
+	}
+}
+
+func TestRunnerDirectOwnerOnly(t *testing.T) {
+	llm := &fakeLlm{replies: [][]*pb.ChatStreamResp{deltas("conv-1", "你好")}}
+	runner, messenger := newTestRunner(t, llm)
+
+	runner.OnMessage(&types.Message{Type: "chat", From: "2", To: "-7", Data: map[string]interface{}{"text": "你好"}})
+	messenger.wait(t)
+	messenger.mu.Lock()
+	if len(messenger.errors) != 1 || len(llm.reqs) != 0 {
+		t.Fatalf("expected permission error for non-owner, got %v", messenger.errors)
+	}
+	messenger.mu.Unlock()
+
+	runner.OnMessage(&types.Message{Type: "chat", From: "1", To: "-7", Data: map[string]interface{}{"text": "你好"}})
+	messenger.wait(t)
+	messenger.mu.Lock()
+	defer messenger.mu.Unlock()
+	if len(messenger.published) != 1 || messenger.published[0].To != "1" {
+		t.Fatalf("expected direct reply to owner, got %+v", messenger.published)
+	}
+}
+
+func TestRunnerToolConfirm(t *testing.T) {
+	toolCall := &pb.ToolCall{
+		Info:   &pb.ToolCallInfo{Id: "call-1", Name: "send_email", ArgumentsJson: `{"to":"a@b.c"}`},
+		Status: chatconsts.TOOL_CALLING_WAITING_CONFIRMATION,
+	}
+	llm := &fakeLlm{replies: [][]*pb.ChatStreamResp{
+		append(deltas("conv-1", "我来发邮件"), &pb.ChatStreamResp{ConversationId: "conv-1", RespMsg: &pb.ChatMsg{ToolCalls: []*pb.ToolCall{toolCall}}}),
+		deltas("conv-1", "已发送"),
+	}}
+	runner, messenger := newTestRunner(t, llm)
+
+	runner.OnMessage(&types.Message{Type: "chat", From: "2", Room: "100", Data: map[string]interface{}{"text": "@小助手 发邮件", "mentions": []interface{}{"-7"}}})
+	messenger.wait(t)
+	messenger.wait(t)
+
+	messenger.mu.Lock()
+	confirm := messenger.published[1]
+	messenger.mu.Unlock()
+	event := confirm.Data.(ToolConfirmEvent)
+	if confirm.Type != websocket.MessageTypeBotToolConfirm || event.Requester != "2" || event.ToolCalls[0].Name != "send_email" {
+		t.Fatalf("unexpected confirm message: %+v", confirm)
+	}
+
+	reply := &types.Message{Type: websocket.MessageTypeBotToolReply, Data: map[string]interface{}{"confirmId": event.ConfirmID, "approved": true}}
+	if err := runner.OnToolReply("3", reply); err == nil {
+		t.Fatal("expected other users to be rejected")
+	}
+	if err := runner.OnToolReply("2", reply); err != nil {
+		t.Fatalf("confirm failed: %v", err)
+	}
+	if err := runner.OnToolReply("2", reply); err == nil {
+		t.Fatal("expected repeated confirm to fail")
+	}
+	messenger.wait(t)
+
+	llm.mu.Lock()
+	resumed := llm.reqs[1]
+	llm.mu.Unlock()
+	msg := resumed.Messages[0]
+	if resumed.ConversationId != "conv-1" || msg.Role != chatconsts.ChatMessageRoleTool ||
+		msg.ToolCallId != "call-1" || msg.ToolCalls[0].Status != chatconsts.TOOL_CALLING_CONFIRMED {
+		t.Fatalf("unexpected resume request: %+v", resumed)
+	}
+	messenger.mu.Lock()
+	defer messenger.mu.Unlock()
+	final := messenger.published[2].Data.(map[string]interface{})
+	if !strings.Contains(final["text"].(string), "已发送") {
+		t.Fatalf("unexpected final reply: %+v", final)
+	}
+}
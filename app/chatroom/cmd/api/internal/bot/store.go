@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+)
+
+// Store 机器人及其对话上下文的查询
+type Store interface {
+	// FindBot 按机器人 id 查询
+	FindBot(ctx context.Context, botId int64) (*model.Bot, error)
+	// RoomBots 房间中的机器人成员
+	RoomBots(ctx context.Context, roomId int64) ([]*model.Bot, error)
+	// RoomContext 房间最近的消息，按 id 倒序
+	RoomContext(ctx context.Context, roomId, limit int64) ([]*model.ChatMessage, error)
+	// DirectContext 用户与机器人最近的私聊消息，按 id 倒序
+	DirectContext(ctx context.Context, userId, botUserId, limit int64) ([]*model.ChatMessage, error)
+}
+
+type modelStore struct {
+	botModel         model.BotModel
+	roomMemberModel  model.RoomMemberModel
+	chatMessageModel model.ChatMessageModel
+}
+
+// NewModelStore 基于 MySQL 的机器人存储
+func NewModelStore(botModel model.BotModel, roomMemberModel model.RoomMemberModel, chatMessageModel model.ChatMessageModel) Store {
+	return &modelStore{
+		botModel:         botModel,
+		roomMemberModel:  roomMemberModel,
+		chatMessageModel: chatMessageModel,
+	}
+}
+
+func (s *modelStore) FindBot(ctx context.Context, botId int64) (*model.Bot, error) {
+	bot, err := s.botModel.FindOne(ctx, botId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, xerr.NewErrCode(xerr.BOT_NOT_FOUND_ERROR)
+		}
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find bot %d failed: %v", botId, err)
+	}
+	return bot, nil
+}
+
+func (s *modelStore) RoomBots(ctx context.Context, roomId int64) ([]*model.Bot, error) {
+	members, err := s.roomMemberModel.FindAllByRoomId(ctx, roomId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find members of room %d failed: %v", roomId, err)
+	}
+	var bots []*model.Bot
+	for _, member := range members {
+		if !model.IsBotUserId(member.UserId) {
+			continue
+		}
+		bot, err := s.botModel.FindOne(ctx, -member.UserId)
+		if err != nil {
+			if err == model.ErrNotFound {
+				continue
+			}
+			return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find bot %d failed: %v", -member.UserId, err)
+		}
+		bots = append(bots, bot)
+	}
+	return bots, nil
+}
+
+func (s *modelStore) RoomContext(ctx context.Context, roomId, limit int64) ([]*model.ChatMessage, error) {
+	rows, err := s.chatMessageModel.FindRoomHistory(ctx, roomId, 0, limit)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find history of room %d failed: %v", roomId, err)
+	}
+	return rows, nil
+}
+
+func (s *modelStore) DirectContext(ctx context.Context, userId, botUserId, limit int64) ([]*model.ChatMessage, error) {
+	rows, err := s.chatMessageModel.FindDirectHistory(ctx, userId, botUserId, 0, limit)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find history between user %d and %d failed: %v", userId, botUserId, err)
+	}
+	return rows, nil
+}
@@ -4,8 +4,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -14,6 +18,65 @@ type Config struct {
 	rest.RestConf
 	ChatroomRpcConf zrpc.RpcClientConf
 	Websocket       WsConfig
+	// 订阅其他服务投递的聊天室消息（chatbridge）
+	Redis redis.RedisConf
+	// 房间与成员关系
+	DB struct {
+		DataSource string
+	}
+	Cache cache.CacheConf
+	// WebSocket 握手鉴权
+	Auth AuthConf
+	// LLM 服务，聊天室机器人使用
+	LlmRpcConf zrpc.RpcClientConf
+	// 聊天室机器人
+	Bot BotConf
+	// 管理接口
+	Admin AdminConf
+}
+
+type AdminConf struct {
+	// 管理接口的访问 token，请求通过 Authorization: Bearer 携带；为空时关闭管理接口
+	Token string `json:",optional"`
+}
+
+type BotConf struct {
+	// 提及机器人时作为上下文的最近消息数
+	ContextSize int64 `json:",default=20"`
+	// 单次回复的超时时间
+	ReplyTimeout time.Duration `json:",default=2m"`
+	// 工具调用等待用户确认的有效期
+	ConfirmTTL time.Duration `json:",default=10m"`
+}
+
+type AuthConf struct {
+	// secret：用 AccessSecret 本地校验 JWT；rpc：调用 usercenter VerifyToken；
+	// header：信任 X-User-Id 请求头，不做任何校验，只能在会校验 token 并覆盖该请求头的网关之后显式开启
+	Mode string `json:",default=secret,options=header|secret|rpc"`
+	// JWT 签名密钥，secret 模式使用
+	AccessSecret string `json:",optional"`
+	// usercenter rpc，rpc 模式使用
+	UsercenterRpcConf zrpc.RpcClientConf `json:",optional"`
+}
+
+// Validate 启动时校验：secret 模式必须配置签名密钥
+func (c AuthConf) Validate() error {
+	switch c.Mode {
+	case "secret":
+		if c.AccessSecret == "" {
+			return errors.New("Auth.AccessSecret is required in secret mode")
+		}
+	case "rpc", "header":
+	default:
+		return fmt.Errorf("unknown Auth.Mode %q", c.Mode)
+	}
+	return nil
+}
+
+// RateLimitConf 令牌桶限流：每秒补充的令牌数与桶容量
+type RateLimitConf struct {
+	Rate  float64
+	Burst int
 }
 
 type WsConfig struct {
@@ -39,7 +102,7 @@ type WsConfig struct {
 	MessageQueueSize int
 	// 是否启用集群模式
 	EnableCluster bool
-	// 集群节点ID
+	// 集群节点ID，为空时使用 主机名-进程号
 	ClusterNodeID string
 	// 分片数量
 	ShardCount int
@@ -57,4 +120,12 @@ type WsConfig struct {
 	EnableGlobalPing bool
 	// 全局心跳workers
 	PingWorkerCount int
+	// 允许的跨域来源，逗号分隔；为空时只允许同源，* 允许所有
+	AllowedOrigins string `json:",optional"`
+	// 按消息类型限流（每个连接独立），未配置的类型使用 default，Rate 为 0 时不限流
+	RateLimits map[string]RateLimitConf `json:",optional"`
+	// 下线时等待发送缓冲区发完的最长时间，超时后强制断开
+	DrainTimeout time.Duration `json:",default=10s"`
+	// 下线时建议客户端重连前等待的最长时间，每个连接在 0 到该值之间随机，避免同时重连
+	ReconnectDelay time.Duration `json:",default=5s"`
 }
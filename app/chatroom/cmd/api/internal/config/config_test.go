@@ -0,0 +1,23 @@
+package config
+
+import "testing"
+
+func TestAuthConfValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       AuthConf
+		wantErr bool
+	}{
+		{"secret", AuthConf{Mode: "secret", AccessSecret: "key"}, false},
+		{"secret without key", AuthConf{Mode: "secret"}, true},
+		{"rpc", AuthConf{Mode: "rpc"}, false},
+		{"explicit header", AuthConf{Mode: "header"}, false},
+		{"empty mode", AuthConf{}, true},
+		{"unknown mode", AuthConf{Mode: "none"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
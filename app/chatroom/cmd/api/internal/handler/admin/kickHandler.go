@@ -0,0 +1,32 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/logic/admin"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+)
+
+// 断开指定连接或用户的所有连接
+func KickHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.AdminKickReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := admin.NewKickLogic(r.Context(), svcCtx)
+		resp, err := l.Kick(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/logic/admin"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+)
+
+// 发送系统消息给房间、用户或所有连接
+func SendSystemMessageHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.AdminSystemMessageReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := admin.NewSendSystemMessageLogic(r.Context(), svcCtx)
+		resp, err := l.SendSystemMessage(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package health
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/logic/health"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+)
+
+// 就绪探针,节点下线中返回 503 与剩余连接数
+func ReadinessHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := health.NewReadinessLogic(r.Context(), svcCtx)
+		resp, err := l.Readiness()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		// 下线中返回 503,负载均衡不再把新连接分配到该节点
+		status := http.StatusOK
+		if resp.Status != health.StatusReady {
+			status = http.StatusServiceUnavailable
+		}
+		httpx.WriteJsonCtx(r.Context(), w, status, resp)
+	}
+}
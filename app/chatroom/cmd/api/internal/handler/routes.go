@@ -6,6 +6,13 @@ package handler
 import (
 	"net/http"
 
+	admin "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/admin"
+	bot "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/bot"
+	health "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/health"
+	history "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/history"
+	presence "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/presence"
+	receipt "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/receipt"
+	room "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/room"
 	ws "go-zero-voice-agent/app/chatroom/cmd/api/internal/handler/ws"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
 
@@ -24,4 +31,175 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		},
 		rest.WithPrefix("/ws/v1"),
 	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 创建房间,创建者为房主
+				Method:  http.MethodPost,
+				Path:    "/room",
+				Handler: room.CreateRoomHandler(serverCtx),
+			},
+			{
+				// 查询当前用户加入的房间
+				Method:  http.MethodPost,
+				Path:    "/room/list",
+				Handler: room.ListRoomsHandler(serverCtx),
+			},
+			{
+				// 查询房间成员及在线状态
+				Method:  http.MethodGet,
+				Path:    "/room/:roomId/members",
+				Handler: room.ListRoomMembersHandler(serverCtx),
+			},
+			{
+				// 设置成员角色(管理员/普通成员/禁言),房主可设置所有成员,管理员只能设置普通成员
+				Method:  http.MethodPost,
+				Path:    "/room/:roomId/member/role",
+				Handler: room.SetRoomMemberRoleHandler(serverCtx),
+			},
+			{
+				// 将成员移出房间
+				Method:  http.MethodPost,
+				Path:    "/room/:roomId/member/remove",
+				Handler: room.RemoveRoomMemberHandler(serverCtx),
+			},
+			{
+				// 邀请用户加入房间,仅房主和管理员可操作,被邀请后才能通过 join 进入房间
+				Method:  http.MethodPost,
+				Path:    "/room/:roomId/member/invite",
+				Handler: room.InviteRoomMemberHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/ws/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 查询房间历史消息,仅房间成员可查
+				Method:  http.MethodGet,
+				Path:    "/history/room/:roomId",
+				Handler: history.RoomHistoryHandler(serverCtx),
+			},
+			{
+				// 查询与某个用户的私聊历史消息
+				Method:  http.MethodGet,
+				Path:    "/history/user/:peerId",
+				Handler: history.DirectHistoryHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/ws/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 创建机器人,使用当前用户的 LLM 配置
+				Method:  http.MethodPost,
+				Path:    "/bot",
+				Handler: bot.CreateBotHandler(serverCtx),
+			},
+			{
+				// 查询当前用户创建的机器人
+				Method:  http.MethodPost,
+				Path:    "/bot/list",
+				Handler: bot.ListBotsHandler(serverCtx),
+			},
+			{
+				// 把机器人加入房间,仅房主和管理员可操作且需是机器人的创建者,移出机器人使用成员移除接口
+				Method:  http.MethodPost,
+				Path:    "/room/:roomId/bot",
+				Handler: bot.AddRoomBotHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/ws/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 查询用户的在线状态(online/away/offline)
+				Method:  http.MethodPost,
+				Path:    "/presence/query",
+				Handler: presence.QueryPresenceHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/ws/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 查询房间成员的已读位置,仅房间成员可查
+				Method:  http.MethodGet,
+				Path:    "/receipt/room/:roomId",
+				Handler: receipt.RoomReceiptsHandler(serverCtx),
+			},
+			{
+				// 查询与某个用户私聊的双方已读位置
+				Method:  http.MethodGet,
+				Path:    "/receipt/user/:peerId",
+				Handler: receipt.DirectReceiptsHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/ws/v1"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AdminAuth},
+			[]rest.Route{
+				{
+					// 查询节点上的连接,可按用户和分片过滤
+					Method:  http.MethodPost,
+					Path:    "/admin/connections",
+					Handler: admin.ListConnectionsHandler(serverCtx),
+				},
+				{
+					// 断开指定连接或用户的所有连接
+					Method:  http.MethodPost,
+					Path:    "/admin/kick",
+					Handler: admin.KickHandler(serverCtx),
+				},
+				{
+					// 发送系统消息给房间、用户或所有连接
+					Method:  http.MethodPost,
+					Path:    "/admin/message",
+					Handler: admin.SendSystemMessageHandler(serverCtx),
+				},
+				{
+					// 查询节点的连接数、分片队列深度和丢弃计数
+					Method:  http.MethodGet,
+					Path:    "/admin/stats",
+					Handler: admin.StatsHandler(serverCtx),
+				},
+				{
+					// 修改背压设置,集群模式下同步到所有节点
+					Method:  http.MethodPost,
+					Path:    "/admin/backpressure",
+					Handler: admin.SetBackpressureHandler(serverCtx),
+				},
+				{
+					// 查询用户在房间中的角色,供语音房间等服务校验成员关系
+					Method:  http.MethodGet,
+					Path:    "/admin/room/:roomId/member/:userId",
+					Handler: admin.RoomMemberHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/ws/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 就绪探针,节点下线中返回 503 与剩余连接数
+				Method:  http.MethodGet,
+				Path:    "/health/ready",
+				Handler: health.ReadinessHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/ws/v1"),
+	)
 }
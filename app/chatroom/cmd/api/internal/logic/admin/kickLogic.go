@@ -0,0 +1,43 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type KickLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 断开指定连接或用户的所有连接
+func NewKickLogic(ctx context.Context, svcCtx *svc.ServiceContext) *KickLogic {
+	return &KickLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *KickLogic) Kick(req *types.AdminKickReq) (resp *types.AdminKickResp, err error) {
+	if req.ConnectionId == "" && req.UserId == 0 {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	var userId string
+	if req.UserId != 0 {
+		userId = strconv.FormatInt(req.UserId, 10)
+	}
+	kicked := l.svcCtx.WsManager.Kick(req.ConnectionId, userId)
+	return &types.AdminKickResp{Kicked: int64(kicked)}, nil
+}
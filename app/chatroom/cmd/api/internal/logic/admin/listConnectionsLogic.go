@@ -0,0 +1,58 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListConnectionsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询节点上的连接,可按用户和分片过滤
+func NewListConnectionsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListConnectionsLogic {
+	return &ListConnectionsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListConnectionsLogic) ListConnections(req *types.AdminListConnectionsReq) (resp *types.AdminListConnectionsResp, err error) {
+	filter := websocket.ConnectionFilter{Shard: int(req.Shard)}
+	if req.UserId != 0 {
+		filter.UserID = strconv.FormatInt(req.UserId, 10)
+	}
+	conns, err := l.svcCtx.WsManager.ListConnections(l.ctx, req.Node, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]types.AdminConnection, len(conns))
+	for i, conn := range conns {
+		userId, _ := strconv.ParseInt(conn.UserID, 10, 64)
+		list[i] = types.AdminConnection{
+			Id:           conn.ID,
+			UserId:       userId,
+			Node:         conn.Node,
+			Shard:        int64(conn.Shard),
+			Status:       conn.Status,
+			Subprotocol:  conn.Subprotocol,
+			Rooms:        conn.Rooms,
+			Buffered:     int64(conn.Buffered),
+			LastPingTime: conn.LastPingTime,
+		}
+	}
+	return &types.AdminListConnectionsResp{List: list}, nil
+}
@@ -0,0 +1,61 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/globalkey"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RoomMemberLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询用户在房间中的角色,供语音房间等服务校验成员关系
+func NewRoomMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RoomMemberLogic {
+	return &RoomMemberLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// RoomMember 房间不存在或不是成员时不返回错误，由调用方按 RoomExists 与 Role 判断
+func (l *RoomMemberLogic) RoomMember(req *types.AdminRoomMemberReq) (resp *types.AdminRoomMemberResp, err error) {
+	if req.RoomId <= 0 || req.UserId == 0 {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	room, err := l.svcCtx.RoomModel.FindOne(l.ctx, req.RoomId)
+	if err == model.ErrNotFound {
+		return &types.AdminRoomMemberResp{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d failed: %v", req.RoomId, err)
+	}
+	if room.DelState != globalkey.DelStateNo {
+		return &types.AdminRoomMemberResp{}, nil
+	}
+
+	resp = &types.AdminRoomMemberResp{RoomExists: true, OwnerId: room.OwnerId}
+	member, err := l.svcCtx.RoomMemberModel.FindOneByRoomIdUserId(l.ctx, req.RoomId, req.UserId)
+	if err == model.ErrNotFound {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", req.RoomId, req.UserId, err)
+	}
+	resp.Role = member.Role
+	return resp, nil
+}
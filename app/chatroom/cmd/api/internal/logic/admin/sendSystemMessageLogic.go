@@ -0,0 +1,47 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SendSystemMessageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 发送系统消息给房间、用户或所有连接
+func NewSendSystemMessageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SendSystemMessageLogic {
+	return &SendSystemMessageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SendSystemMessageLogic) SendSystemMessage(req *types.AdminSystemMessageReq) (resp *types.Empty, err error) {
+	if strings.TrimSpace(req.Text) == "" || (req.RoomId != 0 && req.UserId != 0) {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	var room, userId string
+	if req.RoomId != 0 {
+		room = strconv.FormatInt(req.RoomId, 10)
+	}
+	if req.UserId != 0 {
+		userId = strconv.FormatInt(req.UserId, 10)
+	}
+	l.svcCtx.WsManager.SendSystem(room, userId, map[string]interface{}{"text": req.Text})
+	return &types.Empty{}, nil
+}
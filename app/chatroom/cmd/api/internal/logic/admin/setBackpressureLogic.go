@@ -0,0 +1,47 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SetBackpressureLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 修改背压设置,集群模式下同步到所有节点
+func NewSetBackpressureLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SetBackpressureLogic {
+	return &SetBackpressureLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SetBackpressureLogic) SetBackpressure(req *types.AdminBackpressure) (resp *types.AdminBackpressure, err error) {
+	if req.SendTimeoutMs < 0 {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	l.svcCtx.WsManager.SetBackpressure(websocket.Backpressure{
+		DropOnFull:  req.DropOnFull,
+		SendTimeout: time.Duration(req.SendTimeoutMs) * time.Millisecond,
+	})
+	current := l.svcCtx.WsManager.Backpressure()
+	return &types.AdminBackpressure{
+		DropOnFull:    current.DropOnFull,
+		SendTimeoutMs: current.SendTimeout.Milliseconds(),
+	}, nil
+}
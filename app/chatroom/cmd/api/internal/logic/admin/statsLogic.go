@@ -0,0 +1,64 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package admin
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type StatsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询节点的连接数、分片队列深度和丢弃计数
+func NewStatsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StatsLogic {
+	return &StatsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *StatsLogic) Stats(req *types.AdminStatsReq) (resp *types.AdminStatsResp, err error) {
+	stats, err := l.svcCtx.WsManager.NodeStats(l.ctx, req.Node)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := l.svcCtx.WsManager.Nodes(l.ctx)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "list cluster nodes failed: %v", err)
+	}
+
+	shards := make([]types.AdminShardStats, len(stats.Shards))
+	for i, shard := range stats.Shards {
+		shards[i] = types.AdminShardStats{
+			Shard:       int64(shard.Shard),
+			Connections: int64(shard.Connections),
+			QueueDepth:  shard.QueueDepth,
+			Buffered:    int64(shard.Buffered),
+			Dropped:     shard.Dropped,
+			JobsDropped: shard.JobsDropped,
+		}
+	}
+	return &types.AdminStatsResp{
+		Node:           stats.Node,
+		Nodes:          nodes,
+		Connections:    stats.Connections,
+		BroadcastQueue: int64(stats.BroadcastQueue),
+		Backpressure: types.AdminBackpressure{
+			DropOnFull:    stats.Backpressure.DropOnFull,
+			SendTimeoutMs: stats.Backpressure.SendTimeout.Milliseconds(),
+		},
+		Shards: shards,
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package bot
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type AddRoomBotLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 把机器人加入房间,仅房主和管理员可操作且需是机器人的创建者,移出机器人使用成员移除接口
+func NewAddRoomBotLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AddRoomBotLogic {
+	return &AddRoomBotLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AddRoomBotLogic) AddRoomBot(req *types.AddRoomBotReq) (resp *types.Empty, err error) {
+	operator, err := l.svcCtx.RoomMemberModel.FindOneByRoomIdUserId(l.ctx, req.RoomId, req.UserId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+		}
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", req.RoomId, req.UserId, err)
+	}
+	if operator.Role != model.RoomRoleOwner && operator.Role != model.RoomRoleAdmin {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	// 机器人回复使用创建者的 LLM 配置，只能加入自己创建的机器人
+	bot, err := l.svcCtx.BotModel.FindOne(l.ctx, req.BotId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, xerr.NewErrCode(xerr.BOT_NOT_FOUND_ERROR)
+		}
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find bot %d failed: %v", req.BotId, err)
+	}
+	if bot.OwnerId != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	botUserId := model.BotUserId(bot.Id)
+	_, err = l.svcCtx.RoomMemberModel.FindOneByRoomIdUserId(l.ctx, req.RoomId, botUserId)
+	if err == nil {
+		return &types.Empty{}, nil
+	}
+	if err != model.ErrNotFound {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", req.RoomId, botUserId, err)
+	}
+	if _, err := l.svcCtx.RoomMemberModel.Insert(l.ctx, nil, &model.RoomMember{
+		RoomId: req.RoomId,
+		UserId: botUserId,
+		Role:   model.RoomRoleMember,
+	}); err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "add bot %d to room %d failed: %v", bot.Id, req.RoomId, err)
+	}
+
+	room := strconv.FormatInt(req.RoomId, 10)
+	l.svcCtx.WsManager.Push(&types.Message{
+		Type:      websocket.MessageTypeMemberJoin,
+		Room:      room,
+		Data:      websocket.RoomMemberEvent{Room: room, UserID: strconv.FormatInt(botUserId, 10), Role: model.RoomRoleMember},
+		Timestamp: time.Now().Unix(),
+	})
+
+	return &types.Empty{}, nil
+}
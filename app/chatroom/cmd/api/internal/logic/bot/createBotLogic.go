@@ -0,0 +1,64 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateBotLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 创建机器人,使用当前用户的 LLM 配置
+func NewCreateBotLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateBotLogic {
+	return &CreateBotLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateBotLogic) CreateBot(req *types.CreateBotReq) (resp *types.CreateBotResp, err error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || req.LlmConfigId <= 0 {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	configResp, err := l.svcCtx.LlmConfigRpc.GetConfig(l.ctx, &llmconfigservice.GetConfigReq{Id: req.LlmConfigId})
+	if err != nil {
+		return nil, err
+	}
+	if configResp.Config.UserId != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	result, err := l.svcCtx.BotModel.Insert(l.ctx, nil, &model.Bot{
+		OwnerId:      req.UserId,
+		Name:         name,
+		LlmConfigId:  req.LlmConfigId,
+		SystemPrompt: req.SystemPrompt,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "create bot failed, user_id: %d, err: %v", req.UserId, err)
+	}
+	botId, err := result.LastInsertId()
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "get bot id failed: %v", err)
+	}
+
+	return &types.CreateBotResp{BotId: botId, BotUserId: model.BotUserId(botId)}, nil
+}
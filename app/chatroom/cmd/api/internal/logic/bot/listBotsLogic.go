@@ -0,0 +1,51 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package bot
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListBotsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询当前用户创建的机器人
+func NewListBotsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListBotsLogic {
+	return &ListBotsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListBotsLogic) ListBots(req *types.ListBotsReq) (resp *types.ListBotsResp, err error) {
+	bots, err := l.svcCtx.BotModel.FindAllByOwnerId(l.ctx, req.UserId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find bots of user %d failed: %v", req.UserId, err)
+	}
+
+	list := make([]types.BotInfo, 0, len(bots))
+	for _, bot := range bots {
+		list = append(list, types.BotInfo{
+			BotId:        bot.Id,
+			BotUserId:    model.BotUserId(bot.Id),
+			Name:         bot.Name,
+			LlmConfigId:  bot.LlmConfigId,
+			SystemPrompt: bot.SystemPrompt,
+		})
+	}
+
+	return &types.ListBotsResp{List: list}, nil
+}
@@ -0,0 +1,46 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package health
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 就绪探针返回的状态
+const (
+	StatusReady    = "ready"
+	StatusDraining = "draining"
+)
+
+type ReadinessLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 就绪探针,节点下线中返回 503 与剩余连接数
+func NewReadinessLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReadinessLogic {
+	return &ReadinessLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ReadinessLogic) Readiness() (resp *types.ReadinessResp, err error) {
+	drain := l.svcCtx.WsManager.DrainStatus()
+	status := StatusReady
+	if drain.Draining {
+		status = StatusDraining
+	}
+	return &types.ReadinessResp{
+		Status:      status,
+		Connections: drain.Connections,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package history
+
+import (
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+)
+
+// toHistoryResp 消息按 id 倒序，取满一页时以最后一条的 id 作为下一页游标
+func toHistoryResp(rows []*model.ChatMessage, limit int64) *types.HistoryResp {
+	list := make([]types.HistoryMessage, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, types.HistoryMessage{
+			Id:         row.Id,
+			Type:       row.Type,
+			FromUserId: row.FromUserId,
+			ToUserId:   row.ToUserId,
+			RoomId:     row.RoomId,
+			Data:       row.Data,
+			Timestamp:  row.SendTime,
+		})
+	}
+	resp := &types.HistoryResp{List: list}
+	if int64(len(rows)) == limit && len(rows) > 0 {
+		resp.NextCursor = rows[len(rows)-1].Id
+	}
+	return resp
+}
@@ -0,0 +1,38 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package history
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DirectHistoryLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询与某个用户的私聊历史消息
+func NewDirectHistoryLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DirectHistoryLogic {
+	return &DirectHistoryLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DirectHistoryLogic) DirectHistory(req *types.DirectHistoryReq) (resp *types.HistoryResp, err error) {
+	rows, err := l.svcCtx.ChatMessageModel.FindDirectHistory(l.ctx, req.UserId, req.PeerId, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find history between user %d and %d failed: %v", req.UserId, req.PeerId, err)
+	}
+	return toHistoryResp(rows, req.Limit), nil
+}
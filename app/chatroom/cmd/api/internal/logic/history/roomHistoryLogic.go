@@ -0,0 +1,46 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package history
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RoomHistoryLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询房间历史消息,仅房间成员可查
+func NewRoomHistoryLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RoomHistoryLogic {
+	return &RoomHistoryLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RoomHistoryLogic) RoomHistory(req *types.RoomHistoryReq) (resp *types.HistoryResp, err error) {
+	if _, err := l.svcCtx.RoomMemberModel.FindOneByRoomIdUserId(l.ctx, req.RoomId, req.UserId); err != nil {
+		if err == model.ErrNotFound {
+			return nil, xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+		}
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", req.RoomId, req.UserId, err)
+	}
+
+	rows, err := l.svcCtx.ChatMessageModel.FindRoomHistory(l.ctx, req.RoomId, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find history of room %d failed: %v", req.RoomId, err)
+	}
+	return toHistoryResp(rows, req.Limit), nil
+}
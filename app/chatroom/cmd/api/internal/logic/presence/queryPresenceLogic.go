@@ -0,0 +1,51 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package presence
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// maxQueryUsers 单次最多查询的用户数
+const maxQueryUsers = 200
+
+type QueryPresenceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询用户的在线状态(online/away/offline)
+func NewQueryPresenceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *QueryPresenceLogic {
+	return &QueryPresenceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *QueryPresenceLogic) QueryPresence(req *types.QueryPresenceReq) (resp *types.QueryPresenceResp, err error) {
+	if len(req.UserIds) == 0 || len(req.UserIds) > maxQueryUsers {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	userIds := make([]string, len(req.UserIds))
+	for i, userId := range req.UserIds {
+		userIds[i] = strconv.FormatInt(userId, 10)
+	}
+	statuses := l.svcCtx.WsManager.Presence(l.ctx, userIds)
+
+	list := make([]types.UserPresence, len(req.UserIds))
+	for i, userId := range req.UserIds {
+		list[i] = types.UserPresence{UserId: userId, Status: statuses[userIds[i]]}
+	}
+	return &types.QueryPresenceResp{List: list}, nil
+}
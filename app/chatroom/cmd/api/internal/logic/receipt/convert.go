@@ -0,0 +1,18 @@
+package receipt
+
+import (
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+)
+
+func toReceiptsResp(rows []*model.ReadCursor) *types.ReceiptsResp {
+	list := make([]types.ReadReceipt, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, types.ReadReceipt{
+			UserId:    row.UserId,
+			MessageId: row.MessageId,
+			ReadTime:  row.UpdateTime.Unix(),
+		})
+	}
+	return &types.ReceiptsResp{List: list}
+}
@@ -0,0 +1,38 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package receipt
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DirectReceiptsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询与某个用户私聊的双方已读位置
+func NewDirectReceiptsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DirectReceiptsLogic {
+	return &DirectReceiptsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DirectReceiptsLogic) DirectReceipts(req *types.DirectReceiptsReq) (resp *types.ReceiptsResp, err error) {
+	rows, err := l.svcCtx.ReadCursorModel.FindDirect(l.ctx, req.UserId, req.PeerId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find read cursors between user %d and %d failed: %v", req.UserId, req.PeerId, err)
+	}
+	return toReceiptsResp(rows), nil
+}
@@ -0,0 +1,46 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package receipt
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RoomReceiptsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询房间成员的已读位置,仅房间成员可查
+func NewRoomReceiptsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RoomReceiptsLogic {
+	return &RoomReceiptsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RoomReceiptsLogic) RoomReceipts(req *types.RoomReceiptsReq) (resp *types.ReceiptsResp, err error) {
+	if _, err := l.svcCtx.RoomMemberModel.FindOneByRoomIdUserId(l.ctx, req.RoomId, req.UserId); err != nil {
+		if err == model.ErrNotFound {
+			return nil, xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+		}
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", req.RoomId, req.UserId, err)
+	}
+
+	rows, err := l.svcCtx.ReadCursorModel.FindAllByRoomId(l.ctx, req.RoomId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find read cursors of room %d failed: %v", req.RoomId, err)
+	}
+	return toReceiptsResp(rows), nil
+}
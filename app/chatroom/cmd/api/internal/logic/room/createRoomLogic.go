@@ -0,0 +1,65 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package room
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+type CreateRoomLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 创建房间,创建者为房主
+func NewCreateRoomLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateRoomLogic {
+	return &CreateRoomLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateRoomLogic) CreateRoom(req *types.CreateRoomReq) (resp *types.CreateRoomResp, err error) {
+	if req.Name == "" {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	var roomId int64
+	err = l.svcCtx.RoomModel.Trans(l.ctx, func(ctx context.Context, session sqlx.Session) error {
+		result, err := l.svcCtx.RoomModel.Insert(ctx, session, &model.Room{
+			Name:        req.Name,
+			Description: req.Description,
+			OwnerId:     req.UserId,
+		})
+		if err != nil {
+			return err
+		}
+		roomId, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		_, err = l.svcCtx.RoomMemberModel.Insert(ctx, session, &model.RoomMember{
+			RoomId: roomId,
+			UserId: req.UserId,
+			Role:   model.RoomRoleOwner,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "create room failed, user_id: %d, err: %v", req.UserId, err)
+	}
+
+	return &types.CreateRoomResp{RoomId: roomId}, nil
+}
@@ -0,0 +1,73 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package room
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type InviteRoomMemberLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 邀请用户加入房间,仅房主和管理员可操作,被邀请后才能通过 join 进入房间
+func NewInviteRoomMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *InviteRoomMemberLogic {
+	return &InviteRoomMemberLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// InviteRoomMember 写入成员关系并通知房间成员与被邀请人，已是成员时不做处理；机器人使用 addRoomBot 加入
+func (l *InviteRoomMemberLogic) InviteRoomMember(req *types.InviteRoomMemberReq) (resp *types.Empty, err error) {
+	if req.TargetUserId <= 0 {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	operator, err := findMember(l.ctx, l.svcCtx, req.RoomId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if operator.Role != model.RoomRoleOwner && operator.Role != model.RoomRoleAdmin {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	_, err = l.svcCtx.RoomMemberModel.FindOneByRoomIdUserId(l.ctx, req.RoomId, req.TargetUserId)
+	if err == nil {
+		return &types.Empty{}, nil
+	}
+	if err != model.ErrNotFound {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", req.RoomId, req.TargetUserId, err)
+	}
+	if _, err := l.svcCtx.RoomMemberModel.Insert(l.ctx, nil, &model.RoomMember{
+		RoomId: req.RoomId,
+		UserId: req.TargetUserId,
+		Role:   model.RoomRoleMember,
+	}); err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "invite user %d to room %d failed: %v", req.TargetUserId, req.RoomId, err)
+	}
+
+	room := strconv.FormatInt(req.RoomId, 10)
+	target := strconv.FormatInt(req.TargetUserId, 10)
+	event := websocket.RoomMemberEvent{Room: room, UserID: target, Role: model.RoomRoleMember}
+	now := time.Now().Unix()
+	l.svcCtx.WsManager.Push(&types.Message{Type: websocket.MessageTypeMemberJoin, Room: room, Data: event, Timestamp: now})
+	// 被邀请人还没有订阅房间，单独通知
+	l.svcCtx.WsManager.Push(&types.Message{Type: websocket.MessageTypeMemberJoin, To: target, Data: event, Timestamp: now})
+
+	return &types.Empty{}, nil
+}
@@ -0,0 +1,60 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package room
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListRoomMembersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询房间成员及在线状态
+func NewListRoomMembersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListRoomMembersLogic {
+	return &ListRoomMembersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListRoomMembersLogic) ListRoomMembers(req *types.ListRoomMembersReq) (resp *types.ListRoomMembersResp, err error) {
+	if _, err := findMember(l.ctx, l.svcCtx, req.RoomId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	members, err := l.svcCtx.RoomMemberModel.FindAllByRoomId(l.ctx, req.RoomId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find members of room %d failed: %v", req.RoomId, err)
+	}
+
+	userIds := make([]string, 0, len(members))
+	for _, member := range members {
+		userIds = append(userIds, strconv.FormatInt(member.UserId, 10))
+	}
+	online := l.svcCtx.WsManager.OnlineUsers(l.ctx, userIds)
+
+	list := make([]types.RoomMemberInfo, 0, len(members))
+	for i, member := range members {
+		list = append(list, types.RoomMemberInfo{
+			UserId:   member.UserId,
+			Role:     member.Role,
+			Online:   online[userIds[i]],
+			JoinTime: member.CreateTime.Unix(),
+		})
+	}
+
+	return &types.ListRoomMembersResp{List: list}, nil
+}
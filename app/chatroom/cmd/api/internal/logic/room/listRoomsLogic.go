@@ -0,0 +1,58 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package room
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListRoomsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询当前用户加入的房间
+func NewListRoomsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListRoomsLogic {
+	return &ListRoomsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListRoomsLogic) ListRooms(req *types.ListRoomsReq) (resp *types.ListRoomsResp, err error) {
+	members, err := l.svcCtx.RoomMemberModel.FindAllByUserId(l.ctx, req.UserId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find rooms of user %d failed: %v", req.UserId, err)
+	}
+
+	list := make([]types.RoomInfo, 0, len(members))
+	for _, member := range members {
+		room, err := l.svcCtx.RoomModel.FindOne(l.ctx, member.RoomId)
+		if err != nil {
+			if err == model.ErrNotFound {
+				continue
+			}
+			return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d failed: %v", member.RoomId, err)
+		}
+		list = append(list, types.RoomInfo{
+			RoomId:      room.Id,
+			Name:        room.Name,
+			Description: room.Description,
+			OwnerId:     room.OwnerId,
+			Role:        member.Role,
+		})
+	}
+
+	return &types.ListRoomsResp{List: list}, nil
+}
@@ -0,0 +1,35 @@
+package room
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+)
+
+// findMember 查询用户在房间中的成员记录，不是成员时返回 ROOM_MEMBER_NOT_FOUND_ERROR
+func findMember(ctx context.Context, svcCtx *svc.ServiceContext, roomId, userId int64) (*model.RoomMember, error) {
+	member, err := svcCtx.RoomMemberModel.FindOneByRoomIdUserId(ctx, roomId, userId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+		}
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", roomId, userId, err)
+	}
+	return member, nil
+}
+
+// canManage 房主可以管理其他所有成员，管理员只能管理普通成员和被禁言的成员
+func canManage(operatorRole, targetRole string) bool {
+	switch operatorRole {
+	case model.RoomRoleOwner:
+		return targetRole != model.RoomRoleOwner
+	case model.RoomRoleAdmin:
+		return targetRole == model.RoomRoleMember || targetRole == model.RoomRoleMuted
+	default:
+		return false
+	}
+}
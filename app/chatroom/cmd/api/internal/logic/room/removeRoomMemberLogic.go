@@ -0,0 +1,52 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package room
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RemoveRoomMemberLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 将成员移出房间
+func NewRemoveRoomMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RemoveRoomMemberLogic {
+	return &RemoveRoomMemberLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RemoveRoomMemberLogic) RemoveRoomMember(req *types.RemoveRoomMemberReq) (resp *types.Empty, err error) {
+	operator, err := findMember(l.ctx, l.svcCtx, req.RoomId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findMember(l.ctx, l.svcCtx, req.RoomId, req.TargetUserId)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(operator.Role, target.Role) {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	if err := l.svcCtx.RoomMemberModel.Delete(l.ctx, nil, target.Id); err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "delete room %d member %d failed: %v", req.RoomId, req.TargetUserId, err)
+	}
+	l.svcCtx.WsManager.RemoveRoomMember(strconv.FormatInt(req.RoomId, 10), strconv.FormatInt(req.TargetUserId, 10))
+
+	return &types.Empty{}, nil
+}
@@ -0,0 +1,58 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package room
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SetRoomMemberRoleLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 设置成员角色(管理员/普通成员/禁言),房主可设置所有成员,管理员只能设置普通成员
+func NewSetRoomMemberRoleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SetRoomMemberRoleLogic {
+	return &SetRoomMemberRoleLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SetRoomMemberRoleLogic) SetRoomMemberRole(req *types.SetRoomMemberRoleReq) (resp *types.Empty, err error) {
+	operator, err := findMember(l.ctx, l.svcCtx, req.RoomId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findMember(l.ctx, l.svcCtx, req.RoomId, req.TargetUserId)
+	if err != nil {
+		return nil, err
+	}
+	// 管理员不能任命其他管理员
+	if !canManage(operator.Role, target.Role) || (req.Role == model.RoomRoleAdmin && operator.Role != model.RoomRoleOwner) {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+	if target.Role == req.Role {
+		return &types.Empty{}, nil
+	}
+
+	target.Role = req.Role
+	if err := l.svcCtx.RoomMemberModel.UpdateWithVersion(l.ctx, nil, target); err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "update room %d member %d role failed: %v", req.RoomId, req.TargetUserId, err)
+	}
+	l.svcCtx.WsManager.SetRoomMemberRole(strconv.FormatInt(req.RoomId, 10), strconv.FormatInt(req.TargetUserId, 10), req.Role)
+
+	return &types.Empty{}, nil
+}
@@ -28,6 +28,16 @@ func NewOpenConnectionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Op
 	}
 }
 
-func (l *OpenConnectionLogic) OpenConnection(w http.ResponseWriter, r *http.Request, userId string) {
-	websocket.NewConnection(l.svcCtx.WsManager, w, r, userId)
+func (l *OpenConnectionLogic) OpenConnection(w http.ResponseWriter, r *http.Request) {
+	userId, err := l.svcCtx.Authenticator.Authenticate(r)
+	if err != nil {
+		// 鉴权失败也完成握手，以 error 消息告知客户端原因后关闭
+		if err := websocket.RejectConnection(l.svcCtx.WsManager, w, r, err); err != nil {
+			l.Errorf("reject websocket connection failed: %v", err)
+		}
+		return
+	}
+	if err := websocket.NewConnection(l.svcCtx.WsManager, w, r, userId); err != nil {
+		l.Errorf("open websocket connection for user %s failed: %v", userId, err)
+	}
 }
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+type AdminAuthMiddleware struct {
+	token string
+}
+
+func NewAdminAuthMiddleware(token string) *AdminAuthMiddleware {
+	return &AdminAuthMiddleware{
+		token: token,
+	}
+}
+
+// Handle 校验 Authorization: Bearer 中的管理员 token，未配置 token 时拒绝所有请求
+func (m *AdminAuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if m.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
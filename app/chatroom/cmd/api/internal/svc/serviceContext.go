@@ -4,21 +4,98 @@
 package svc
 
 import (
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/bot"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/middleware"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/app/usercenter/cmd/rpc/usercenter"
+	"go-zero-voice-agent/pkg/chatbridge"
+
+	red "github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
 	Config config.Config
 
-	WsManager *websocket.WsManager
+	WsManager     *websocket.WsManager
+	Authenticator websocket.Authenticator
+
+	RoomModel        model.RoomModel
+	RoomMemberModel  model.RoomMemberModel
+	ChatMessageModel model.ChatMessageModel
+	UserMessageModel model.UserMessageModel
+	BotModel         model.BotModel
+	ReadCursorModel  model.ReadCursorModel
+
+	LlmChatRpc   llmchatservice.LlmChatService
+	LlmConfigRpc llmconfigservice.LlmConfigService
+
+	AdminAuth rest.Middleware
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	sqlConn := sqlx.NewMysql(c.DB.DataSource)
+	roomModel := model.NewRoomModel(sqlConn, c.Cache)
+	roomMemberModel := model.NewRoomMemberModel(sqlConn, c.Cache)
+	chatMessageModel := model.NewChatMessageModel(sqlConn, c.Cache)
+	userMessageModel := model.NewUserMessageModel(sqlConn, c.Cache)
+	botModel := model.NewBotModel(sqlConn, c.Cache)
+	readCursorModel := model.NewReadCursorModel(sqlConn, c.Cache)
+	rds := redis.MustNewRedis(c.Redis)
+	llmRpcClient := zrpc.MustNewClient(c.LlmRpcConf)
+	llmChatRpc := llmchatservice.NewLlmChatService(llmRpcClient)
+	llmConfigRpc := llmconfigservice.NewLlmConfigService(llmRpcClient)
+
 	wsManager := websocket.NewWsManager(&c.Websocket)
+	wsManager.RoomStore = websocket.NewModelRoomStore(roomModel, roomMemberModel)
+	wsManager.MessageStore = websocket.NewModelMessageStore(rds, chatMessageModel, userMessageModel)
+	wsManager.ReadStore = websocket.NewModelReadStore(readCursorModel, chatMessageModel)
+	if c.Websocket.EnableCluster {
+		cluster, err := websocket.NewCluster(wsManager, red.NewClient(&red.Options{
+			Addr:     c.Redis.Host,
+			Password: c.Redis.Pass,
+		}))
+		logx.Must(err)
+		wsManager.Cluster = cluster
+	}
+	wsManager.Bots = bot.NewRunner(wsManager.Ctx, c.Bot, bot.NewModelStore(botModel, roomMemberModel, chatMessageModel),
+		wsManager, llmChatRpc, llmConfigRpc, rds)
+	go chatbridge.Subscribe(wsManager.Ctx, c.Redis, wsManager.Deliver)
 
 	return &ServiceContext{
-		Config:    c,
-		WsManager: wsManager,
+		Config:           c,
+		WsManager:        wsManager,
+		Authenticator:    newAuthenticator(c.Auth),
+		RoomModel:        roomModel,
+		RoomMemberModel:  roomMemberModel,
+		ChatMessageModel: chatMessageModel,
+		UserMessageModel: userMessageModel,
+		BotModel:         botModel,
+		ReadCursorModel:  readCursorModel,
+		LlmChatRpc:       llmChatRpc,
+		LlmConfigRpc:     llmConfigRpc,
+		AdminAuth:        middleware.NewAdminAuthMiddleware(c.Admin.Token).Handle,
+	}
+}
+
+// newAuthenticator 按配置的鉴权方式创建握手鉴权器，配置无效时拒绝启动
+func newAuthenticator(c config.AuthConf) websocket.Authenticator {
+	logx.Must(c.Validate())
+	switch c.Mode {
+	case "rpc":
+		return websocket.NewRpcAuthenticator(usercenter.NewUsercenter(zrpc.MustNewClient(c.UsercenterRpcConf)))
+	case "header":
+		logx.Infof("warning: chatroom trusts the X-User-Id header without verification, only use it behind a gateway that authenticates the token")
+		return websocket.NewHeaderAuthenticator()
+	default:
+		return websocket.NewSecretAuthenticator(c.AccessSecret)
 	}
 }
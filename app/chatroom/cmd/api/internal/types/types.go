@@ -3,5 +3,243 @@
 
 package types
 
+type AddRoomBotReq struct {
+	UserId int64 `header:"X-User-Id"`
+	RoomId int64 `path:"roomId"`
+	BotId  int64 `json:"botId"`
+}
+
+type AdminBackpressure struct {
+	DropOnFull    bool  `json:"dropOnFull"`
+	SendTimeoutMs int64 `json:"sendTimeoutMs,optional"` // 非丢弃模式下的发送等待时间,为 0 时保持不变
+}
+
+type AdminConnection struct {
+	Id           string   `json:"id"`
+	UserId       int64    `json:"userId"`
+	Node         string   `json:"node"` // 集群节点,单机模式为空
+	Shard        int64    `json:"shard"`
+	Status       string   `json:"status"`      // online/away
+	Subprotocol  string   `json:"subprotocol"` // 协商的帧编码
+	Rooms        []string `json:"rooms"`
+	Buffered     int64    `json:"buffered"` // 发送缓冲区中等待发送的消息数
+	LastPingTime int64    `json:"lastPingTime"`
+}
+
+type AdminKickReq struct {
+	ConnectionId string `json:"connectionId,optional"`
+	UserId       int64  `json:"userId,optional"` // 未指定连接时断开用户的所有连接
+}
+
+type AdminKickResp struct {
+	Kicked int64 `json:"kicked"` // 处理请求的节点上断开的连接数,其他节点异步断开
+}
+
+type AdminListConnectionsReq struct {
+	Node   string `json:"node,optional"` // 为空时查询处理请求的节点
+	UserId int64  `json:"userId,optional"`
+	Shard  int64  `json:"shard,default=-1"` // 负数表示所有分片
+}
+
+type AdminListConnectionsResp struct {
+	List []AdminConnection `json:"list"`
+}
+
+type AdminRoomMemberReq struct {
+	RoomId int64 `path:"roomId"`
+	UserId int64 `path:"userId"`
+}
+
+type AdminRoomMemberResp struct {
+	RoomExists bool   `json:"roomExists"` // 房间不存在或已删除时为 false
+	OwnerId    int64  `json:"ownerId"`
+	Role       string `json:"role"` // owner/admin/member/muted,不是成员时为空
+}
+
+type AdminShardStats struct {
+	Shard       int64 `json:"shard"`
+	Connections int64 `json:"connections"`
+	QueueDepth  int64 `json:"queueDepth"`  // 等待 worker 处理的广播任务
+	Buffered    int64 `json:"buffered"`    // 连接发送缓冲区中的消息总数
+	Dropped     int64 `json:"dropped"`     // 发送缓冲区满丢弃的消息
+	JobsDropped int64 `json:"jobsDropped"` // 广播队列满丢弃的任务
+}
+
+type AdminStatsReq struct {
+	Node string `form:"node,optional"` // 为空时查询处理请求的节点
+}
+
+type AdminStatsResp struct {
+	Node           string            `json:"node"`
+	Nodes          []string          `json:"nodes"`
+	Connections    int64             `json:"connections"`
+	BroadcastQueue int64             `json:"broadcastQueue"`
+	Backpressure   AdminBackpressure `json:"backpressure"`
+	Shards         []AdminShardStats `json:"shards"`
+}
+
+type AdminSystemMessageReq struct {
+	RoomId int64  `json:"roomId,optional"`
+	UserId int64  `json:"userId,optional"` // 房间和用户都为空时广播给所有连接
+	Text   string `json:"text"`
+}
+
+type BotInfo struct {
+	BotId        int64  `json:"botId"`
+	BotUserId    int64  `json:"botUserId"` // 机器人在聊天室中的用户 id,私聊时作为 to,房间中可放入 mentions
+	Name         string `json:"name"`
+	LlmConfigId  int64  `json:"llmConfigId"`
+	SystemPrompt string `json:"systemPrompt"`
+}
+
+type CreateBotReq struct {
+	UserId       int64  `header:"X-User-Id"`
+	Name         string `json:"name"`
+	LlmConfigId  int64  `json:"llmConfigId"`
+	SystemPrompt string `json:"systemPrompt,optional"`
+}
+
+type CreateBotResp struct {
+	BotId     int64 `json:"botId"`
+	BotUserId int64 `json:"botUserId"`
+}
+
+type CreateRoomReq struct {
+	UserId      int64  `header:"X-User-Id"`
+	Name        string `json:"name"`
+	Description string `json:"description,optional"`
+}
+
+type CreateRoomResp struct {
+	RoomId int64 `json:"roomId"`
+}
+
+type DirectHistoryReq struct {
+	UserId int64 `header:"X-User-Id"`
+	PeerId int64 `path:"peerId"`
+	Cursor int64 `form:"cursor,optional"` // 上一页返回的nextCursor,不传从最新消息开始
+	Limit  int64 `form:"limit,default=20,range=[1:100]"`
+}
+
+type DirectReceiptsReq struct {
+	UserId int64 `header:"X-User-Id"`
+	PeerId int64 `path:"peerId"`
+}
+
 type Empty struct {
 }
+
+type HistoryMessage struct {
+	Id         int64  `json:"id"`
+	Type       string `json:"type"`
+	FromUserId int64  `json:"fromUserId"`
+	ToUserId   int64  `json:"toUserId"` // 私聊接收者,房间消息为0
+	RoomId     int64  `json:"roomId"`   // 房间id,私聊消息为0
+	Data       string `json:"data"`     // 消息内容json
+	Timestamp  int64  `json:"timestamp"`
+}
+
+type HistoryResp struct {
+	List       []HistoryMessage `json:"list"`
+	NextCursor int64            `json:"nextCursor"` // 0 表示没有更早的消息
+}
+
+type InviteRoomMemberReq struct {
+	UserId       int64 `header:"X-User-Id"`
+	RoomId       int64 `path:"roomId"`
+	TargetUserId int64 `json:"targetUserId"`
+}
+
+type ListBotsReq struct {
+	UserId int64 `header:"X-User-Id"`
+}
+
+type ListBotsResp struct {
+	List []BotInfo `json:"list"`
+}
+
+type ListRoomMembersReq struct {
+	UserId int64 `header:"X-User-Id"`
+	RoomId int64 `path:"roomId"`
+}
+
+type ListRoomMembersResp struct {
+	List []RoomMemberInfo `json:"list"`
+}
+
+type ListRoomsReq struct {
+	UserId int64 `header:"X-User-Id"`
+}
+
+type ListRoomsResp struct {
+	List []RoomInfo `json:"list"`
+}
+
+type QueryPresenceReq struct {
+	UserId  int64   `header:"X-User-Id"`
+	UserIds []int64 `json:"userIds"`
+}
+
+type QueryPresenceResp struct {
+	List []UserPresence `json:"list"`
+}
+
+type ReadReceipt struct {
+	UserId    int64 `json:"userId"`
+	MessageId int64 `json:"messageId"` // 已读到的消息id
+	ReadTime  int64 `json:"readTime"`
+}
+
+type ReadinessResp struct {
+	Status      string `json:"status"`      // ready/draining
+	Connections int64  `json:"connections"` // 节点上剩余的连接数
+}
+
+type ReceiptsResp struct {
+	List []ReadReceipt `json:"list"`
+}
+
+type RemoveRoomMemberReq struct {
+	UserId       int64 `header:"X-User-Id"`
+	RoomId       int64 `path:"roomId"`
+	TargetUserId int64 `json:"targetUserId"`
+}
+
+type RoomHistoryReq struct {
+	UserId int64 `header:"X-User-Id"`
+	RoomId int64 `path:"roomId"`
+	Cursor int64 `form:"cursor,optional"` // 上一页返回的nextCursor,不传从最新消息开始
+	Limit  int64 `form:"limit,default=20,range=[1:100]"`
+}
+
+type RoomInfo struct {
+	RoomId      int64  `json:"roomId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OwnerId     int64  `json:"ownerId"`
+	Role        string `json:"role"` // 当前用户在房间中的角色
+}
+
+type RoomMemberInfo struct {
+	UserId   int64  `json:"userId"`
+	Role     string `json:"role"` // owner/admin/member/muted
+	Online   bool   `json:"online"`
+	JoinTime int64  `json:"joinTime"`
+}
+
+type RoomReceiptsReq struct {
+	UserId int64 `header:"X-User-Id"`
+	RoomId int64 `path:"roomId"`
+}
+
+type SetRoomMemberRoleReq struct {
+	UserId       int64  `header:"X-User-Id"`
+	RoomId       int64  `path:"roomId"`
+	TargetUserId int64  `json:"targetUserId"`
+	Role         string `json:"role,options=admin|member|muted"`
+}
+
+type UserPresence struct {
+	UserId int64  `json:"userId"`
+	Status string `json:"status"` // online/away/offline
+}
@@ -1,9 +1,12 @@
 package types
 
 type Message struct {
+	ID        int64       `json:"id,omitempty"`  // 持久化后的消息id
+	Seq       int64       `json:"seq,omitempty"` // 接收者的消息序号，客户端据此确认、去重和补发
 	Type      string      `json:"type"`
 	From      string      `json:"from,omitempty"`
 	To        string      `json:"to,omitempty"`
+	Room      string      `json:"room,omitempty"`
 	Data      interface{} `json:"data"`
 	Timestamp int64       `json:"timestamp"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,255 @@
+package websocket
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	wsTool "github.com/gorilla/websocket"
+)
+
+// ConnectionFilter 查询连接的条件，UserID 为空或 Shard 为负数时不按该条件过滤
+type ConnectionFilter struct {
+	UserID string `json:"userId,omitempty"`
+	Shard  int    `json:"shard"`
+}
+
+// ConnectionInfo 连接的管理视图
+type ConnectionInfo struct {
+	ID           string   `json:"id"`
+	UserID       string   `json:"userId"`
+	Node         string   `json:"node"`
+	Shard        int      `json:"shard"`
+	Status       string   `json:"status"`
+	Subprotocol  string   `json:"subprotocol"`
+	Rooms        []string `json:"rooms"`
+	Buffered     int      `json:"buffered"`
+	LastPingTime int64    `json:"lastPingTime"`
+}
+
+// ShardStats 分片的连接数、队列深度与丢弃计数
+type ShardStats struct {
+	Shard       int   `json:"shard"`
+	Connections int   `json:"connections"`
+	QueueDepth  int64 `json:"queueDepth"`
+	Buffered    int   `json:"buffered"`
+	Dropped     int64 `json:"dropped"`
+	JobsDropped int64 `json:"jobsDropped"`
+}
+
+// Backpressure 发送缓冲区满时的处理方式，可在运行时修改
+type Backpressure struct {
+	DropOnFull  bool          `json:"dropOnFull"`
+	SendTimeout time.Duration `json:"sendTimeout"`
+}
+
+// Stats 节点的运行状态
+type Stats struct {
+	Node           string       `json:"node"`
+	Connections    int64        `json:"connections"`
+	BroadcastQueue int          `json:"broadcastQueue"`
+	Backpressure   Backpressure `json:"backpressure"`
+	Shards         []ShardStats `json:"shards"`
+}
+
+// NodeID 集群模式下的节点 ID，单机模式为空
+func (m *WsManager) NodeID() string {
+	if m.Cluster == nil {
+		return ""
+	}
+	return m.Cluster.NodeID
+}
+
+// Nodes 集群中的所有节点，单机模式只有本节点
+func (m *WsManager) Nodes(ctx context.Context) ([]string, error) {
+	if m.Cluster == nil {
+		return []string{m.NodeID()}, nil
+	}
+	nodes, err := m.Cluster.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// ListConnections 查询节点上的连接，node 为空时查询本节点
+func (m *WsManager) ListConnections(ctx context.Context, node string, filter ConnectionFilter) ([]ConnectionInfo, error) {
+	if node == "" || node == m.NodeID() {
+		return m.localConnections(filter), nil
+	}
+	if m.Cluster == nil {
+		return nil, xerr.NewErrMsg("cluster mode is not enabled")
+	}
+	var list []ConnectionInfo
+	if err := m.Cluster.query(ctx, node, clusterQueryConnections, filter, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// NodeStats 查询节点的运行状态，node 为空时查询本节点
+func (m *WsManager) NodeStats(ctx context.Context, node string) (*Stats, error) {
+	if node == "" || node == m.NodeID() {
+		return m.localStats(), nil
+	}
+	if m.Cluster == nil {
+		return nil, xerr.NewErrMsg("cluster mode is not enabled")
+	}
+	var stats Stats
+	if err := m.Cluster.query(ctx, node, clusterQueryStats, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (m *WsManager) localConnections(filter ConnectionFilter) []ConnectionInfo {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+
+	var conns []*Connection
+	if filter.UserID != "" {
+		for connID := range m.UserConnections[filter.UserID] {
+			if conn, ok := m.Connections[connID]; ok {
+				conns = append(conns, conn)
+			}
+		}
+	} else {
+		for _, conn := range m.Connections {
+			conns = append(conns, conn)
+		}
+	}
+
+	list := make([]ConnectionInfo, 0, len(conns))
+	for _, conn := range conns {
+		shard := m.shardIndex(conn.ID)
+		if filter.Shard >= 0 && shard != filter.Shard {
+			continue
+		}
+		rooms := make([]string, 0, len(conn.Rooms))
+		for room := range conn.Rooms {
+			rooms = append(rooms, room)
+		}
+		sort.Strings(rooms)
+		conn.mu.RLock()
+		lastPing := conn.LastPingTime.Unix()
+		conn.mu.RUnlock()
+		list = append(list, ConnectionInfo{
+			ID:           conn.ID,
+			UserID:       conn.UserID,
+			Node:         m.NodeID(),
+			Shard:        shard,
+			Status:       conn.Status,
+			Subprotocol:  conn.codec.Subprotocol(),
+			Rooms:        rooms,
+			Buffered:     len(conn.Send),
+			LastPingTime: lastPing,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (m *WsManager) localStats() *Stats {
+	return &Stats{
+		Node:           m.NodeID(),
+		Connections:    atomic.LoadInt64(&m.ConnectionCount),
+		BroadcastQueue: len(m.BroadcastJobs),
+		Backpressure:   m.Backpressure(),
+		Shards:         m.shardStats(),
+	}
+}
+
+func (m *WsManager) shardStats() []ShardStats {
+	shards := make([]ShardStats, m.ShardCount)
+	for i := range shards {
+		shards[i] = ShardStats{
+			Shard:       i,
+			QueueDepth:  atomic.LoadInt64(&m.shardCounters[i].pending),
+			Dropped:     atomic.LoadInt64(&m.shardCounters[i].dropped),
+			JobsDropped: atomic.LoadInt64(&m.shardCounters[i].jobsDropped),
+		}
+		m.ShardLocks[i].RLock()
+		shards[i].Connections = len(m.ShardConns[i])
+		for _, conn := range m.ShardConns[i] {
+			shards[i].Buffered += len(conn.Send)
+		}
+		m.ShardLocks[i].RUnlock()
+	}
+	return shards
+}
+
+// Kick 断开连接，connID 为空时断开用户的所有连接，集群模式下同时通知其他节点，返回本节点断开的连接数
+func (m *WsManager) Kick(connID, userID string) int {
+	kicked := m.kickLocal(connID, userID)
+	if m.Cluster != nil && (kicked == 0 || connID == "") {
+		m.Cluster.forwardKick(connID, userID)
+	}
+	return kicked
+}
+
+func (m *WsManager) kickLocal(connID, userID string) int {
+	m.Mu.RLock()
+	var conns []*Connection
+	if connID != "" {
+		if conn, ok := m.Connections[connID]; ok {
+			conns = append(conns, conn)
+		}
+	} else {
+		for id := range m.UserConnections[userID] {
+			if conn, ok := m.Connections[id]; ok {
+				conns = append(conns, conn)
+			}
+		}
+	}
+	// 先放入 error 消息，注销后 writePump 发出关闭帧再断开；读协程在断开前收到的消息产生的回复直接丢弃
+	for _, conn := range conns {
+		m.logx.Infof("kick websocket connection %s of user %s", conn.ID, conn.UserID)
+		conn.closeWithError(xerr.NewErrCode(xerr.WS_KICKED_ERROR), wsTool.ClosePolicyViolation)
+	}
+	m.Mu.RUnlock()
+
+	for _, conn := range conns {
+		m.Unregister <- conn
+	}
+	return len(conns)
+}
+
+// SendSystem 发送系统消息，room 和 userID 都为空时广播给所有连接
+func (m *WsManager) SendSystem(room, userID string, data interface{}) {
+	m.emit(&types.Message{
+		Type:      MessageTypeSystem,
+		To:        userID,
+		Room:      room,
+		Data:      data,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// Backpressure 当前的背压设置
+func (m *WsManager) Backpressure() Backpressure {
+	return Backpressure{
+		DropOnFull:  m.dropOnFull.Load(),
+		SendTimeout: time.Duration(m.sendTimeout.Load()),
+	}
+}
+
+// SetBackpressure 修改背压设置，集群模式下同步到所有节点
+func (m *WsManager) SetBackpressure(conf Backpressure) {
+	m.setLocalBackpressure(conf)
+	if m.Cluster != nil {
+		m.Cluster.forwardBackpressure(conf)
+	}
+}
+
+func (m *WsManager) setLocalBackpressure(conf Backpressure) {
+	m.dropOnFull.Store(conf.DropOnFull)
+	if conf.SendTimeout > 0 {
+		m.sendTimeout.Store(int64(conf.SendTimeout))
+	}
+	m.logx.Infof("websocket backpressure updated: dropOnFull=%v sendTimeout=%s", conf.DropOnFull, m.Backpressure().SendTimeout)
+}
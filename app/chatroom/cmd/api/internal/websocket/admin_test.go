@@ -0,0 +1,164 @@
+package websocket
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/alicebob/miniredis/v2"
+	wsTool "github.com/gorilla/websocket"
+)
+
+func TestAdminStatsAndKick(t *testing.T) {
+	m := newTestManager(nil)
+	defer m.Cancel()
+
+	newTestConnection(t, m, "c1", "1")
+	c2 := newTestConnection(t, m, "c2", "2")
+
+	// 不读取发送缓冲区，超出缓冲区的消息被丢弃并计入所在分片
+	for i := 0; i < m.Config.MessageBufferSize+5; i++ {
+		m.Broadcast <- &types.Message{Type: "chat", From: "1", To: "2"}
+	}
+	shard := m.shardIndex(c2.ID)
+	waitUntil(t, func() bool {
+		stats, _ := m.NodeStats(context.Background(), "")
+		return stats.Shards[shard].Dropped == 5
+	})
+	stats, _ := m.NodeStats(context.Background(), "")
+	if stats.Connections != 2 || stats.Shards[shard].Buffered != m.Config.MessageBufferSize {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	list, _ := m.ListConnections(context.Background(), "", ConnectionFilter{UserID: "2", Shard: -1})
+	if len(list) != 1 || list[0].ID != "c2" || list[0].Shard != shard || list[0].Subprotocol != SubprotocolJSON {
+		t.Fatalf("unexpected connections: %+v", list)
+	}
+	if list, _ := m.ListConnections(context.Background(), "", ConnectionFilter{Shard: (shard + 1) % m.ShardCount}); len(list) > 1 {
+		t.Fatalf("shard filter not applied: %+v", list)
+	}
+
+	for len(c2.Send) > 0 {
+		<-c2.Send
+	}
+	if kicked := m.Kick("", "2"); kicked != 1 {
+		t.Fatalf("kicked %d connections, want 1", kicked)
+	}
+	if msg := waitMessage(t, c2, MessageTypeError); !hasErrorCode(msg, xerr.WS_KICKED_ERROR) {
+		t.Fatalf("unexpected error message: %+v", msg)
+	}
+	waitUntil(t, func() bool { return !m.OnlineUsers(m.Ctx, []string{"2"})["2"] })
+
+	m.SetBackpressure(Backpressure{DropOnFull: false, SendTimeout: 10 * time.Millisecond})
+	if conf := m.Backpressure(); conf.DropOnFull || conf.SendTimeout != 10*time.Millisecond {
+		t.Fatalf("unexpected backpressure: %+v", conf)
+	}
+	// 超时为 0 时保持原值
+	m.SetBackpressure(Backpressure{DropOnFull: true})
+	if conf := m.Backpressure(); !conf.DropOnFull || conf.SendTimeout != 10*time.Millisecond {
+		t.Fatalf("unexpected backpressure: %+v", conf)
+	}
+}
+
+func TestClusterAdmin(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newClusterNode(t, mr, "node-a", nil)
+	defer a.Cancel()
+	b := newClusterNode(t, mr, "node-b", nil)
+	defer b.Cancel()
+
+	c2 := newTestConnection(t, b, "c2", "2")
+	waitUntil(t, func() bool { return len(setMembers(t, mr, clusterUserNodesPrefix+"2")) == 1 })
+
+	ctx := context.Background()
+	if nodes, _ := a.Nodes(ctx); !reflect.DeepEqual(nodes, []string{"node-a", "node-b"}) {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	}
+	list, err := a.ListConnections(ctx, "node-b", ConnectionFilter{Shard: -1})
+	if err != nil || len(list) != 1 || list[0].ID != "c2" || list[0].Node != "node-b" {
+		t.Fatalf("unexpected remote connections: %+v %v", list, err)
+	}
+	if stats, err := a.NodeStats(ctx, "node-b"); err != nil || stats.Node != "node-b" || stats.Connections != 1 {
+		t.Fatalf("unexpected remote stats: %+v %v", stats, err)
+	}
+	if _, err := a.NodeStats(ctx, "node-x"); err == nil {
+		t.Fatal("expected error for unknown node")
+	}
+
+	// 背压设置同步到其他节点
+	a.SetBackpressure(Backpressure{DropOnFull: false, SendTimeout: time.Second})
+	waitUntil(t, func() bool { return !b.Backpressure().DropOnFull && b.Backpressure().SendTimeout == time.Second })
+
+	// 本节点没有该用户的连接时由所在节点断开
+	if kicked := a.Kick("", "2"); kicked != 0 {
+		t.Fatalf("kicked %d local connections, want 0", kicked)
+	}
+	if msg := waitMessage(t, c2, MessageTypeError); !hasErrorCode(msg, xerr.WS_KICKED_ERROR) {
+		t.Fatalf("unexpected error message: %+v", msg)
+	}
+	waitUntil(t, func() bool { return len(setMembers(t, mr, clusterUserNodesPrefix+"2")) == 0 })
+}
+
+// pingLoop 每隔 interval 发送一次 ping，直到连接断开，interval 为 0 时不间断发送
+func pingLoop(conn *wsTool.Conn, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if err := conn.WriteMessage(wsTool.TextMessage, []byte(`{"type":"ping"}`)); err != nil {
+				return
+			}
+			time.Sleep(interval)
+		}
+	}()
+	return done
+}
+
+func TestKickWhileClientSending(t *testing.T) {
+	m := newTestManager(nil)
+	defer m.Cancel()
+	url := newHandshakeServer(t, m) + "?token=" + signToken(t, testSecret, 1)
+
+	conn, _, err := wsTool.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	waitUntil(t, func() bool { return m.DrainStatus().Connections == 1 })
+	m.Mu.RLock()
+	var server *Connection
+	for _, c := range m.Connections {
+		server = c
+	}
+	m.Mu.RUnlock()
+
+	// 客户端持续发送 ping，踢下线后读协程仍在处理消息，回复不能写入已关闭的发送缓冲区
+	writing := pingLoop(conn, 0)
+	time.Sleep(20 * time.Millisecond)
+	if kicked := m.Kick("", "1"); kicked != 1 {
+		t.Fatalf("kicked %d connections, want 1", kicked)
+	}
+	// 发送缓冲区被 pong 占满时 error 消息会被丢弃，但仍以 1008 关闭
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if !wsTool.IsCloseError(err, wsTool.ClosePolicyViolation) {
+				t.Fatalf("close error = %v, want code %d", err, wsTool.ClosePolicyViolation)
+			}
+			break
+		}
+	}
+	conn.Close()
+	<-writing
+	waitUntil(t, func() bool { return m.DrainStatus().Connections == 0 })
+
+	// 注销后的回复直接丢弃
+	server.handleMessage([]byte(`{"type":"ping"}`))
+	if err := server.SendMessage(&types.Message{Type: MessageTypePong}); err != errConnectionClosed {
+		t.Fatalf("send after kick error = %v, want %v", err, errConnectionClosed)
+	}
+}
@@ -0,0 +1,93 @@
+package websocket
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-zero-voice-agent/app/usercenter/cmd/rpc/usercenter"
+	"go-zero-voice-agent/pkg/tool"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+)
+
+// Authenticator 握手时校验客户端身份，返回用户 id
+type Authenticator interface {
+	Authenticate(r *http.Request) (string, error)
+}
+
+type headerAuthenticator struct{}
+
+// NewHeaderAuthenticator 信任网关鉴权后注入的 X-User-Id
+func NewHeaderAuthenticator() Authenticator {
+	return headerAuthenticator{}
+}
+
+func (headerAuthenticator) Authenticate(r *http.Request) (string, error) {
+	userId, err := tool.GetUserIdFromHeader(r)
+	if err != nil {
+		return "", errors.Wrapf(xerr.NewErrCode(xerr.TOKEN_EXPIRE_ERROR), "header auth failed: %v", err)
+	}
+	return userId, nil
+}
+
+type secretAuthenticator struct {
+	secret []byte
+}
+
+// NewSecretAuthenticator 用与 usercenter 相同的签名密钥在本地校验 JWT
+func NewSecretAuthenticator(secret string) Authenticator {
+	return &secretAuthenticator{secret: []byte(secret)}
+}
+
+func (a *secretAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token, err := jwt.Parse(requestToken(r), func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return a.secret, nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(xerr.NewErrCode(xerr.TOKEN_EXPIRE_ERROR), "parse token failed: %v", err)
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", errors.Wrap(xerr.NewErrCode(xerr.TOKEN_EXPIRE_ERROR), "invalid token claims")
+	}
+	userId, ok := claims["userId"].(float64)
+	if !ok {
+		return "", errors.Wrap(xerr.NewErrCode(xerr.TOKEN_EXPIRE_ERROR), "token has no userId claim")
+	}
+	return strconv.FormatInt(int64(userId), 10), nil
+}
+
+type rpcAuthenticator struct {
+	usercenterRpc usercenter.Usercenter
+}
+
+// NewRpcAuthenticator 调用 usercenter 的 VerifyToken 校验 JWT
+func NewRpcAuthenticator(usercenterRpc usercenter.Usercenter) Authenticator {
+	return &rpcAuthenticator{usercenterRpc: usercenterRpc}
+}
+
+func (a *rpcAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token := requestToken(r)
+	if token == "" {
+		return "", errors.Wrap(xerr.NewErrCode(xerr.TOKEN_EXPIRE_ERROR), "missing token")
+	}
+	resp, err := a.usercenterRpc.VerifyToken(r.Context(), &usercenter.VerifyTokenReq{Token: token})
+	if err != nil {
+		return "", errors.Wrapf(xerr.NewErrCode(xerr.TOKEN_EXPIRE_ERROR), "verify token failed: %v", err)
+	}
+	return strconv.FormatInt(resp.UserId, 10), nil
+}
+
+// requestToken 优先取 Authorization 头，浏览器无法设置握手请求头时使用 token 查询参数
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return r.URL.Query().Get("token")
+}
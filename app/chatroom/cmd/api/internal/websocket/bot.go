@@ -0,0 +1,37 @@
+package websocket
+
+import (
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+// BotHandler 处理发给机器人或在房间中提及机器人的消息
+type BotHandler interface {
+	// OnMessage 用户的私聊或房间 chat 消息投递之后调用，不能阻塞
+	OnMessage(msg *types.Message)
+	// OnToolReply 用户确认或拒绝机器人发起的工具调用
+	OnToolReply(userID string, msg *types.Message) error
+}
+
+// Push 投递不需要保存的消息，如机器人回复的增量分片
+func (m *WsManager) Push(msg *types.Message) {
+	m.emit(msg)
+}
+
+// PushError 把错误作为 error 消息发给用户的所有连接
+func (m *WsManager) PushError(userID string, err error) {
+	m.emit(&types.Message{Type: MessageTypeError, To: userID, Data: errorEvent(err), Timestamp: time.Now().Unix()})
+}
+
+// handleBotToolReply 用户对机器人工具调用的确认结果
+func (c *Connection) handleBotToolReply(msg types.Message) {
+	if c.WsManager.Bots == nil {
+		c.sendError(xerr.NewErrMsg("bots are not enabled"))
+		return
+	}
+	if err := c.WsManager.Bots.OnToolReply(c.UserID, &msg); err != nil {
+		c.sendError(err)
+	}
+}
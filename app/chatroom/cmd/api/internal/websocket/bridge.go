@@ -0,0 +1,20 @@
+package websocket
+
+import (
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/chatbridge"
+)
+
+// Deliver 投递其他服务通过 chatbridge 发来的消息（如语音房间的识别文字）。
+// 每个节点都订阅了 chatbridge，集群模式下也只投递给本节点的连接，不再转发
+func (m *WsManager) Deliver(msg *chatbridge.Message) {
+	message := &types.Message{
+		Type:      msg.Type,
+		From:      msg.From,
+		To:        msg.To,
+		Room:      msg.Room,
+		Data:      msg.Data,
+		Timestamp: msg.Timestamp,
+	}
+	m.dispatchLocal(message, newFrame(message))
+}
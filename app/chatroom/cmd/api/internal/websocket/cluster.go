@@ -0,0 +1,624 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	red "github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 集群模式的 Redis 频道与登记表
+const (
+	clusterBroadcastChannel  = "chatroom:cluster:broadcast"   // 全局广播
+	clusterNodeChannelPrefix = "chatroom:cluster:node:"       // 发给指定节点
+	clusterNodesKey          = "chatroom:cluster:nodes"       // 所有节点
+	clusterAliveKeyPrefix    = "chatroom:cluster:alive:"      // 节点存活标记，带过期时间
+	clusterUserNodesPrefix   = "chatroom:cluster:user:"       // 用户 -> 有其连接的节点
+	clusterRoomNodesPrefix   = "chatroom:cluster:room:"       // 房间 -> 有其订阅连接的节点
+	clusterNodeUsersPrefix   = "chatroom:cluster:node_users:" // 节点 -> 登记的用户，节点失效时据此清理
+	clusterNodeRoomsPrefix   = "chatroom:cluster:node_rooms:" // 节点 -> 登记的房间
+	clusterPresencePrefix    = "chatroom:cluster:presence:"   // 用户 -> 各节点上的聚合在线状态
+	clusterReplyPrefix       = "chatroom:cluster:reply:"      // 管理查询的一次性回复列表
+)
+
+const (
+	clusterNodeTTL           = 30 * time.Second
+	clusterHeartbeatInterval = 10 * time.Second
+	clusterTaskQueueSize     = 4096
+	clusterDedupSize         = 4096
+	clusterQueryTimeout      = 3 * time.Second
+)
+
+// 节点间转发的消息类型
+const (
+	clusterKindUser       = "user"         // 发给用户
+	clusterKindRoom       = "room"         // 发给房间
+	clusterKindAll        = "all"          // 全局广播
+	clusterKindRoomRole   = "room_role"    // 更新用户在房间中的角色
+	clusterKindRoomRemove = "room_remove"  // 用户退出房间
+	clusterKindPresence   = "presence"     // 用户在线状态变化
+	clusterKindKick       = "kick"         // 管理员断开连接或用户
+	clusterKindBackpress  = "backpressure" // 管理员修改背压设置
+	clusterKindQuery      = "query"        // 管理员查询节点的连接或状态
+)
+
+// 管理查询的类型
+const (
+	clusterQueryConnections = "connections"
+	clusterQueryStats       = "stats"
+)
+
+type clusterEnvelope struct {
+	ID     string          `json:"id"`
+	Origin string          `json:"origin"`
+	Kind   string          `json:"kind"`
+	Target string          `json:"target,omitempty"` // 用户或房间
+	Room   string          `json:"room,omitempty"`
+	Role   string          `json:"role,omitempty"`
+	Status string          `json:"status,omitempty"`
+	Conn   string          `json:"conn,omitempty"`  // 连接 ID
+	Query  string          `json:"query,omitempty"` // 管理查询的类型，回复写入 Target
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+// Cluster 基于 Redis Pub/Sub 的跨节点投递：每个节点订阅自己的频道和全局广播频道，
+// 在 Redis 中登记本节点上的用户和房间，发给用户或房间的消息只转发到登记了它们的节点
+type Cluster struct {
+	NodeID string
+
+	manager *WsManager
+	client  *red.Client
+	tasks   chan func(ctx context.Context)
+	seq     uint64
+
+	seenMu    sync.Mutex
+	seen      map[string]struct{}
+	seenOrder []string
+	seenNext  int
+
+	logx logx.Logger
+}
+
+// NewCluster 登记节点并订阅集群频道，返回时已可以收到其他节点转发的消息
+func NewCluster(manager *WsManager, client *red.Client) (*Cluster, error) {
+	nodeID := manager.Config.ClusterNodeID
+	if nodeID == "" {
+		host, _ := os.Hostname()
+		nodeID = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+
+	c := &Cluster{
+		NodeID:    nodeID,
+		manager:   manager,
+		client:    client,
+		tasks:     make(chan func(ctx context.Context), clusterTaskQueueSize),
+		seen:      make(map[string]struct{}, clusterDedupSize),
+		seenOrder: make([]string, clusterDedupSize),
+		logx:      logx.WithContext(manager.Ctx),
+	}
+
+	ctx := manager.Ctx
+	// 同一节点 ID 重启时先清掉上次遗留的登记
+	if err := c.cleanupNode(ctx, nodeID); err != nil {
+		return nil, err
+	}
+	if err := c.heartbeat(ctx); err != nil {
+		return nil, err
+	}
+
+	sub := client.Subscribe(ctx, clusterNodeChannelPrefix+nodeID, clusterBroadcastChannel)
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, err
+	}
+
+	go c.receive(sub)
+	go c.runTasks()
+	go c.keepAlive()
+	return c, nil
+}
+
+// forward 把本节点发出的消息转发给其他节点，由运行循环调用，不阻塞
+func (c *Cluster) forward(message *types.Message, data []byte) {
+	env := &clusterEnvelope{Data: data}
+	switch {
+	case message.Room != "":
+		env.Kind, env.Target = clusterKindRoom, message.Room
+		c.enqueue(func(ctx context.Context) {
+			c.publishToNodes(ctx, clusterRoomNodesPrefix+message.Room, env)
+		})
+	case message.To != "":
+		env.Kind, env.Target = clusterKindUser, message.To
+		c.enqueue(func(ctx context.Context) {
+			c.publishToNodes(ctx, clusterUserNodesPrefix+message.To, env)
+		})
+	default:
+		env.Kind = clusterKindAll
+		c.enqueue(func(ctx context.Context) {
+			c.publish(ctx, clusterBroadcastChannel, env)
+		})
+	}
+}
+
+func (c *Cluster) forwardRoomRole(room, userID, role string) {
+	env := &clusterEnvelope{Kind: clusterKindRoomRole, Target: userID, Room: room, Role: role}
+	c.enqueue(func(ctx context.Context) {
+		c.publishToNodes(ctx, clusterUserNodesPrefix+userID, env)
+	})
+}
+
+func (c *Cluster) forwardRoomRemove(room, userID string) {
+	env := &clusterEnvelope{Kind: clusterKindRoomRemove, Target: userID, Room: room}
+	c.enqueue(func(ctx context.Context) {
+		c.publishToNodes(ctx, clusterUserNodesPrefix+userID, env)
+	})
+}
+
+// userOnline 用户在本节点的第一个连接注册，调用方可能持有 WsManager.Mu
+func (c *Cluster) userOnline(userID string) {
+	c.enqueue(func(ctx context.Context) {
+		c.register(ctx, clusterUserNodesPrefix+userID, clusterNodeUsersPrefix+c.NodeID, userID)
+	})
+}
+
+// userOffline 用户在本节点的最后一个连接断开
+func (c *Cluster) userOffline(userID string) {
+	c.enqueue(func(ctx context.Context) {
+		c.unregister(ctx, clusterUserNodesPrefix+userID, clusterNodeUsersPrefix+c.NodeID, userID)
+	})
+}
+
+// roomActive 本节点第一个连接订阅房间
+func (c *Cluster) roomActive(room string) {
+	c.enqueue(func(ctx context.Context) {
+		c.register(ctx, clusterRoomNodesPrefix+room, clusterNodeRoomsPrefix+c.NodeID, room)
+	})
+}
+
+// roomInactive 本节点最后一个连接退订房间
+func (c *Cluster) roomInactive(room string) {
+	c.enqueue(func(ctx context.Context) {
+		c.unregister(ctx, clusterRoomNodesPrefix+room, clusterNodeRoomsPrefix+c.NodeID, room)
+	})
+}
+
+// onlineUsers 在集群任一节点上有连接的用户
+func (c *Cluster) onlineUsers(ctx context.Context, userIDs []string) (map[string]bool, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*red.IntCmd, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = pipe.Exists(ctx, clusterUserNodesPrefix+userID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != red.Nil {
+		return nil, err
+	}
+	online := make(map[string]bool, len(userIDs))
+	for i, userID := range userIDs {
+		online[userID] = cmds[i].Val() > 0
+	}
+	return online, nil
+}
+
+// updatePresence 登记用户在本节点上的状态，合并各节点后的状态变化时通知所有节点的订阅者
+func (c *Cluster) updatePresence(userID, status string) {
+	c.enqueue(func(ctx context.Context) {
+		prev, next, err := c.setPresence(ctx, userID, status)
+		if err != nil {
+			c.logx.Errorf("cluster update presence of %s failed: %v", userID, err)
+			return
+		}
+		c.presenceChanged(ctx, userID, prev, next)
+	})
+}
+
+// presenceChanged 合并各节点后的状态变化时通知本节点和其他节点的订阅者
+func (c *Cluster) presenceChanged(ctx context.Context, userID, prev, next string) {
+	if next == prev {
+		return
+	}
+	c.manager.notifyPresence(userID, next)
+	c.publish(ctx, clusterBroadcastChannel, &clusterEnvelope{Kind: clusterKindPresence, Target: userID, Status: next})
+}
+
+// clusterPresenceScript 在一次原子操作中写入（状态为空时删除）本节点的状态，并返回写入前后各节点的状态，
+// 多个节点同时更新同一用户时不会基于过期的快照计算状态变化
+var clusterPresenceScript = red.NewScript(`
+local prev = redis.call('HVALS', KEYS[1])
+if ARGV[2] == '' then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+else
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+end
+return {prev, redis.call('HVALS', KEYS[1])}
+`)
+
+// setPresence 更新本节点上的用户状态，返回更新前后合并各节点的状态
+func (c *Cluster) setPresence(ctx context.Context, userID, status string) (string, string, error) {
+	return c.setNodePresence(ctx, c.NodeID, userID, status)
+}
+
+// setNodePresence 更新指定节点上的用户状态，清理失效节点时也用它移除该节点上的状态
+func (c *Cluster) setNodePresence(ctx context.Context, node, userID, status string) (string, string, error) {
+	if status == PresenceOffline {
+		status = ""
+	}
+	result, err := clusterPresenceScript.Run(ctx, c.client, []string{clusterPresencePrefix + userID}, node, status).Slice()
+	if err != nil {
+		return "", "", err
+	}
+	if len(result) != 2 {
+		return "", "", fmt.Errorf("unexpected presence script result: %v", result)
+	}
+	return aggregatePresence(scriptStrings(result[0])...), aggregatePresence(scriptStrings(result[1])...), nil
+}
+
+// scriptStrings 把 Lua 脚本返回的数组转换为字符串列表
+func scriptStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// presence 用户在各节点上的聚合状态
+func (c *Cluster) presence(ctx context.Context, userIDs []string) (map[string]string, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*red.MapStringStringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = pipe.HGetAll(ctx, clusterPresencePrefix+userID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != red.Nil {
+		return nil, err
+	}
+	statuses := make(map[string]string, len(userIDs))
+	for i, userID := range userIDs {
+		statuses[userID] = aggregatePresence(mapValues(cmds[i].Val())...)
+	}
+	return statuses, nil
+}
+
+// forwardKick 通知其他节点断开连接：指定连接时发给所有节点，否则只发给登记了用户的节点
+func (c *Cluster) forwardKick(connID, userID string) {
+	env := &clusterEnvelope{Kind: clusterKindKick, Target: userID, Conn: connID}
+	c.enqueue(func(ctx context.Context) {
+		if connID != "" {
+			c.publish(ctx, clusterBroadcastChannel, env)
+			return
+		}
+		c.publishToNodes(ctx, clusterUserNodesPrefix+userID, env)
+	})
+}
+
+// forwardBackpressure 把背压设置同步到其他节点
+func (c *Cluster) forwardBackpressure(conf Backpressure) {
+	data, err := json.Marshal(conf)
+	if err != nil {
+		c.logx.Errorf("failed to marshal backpressure: %v", err)
+		return
+	}
+	env := &clusterEnvelope{Kind: clusterKindBackpress, Data: data}
+	c.enqueue(func(ctx context.Context) {
+		c.publish(ctx, clusterBroadcastChannel, env)
+	})
+}
+
+// nodes 登记的所有节点
+func (c *Cluster) nodes(ctx context.Context) ([]string, error) {
+	return c.client.SMembers(ctx, clusterNodesKey).Result()
+}
+
+// query 向指定节点查询管理数据，节点把结果写入一次性的回复列表，超时未回复时返回错误
+func (c *Cluster) query(ctx context.Context, node, query string, args, result interface{}) error {
+	alive, err := c.client.Exists(ctx, clusterAliveKeyPrefix+node).Result()
+	if err != nil {
+		return errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "check cluster node %s failed: %v", node, err)
+	}
+	if alive == 0 {
+		return xerr.NewErrMsg("cluster node not found")
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	env := &clusterEnvelope{
+		ID:     fmt.Sprintf("%s:%d", c.NodeID, atomic.AddUint64(&c.seq, 1)),
+		Origin: c.NodeID,
+		Kind:   clusterKindQuery,
+		Query:  query,
+		Data:   data,
+	}
+	env.Target = clusterReplyPrefix + env.ID
+	c.publish(ctx, clusterNodeChannelPrefix+node, env)
+
+	reply, err := c.client.BLPop(ctx, clusterQueryTimeout, env.Target).Result()
+	if err == red.Nil {
+		return xerr.NewErrMsg("cluster node did not reply")
+	}
+	if err != nil {
+		return errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "wait reply of cluster node %s failed: %v", node, err)
+	}
+	return json.Unmarshal([]byte(reply[1]), result)
+}
+
+// answer 回复其他节点的管理查询
+func (c *Cluster) answer(env clusterEnvelope) {
+	var result interface{}
+	switch env.Query {
+	case clusterQueryConnections:
+		var filter ConnectionFilter
+		if err := json.Unmarshal(env.Data, &filter); err != nil {
+			c.logx.Errorf("invalid connection filter from %s: %v", env.Origin, err)
+			return
+		}
+		result = c.manager.localConnections(filter)
+	case clusterQueryStats:
+		result = c.manager.localStats()
+	default:
+		return
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		c.logx.Errorf("failed to marshal %s reply: %v", env.Query, err)
+		return
+	}
+	ctx := c.manager.Ctx
+	pipe := c.client.TxPipeline()
+	pipe.RPush(ctx, env.Target, payload)
+	pipe.Expire(ctx, env.Target, clusterQueryTimeout)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logx.Errorf("cluster reply %s failed: %v", env.Target, err)
+	}
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// enqueue Redis 操作按提交顺序在单独的协程中执行，保证同一个 key 的登记与注销不乱序
+func (c *Cluster) enqueue(task func(ctx context.Context)) {
+	select {
+	case c.tasks <- task:
+	default:
+		c.logx.Infof("warning: cluster task queue is full, dropping task")
+	}
+}
+
+func (c *Cluster) runTasks() {
+	for {
+		select {
+		case <-c.manager.Ctx.Done():
+			return
+		case task := <-c.tasks:
+			task(c.manager.Ctx)
+		}
+	}
+}
+
+func (c *Cluster) register(ctx context.Context, key, indexKey, member string) {
+	pipe := c.client.TxPipeline()
+	pipe.SAdd(ctx, key, c.NodeID)
+	pipe.SAdd(ctx, indexKey, member)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logx.Errorf("cluster register %s failed: %v", key, err)
+	}
+}
+
+func (c *Cluster) unregister(ctx context.Context, key, indexKey, member string) {
+	pipe := c.client.TxPipeline()
+	pipe.SRem(ctx, key, c.NodeID)
+	pipe.SRem(ctx, indexKey, member)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logx.Errorf("cluster unregister %s failed: %v", key, err)
+	}
+}
+
+// publishToNodes 转发给登记在 key 下的其他节点
+func (c *Cluster) publishToNodes(ctx context.Context, key string, env *clusterEnvelope) {
+	nodes, err := c.client.SMembers(ctx, key).Result()
+	if err != nil {
+		c.logx.Errorf("cluster lookup %s failed: %v", key, err)
+		return
+	}
+	for _, node := range nodes {
+		if node != c.NodeID {
+			c.publish(ctx, clusterNodeChannelPrefix+node, env)
+		}
+	}
+}
+
+func (c *Cluster) publish(ctx context.Context, channel string, env *clusterEnvelope) {
+	if env.ID == "" {
+		env.ID = fmt.Sprintf("%s:%d", c.NodeID, atomic.AddUint64(&c.seq, 1))
+		env.Origin = c.NodeID
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		c.logx.Errorf("failed to marshal cluster envelope: %v", err)
+		return
+	}
+	if err := c.client.Publish(ctx, channel, payload).Err(); err != nil {
+		c.logx.Errorf("cluster publish to %s failed: %v", channel, err)
+	}
+}
+
+func (c *Cluster) receive(sub *red.PubSub) {
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-c.manager.Ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			c.handle([]byte(msg.Payload))
+		}
+	}
+}
+
+// handle 投递其他节点转发来的消息，只发给本节点的连接
+func (c *Cluster) handle(payload []byte) {
+	var env clusterEnvelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		c.logx.Errorf("invalid cluster envelope: %v", err)
+		return
+	}
+	if env.Origin == c.NodeID || !c.markSeen(env.ID) {
+		return
+	}
+
+	m := c.manager
+	switch env.Kind {
+	case clusterKindUser:
+		m.sendToUser(env.Target, newJSONFrame(env.Data))
+	case clusterKindRoom:
+		m.sendToRoom(env.Target, newJSONFrame(env.Data))
+	case clusterKindAll:
+		m.enqueueBroadcastAll(newJSONFrame(env.Data))
+	case clusterKindRoomRole:
+		m.setLocalRoomRole(env.Room, env.Target, env.Role)
+	case clusterKindRoomRemove:
+		m.removeLocalRoomMember(env.Room, env.Target)
+	case clusterKindPresence:
+		m.notifyPresence(env.Target, env.Status)
+	case clusterKindKick:
+		m.kickLocal(env.Conn, env.Target)
+	case clusterKindBackpress:
+		var conf Backpressure
+		if err := json.Unmarshal(env.Data, &conf); err == nil {
+			m.setLocalBackpressure(conf)
+		}
+	case clusterKindQuery:
+		go c.answer(env)
+	}
+}
+
+// markSeen 记录最近收到的消息 ID，重复的消息返回 false
+func (c *Cluster) markSeen(id string) bool {
+	c.seenMu.Lock()
+	defer c.seenMu.Unlock()
+
+	if _, ok := c.seen[id]; ok {
+		return false
+	}
+	if old := c.seenOrder[c.seenNext]; old != "" {
+		delete(c.seen, old)
+	}
+	c.seen[id] = struct{}{}
+	c.seenOrder[c.seenNext] = id
+	c.seenNext = (c.seenNext + 1) % len(c.seenOrder)
+	return true
+}
+
+// keepAlive 定期续期存活标记并清理失效节点，退出时注销本节点
+func (c *Cluster) keepAlive() {
+	ticker := time.NewTicker(clusterHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.manager.Ctx.Done():
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := c.cleanupNode(ctx, c.NodeID); err != nil {
+				c.logx.Errorf("cluster unregister node %s failed: %v", c.NodeID, err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if err := c.heartbeat(c.manager.Ctx); err != nil {
+				c.logx.Errorf("cluster heartbeat failed: %v", err)
+			}
+			if err := c.cleanupDeadNodes(c.manager.Ctx); err != nil {
+				c.logx.Errorf("cluster cleanup failed: %v", err)
+			}
+		}
+	}
+}
+
+func (c *Cluster) heartbeat(ctx context.Context) error {
+	pipe := c.client.TxPipeline()
+	pipe.SAdd(ctx, clusterNodesKey, c.NodeID)
+	pipe.Set(ctx, clusterAliveKeyPrefix+c.NodeID, time.Now().Unix(), clusterNodeTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// cleanupDeadNodes 清理存活标记已过期的节点的登记
+func (c *Cluster) cleanupDeadNodes(ctx context.Context) error {
+	nodes, err := c.client.SMembers(ctx, clusterNodesKey).Result()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if node == c.NodeID {
+			continue
+		}
+		alive, err := c.client.Exists(ctx, clusterAliveKeyPrefix+node).Result()
+		if err != nil {
+			return err
+		}
+		if alive == 0 {
+			c.logx.Infof("cluster node %s is dead, cleaning up", node)
+			if err := c.cleanupNode(ctx, node); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cleanupNode 从用户和房间的登记中移除节点，节点上的用户因此离线或状态改变时通知订阅者
+func (c *Cluster) cleanupNode(ctx context.Context, node string) error {
+	users, err := c.client.SMembers(ctx, clusterNodeUsersPrefix+node).Result()
+	if err != nil {
+		return err
+	}
+	rooms, err := c.client.SMembers(ctx, clusterNodeRoomsPrefix+node).Result()
+	if err != nil {
+		return err
+	}
+
+	// 先逐个移除节点上的状态，失败时登记仍在，下次清理会重试；
+	// 多个节点同时清理时只有实际移除状态的一方看到变化，不会重复通知
+	for _, userID := range users {
+		prev, next, err := c.setNodePresence(ctx, node, userID, PresenceOffline)
+		if err != nil {
+			return err
+		}
+		c.presenceChanged(ctx, userID, prev, next)
+	}
+
+	pipe := c.client.TxPipeline()
+	for _, userID := range users {
+		pipe.SRem(ctx, clusterUserNodesPrefix+userID, node)
+	}
+	for _, room := range rooms {
+		pipe.SRem(ctx, clusterRoomNodesPrefix+room, node)
+	}
+	pipe.Del(ctx, clusterNodeUsersPrefix+node, clusterNodeRoomsPrefix+node, clusterAliveKeyPrefix+node)
+	pipe.SRem(ctx, clusterNodesKey, node)
+	_, err = pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,255 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+
+	"github.com/alicebob/miniredis/v2"
+	red "github.com/redis/go-redis/v9"
+)
+
+func newClusterNode(t *testing.T, mr *miniredis.Miniredis, nodeID string, store RoomStore) *WsManager {
+	m := NewWsManager(&config.WsConfig{
+		MaxConnections:       16,
+		HeartbeatInterval:    time.Hour,
+		ConnectionTimeout:    time.Hour,
+		MessageBufferSize:    64,
+		MessageQueueSize:     64,
+		ShardCount:           4,
+		BroadcastWorkerCount: 1,
+		DropOnFull:           true,
+		EnableCluster:        true,
+		ClusterNodeID:        nodeID,
+	})
+	m.RoomStore = store
+	cluster, err := NewCluster(m, red.NewClient(&red.Options{Addr: mr.Addr()}))
+	if err != nil {
+		t.Fatalf("new cluster failed: %v", err)
+	}
+	m.Cluster = cluster
+	return m
+}
+
+func setMembers(t *testing.T, mr *miniredis.Miniredis, key string) []string {
+	t.Helper()
+	if !mr.Exists(key) {
+		return nil
+	}
+	members, err := mr.Members(key)
+	if err != nil {
+		t.Fatalf("read %s failed: %v", key, err)
+	}
+	return members
+}
+
+func TestClusterDelivery(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := &fakeRoomStore{rooms: map[int64]map[int64]string{
+		7: {1: model.RoomRoleOwner, 2: model.RoomRoleMember},
+	}}
+	a := newClusterNode(t, mr, "node-a", store)
+	defer a.Cancel()
+	b := newClusterNode(t, mr, "node-b", store)
+	defer b.Cancel()
+
+	c1 := newTestConnection(t, a, "c1", "1")
+	c2 := newTestConnection(t, b, "c2", "2")
+
+	// 用户与房间登记到所在节点
+	waitUntil(t, func() bool {
+		return len(setMembers(t, mr, clusterUserNodesPrefix+"2")) == 1 &&
+			len(setMembers(t, mr, clusterRoomNodesPrefix+"7")) == 2
+	})
+	if online := a.OnlineUsers(context.Background(), []string{"1", "2", "3"}); !online["1"] || !online["2"] || online["3"] {
+		t.Fatalf("unexpected online users: %v", online)
+	}
+
+	// 发给其他节点上的用户
+	a.Broadcast <- &types.Message{Type: "chat", From: "1", To: "2", Data: map[string]interface{}{"text": "direct"}}
+	if msg := waitMessage(t, c2, "chat"); msg.From != "1" || msg.To != "2" {
+		t.Fatalf("unexpected direct message: %+v", msg)
+	}
+
+	// 房间消息转发到有房间成员的节点
+	c2.handleChat(types.Message{Type: "chat", From: "2", Room: "7", Data: map[string]interface{}{"text": "room"}})
+	if msg := waitMessage(t, c1, "chat"); msg.From != "2" || msg.Room != "7" {
+		t.Fatalf("unexpected room message: %+v", msg)
+	}
+
+	// 全局广播每个连接只收到一次
+	a.Broadcast <- &types.Message{Type: "notification", Data: map[string]interface{}{"text": "all"}}
+	waitMessage(t, c1, "notification")
+	waitMessage(t, c2, "notification")
+
+	// 重复投递的消息被丢弃
+	env, _ := json.Marshal(clusterEnvelope{ID: "node-x:1", Origin: "node-x", Kind: clusterKindUser, Target: "2",
+		Data: json.RawMessage(`{"type":"dup","data":null,"timestamp":1}`)})
+	mr.Publish(clusterNodeChannelPrefix+"node-b", string(env))
+	mr.Publish(clusterNodeChannelPrefix+"node-b", string(env))
+	waitMessage(t, c2, "dup")
+	select {
+	case data := <-c2.Send:
+		t.Fatalf("duplicated message should be dropped: %s", data)
+	case <-time.After(100 * time.Millisecond):
+	}
+	select {
+	case data := <-c1.Send:
+		t.Fatalf("node-a should not receive extra messages: %s", data)
+	default:
+	}
+
+	// 其他节点上的角色变更与移出房间
+	a.SetRoomMemberRole("7", "2", model.RoomRoleMuted)
+	waitUntil(t, func() bool { return c2.checkRoomSend("7") != nil })
+	a.RemoveRoomMember("7", "2")
+	waitUntil(t, func() bool { _, ok := b.roomRole(c2, "7"); return !ok })
+}
+
+func TestClusterCleanupDeadNode(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newClusterNode(t, mr, "node-a", nil)
+	defer a.Cancel()
+	b := newClusterNode(t, mr, "node-b", nil)
+	defer b.Cancel()
+
+	// 模拟崩溃的节点：有登记但存活标记已过期；用户 9 只在该节点上在线，用户 10 在 node-a 上还有离开的连接
+	mr.SAdd(clusterNodesKey, "node-dead")
+	mr.SAdd(clusterUserNodesPrefix+"9", "node-dead", "node-a")
+	mr.SAdd(clusterUserNodesPrefix+"10", "node-dead", "node-a")
+	mr.SAdd(clusterNodeUsersPrefix+"node-dead", "9", "10")
+	mr.SAdd(clusterRoomNodesPrefix+"7", "node-dead")
+	mr.SAdd(clusterNodeRoomsPrefix+"node-dead", "7")
+	mr.HSet(clusterPresencePrefix+"9", "node-dead", PresenceOnline)
+	mr.HSet(clusterPresencePrefix+"10", "node-dead", PresenceOnline, "node-a", PresenceAway)
+
+	// 两个节点上都有订阅者，清理节点的一方直接通知，其他节点经广播通知
+	watchers := []*Connection{newTestConnection(t, a, "wa", "1"), newTestConnection(t, b, "wb", "2")}
+	for _, watcher := range watchers {
+		watcher.handlePresenceSubscribe(types.Message{Data: map[string]interface{}{"users": []interface{}{"9", "10"}}})
+		waitPresence(t, watcher, "9", PresenceOnline)
+		waitPresence(t, watcher, "10", PresenceOnline)
+	}
+
+	if err := a.Cluster.cleanupDeadNodes(context.Background()); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	for _, watcher := range watchers {
+		statuses := make(map[string]string)
+		for len(statuses) < 2 {
+			msg := waitMessage(t, watcher, MessageTypeUserPresence)
+			data, _ := json.Marshal(msg.Data)
+			var event UserPresenceEvent
+			_ = json.Unmarshal(data, &event)
+			statuses[event.UserID] = event.Status
+		}
+		if statuses["9"] != PresenceOffline || statuses["10"] != PresenceAway {
+			t.Fatalf("connection %s got presence %v, want 9 offline and 10 away", watcher.ID, statuses)
+		}
+	}
+	if fields, _ := mr.HKeys(clusterPresencePrefix + "10"); len(fields) != 1 || fields[0] != "node-a" {
+		t.Fatalf("presence fields of user 10 = %v, want [node-a]", fields)
+	}
+
+	// 再次清理时节点已不在登记中，不会重复通知
+	if err := b.Cluster.cleanupDeadNodes(context.Background()); err != nil {
+		t.Fatalf("second cleanup failed: %v", err)
+	}
+	select {
+	case data := <-watchers[1].Send:
+		t.Fatalf("unexpected message after second cleanup: %s", data)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if nodes := setMembers(t, mr, clusterUserNodesPrefix+"9"); len(nodes) != 1 || nodes[0] != "node-a" {
+		t.Fatalf("user nodes = %v, want [node-a]", nodes)
+	}
+	if nodes := setMembers(t, mr, clusterRoomNodesPrefix+"7"); len(nodes) != 0 {
+		t.Fatalf("room nodes = %v, want empty", nodes)
+	}
+	if nodes := setMembers(t, mr, clusterNodesKey); len(nodes) != 2 || nodes[0] != "node-a" || nodes[1] != "node-b" {
+		t.Fatalf("nodes = %v, want [node-a node-b]", nodes)
+	}
+
+	// 存活标记过期后，其他节点会把它清理掉
+	mr.FastForward(clusterNodeTTL + time.Second)
+	if mr.Exists(clusterAliveKeyPrefix + "node-a") {
+		t.Fatal("alive key should expire")
+	}
+}
+
+func TestClusterSetPresenceAtomic(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newClusterNode(t, mr, "node-a", nil)
+	defer a.Cancel()
+	b := newClusterNode(t, mr, "node-b", nil)
+	defer b.Cancel()
+	ctx := context.Background()
+
+	steps := []struct {
+		cluster    *Cluster
+		status     string
+		prev, next string
+	}{
+		{a.Cluster, PresenceAway, PresenceOffline, PresenceAway},
+		{b.Cluster, PresenceOnline, PresenceAway, PresenceOnline},
+		{a.Cluster, PresenceOffline, PresenceOnline, PresenceOnline},
+		{b.Cluster, PresenceOffline, PresenceOnline, PresenceOffline},
+	}
+	for i, step := range steps {
+		prev, next, err := step.cluster.setPresence(ctx, "9", step.status)
+		if err != nil {
+			t.Fatalf("step %d: setPresence failed: %v", i, err)
+		}
+		if prev != step.prev || next != step.next {
+			t.Errorf("step %d: %s -> %s, want %s -> %s", i, prev, next, step.prev, step.next)
+		}
+	}
+	if mr.Exists(clusterPresencePrefix + "9") {
+		t.Error("presence hash should be empty after all nodes went offline")
+	}
+
+	// 两个节点并发切换状态：每次返回的前后状态都来自同一次原子更新，上线与下线的通知次数必然相等
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		ups, downs int
+	)
+	for _, cluster := range []*Cluster{a.Cluster, b.Cluster} {
+		wg.Add(1)
+		go func(cluster *Cluster) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				status := PresenceOnline
+				if i%2 == 1 {
+					status = PresenceOffline
+				}
+				prev, next, err := cluster.setPresence(ctx, "10", status)
+				if err != nil {
+					t.Errorf("setPresence failed: %v", err)
+					return
+				}
+				mu.Lock()
+				switch {
+				case prev == PresenceOffline && next == PresenceOnline:
+					ups++
+				case prev == PresenceOnline && next == PresenceOffline:
+					downs++
+				}
+				mu.Unlock()
+			}
+		}(cluster)
+	}
+	wg.Wait()
+	if ups == 0 || ups != downs {
+		t.Errorf("online transitions = %d, offline transitions = %d, want equal", ups, downs)
+	}
+	if mr.Exists(clusterPresencePrefix + "10") {
+		t.Error("presence hash should be empty after both nodes went offline")
+	}
+}
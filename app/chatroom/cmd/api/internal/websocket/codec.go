@@ -0,0 +1,438 @@
+package websocket
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket/pb"
+
+	wsTool "github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/tinylib/msgp/msgp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// 客户端在握手时通过 Sec-WebSocket-Protocol 选择帧编码，按客户端给出的顺序取第一个支持的，不带子协议时使用 JSON
+const (
+	SubprotocolJSON     = "chatroom.json"
+	SubprotocolMsgpack  = "chatroom.msgpack"
+	SubprotocolProtobuf = "chatroom.protobuf"
+)
+
+// Codec 一种帧编码，JSON 使用文本帧，MessagePack 与 protobuf 使用二进制帧，每个帧是一条消息
+type Codec interface {
+	// Subprotocol 协商使用的子协议名
+	Subprotocol() string
+	// FrameType 发送使用的 WebSocket 帧类型
+	FrameType() int
+	Marshal(msg *types.Message) ([]byte, error)
+	Unmarshal(data []byte, msg *types.Message) error
+	// slot 在 frame 编码缓存中的下标
+	slot() int
+}
+
+var (
+	JSONCodec     Codec = jsonCodec{}
+	MsgpackCodec  Codec = msgpackCodec{}
+	ProtobufCodec Codec = protobufCodec{}
+
+	codecs = []Codec{JSONCodec, MsgpackCodec, ProtobufCodec}
+)
+
+// subprotocols 升级器支持的子协议
+func subprotocols() []string {
+	names := make([]string, len(codecs))
+	for i, codec := range codecs {
+		names[i] = codec.Subprotocol()
+	}
+	return names
+}
+
+// codecFor 握手协商出的子协议对应的编码，未协商时使用 JSON
+func codecFor(subprotocol string) Codec {
+	for _, codec := range codecs {
+		if codec.Subprotocol() == subprotocol {
+			return codec
+		}
+	}
+	return JSONCodec
+}
+
+// frame 一条待投递的消息，按连接使用的编码懒序列化，每种编码只序列化一次
+type frame struct {
+	mu      sync.Mutex
+	msg     *types.Message
+	encoded [3][]byte
+}
+
+func newFrame(msg *types.Message) *frame {
+	return &frame{msg: msg}
+}
+
+// newJSONFrame 其他节点转发来的消息已经是 JSON，需要其他编码时再解析
+func newJSONFrame(data []byte) *frame {
+	f := &frame{}
+	f.encoded[JSONCodec.slot()] = data
+	return f
+}
+
+// encode 按编码序列化，同一条消息的同一种编码只执行一次
+func (f *frame) encode(codec Codec) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if data := f.encoded[codec.slot()]; data != nil {
+		return data, nil
+	}
+	if f.msg == nil {
+		var msg types.Message
+		if err := json.Unmarshal(f.encoded[JSONCodec.slot()], &msg); err != nil {
+			return nil, err
+		}
+		f.msg = &msg
+	}
+	data, err := codec.Marshal(f.msg)
+	if err != nil {
+		return nil, err
+	}
+	f.encoded[codec.slot()] = data
+	return data, nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Subprotocol() string { return SubprotocolJSON }
+func (jsonCodec) FrameType() int      { return wsTool.TextMessage }
+func (jsonCodec) slot() int           { return 0 }
+
+func (jsonCodec) Marshal(msg *types.Message) ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+func (jsonCodec) Unmarshal(data []byte, msg *types.Message) error {
+	return json.Unmarshal(data, msg)
+}
+
+// msgpackCodec 消息编码为 MessagePack map，键与 JSON 字段名相同
+type msgpackCodec struct{}
+
+func (msgpackCodec) Subprotocol() string { return SubprotocolMsgpack }
+func (msgpackCodec) FrameType() int      { return wsTool.BinaryMessage }
+func (msgpackCodec) slot() int           { return 1 }
+
+func (msgpackCodec) Marshal(msg *types.Message) ([]byte, error) {
+	data, err := plainData(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := uint32(3)
+	for _, set := range []bool{msg.ID != 0, msg.Seq != 0, msg.From != "", msg.To != "", msg.Room != ""} {
+		if set {
+			fields++
+		}
+	}
+	b := make([]byte, 0, 128)
+	b = msgp.AppendMapHeader(b, fields)
+	if msg.ID != 0 {
+		b = msgp.AppendInt64(msgp.AppendString(b, "id"), msg.ID)
+	}
+	if msg.Seq != 0 {
+		b = msgp.AppendInt64(msgp.AppendString(b, "seq"), msg.Seq)
+	}
+	b = msgp.AppendString(msgp.AppendString(b, "type"), msg.Type)
+	if msg.From != "" {
+		b = msgp.AppendString(msgp.AppendString(b, "from"), msg.From)
+	}
+	if msg.To != "" {
+		b = msgp.AppendString(msgp.AppendString(b, "to"), msg.To)
+	}
+	if msg.Room != "" {
+		b = msgp.AppendString(msgp.AppendString(b, "room"), msg.Room)
+	}
+	if b, err = msgp.AppendIntf(msgp.AppendString(b, "data"), data); err != nil {
+		return nil, err
+	}
+	return msgp.AppendInt64(msgp.AppendString(b, "timestamp"), msg.Timestamp), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, msg *types.Message) error {
+	fields, b, err := msgp.ReadMapHeaderBytes(data)
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < fields; i++ {
+		var key string
+		if key, b, err = msgp.ReadStringBytes(b); err != nil {
+			return err
+		}
+		switch key {
+		case "id":
+			msg.ID, b, err = msgp.ReadInt64Bytes(b)
+		case "seq":
+			msg.Seq, b, err = msgp.ReadInt64Bytes(b)
+		case "type":
+			msg.Type, b, err = msgp.ReadStringBytes(b)
+		case "from":
+			msg.From, b, err = msgp.ReadStringBytes(b)
+		case "to":
+			msg.To, b, err = msgp.ReadStringBytes(b)
+		case "room":
+			msg.Room, b, err = msgp.ReadStringBytes(b)
+		case "timestamp":
+			msg.Timestamp, b, err = msgp.ReadInt64Bytes(b)
+		case "data":
+			var value interface{}
+			if value, b, err = msgp.ReadIntfBytes(b); err == nil {
+				msg.Data = jsonNumbers(value)
+			}
+		default:
+			b, err = msgp.Skip(b)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "decode field %s", key)
+		}
+	}
+	return nil
+}
+
+// protobufCodec 消息编码为 desc/frame.proto 生成的 pb.Frame，data 为 google.protobuf.Value
+type protobufCodec struct{}
+
+func (protobufCodec) Subprotocol() string { return SubprotocolProtobuf }
+func (protobufCodec) FrameType() int      { return wsTool.BinaryMessage }
+func (protobufCodec) slot() int           { return 2 }
+
+func (protobufCodec) Marshal(msg *types.Message) ([]byte, error) {
+	data, err := plainData(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	value, err := structpb.NewValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&pb.Frame{
+		Type:      msg.Type,
+		From:      msg.From,
+		To:        msg.To,
+		Room:      msg.Room,
+		Data:      value,
+		Timestamp: msg.Timestamp,
+		Id:        msg.ID,
+		Seq:       msg.Seq,
+	})
+}
+
+func (protobufCodec) Unmarshal(data []byte, msg *types.Message) error {
+	var frame pb.Frame
+	if err := proto.Unmarshal(data, &frame); err != nil {
+		return err
+	}
+	msg.Type = frame.Type
+	msg.From = frame.From
+	msg.To = frame.To
+	msg.Room = frame.Room
+	msg.Timestamp = frame.Timestamp
+	msg.ID = frame.Id
+	msg.Seq = frame.Seq
+	if frame.Data != nil {
+		msg.Data = frame.Data.AsInterface()
+	}
+	return nil
+}
+
+// plainData 把 data 转换为 map、切片和基本类型组成的值，数字统一为 float64，与 JSON 解码的结果一致
+func plainData(data interface{}) (interface{}, error) {
+	switch data.(type) {
+	case nil, string, bool, float64, map[string]interface{}, []interface{}:
+		return data, nil
+	}
+	return plainValue(reflect.ValueOf(data))
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// plainValue 按 encoding/json 的规则转换：结构体使用 json 标签的字段名，支持 omitempty、"-" 和匿名字段，
+// 只有自定义了 MarshalJSON 的类型才经过 JSON
+func plainValue(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, nil
+	}
+	if v.Type().Implements(jsonMarshalerType) {
+		raw, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		var plain interface{}
+		if err := json.Unmarshal(raw, &plain); err != nil {
+			return nil, err
+		}
+		return plain, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return plainValue(v.Elem())
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+		return plainList(v)
+	case reflect.Array:
+		return plainList(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, errors.Errorf("unsupported map key type %s", v.Type().Key())
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			item, err := plainValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			m[iter.Key().String()] = item
+		}
+		return m, nil
+	case reflect.Struct:
+		m := make(map[string]interface{}, v.NumField())
+		if err := plainFields(v, m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return nil, errors.Errorf("unsupported data type %s", v.Type())
+}
+
+func plainList(v reflect.Value) (interface{}, error) {
+	list := make([]interface{}, v.Len())
+	for i := range list {
+		item, err := plainValue(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		list[i] = item
+	}
+	return list, nil
+}
+
+// plainFields 把结构体字段写入 m，匿名结构体字段展开到外层
+func plainFields(v reflect.Value, m map[string]interface{}) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		value := v.Field(i)
+
+		if field.Anonymous && name == "" {
+			embedded := value
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := plainFields(embedded, m); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if hasOption(opts, "omitempty") && isEmptyValue(value) {
+			continue
+		}
+		item, err := plainValue(value)
+		if err != nil {
+			return errors.Wrapf(err, "field %s", field.Name)
+		}
+		m[name] = item
+	}
+	return nil
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue omitempty 省略的值，与 encoding/json 相同
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// jsonNumbers MessagePack 解码出的整数转为 float64，与 JSON 解码的结果一致，处理逻辑不区分编码
+func jsonNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jsonNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNumbers(item)
+		}
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
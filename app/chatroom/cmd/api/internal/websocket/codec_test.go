@@ -0,0 +1,279 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/websocket/pb"
+
+	wsTool "github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+func testMessage() *types.Message {
+	return &types.Message{
+		ID:   1024,
+		Seq:  7,
+		Type: "chat",
+		From: "1",
+		Room: "7",
+		Data: map[string]interface{}{
+			"text":     "hello",
+			"mentions": []interface{}{"2", float64(3)},
+			"meta":     map[string]interface{}{"bot": true, "score": 0.5},
+		},
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// jsonView 按 JSON 语义比较解码结果
+func jsonView(t testing.TB, msg *types.Message) types.Message {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var view types.Message
+	_ = json.Unmarshal(data, &view)
+	return view
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	messages := []*types.Message{
+		testMessage(),
+		{Type: MessageTypeUserPresence, Data: UserPresenceEvent{UserID: "2", Status: PresenceAway}, Timestamp: 1},
+		{Type: MessageTypePong},
+	}
+	for _, codec := range codecs {
+		for _, msg := range messages {
+			data, err := codec.Marshal(msg)
+			if err != nil {
+				t.Fatalf("%s marshal failed: %v", codec.Subprotocol(), err)
+			}
+			var decoded types.Message
+			if err := codec.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("%s unmarshal failed: %v", codec.Subprotocol(), err)
+			}
+			if want := jsonView(t, msg); !reflect.DeepEqual(decoded, want) {
+				t.Fatalf("%s round trip mismatch:\n got %+v\nwant %+v", codec.Subprotocol(), decoded, want)
+			}
+		}
+	}
+}
+
+func TestPlainDataMatchesJSON(t *testing.T) {
+	type base struct {
+		Room string `json:"room"`
+	}
+	type event struct {
+		base
+		UserID  string          `json:"userId"`
+		Skip    string          `json:"-"`
+		Empty   string          `json:"empty,omitempty"`
+		Count   int64           `json:"count"`
+		Ratio   float32         `json:"ratio"`
+		Tags    []string        `json:"tags"`
+		Raw     []byte          `json:"raw"`
+		Labels  map[string]int  `json:"labels"`
+		Nested  *ErrorEvent     `json:"nested,omitempty"`
+		Missing *ErrorEvent     `json:"missing"`
+		Extra   json.RawMessage `json:"extra"`
+		Calls   []TypingEvent   `json:"calls"`
+		Plain   string
+		hidden  string
+	}
+	data := event{
+		base:   base{Room: "7"},
+		UserID: "1",
+		Skip:   "x",
+		Count:  3,
+		Ratio:  0.5,
+		Tags:   []string{"a"},
+		Raw:    []byte("hi"),
+		Labels: map[string]int{"a": 1},
+		Nested: &ErrorEvent{Code: 1, Message: "m"},
+		Extra:  json.RawMessage(`{"k":[1,"v"]}`),
+		Calls:  []TypingEvent{{UserID: "2", Typing: true}},
+		Plain:  "p",
+		hidden: "h",
+	}
+
+	got, err := plainData(data)
+	if err != nil {
+		t.Fatalf("plainData failed: %v", err)
+	}
+	raw, _ := json.Marshal(data)
+	var want interface{}
+	_ = json.Unmarshal(raw, &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("plainData mismatch:\n got %#v\nwant %#v", got, want)
+	}
+
+	if _, err := plainData(map[int]string{1: "a"}); err == nil {
+		t.Fatal("expected error for non-string map key")
+	}
+}
+
+func TestProtobufCodecUsesFrame(t *testing.T) {
+	msg := testMessage()
+	data, err := ProtobufCodec.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var frame pb.Frame
+	if err := proto.Unmarshal(data, &frame); err != nil {
+		t.Fatalf("unmarshal frame failed: %v", err)
+	}
+	if frame.Type != msg.Type || frame.From != msg.From || frame.Room != msg.Room || frame.Id != msg.ID || frame.Seq != msg.Seq || frame.Timestamp != msg.Timestamp {
+		t.Fatalf("unexpected frame: %v", &frame)
+	}
+	if text := frame.Data.GetStructValue().GetFields()["text"].GetStringValue(); text != "hello" {
+		t.Fatalf("unexpected frame data: %v", frame.Data)
+	}
+}
+
+func TestFrameEncodesOncePerCodec(t *testing.T) {
+	f := newFrame(testMessage())
+	first, _ := f.encode(MsgpackCodec)
+	second, _ := f.encode(MsgpackCodec)
+	if &first[0] != &second[0] {
+		t.Fatal("expected cached encoding to be reused")
+	}
+
+	// 其他节点转发来的 JSON 在需要时转码
+	data, _ := f.encode(JSONCodec)
+	remote := newJSONFrame(data)
+	encoded, err := remote.encode(ProtobufCodec)
+	if err != nil {
+		t.Fatalf("transcode failed: %v", err)
+	}
+	var decoded types.Message
+	if err := ProtobufCodec.Unmarshal(encoded, &decoded); err != nil || decoded.ID != 1024 || decoded.Room != "7" {
+		t.Fatalf("unexpected transcoded message: %+v %v", decoded, err)
+	}
+}
+
+func TestSubprotocolNegotiation(t *testing.T) {
+	m := newTestManager(nil)
+	defer m.Cancel()
+	url := newHandshakeServer(t, m) + "?token=" + signToken(t, testSecret, 1)
+
+	for _, codec := range []Codec{MsgpackCodec, ProtobufCodec} {
+		dialer := wsTool.Dialer{Subprotocols: []string{"unknown", codec.Subprotocol()}}
+		conn, _, err := dialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("dial failed: %v", err)
+		}
+		if conn.Subprotocol() != codec.Subprotocol() {
+			t.Fatalf("negotiated %q, want %q", conn.Subprotocol(), codec.Subprotocol())
+		}
+
+		ping, _ := codec.Marshal(&types.Message{Type: "ping"})
+		if err := conn.WriteMessage(wsTool.BinaryMessage, ping); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		frameType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		var pong types.Message
+		if err := codec.Unmarshal(data, &pong); err != nil || frameType != wsTool.BinaryMessage || pong.Type != MessageTypePong {
+			t.Fatalf("unexpected pong over %s: %+v %v", codec.Subprotocol(), pong, err)
+		}
+		conn.Close()
+	}
+
+	// 不带子协议时仍为 JSON 文本帧
+	conn, _, err := wsTool.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteMessage(wsTool.TextMessage, []byte(`{"type":"ping"}`)); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if frameType, data, err := conn.ReadMessage(); err != nil || frameType != wsTool.TextMessage || !strings.Contains(string(data), MessageTypePong) {
+		t.Fatalf("unexpected json pong: %s %v", data, err)
+	}
+}
+
+func BenchmarkCodecMarshal(b *testing.B) {
+	msg := testMessage()
+	for _, codec := range codecs {
+		b.Run(codec.Subprotocol(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := codec.Marshal(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+var benchSink []byte
+
+// BenchmarkFanout 一条消息投递给 1000 个连接，json-once 为原来只支持 JSON 时的路径，
+// per-connection 为按连接编码，frame 为按编码缓存，连接的编码交替分布
+func BenchmarkFanout(b *testing.B) {
+	const conns = 1000
+	msg := testMessage()
+	mixed := make([]Codec, conns)
+	for i := range mixed {
+		mixed[i] = codecs[i%len(codecs)]
+	}
+
+	b.Run("json-once", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j := 0; j < conns; j++ {
+				benchSink = data
+			}
+		}
+	})
+	b.Run("per-connection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, codec := range mixed {
+				data, err := codec.Marshal(msg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				benchSink = data
+			}
+		}
+	})
+	for _, codecSet := range [][]Codec{{JSONCodec}, mixed} {
+		b.Run(fmt.Sprintf("frame-%d-codecs", len(uniqueCodecs(codecSet))), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f := newFrame(msg)
+				for j := 0; j < conns; j++ {
+					data, err := f.encode(codecSet[j%len(codecSet)])
+					if err != nil {
+						b.Fatal(err)
+					}
+					benchSink = data
+				}
+			}
+		})
+	}
+}
+
+func uniqueCodecs(list []Codec) map[string]bool {
+	names := make(map[string]bool)
+	for _, codec := range list {
+		names[codec.Subprotocol()] = true
+	}
+	return names
+}
@@ -1,20 +1,35 @@
 package websocket
 
 import (
-	"encoding/json"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
 	"go-zero-voice-agent/pkg/uniqueid"
+	"go-zero-voice-agent/pkg/xerr"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	wsTool "github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 )
 
+// closeWait 发送错误消息后等待写协程发出关闭帧的最长时间
+const closeWait = 5 * time.Second
+
+var (
+	// errSendBufferFull 发送缓冲区已满
+	errSendBufferFull = errors.New("send buffer is full")
+	// errConnectionClosed 连接已注销，发送缓冲区已关闭
+	errConnectionClosed = errors.New("connection is closed")
+)
+
 type UpgraderConfig struct {
 	ReadBufferSize    int
 	WriteBufferSize   int
 	EnableCompression bool
+	AllowedOrigins    string
 }
 
 func newUpgrader(config UpgraderConfig) *wsTool.Upgrader {
@@ -23,23 +38,78 @@ func newUpgrader(config UpgraderConfig) *wsTool.Upgrader {
 		ReadBufferSize:    config.ReadBufferSize,
 		WriteBufferSize:   config.WriteBufferSize,
 		EnableCompression: config.EnableCompression,
-		CheckOrigin: func(r *http.Request) bool {
-			// 在生产环境需检查跨域
-			return true
-		},
+		CheckOrigin:       checkOrigin(config.AllowedOrigins),
+		Subprotocols:      subprotocols(),
 	}
 
 	return &upgrader
 }
 
-func NewConnection(wsManager *WsManager, w http.ResponseWriter, r *http.Request, userId string) error {
-	upgrader := newUpgrader(UpgraderConfig{
+// checkOrigin 校验浏览器握手请求的 Origin：为空时只允许同源，* 允许所有，否则必须在列表中
+func checkOrigin(allowedOrigins string) func(r *http.Request) bool {
+	var allowed []string
+	for _, origin := range strings.Split(allowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowed = append(allowed, strings.TrimSuffix(origin, "/"))
+		}
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// 非浏览器客户端不带 Origin
+			return true
+		}
+		if len(allowed) == 0 {
+			u, err := url.Parse(origin)
+			return err == nil && strings.EqualFold(u.Host, r.Host)
+		}
+		for _, item := range allowed {
+			if item == "*" || strings.EqualFold(item, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func newManagerUpgrader(wsManager *WsManager) *wsTool.Upgrader {
+	return newUpgrader(UpgraderConfig{
 		ReadBufferSize:    wsManager.Config.ReadBufferSize,
 		WriteBufferSize:   wsManager.Config.WriteBufferSize,
 		EnableCompression: wsManager.Config.EnableCompression,
+		AllowedOrigins:    wsManager.Config.AllowedOrigins,
 	})
+}
+
+// RejectConnection 鉴权失败时完成握手，发送 error 消息后以 1008 关闭，浏览器客户端拿不到握手失败的 HTTP 状态码
+func RejectConnection(wsManager *WsManager, w http.ResponseWriter, r *http.Request, reason error) error {
+	if refuseDraining(wsManager, w) {
+		return nil
+	}
+	conn, err := newManagerUpgrader(wsManager).Upgrade(w, r, nil)
+	if err != nil {
+		return errors.Errorf("Fail to upgrade http to websocket, %v", err)
+	}
+	defer conn.Close()
+
+	event := errorEvent(reason)
+	wsManager.logx.Infof("reject websocket connection from %s: %v", r.RemoteAddr, reason)
+	codec := codecFor(conn.Subprotocol())
+	data, _ := codec.Marshal(&types.Message{Type: MessageTypeError, Data: event, Timestamp: time.Now().Unix()})
+	_ = conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if err := conn.WriteMessage(codec.FrameType(), data); err != nil {
+		return nil
+	}
+	_ = conn.WriteControl(wsTool.CloseMessage, wsTool.FormatCloseMessage(wsTool.ClosePolicyViolation, event.Message),
+		time.Now().Add(10*time.Second))
+	return nil
+}
 
-	conn, err := upgrader.Upgrade(w, r, nil)
+func NewConnection(wsManager *WsManager, w http.ResponseWriter, r *http.Request, userId string) error {
+	if refuseDraining(wsManager, w) {
+		return nil
+	}
+	conn, err := newManagerUpgrader(wsManager).Upgrade(w, r, nil)
 	if err != nil {
 		return errors.Errorf("Fail to upgrade http to websocket, %v", err)
 	}
@@ -59,7 +129,19 @@ func NewConnection(wsManager *WsManager, w http.ResponseWriter, r *http.Request,
 		WsManager: wsManager,
 		LastPingTime: time.Now(),
 		IsAlive:   true,
+		Status:    PresenceOnline,
 		Metadata:  make(map[string]interface{}),
+		Rooms:     make(map[string]string),
+		LastSeq:   -1,
+		limiter:   newMessageLimiter(wsManager.Config.RateLimits),
+		codec:     codecFor(conn.Subprotocol()),
+		done:      make(chan struct{}),
+	}
+	// 重连时客户端带上最后收到的序号，补发之后的消息
+	if lastSeq := r.URL.Query().Get("lastSeq"); lastSeq != "" {
+		if seq, err := strconv.ParseInt(lastSeq, 10, 64); err == nil && seq >= 0 {
+			connection.LastSeq = seq
+		}
 	}
 
 	wsManager.Register <- connection
@@ -72,10 +154,14 @@ func NewConnection(wsManager *WsManager, w http.ResponseWriter, r *http.Request,
 func (c *Connection) readPump() {
 	defer func() {
 		c.WsManager.Unregister <- c
+		if c.closeCode() != 0 {
+			// 写协程发完 error 消息和关闭帧后关闭连接，超时兜底
+			time.AfterFunc(closeWait, func() { c.Conn.Close() })
+			return
+		}
 		c.Conn.Close()
 	}()
 
-	c.Conn.SetReadLimit(int64(c.WsManager.Config.MaxMessageSize))
 	c.Conn.SetReadDeadline(time.Now().Add(c.WsManager.Config.ConnectionTimeout))
 	c.Conn.SetPongHandler(func(string) error {
 		c.mu.Lock()
@@ -86,23 +172,58 @@ func (c *Connection) readPump() {
 	})
 
 	for {
-		_, message, err := c.Conn.ReadMessage()
+		message, err := c.readMessage()
 		if err != nil {
 			if wsTool.IsUnexpectedCloseError(err, wsTool.CloseGoingAway, wsTool.CloseAbnormalClosure) {
 				c.WsManager.logx.Errorf("error: unexpected websocket close error: %v", err)
 			}
 			break
 		}
+		if limit := c.WsManager.Config.MaxMessageSize; limit > 0 && len(message) > limit {
+			c.closeWithError(xerr.NewErrCode(xerr.WS_MESSAGE_TOO_LARGE_ERROR), wsTool.CloseMessageTooBig)
+			break
+		}
 
 		// 处理接收到的消息
 		c.handleMessage(message)
 	}
 }
 
+// readMessage 读取一条消息，最多读取 MaxMessageSize+1 字节，超出部分不再读入内存
+func (c *Connection) readMessage() ([]byte, error) {
+	_, reader, err := c.Conn.NextReader()
+	if err != nil {
+		return nil, err
+	}
+	if limit := c.WsManager.Config.MaxMessageSize; limit > 0 {
+		reader = io.LimitReader(reader, int64(limit)+1)
+	}
+	return io.ReadAll(reader)
+}
+
+// closeWithError 发送 error 消息后以指定关闭码断开连接
+func (c *Connection) closeWithError(err error, code int) {
+	c.sendError(err)
+	c.mu.Lock()
+	c.closeStatus = code
+	c.mu.Unlock()
+}
+
+func (c *Connection) closeCode() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.closeStatus
+}
+
 func (c *Connection) handleMessage(message []byte) {
 	var msg types.Message
-	if err := json.Unmarshal(message, &msg); err != nil {
+	if err := c.codec.Unmarshal(message, &msg); err != nil {
 		c.WsManager.logx.Errorf("消息解析失败: %v", err)
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+	if !c.limiter.Allow(msg.Type) {
+		c.sendError(xerr.NewErrCode(xerr.WS_RATE_LIMIT_ERROR))
 		return
 	}
 
@@ -119,6 +240,24 @@ func (c *Connection) handleMessage(message []byte) {
 		c.handleNotification(msg)
 	case "status":
 		c.handleStatus(msg)
+	case MessageTypeJoin:
+		c.handleJoin(msg)
+	case MessageTypeLeave:
+		c.handleLeave(msg)
+	case MessageTypeAck:
+		c.handleAck(msg)
+	case MessageTypeSync:
+		c.handleSync(msg)
+	case MessageTypeBotToolReply:
+		c.handleBotToolReply(msg)
+	case MessageTypeTyping:
+		c.handleTyping(msg)
+	case MessageTypeRead:
+		c.handleRead(msg)
+	case MessageTypePresenceSubscribe:
+		c.handlePresenceSubscribe(msg)
+	case MessageTypePresenceUnsubscribe:
+		c.handlePresenceUnsubscribe(msg)
 	default:
 		c.WsManager.logx.Infof("warn: unknown message type: %s", msg.Type)
 	}
@@ -135,10 +274,8 @@ func (c *Connection) handlePing() {
 		Timestamp: time.Now().Unix(),
 	}
 
-	data, _ := json.Marshal(response)
-	select {
-	case c.Send <- data:
-	default:
+	data, _ := c.codec.Marshal(&response)
+	if c.enqueue(data, 0) == errSendBufferFull {
 		c.WsManager.logx.Infof("warning: connection %s send buffer is full, applying backpressure policy", c.ID)
 	}
 }
@@ -151,13 +288,31 @@ func (c *Connection) handleChat(msg types.Message) {
 	}
 
 	// 检查是否有目标用户或组
-	if msg.To == "" {
+	if msg.To == "" && msg.Room == "" {
 		c.WsManager.logx.Infof("warn: Chat message is missing target")
 		return
 	}
+	if err := checkTarget(msg.To); err != nil {
+		c.sendError(err)
+		return
+	}
+	if msg.Room != "" {
+		if err := c.checkRoomSend(msg.Room); err != nil {
+			c.sendError(err)
+			return
+		}
+	}
 
-	// 广播消息
-	c.WsManager.Broadcast <- &msg
+	// 启用消息存储时先持久化再投递
+	if err := c.WsManager.Publish(&msg); err != nil {
+		c.sendError(err)
+		return
+	}
+
+	// 发给机器人或在房间中提及机器人的消息交给机器人回复
+	if c.WsManager.Bots != nil {
+		c.WsManager.Bots.OnMessage(&msg)
+	}
 }
 
 func (c *Connection) handleNotification(msg types.Message) {
@@ -166,33 +321,38 @@ func (c *Connection) handleNotification(msg types.Message) {
 		c.WsManager.logx.Infof("warn: Invalid notification data: %v", msg.Data)
 		return
 	}
+	if err := checkTarget(msg.To); err != nil {
+		c.sendError(err)
+		return
+	}
+	if msg.Room != "" {
+		if err := c.checkRoomSend(msg.Room); err != nil {
+			c.sendError(err)
+			return
+		}
+	}
+
+	// 私聊和房间通知同样持久化
+	if msg.To != "" || msg.Room != "" {
+		if err := c.WsManager.Publish(&msg); err != nil {
+			c.sendError(err)
+		}
+		return
+	}
 
 	// 广播通知
 	c.WsManager.Broadcast <- &msg
 }
 
-func (c *Connection) handleStatus(msg types.Message) {
-	// 更新连接状态
-	if statusData, ok := msg.Data.(map[string]interface{}); ok {
-		c.mu.Lock()
-		for key, value := range statusData {
-			c.Metadata[key] = value
-		}
-		c.mu.Unlock()
-	}
-
-	// 发送状态确认
-	response := types.Message{
-		Type:      MessageTypeStatusUpdated,
-		Timestamp: time.Now().Unix(),
+// checkTarget 私聊目标必须是用户 id，机器人的用户 id 为负数
+func checkTarget(to string) error {
+	if to == "" {
+		return nil
 	}
-
-	data, _ := json.Marshal(response)
-	select {
-	case c.Send <- data:
-	default:
-		c.WsManager.logx.Infof("warn: Connection %s send buffer is full", c.ID)
+	if userId, err := strconv.ParseInt(to, 10, 64); err != nil || userId == 0 {
+		return xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
 	}
+	return nil
 }
 
 // writePump 发送消息的协程
@@ -211,6 +371,7 @@ func (c *Connection) writePump() {
 			ticker.Stop()
 		}
 		c.Conn.Close()
+		close(c.done)
 	}()
 
 	for {
@@ -218,10 +379,22 @@ func (c *Connection) writePump() {
 		case message, ok := <-c.Send:
 			c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
 			if !ok {
-				c.Conn.WriteMessage(wsTool.CloseMessage, []byte{})
+				closeMessage := []byte{}
+				if code := c.closeCode(); code != 0 {
+					closeMessage = wsTool.FormatCloseMessage(code, "")
+				}
+				c.Conn.WriteMessage(wsTool.CloseMessage, closeMessage)
 				return
 			}
 
+			// 二进制编码每个帧是一条消息，不合并发送
+			if c.codec.FrameType() == wsTool.BinaryMessage {
+				if err := c.Conn.WriteMessage(wsTool.BinaryMessage, message); err != nil {
+					return
+				}
+				continue
+			}
+
 			w, err := c.Conn.NextWriter(wsTool.TextMessage)
 			if err != nil {
 				return
@@ -254,15 +427,46 @@ func (c *Connection) writePump() {
 
 // SendMessage 发送消息给当前连接
 func (c *Connection) SendMessage(message *types.Message) error {
-	data, err := json.Marshal(message)
+	data, err := c.codec.Marshal(message)
 	if err != nil {
 		return err
 	}
 
+	return c.enqueue(data, 0)
+}
+
+// enqueue 把已编码的消息放入发送缓冲区，timeout 为 0 时缓冲区满立即返回。
+// 与 closeSend 共用 sendMu，连接注销后读协程、回放或广播继续发送时直接丢弃，不会写入已关闭的通道
+func (c *Connection) enqueue(data []byte, timeout time.Duration) error {
+	c.sendMu.RLock()
+	defer c.sendMu.RUnlock()
+	if c.sendClosed {
+		return errConnectionClosed
+	}
+	if timeout <= 0 {
+		select {
+		case c.Send <- data:
+			return nil
+		default:
+			return errSendBufferFull
+		}
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	select {
 	case c.Send <- data:
 		return nil
-	default:
-		return errors.New("send buffer is full")
+	case <-timer.C:
+		return errSendBufferFull
+	}
+}
+
+// closeSend 关闭发送缓冲区，写协程发完剩余消息后发出关闭帧，重复调用只关闭一次
+func (c *Connection) closeSend() {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if !c.sendClosed {
+		c.sendClosed = true
+		close(c.Send)
 	}
 }
\ No newline at end of file
@@ -9,4 +9,35 @@ const (
 	MessageTypePong = "pong"
 	MessageTypeStatus = "status"
 	MessageTypeStatusUpdated = "status_updated"
-)
\ No newline at end of file
+	MessageTypeError = "error"
+
+	// 房间消息类型
+	MessageTypeJoin = "join"
+	MessageTypeLeave = "leave"
+	MessageTypeMemberJoin = "member_join"
+	MessageTypeMemberLeave = "member_leave"
+	MessageTypeMemberRole = "member_role"
+	MessageTypePresence = "presence"
+
+	// 离线消息
+	MessageTypeAck = "ack"
+	MessageTypeSync = "sync"
+
+	// 机器人消息
+	MessageTypeBotStream = "bot_stream"
+	MessageTypeBotToolConfirm = "bot_tool_confirm"
+	MessageTypeBotToolReply = "bot_tool_reply"
+
+	// 在线状态、正在输入与已读回执
+	MessageTypeTyping = "typing"
+	MessageTypeRead = "read"
+	MessageTypeUserPresence = "user_presence"
+	MessageTypePresenceSubscribe = "presence_subscribe"
+	MessageTypePresenceUnsubscribe = "presence_unsubscribe"
+
+	// 管理员发送的系统消息
+	MessageTypeSystem = "system"
+
+	// 节点下线前通知客户端重连
+	MessageTypeReconnect = "reconnect"
+)
@@ -0,0 +1,114 @@
+package websocket
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+
+	wsTool "github.com/gorilla/websocket"
+)
+
+// defaultDrainTimeout 未配置 DrainTimeout 时等待发送缓冲区发完的时间
+const defaultDrainTimeout = 10 * time.Second
+
+// ReconnectEvent 节点下线前推送给客户端，客户端等待 Delay 毫秒后重连，由负载均衡分配到其他节点
+type ReconnectEvent struct {
+	Delay int64 `json:"delay"`
+}
+
+// DrainStatus 节点的下线状态，供就绪探针使用
+type DrainStatus struct {
+	Draining    bool  `json:"draining"`
+	Connections int64 `json:"connections"`
+}
+
+// Draining 节点是否正在下线
+func (m *WsManager) Draining() bool {
+	return m.draining.Load()
+}
+
+// DrainStatus 节点的下线状态与剩余连接数
+func (m *WsManager) DrainStatus() DrainStatus {
+	return DrainStatus{
+		Draining:    m.draining.Load(),
+		Connections: atomic.LoadInt64(&m.ConnectionCount),
+	}
+}
+
+// Drain 优雅下线：不再接受新连接，通知所有连接在随机的延迟后重连，注销连接后写协程发完发送缓冲区中的消息再以 1001 关闭，
+// 超过 DrainTimeout 或 ctx 结束时强制断开剩余连接，最后停止运行循环。重复调用只执行一次
+func (m *WsManager) Drain(ctx context.Context) {
+	if !m.draining.CompareAndSwap(false, true) {
+		return
+	}
+	timeout := m.Config.DrainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	defer m.Cancel()
+
+	now := time.Now().Unix()
+	m.Mu.RLock()
+	conns := make([]*Connection, 0, len(m.Connections))
+	for _, conn := range m.Connections {
+		conns = append(conns, conn)
+		// 缓冲区已满时客户端仍会收到 1001 关闭码，注销后客户端继续发送的消息不再回复
+		_ = conn.SendMessage(&types.Message{
+			Type:      MessageTypeReconnect,
+			Data:      ReconnectEvent{Delay: m.reconnectDelay().Milliseconds()},
+			Timestamp: now,
+		})
+		conn.mu.Lock()
+		conn.closeStatus = wsTool.CloseGoingAway
+		conn.mu.Unlock()
+	}
+	m.Mu.RUnlock()
+	m.logx.Infof("draining %d websocket connections", len(conns))
+
+	for _, conn := range conns {
+		select {
+		case m.Unregister <- conn:
+		case <-ctx.Done():
+		}
+	}
+
+	forced := 0
+	for _, conn := range conns {
+		if conn.done == nil {
+			continue
+		}
+		select {
+		case <-conn.done:
+		case <-ctx.Done():
+			// 超时后强制断开剩余的连接，未发出的消息在客户端重连后补发
+			conn.Conn.Close()
+			forced++
+		}
+	}
+	m.logx.Infof("websocket connections drained, %d closed forcibly", forced)
+}
+
+// reconnectDelay 在 0 到 ReconnectDelay 之间随机，避免客户端同时重连
+func (m *WsManager) reconnectDelay() time.Duration {
+	if m.Config.ReconnectDelay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(m.Config.ReconnectDelay) + 1))
+}
+
+// refuseDraining 下线中不再升级连接，返回 503 让负载均衡重试其他节点
+func refuseDraining(m *WsManager, w http.ResponseWriter) bool {
+	if !m.Draining() {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(m.Config.ReconnectDelay.Seconds())+1))
+	http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	return true
+}
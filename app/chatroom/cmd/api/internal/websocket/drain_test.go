@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+
+	wsTool "github.com/gorilla/websocket"
+)
+
+func TestDrain(t *testing.T) {
+	m := newTestManager(nil)
+	m.Config.ReconnectDelay = 3 * time.Second
+	m.Config.DrainTimeout = 2 * time.Second
+	url := newHandshakeServer(t, m) + "?token=" + signToken(t, testSecret, 1)
+
+	conn, _, err := wsTool.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	local := newTestConnection(t, m, "c2", "2")
+	waitUntil(t, func() bool { return m.DrainStatus().Connections == 2 })
+
+	// 下线期间客户端持续发送 ping，注销后读协程的回复不能写入已关闭的发送缓冲区
+	writing := pingLoop(conn, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	// 下线时收到 reconnect 消息，发送缓冲区发完后以 1001 关闭
+	drained := make(chan struct{})
+	go func() {
+		m.Drain(context.Background())
+		close(drained)
+	}()
+
+	var received []types.Message
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if !wsTool.IsCloseError(err, wsTool.CloseGoingAway) {
+				t.Fatalf("close error = %v, want code %d", err, wsTool.CloseGoingAway)
+			}
+			break
+		}
+		for _, line := range bytes.Split(data, []byte{'\n'}) {
+			var msg types.Message
+			if err := json.Unmarshal(line, &msg); err != nil {
+				t.Fatalf("invalid message: %s", line)
+			}
+			if msg.Type != MessageTypePong {
+				received = append(received, msg)
+			}
+		}
+	}
+	if len(received) != 1 || received[0].Type != MessageTypeReconnect {
+		t.Fatalf("unexpected messages before close: %+v", received)
+	}
+	if delay := received[0].Data.(map[string]interface{})["delay"].(float64); delay < 0 || delay > 3000 {
+		t.Fatalf("reconnect delay %v out of range", delay)
+	}
+	conn.Close()
+	<-writing
+
+	// 没有写协程的连接只关闭发送缓冲区
+	waitMessage(t, local, MessageTypeReconnect)
+	waitUntil(t, func() bool {
+		select {
+		case _, ok := <-local.Send:
+			return !ok
+		default:
+			return false
+		}
+	})
+
+	select {
+	case <-drained:
+	case <-time.After(3 * time.Second):
+		t.Fatal("drain did not finish")
+	}
+	if status := m.DrainStatus(); !status.Draining || status.Connections != 0 {
+		t.Fatalf("unexpected drain status: %+v", status)
+	}
+	if m.Ctx.Err() == nil {
+		t.Fatal("run loop should be stopped after drain")
+	}
+
+	// 下线后注册的连接直接关闭发送缓冲区，读协程随后收到的消息不再回复
+	late := &Connection{ID: "c3", UserID: "3", Send: make(chan []byte, 1), WsManager: m, codec: JSONCodec}
+	m.registerConnection(late)
+	if _, ok := <-late.Send; ok {
+		t.Fatal("send buffer of late connection should be closed")
+	}
+	late.handleMessage([]byte(`{"type":"ping"}`))
+	if err := late.SendMessage(&types.Message{Type: MessageTypePong}); err != errConnectionClosed {
+		t.Fatalf("send after drain error = %v, want %v", err, errConnectionClosed)
+	}
+
+	// 下线中不再升级连接
+	_, resp, err := wsTool.DefaultDialer.Dial(url, nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("upgrade should be refused while draining, err=%v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/golang-jwt/jwt/v4"
+	wsTool "github.com/gorilla/websocket"
+)
+
+const testSecret = "test-secret"
+
+func newHandshakeServer(t *testing.T, m *WsManager) string {
+	auth := NewSecretAuthenticator(testSecret)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userId, err := auth.Authenticate(r)
+		if err != nil {
+			_ = RejectConnection(m, w, r, err)
+			return
+		}
+		_ = NewConnection(m, w, r, userId)
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func signToken(t *testing.T, secret string, userId int64) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userId": userId,
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token failed: %v", err)
+	}
+	return token
+}
+
+// readError 读取 error 消息，并确认随后以指定关闭码断开
+func readError(t *testing.T, conn *wsTool.Conn, code uint32, closeCode int) {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read error message failed: %v", err)
+		}
+		var msg types.Message
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type != MessageTypeError {
+			continue
+		}
+		if !hasErrorCode(msg, code) {
+			t.Fatalf("unexpected error message: %s", data)
+		}
+		break
+	}
+	if closeCode == 0 {
+		return
+	}
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if !wsTool.IsCloseError(err, closeCode) {
+				t.Fatalf("close error = %v, want code %d", err, closeCode)
+			}
+			return
+		}
+	}
+}
+
+func TestHandshakeAuthAndOrigin(t *testing.T) {
+	m := newTestManager(nil)
+	m.Config.AllowedOrigins = "https://app.example.com"
+	defer m.Cancel()
+	url := newHandshakeServer(t, m)
+
+	// 不在允许列表中的来源在握手阶段被拒绝
+	_, resp, err := wsTool.DefaultDialer.Dial(url+"?token="+signToken(t, testSecret, 1),
+		http.Header{"Origin": []string{"https://evil.example.com"}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("disallowed origin should be rejected, err=%v", err)
+	}
+
+	// token 无效时收到 error 消息后以 1008 关闭
+	conn, _, err := wsTool.DefaultDialer.Dial(url+"?token="+signToken(t, "other-secret", 1),
+		http.Header{"Origin": []string{"https://app.example.com"}})
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	readError(t, conn, xerr.TOKEN_EXPIRE_ERROR, wsTool.ClosePolicyViolation)
+	conn.Close()
+
+	// token 有效时注册为 token 中的用户
+	conn, _, err = wsTool.DefaultDialer.Dial(url, http.Header{
+		"Origin":        []string{"https://app.example.com"},
+		"Authorization": []string{"Bearer " + signToken(t, testSecret, 42)},
+	})
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	waitUntil(t, func() bool { return m.OnlineUsers(m.Ctx, []string{"42"})["42"] })
+}
+
+func TestMessageSizeAndRateLimit(t *testing.T) {
+	m := newTestManager(nil)
+	m.Config.MaxMessageSize = 256
+	m.Config.RateLimits = map[string]config.RateLimitConf{
+		"chat": {Rate: 0.01, Burst: 1},
+	}
+	defer m.Cancel()
+	url := newHandshakeServer(t, m) + "?token=" + signToken(t, testSecret, 1)
+
+	conn, _, err := wsTool.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	// 超过令牌桶的 chat 消息被丢弃并收到 error 消息，其他类型不受影响
+	chat := `{"type":"chat","to":"2","data":{"text":"hi"}}`
+	for i := 0; i < 2; i++ {
+		if err := conn.WriteMessage(wsTool.TextMessage, []byte(chat)); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	readError(t, conn, xerr.WS_RATE_LIMIT_ERROR, 0)
+	if err := conn.WriteMessage(wsTool.TextMessage, []byte(`{"type":"ping"}`)); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, data, err := conn.ReadMessage(); err != nil || !strings.Contains(string(data), MessageTypePong) {
+		t.Fatalf("ping should not be limited: %s %v", data, err)
+	}
+
+	// 超过大小限制时收到 error 消息后以 1009 关闭
+	big := `{"type":"chat","to":"2","data":{"text":"` + strings.Repeat("x", 512) + `"}}`
+	if err := conn.WriteMessage(wsTool.TextMessage, []byte(big)); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	readError(t, conn, xerr.WS_MESSAGE_TOO_LARGE_ERROR, wsTool.CloseMessageTooBig)
+}
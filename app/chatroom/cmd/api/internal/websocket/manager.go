@@ -2,7 +2,6 @@ package websocket
 
 import (
 	"context"
-	"encoding/json"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
 	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
 
@@ -24,21 +23,33 @@ type Connection struct {
 	WsManager    *WsManager
 	LastPingTime time.Time
 	IsAlive      bool
+	Status       string // presence reported by the client (online/away), guarded by WsManager.Mu
 	mu           sync.RWMutex
 	Metadata     map[string]interface{}
+	Rooms        map[string]string // subscribed room ID -> member role, guarded by WsManager.Mu
+	LastSeq      int64             // seq supplied by the client on reconnect, negative to resume from the stored ack
+	AckedSeq     int64             // highest seq acknowledged on this connection, guarded by mu
+	limiter      *messageLimiter   // per message type rate limits
+	closeStatus  int               // close code sent after the pending error frame, guarded by mu
+	presenceSubs map[string]bool   // users whose presence this connection subscribes to, guarded by WsManager.Mu
+	codec        Codec             // frame encoding negotiated via the WebSocket subprotocol
+	done         chan struct{}     // closed when writePump exits, nil for connections without a writer
+	sendMu       sync.RWMutex      // guards sends on Send against closeSend
+	sendClosed   bool              // Send has been closed, guarded by sendMu
 }
 
 type broadcastJob struct {
 	kind  int
 	shard int
-	data  []byte
+	frame *frame
+	conns []string // target connection IDs for room jobs
 }
 
 // WsManager orchestrates WebSocket connections, fanout, and housekeeping.
 type WsManager struct {
 	Connections      map[string]*Connection     // active connections keyed by connection ID
 	UserConnections  map[string]map[string]bool // user ID -> connection IDs
-	GroupConnections map[string]map[string]bool // reserved group mapping
+	GroupConnections map[string]map[string]bool // room ID -> connection IDs
 	Broadcast        chan *types.Message        // inbound broadcast messages
 	Register         chan *Connection           // queue for new registrations
 	Unregister       chan *Connection           // queue for disconnects
@@ -55,26 +66,50 @@ type WsManager struct {
 	BroadcastJobs chan broadcastJob
 	PingJobs      chan int
 
+	// RoomStore persists room membership, rooms are disabled when nil
+	RoomStore RoomStore
+	// Cluster forwards messages to other nodes, nil when cluster mode is off
+	Cluster *Cluster
+	// MessageStore persists directed and room messages for offline replay, nil disables persistence
+	MessageStore MessageStore
+	// Bots replies to messages addressed to chatroom bots, nil disables bots
+	Bots BotHandler
+	// ReadStore persists read cursors, nil disables read receipts
+	ReadStore ReadStore
+
+	PresenceSubscribers map[string]map[string]bool // user ID -> connection IDs subscribed to the user's presence, guarded by Mu
+	presence            map[string]string          // aggregated presence of users with connections on this node, guarded by Mu
+
+	shardCounters []shardCounters // per shard queue depth and drop counters
+	dropOnFull    atomic.Bool     // runtime copy of Config.DropOnFull, changed by the admin API
+	sendTimeout   atomic.Int64    // runtime copy of Config.SendTimeout in nanoseconds
+	draining      atomic.Bool     // set by Drain, new connections are refused
+
 	logx logx.Logger
 }
 
-const broadcastJobAll = 1 // broadcast payload to every shard
+const (
+	broadcastJobAll  = 1 // broadcast payload to every shard
+	broadcastJobRoom = 2 // deliver payload to the listed connections of a shard
+)
 
 // NewWsManager constructs a WebSocket manager with shard workers and background tasks.
 func NewWsManager(config *config.WsConfig) *WsManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	manager := &WsManager{
-		Connections:      make(map[string]*Connection),
-		UserConnections:  make(map[string]map[string]bool),
-		GroupConnections: make(map[string]map[string]bool),
-		Broadcast:        make(chan *types.Message, config.MessageQueueSize),
-		Register:         make(chan *Connection, 1000),
-		Unregister:       make(chan *Connection, 1000),
-		Config:           config,
-		Ctx:              ctx,
-		Cancel:           cancel,
-		logx:             logx.WithContext(ctx),
+		Connections:         make(map[string]*Connection),
+		UserConnections:     make(map[string]map[string]bool),
+		GroupConnections:    make(map[string]map[string]bool),
+		PresenceSubscribers: make(map[string]map[string]bool),
+		presence:            make(map[string]string),
+		Broadcast:           make(chan *types.Message, config.MessageQueueSize),
+		Register:            make(chan *Connection, 1000),
+		Unregister:          make(chan *Connection, 1000),
+		Config:              config,
+		Ctx:                 ctx,
+		Cancel:              cancel,
+		logx:                logx.WithContext(ctx),
 	}
 
 	// init shards
@@ -84,10 +119,19 @@ func NewWsManager(config *config.WsConfig) *WsManager {
 	manager.ShardCount = manager.Config.ShardCount
 	manager.ShardConns = make([]map[string]*Connection, manager.ShardCount)
 	manager.ShardLocks = make([]sync.RWMutex, manager.ShardCount)
+	manager.shardCounters = make([]shardCounters, manager.ShardCount)
 	for i := 0; i < manager.ShardCount; i++ {
 		manager.ShardConns[i] = make(map[string]*Connection)
 	}
 
+	// Backpressure settings can be changed at runtime, keep them outside the config.
+	sendTimeout := manager.Config.SendTimeout
+	if sendTimeout <= 0 {
+		sendTimeout = 50 * time.Millisecond
+	}
+	manager.dropOnFull.Store(manager.Config.DropOnFull)
+	manager.sendTimeout.Store(int64(sendTimeout))
+
 	// init broadcast workers
 	if manager.Config.BroadcastWorkerCount <= 0 {
 		manager.Config.BroadcastWorkerCount = 1
@@ -109,6 +153,7 @@ func NewWsManager(config *config.WsConfig) *WsManager {
 	}
 
 	go manager.run()
+	go manager.reportMetrics()
 	return manager
 }
 
@@ -126,20 +171,20 @@ func (m *WsManager) run() {
 		case conn := <-m.Unregister:
 			m.unregisterConnection(conn)
 		case message := <-m.Broadcast:
-			// Marshal once to avoid repeated allocations.
+			// Marshal once per encoding to avoid repeated allocations.
 			if message.Timestamp == 0 {
 				message.Timestamp = time.Now().Unix()
 			}
-			data, err := json.Marshal(message)
-			if err != nil {
-				m.logx.Errorf("failed to marshal outbound message: %v", err)
-				continue
-			}
-			switch {
-			case message.To != "":
-				m.sendToUser(message.To, data)
-			default:
-				m.enqueueBroadcastAll(data)
+			f := newFrame(message)
+			m.dispatchLocal(message, f)
+			if m.Cluster != nil {
+				// Other nodes always receive JSON and re-encode for their own connections.
+				data, err := f.encode(JSONCodec)
+				if err != nil {
+					m.logx.Errorf("failed to marshal outbound message: %v", err)
+					continue
+				}
+				m.Cluster.forward(message, data)
 			}
 		case <-ticker.C:
 			if m.Config.EnableGlobalPing {
@@ -156,15 +201,39 @@ func (m *WsManager) run() {
 	}
 }
 
+// dispatchLocal delivers a message to the connections on this node.
+func (m *WsManager) dispatchLocal(message *types.Message, f *frame) {
+	switch {
+	case message.Room != "":
+		m.sendToRoom(message.Room, f)
+	case message.To != "":
+		m.sendToUser(message.To, f)
+	default:
+		m.enqueueBroadcastAll(f)
+	}
+}
+
 // broadcastWorker fans out broadcast jobs for a specific shard.
 func (m *WsManager) broadcastWorker() {
 	for job := range m.BroadcastJobs {
+		atomic.AddInt64(&m.shardCounters[job.shard].pending, -1)
 		m.ShardLocks[job.shard].RLock()
-		for _, conn := range m.ShardConns[job.shard] {
-			if conn.IsAlive {
-				m.trySend(conn, job.data, func() {
-					m.logx.Infof("warning: connection %s send buffer is full, applying backpressure policy", conn.ID)
-				})
+		switch job.kind {
+		case broadcastJobRoom:
+			for _, id := range job.conns {
+				if conn, ok := m.ShardConns[job.shard][id]; ok && conn.IsAlive {
+					m.trySend(conn, job.frame, func() {
+						m.logx.Infof("warning: connection %s send buffer is full, applying backpressure policy", conn.ID)
+					})
+				}
+			}
+		default:
+			for _, conn := range m.ShardConns[job.shard] {
+				if conn.IsAlive {
+					m.trySend(conn, job.frame, func() {
+						m.logx.Infof("warning: connection %s send buffer is full, applying backpressure policy", conn.ID)
+					})
+				}
 			}
 		}
 		m.ShardLocks[job.shard].RUnlock()
@@ -189,6 +258,15 @@ func (m *WsManager) registerConnection(conn *Connection) {
 	m.Mu.Lock()
 	defer m.Mu.Unlock()
 
+	// Connections upgraded after the node started draining are closed with 1001 so clients reconnect elsewhere.
+	if m.draining.Load() {
+		conn.mu.Lock()
+		conn.closeStatus = websocket.CloseGoingAway
+		conn.mu.Unlock()
+		conn.closeSend()
+		return
+	}
+
 	// Enforce the maximum connection limit.
 	if atomic.LoadInt64(&m.ConnectionCount) >= m.Config.MaxConnections {
 		conn.Conn.Close()
@@ -211,6 +289,20 @@ func (m *WsManager) registerConnection(conn *Connection) {
 			m.UserConnections[conn.UserID] = make(map[string]bool)
 		}
 		m.UserConnections[conn.UserID][conn.ID] = true
+		if m.Cluster != nil && len(m.UserConnections[conn.UserID]) == 1 {
+			m.Cluster.userOnline(conn.UserID)
+		}
+		m.refreshPresenceLocked(conn.UserID)
+	}
+
+	// Subscribe the connection to the rooms the user has joined.
+	if m.RoomStore != nil && conn.UserID != "" {
+		go m.restoreRooms(conn)
+	}
+
+	// Replay the messages the user missed while offline.
+	if m.MessageStore != nil && conn.UserID != "" {
+		go m.replay(conn, conn.LastSeq)
 	}
 
 	m.logx.Infof("registered websocket connection %s for user %s, active connections: %d",
@@ -222,6 +314,10 @@ func (m *WsManager) unregisterConnection(conn *Connection) {
 	m.Mu.Lock()
 	defer m.Mu.Unlock()
 
+	// Leave subscribed rooms even if the registration was rejected.
+	m.unsubscribeAllLocked(conn)
+	m.unsubscribePresenceLocked(conn)
+
 	if _, exists := m.Connections[conn.ID]; exists {
 		delete(m.Connections, conn.ID)
 		atomic.AddInt64(&m.ConnectionCount, -1)
@@ -237,10 +333,14 @@ func (m *WsManager) unregisterConnection(conn *Connection) {
 			delete(m.UserConnections[conn.UserID], conn.ID)
 			if len(m.UserConnections[conn.UserID]) == 0 {
 				delete(m.UserConnections, conn.UserID)
+				if m.Cluster != nil {
+					m.Cluster.userOffline(conn.UserID)
+				}
 			}
+			m.refreshPresenceLocked(conn.UserID)
 		}
 
-		close(conn.Send)
+		conn.closeSend()
 		m.logx.Infof("unregistered websocket connection %s, active connections: %d",
 			conn.ID, atomic.LoadInt64(&m.ConnectionCount))
 	}
@@ -256,56 +356,66 @@ func (m *WsManager) shardIndex(id string) int {
 	return int(hasher.Sum32() % uint32(m.ShardCount))
 }
 
-// trySend attempts to enqueue data for the connection while applying backpressure policies.
-func (m *WsManager) trySend(conn *Connection, data []byte, onDrop func()) {
-	if m.Config.DropOnFull {
-		select {
-		case conn.Send <- data:
-		default:
-			onDrop()
-			if m.Config.CloseOnBackpressure {
-				conn.Conn.Close()
-			}
-		}
+// trySend attempts to enqueue the frame, encoded with the connection's codec, while applying backpressure policies.
+func (m *WsManager) trySend(conn *Connection, f *frame, onDrop func()) {
+	data, err := f.encode(conn.codec)
+	if err != nil {
+		m.logx.Errorf("failed to marshal message for connection %s: %v", conn.ID, err)
 		return
 	}
-	// In blocking mode, wait at most the configured duration before giving up.
-	timeout := m.Config.SendTimeout
-	if timeout <= 0 {
-		timeout = 50 * time.Millisecond
-	}
-	select {
-	case conn.Send <- data:
-	case <-time.After(timeout):
+	drop := func() {
 		onDrop()
+		m.recordDrop(m.shardIndex(conn.ID), dropReasonSendBufferFull)
 		if m.Config.CloseOnBackpressure {
 			conn.Conn.Close()
 		}
 	}
+	// In blocking mode, wait at most the configured duration before giving up.
+	var timeout time.Duration
+	if !m.dropOnFull.Load() {
+		timeout = time.Duration(m.sendTimeout.Load())
+	}
+	if err := conn.enqueue(data, timeout); err == errSendBufferFull {
+		drop()
+	}
 }
 
 // sendToUser delivers a payload to all connections attached to a user ID.
-func (m *WsManager) sendToUser(userID string, data []byte) {
+func (m *WsManager) sendToUser(userID string, f *frame) {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+
 	if connections, exists := m.UserConnections[userID]; exists {
 		for connID := range connections {
 			if conn, ok := m.Connections[connID]; ok && conn.IsAlive {
-				m.trySend(conn, data, func() { m.logx.Infof("warning: send buffer is full for user %s connection %s", userID, conn.ID) })
+				m.trySend(conn, f, func() { m.logx.Infof("warning: send buffer is full for user %s connection %s", userID, conn.ID) })
 			}
 		}
 	}
 }
 
 // enqueueBroadcastAll schedules a broadcast job for every shard.
-func (m *WsManager) enqueueBroadcastAll(data []byte) {
+func (m *WsManager) enqueueBroadcastAll(f *frame) {
 	for i := 0; i < m.ShardCount; i++ {
-		select {
-		case m.BroadcastJobs <- broadcastJob{kind: broadcastJobAll, shard: i, data: data}:
-		default:
+		if !m.enqueueJob(broadcastJob{kind: broadcastJobAll, shard: i, frame: f}) {
 			m.logx.Infof("warning: broadcast job queue is full, dropping message")
 		}
 	}
 }
 
+// enqueueJob hands a job to the broadcast workers without blocking and tracks the shard queue depth.
+func (m *WsManager) enqueueJob(job broadcastJob) bool {
+	atomic.AddInt64(&m.shardCounters[job.shard].pending, 1)
+	select {
+	case m.BroadcastJobs <- job:
+		return true
+	default:
+		atomic.AddInt64(&m.shardCounters[job.shard].pending, -1)
+		m.recordDrop(job.shard, dropReasonJobQueueFull)
+		return false
+	}
+}
+
 // checkHeartbeats closes connections that missed the heartbeat deadline.
 func (m *WsManager) checkHeartbeats() {
 	m.Mu.RLock()
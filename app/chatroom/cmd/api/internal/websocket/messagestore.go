@@ -0,0 +1,330 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const (
+	messageSeqKeyPrefix     = "chatroom:seq:"
+	messageAckKeyPrefix     = "chatroom:ack:"
+	messageRoomAckKeyPrefix = "chatroom:ack:room:" // 用户 -> 各房间已确认的房间序号
+)
+
+// 序号不存在时用数据库中的最大序号初始化，避免 Redis 数据丢失后序号回退
+const nextSeqScript = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return redis.call('INCR', KEYS[1])`
+
+// 确认序号只增不减，多台设备乱序确认时保留最大值
+const ackScript = `
+local cur = tonumber(redis.call('GET', KEYS[1]) or '0')
+local seq = tonumber(ARGV[1])
+if seq > cur then
+	redis.call('SET', KEYS[1], seq)
+	return seq
+end
+return cur`
+
+// 房间确认序号同样只增不减，按房间保存在用户的 hash 中
+const roomAckScript = `
+local cur = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+local seq = tonumber(ARGV[2])
+if seq > cur then
+	redis.call('HSET', KEYS[1], ARGV[1], seq)
+	return seq
+end
+return cur`
+
+// MessageStore 私聊与房间消息的持久化存储：私聊消息在每个接收者的收件箱中有独立递增的序号，
+// 房间消息在房间内有连续递增的序号，所有成员收到同一个序号
+type MessageStore interface {
+	// SaveRoom 保存房间消息并分配房间序号，返回消息 id 和序号
+	SaveRoom(ctx context.Context, msg *types.Message) (id, seq int64, err error)
+	// SaveDirect 保存私聊消息并为双方（包括发送者自己）分配收件箱序号，返回消息 id 和 用户 -> 序号
+	SaveDirect(ctx context.Context, msg *types.Message) (id int64, seqs map[string]int64, err error)
+	// Since 用户收件箱中序号大于 lastSeq 的消息，按序号升序，消息的 Seq 为该用户的序号
+	Since(ctx context.Context, userId, lastSeq, limit int64) ([]*types.Message, error)
+	// RoomSince 房间中序号大于 lastSeq 的消息，按序号升序，消息的 Seq 为房间序号
+	RoomSince(ctx context.Context, roomId, lastSeq, limit int64) ([]*types.Message, error)
+	// Ack 记录用户收件箱已收到的最大序号
+	Ack(ctx context.Context, userId, seq int64) error
+	// LastAck 用户收件箱已确认的最大序号，客户端没有带 lastSeq 时从这里补发
+	LastAck(ctx context.Context, userId int64) (int64, error)
+	// AckRoom 记录用户在房间中已收到的最大房间序号
+	AckRoom(ctx context.Context, userId, roomId, seq int64) error
+	// LastRoomAcks 用户在各房间已确认的最大房间序号，没有确认过的房间不在结果中
+	LastRoomAcks(ctx context.Context, userId int64) (map[int64]int64, error)
+}
+
+type modelMessageStore struct {
+	redis            *redis.Redis
+	chatMessageModel model.ChatMessageModel
+	userMessageModel model.UserMessageModel
+}
+
+// NewModelMessageStore 基于 MySQL 的消息存储，序号与确认位置保存在 Redis
+func NewModelMessageStore(rds *redis.Redis, chatMessageModel model.ChatMessageModel,
+	userMessageModel model.UserMessageModel) MessageStore {
+	return &modelMessageStore{
+		redis:            rds,
+		chatMessageModel: chatMessageModel,
+		userMessageModel: userMessageModel,
+	}
+}
+
+func (s *modelMessageStore) SaveRoom(ctx context.Context, msg *types.Message) (int64, int64, error) {
+	row, err := newChatMessageRow(msg)
+	if err != nil {
+		return 0, 0, err
+	}
+	if row.RoomId, err = strconv.ParseInt(msg.Room, 10, 64); err != nil {
+		return 0, 0, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	// 序号在写入的事务中分配，按序号顺序提交，补发时不会先看到较大的序号而跳过仍未提交的消息
+	var id int64
+	err = s.chatMessageModel.Trans(ctx, func(ctx context.Context, session sqlx.Session) error {
+		seq, err := s.chatMessageModel.NextRoomSeq(ctx, session, row.RoomId)
+		if err != nil {
+			return err
+		}
+		row.Seq = seq
+		ret, err := s.chatMessageModel.Insert(ctx, session, row)
+		if err != nil {
+			return err
+		}
+		id, err = ret.LastInsertId()
+		return err
+	})
+	if err != nil {
+		return 0, 0, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "save message from user %d to room %d failed: %v", row.FromUserId, row.RoomId, err)
+	}
+	return id, row.Seq, nil
+}
+
+func (s *modelMessageStore) SaveDirect(ctx context.Context, msg *types.Message) (int64, map[string]int64, error) {
+	row, err := newChatMessageRow(msg)
+	if err != nil {
+		return 0, nil, err
+	}
+	if row.ToUserId, err = strconv.ParseInt(msg.To, 10, 64); err != nil {
+		return 0, nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	recipients := []int64{row.FromUserId}
+	if row.ToUserId != row.FromUserId {
+		recipients = append(recipients, row.ToUserId)
+	}
+	// 机器人不需要收件箱
+	if model.IsBotUserId(row.FromUserId) {
+		recipients = recipients[1:]
+	} else if model.IsBotUserId(row.ToUserId) && len(recipients) > 1 {
+		recipients = recipients[:1]
+	}
+
+	// 先分配序号，事务失败时序号留空洞，客户端按序号去重不受影响
+	inbox := make([]*model.UserMessage, 0, len(recipients))
+	seqs := make(map[string]int64, len(recipients))
+	for _, userId := range recipients {
+		seq, err := s.nextSeq(ctx, userId)
+		if err != nil {
+			return 0, nil, err
+		}
+		inbox = append(inbox, &model.UserMessage{UserId: userId, Seq: seq})
+		seqs[strconv.FormatInt(userId, 10)] = seq
+	}
+
+	var id int64
+	err = s.chatMessageModel.Trans(ctx, func(ctx context.Context, session sqlx.Session) error {
+		ret, err := s.chatMessageModel.Insert(ctx, session, row)
+		if err != nil {
+			return err
+		}
+		if id, err = ret.LastInsertId(); err != nil {
+			return err
+		}
+		for _, item := range inbox {
+			item.MessageId = id
+		}
+		return s.userMessageModel.InsertBatch(ctx, session, inbox)
+	})
+	if err != nil {
+		return 0, nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "save message from user %d failed: %v", row.FromUserId, err)
+	}
+	return id, seqs, nil
+}
+
+// newChatMessageRow 消息的公共字段
+func newChatMessageRow(msg *types.Message) (*model.ChatMessage, error) {
+	fromUserId, err := strconv.ParseInt(msg.From, 10, 64)
+	if err != nil {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR), "marshal message data failed: %v", err)
+	}
+	return &model.ChatMessage{
+		Type:       msg.Type,
+		FromUserId: fromUserId,
+		Data:       string(data),
+		SendTime:   msg.Timestamp,
+	}, nil
+}
+
+func (s *modelMessageStore) Since(ctx context.Context, userId, lastSeq, limit int64) ([]*types.Message, error) {
+	inbox, err := s.userMessageModel.FindSince(ctx, userId, lastSeq, limit)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find messages of user %d since %d failed: %v", userId, lastSeq, err)
+	}
+	if len(inbox) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, 0, len(inbox))
+	for _, item := range inbox {
+		ids = append(ids, item.MessageId)
+	}
+	rows, err := s.chatMessageModel.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find messages %v failed: %v", ids, err)
+	}
+	byId := make(map[int64]*model.ChatMessage, len(rows))
+	for _, row := range rows {
+		byId[row.Id] = row
+	}
+
+	messages := make([]*types.Message, 0, len(inbox))
+	for _, item := range inbox {
+		row, ok := byId[item.MessageId]
+		if !ok {
+			continue
+		}
+		msg := ChatMessageToMessage(row)
+		msg.Seq = item.Seq
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+func (s *modelMessageStore) RoomSince(ctx context.Context, roomId, lastSeq, limit int64) ([]*types.Message, error) {
+	rows, err := s.chatMessageModel.FindRoomSince(ctx, roomId, lastSeq, limit)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find messages of room %d since %d failed: %v", roomId, lastSeq, err)
+	}
+	messages := make([]*types.Message, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, ChatMessageToMessage(row))
+	}
+	return messages, nil
+}
+
+func (s *modelMessageStore) Ack(ctx context.Context, userId, seq int64) error {
+	key := fmt.Sprintf("%s%d", messageAckKeyPrefix, userId)
+	if _, err := s.redis.EvalCtx(ctx, ackScript, []string{key}, seq); err != nil {
+		return errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "ack seq %d of user %d failed: %v", seq, userId, err)
+	}
+	return nil
+}
+
+func (s *modelMessageStore) LastAck(ctx context.Context, userId int64) (int64, error) {
+	val, err := s.redis.GetCtx(ctx, fmt.Sprintf("%s%d", messageAckKeyPrefix, userId))
+	if err != nil {
+		return 0, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "get ack seq of user %d failed: %v", userId, err)
+	}
+	if val == "" {
+		return 0, nil
+	}
+	seq, _ := strconv.ParseInt(val, 10, 64)
+	return seq, nil
+}
+
+func (s *modelMessageStore) AckRoom(ctx context.Context, userId, roomId, seq int64) error {
+	key := fmt.Sprintf("%s%d", messageRoomAckKeyPrefix, userId)
+	if _, err := s.redis.EvalCtx(ctx, roomAckScript, []string{key}, roomId, seq); err != nil {
+		return errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "ack seq %d of user %d in room %d failed: %v", seq, userId, roomId, err)
+	}
+	return nil
+}
+
+func (s *modelMessageStore) LastRoomAcks(ctx context.Context, userId int64) (map[int64]int64, error) {
+	vals, err := s.redis.HgetallCtx(ctx, fmt.Sprintf("%s%d", messageRoomAckKeyPrefix, userId))
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "get room ack seqs of user %d failed: %v", userId, err)
+	}
+	acks := make(map[int64]int64, len(vals))
+	for room, val := range vals {
+		roomId, err := strconv.ParseInt(room, 10, 64)
+		if err != nil {
+			continue
+		}
+		seq, _ := strconv.ParseInt(val, 10, 64)
+		acks[roomId] = seq
+	}
+	return acks, nil
+}
+
+func (s *modelMessageStore) nextSeq(ctx context.Context, userId int64) (int64, error) {
+	seq, err := s.incrSeq(ctx, fmt.Sprintf("%s%d", messageSeqKeyPrefix, userId), func() (int64, error) {
+		return s.userMessageModel.FindMaxSeq(ctx, userId)
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "user %d", userId)
+	}
+	return seq, nil
+}
+
+// incrSeq 递增 key 上的序号，key 不存在时用 maxSeq 查到的数据库中的最大序号初始化
+func (s *modelMessageStore) incrSeq(ctx context.Context, key string, maxSeq func() (int64, error)) (int64, error) {
+	var base int64
+	exists, err := s.redis.ExistsCtx(ctx, key)
+	if err != nil {
+		return 0, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "check seq %s failed: %v", key, err)
+	}
+	if !exists {
+		if base, err = maxSeq(); err != nil {
+			return 0, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find max seq of %s failed: %v", key, err)
+		}
+	}
+	val, err := s.redis.EvalCtx(ctx, nextSeqScript, []string{key}, base)
+	if err != nil {
+		return 0, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "incr seq %s failed: %v", key, err)
+	}
+	seq, ok := val.(int64)
+	if !ok {
+		return 0, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "unexpected seq %v of %s", val, key)
+	}
+	return seq, nil
+}
+
+// ChatMessageToMessage 把数据库中的消息还原为推送给客户端的格式
+func ChatMessageToMessage(row *model.ChatMessage) *types.Message {
+	msg := &types.Message{
+		ID:        row.Id,
+		Type:      row.Type,
+		From:      strconv.FormatInt(row.FromUserId, 10),
+		Timestamp: row.SendTime,
+	}
+	if row.RoomId > 0 {
+		msg.Room = strconv.FormatInt(row.RoomId, 10)
+		msg.Seq = row.Seq
+	} else {
+		msg.To = strconv.FormatInt(row.ToUserId, 10)
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(row.Data), &data); err == nil {
+		msg.Data = data
+	}
+	return msg
+}
@@ -0,0 +1,79 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+func newTestModelMessageStore(t *testing.T) (MessageStore, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	mr := miniredis.RunT(t)
+	rds := redis.MustNewRedis(redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType})
+	c := cache.CacheConf{{RedisConf: redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType}, Weight: 100}}
+	conn := sqlx.NewSqlConnFromDB(db)
+	return NewModelMessageStore(rds, model.NewChatMessageModel(conn, c), model.NewUserMessageModel(conn, c)), mock
+}
+
+func TestSaveRoomAllocatesSeqInTransaction(t *testing.T) {
+	store, mock := newTestModelMessageStore(t)
+	msg := &types.Message{Type: "text", From: "2", Room: "7", Data: "hi", Timestamp: 100}
+
+	// 先锁定房间行，再在同一事务中取序号并写入
+	mock.ExpectBegin()
+	mock.ExpectQuery("select `id` from `room` where `id` = \\? for update").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery("select ifnull\\(max\\(`seq`\\), 0\\) \\+ 1 from `chat_message` where `room_id` = \\?").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(4))
+	mock.ExpectExec("insert into `chat_message`").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "text", int64(2), int64(0), int64(7), `"hi"`, int64(100), int64(4)).
+		WillReturnResult(sqlmock.NewResult(11, 1))
+	mock.ExpectCommit()
+
+	id, seq, err := store.SaveRoom(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("SaveRoom failed: %v", err)
+	}
+	if id != 11 || seq != 4 {
+		t.Fatalf("unexpected id %d seq %d", id, seq)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveRoomRollsBackSeqOnInsertError(t *testing.T) {
+	store, mock := newTestModelMessageStore(t)
+	msg := &types.Message{Type: "text", From: "2", Room: "7", Data: "hi", Timestamp: 100}
+
+	// 写入失败时事务回滚，序号没有被占用，房间序号保持连续
+	mock.ExpectBegin()
+	mock.ExpectQuery("for update").WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery("max\\(`seq`\\)").WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(4))
+	mock.ExpectExec("insert into `chat_message`").WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	if _, _, err := store.SaveRoom(context.Background(), msg); err == nil {
+		t.Fatal("expected SaveRoom to fail")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+// 消息被丢弃的原因
+const (
+	dropReasonSendBufferFull = "send_buffer_full" // 连接的发送缓冲区已满
+	dropReasonJobQueueFull   = "job_queue_full"   // 广播任务队列已满
+)
+
+// metricsInterval 刷新分片指标的间隔
+const metricsInterval = 5 * time.Second
+
+var (
+	metricShardConnections = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "chatroom",
+		Subsystem: "ws",
+		Name:      "shard_connections",
+		Help:      "chatroom websocket connections per shard.",
+		Labels:    []string{"shard"},
+	})
+	metricShardQueueDepth = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "chatroom",
+		Subsystem: "ws",
+		Name:      "shard_queue_depth",
+		Help:      "chatroom broadcast jobs waiting for a worker per shard.",
+		Labels:    []string{"shard"},
+	})
+	metricShardBuffered = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "chatroom",
+		Subsystem: "ws",
+		Name:      "shard_send_buffered",
+		Help:      "chatroom messages waiting in connection send buffers per shard.",
+		Labels:    []string{"shard"},
+	})
+	metricDropped = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "chatroom",
+		Subsystem: "ws",
+		Name:      "dropped_messages_total",
+		Help:      "chatroom messages dropped by backpressure.",
+		Labels:    []string{"shard", "reason"},
+	})
+)
+
+// shardCounters 分片的队列深度与丢弃计数，原子读写
+type shardCounters struct {
+	pending     int64 // 已入队未处理的广播任务
+	dropped     int64 // 发送缓冲区满丢弃的消息
+	jobsDropped int64 // 广播任务队列满丢弃的任务
+}
+
+// recordDrop 记录分片上被丢弃的消息
+func (m *WsManager) recordDrop(shard int, reason string) {
+	if reason == dropReasonJobQueueFull {
+		atomic.AddInt64(&m.shardCounters[shard].jobsDropped, 1)
+	} else {
+		atomic.AddInt64(&m.shardCounters[shard].dropped, 1)
+	}
+	metricDropped.Inc(strconv.Itoa(shard), reason)
+}
+
+// reportMetrics 定期把分片的连接数、队列深度和发送缓冲区积压写入 Prometheus
+func (m *WsManager) reportMetrics() {
+	ticker := time.NewTicker(metricsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.Ctx.Done():
+			return
+		case <-ticker.C:
+			for _, shard := range m.shardStats() {
+				label := strconv.Itoa(shard.Shard)
+				metricShardConnections.Set(float64(shard.Connections), label)
+				metricShardQueueDepth.Set(float64(shard.QueueDepth), label)
+				metricShardBuffered.Set(float64(shard.Buffered), label)
+			}
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package websocket
+
+import (
+	"strconv"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+// replayBatchSize 每次从存储读取的补发消息数
+const replayBatchSize = 100
+
+// Publish 投递私聊或房间消息：启用消息存储时先保存并分配序号再投递，接收者离线时重连后补发。
+// 房间消息带房间序号，与未保存的消息一样经分片 worker 批量投递；私聊消息按双方各自的序号投递
+func (m *WsManager) Publish(msg *types.Message) error {
+	if m.MessageStore == nil {
+		message := *msg
+		m.Broadcast <- &message
+		return nil
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().Unix()
+	}
+	if msg.Room != "" {
+		id, seq, err := m.MessageStore.SaveRoom(m.Ctx, msg)
+		if err != nil {
+			return err
+		}
+		msg.ID = id
+		message := *msg
+		message.Seq = seq
+		m.Broadcast <- &message
+		return nil
+	}
+	id, seqs, err := m.MessageStore.SaveDirect(m.Ctx, msg)
+	if err != nil {
+		return err
+	}
+	msg.ID = id
+	m.deliverPersisted(msg, seqs)
+	return nil
+}
+
+// deliverPersisted 把私聊消息以双方各自的序号分别发给其所有连接，包括其他节点上的连接
+func (m *WsManager) deliverPersisted(msg *types.Message, seqs map[string]int64) {
+	for userID, seq := range seqs {
+		delivered := *msg
+		delivered.Seq = seq
+		f := newFrame(&delivered)
+		m.sendToUser(userID, f)
+		if m.Cluster != nil {
+			data, err := f.encode(JSONCodec)
+			if err != nil {
+				m.logx.Errorf("failed to marshal persisted message %d for user %s: %v", msg.ID, userID, err)
+				continue
+			}
+			m.Cluster.forward(&types.Message{To: userID}, data)
+		}
+	}
+}
+
+// replay 补发收件箱中序号大于 lastSeq 的私聊消息，lastSeq 为负数时从用户已确认的位置开始，
+// 之后补发用户所在房间中已确认位置之后的房间消息
+func (m *WsManager) replay(conn *Connection, lastSeq int64) {
+	userId, err := strconv.ParseInt(conn.UserID, 10, 64)
+	if err != nil {
+		return
+	}
+	if lastSeq < 0 {
+		if lastSeq, err = m.MessageStore.LastAck(m.Ctx, userId); err != nil {
+			m.logx.Errorf("failed to load ack seq of user %s: %v", conn.UserID, err)
+			return
+		}
+	}
+	if !m.replayFrom(conn, lastSeq, func(lastSeq int64) ([]*types.Message, error) {
+		return m.MessageStore.Since(m.Ctx, userId, lastSeq, replayBatchSize)
+	}) {
+		return
+	}
+	m.replayRooms(conn, userId)
+}
+
+// replayRooms 补发用户所在房间中已确认位置之后的消息，没有确认记录的房间不补发，可通过历史消息接口查询
+func (m *WsManager) replayRooms(conn *Connection, userId int64) {
+	if m.RoomStore == nil {
+		return
+	}
+	acks, err := m.MessageStore.LastRoomAcks(m.Ctx, userId)
+	if err != nil || len(acks) == 0 {
+		if err != nil {
+			m.logx.Errorf("failed to load room ack seqs of user %s: %v", conn.UserID, err)
+		}
+		return
+	}
+	// 只补发仍是成员的房间，被移出后不再收到房间消息
+	rooms, err := m.RoomStore.Rooms(m.Ctx, userId)
+	if err != nil {
+		m.logx.Errorf("failed to load rooms of user %s: %v", conn.UserID, err)
+		return
+	}
+	for roomId := range rooms {
+		lastSeq, ok := acks[roomId]
+		if !ok {
+			continue
+		}
+		if !m.replayRoom(conn, roomId, lastSeq) {
+			return
+		}
+	}
+}
+
+// replayRoom 补发房间中序号大于 lastSeq 的消息
+func (m *WsManager) replayRoom(conn *Connection, roomId, lastSeq int64) bool {
+	return m.replayFrom(conn, lastSeq, func(lastSeq int64) ([]*types.Message, error) {
+		return m.MessageStore.RoomSince(m.Ctx, roomId, lastSeq, replayBatchSize)
+	})
+}
+
+// replayFrom 分批读取 lastSeq 之后的消息发给连接，全部发完时返回 true
+func (m *WsManager) replayFrom(conn *Connection, lastSeq int64, load func(lastSeq int64) ([]*types.Message, error)) bool {
+	for {
+		messages, err := load(lastSeq)
+		if err != nil {
+			m.logx.Errorf("failed to replay messages to connection %s since %d: %v", conn.ID, lastSeq, err)
+			return false
+		}
+		for _, msg := range messages {
+			data, err := conn.codec.Marshal(msg)
+			if err != nil {
+				m.logx.Errorf("failed to marshal replayed message %d: %v", msg.ID, err)
+				return false
+			}
+			// 发送缓冲区满或连接已断开时停止，客户端可以用 sync 从最后收到的序号继续
+			if !m.sendToConnection(conn, data) {
+				m.logx.Infof("warning: stop replaying messages to connection %s at seq %d", conn.ID, msg.Seq)
+				return false
+			}
+			lastSeq = msg.Seq
+		}
+		if len(messages) < replayBatchSize {
+			return true
+		}
+	}
+}
+
+// sendToConnection 在连接仍注册时把数据放入发送缓冲区，不阻塞
+func (m *WsManager) sendToConnection(conn *Connection, data []byte) bool {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+
+	if current, ok := m.Connections[conn.ID]; !ok || current != conn {
+		return false
+	}
+	return conn.enqueue(data, 0) == nil
+}
+
+// handleAck 客户端确认已收到 seq 及之前的消息，带 room 时确认的是房间序号
+func (c *Connection) handleAck(msg types.Message) {
+	if c.WsManager.MessageStore == nil || msg.Seq <= 0 {
+		return
+	}
+	userId, err := strconv.ParseInt(c.UserID, 10, 64)
+	if err != nil {
+		return
+	}
+	if msg.Room != "" {
+		roomId, err := strconv.ParseInt(msg.Room, 10, 64)
+		if err != nil {
+			return
+		}
+		if _, ok := c.WsManager.roomRole(c, msg.Room); !ok {
+			return
+		}
+		if err := c.WsManager.MessageStore.AckRoom(c.WsManager.Ctx, userId, roomId, msg.Seq); err != nil {
+			c.WsManager.logx.Errorf("failed to ack room %s seq %d for connection %s: %v", msg.Room, msg.Seq, c.ID, err)
+		}
+		return
+	}
+
+	c.mu.Lock()
+	if msg.Seq > c.AckedSeq {
+		c.AckedSeq = msg.Seq
+	}
+	c.mu.Unlock()
+	if err := c.WsManager.MessageStore.Ack(c.WsManager.Ctx, userId, msg.Seq); err != nil {
+		c.WsManager.logx.Errorf("failed to ack seq %d for connection %s: %v", msg.Seq, c.ID, err)
+	}
+}
+
+// handleSync 客户端发现序号不连续时请求补发 seq 之后的消息，seq 为 0 时从当前连接确认的位置开始；
+// 带 room 时补发房间消息，seq 为 0 时从用户在房间中已确认的位置开始
+func (c *Connection) handleSync(msg types.Message) {
+	if c.WsManager.MessageStore == nil {
+		c.sendError(xerr.NewErrMsg("offline messages are not enabled"))
+		return
+	}
+	if msg.Room != "" {
+		c.syncRoom(msg)
+		return
+	}
+	lastSeq := msg.Seq
+	if lastSeq <= 0 {
+		c.mu.RLock()
+		lastSeq = c.AckedSeq
+		c.mu.RUnlock()
+	}
+	go c.WsManager.replay(c, lastSeq)
+}
+
+// syncRoom 补发房间消息，只有已进入房间的连接可以请求
+func (c *Connection) syncRoom(msg types.Message) {
+	roomId, userId, err := c.roomIdentity(msg.Room)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+	if _, ok := c.WsManager.roomRole(c, msg.Room); !ok {
+		c.sendError(xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR))
+		return
+	}
+	go func() {
+		lastSeq := msg.Seq
+		if lastSeq <= 0 {
+			acks, err := c.WsManager.MessageStore.LastRoomAcks(c.WsManager.Ctx, userId)
+			if err != nil {
+				c.WsManager.logx.Errorf("failed to load room ack seqs of user %s: %v", c.UserID, err)
+				return
+			}
+			lastSeq = acks[roomId]
+		}
+		c.WsManager.replayRoom(c, roomId, lastSeq)
+	}()
+}
@@ -0,0 +1,262 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+type fakeMessageStore struct {
+	mu       sync.Mutex
+	nextId   int64
+	seqs     map[int64]int64
+	inbox    map[int64][]types.Message
+	acks     map[int64]int64
+	roomMsgs map[int64][]types.Message
+	roomAcks map[int64]map[int64]int64
+}
+
+func newFakeMessageStore() *fakeMessageStore {
+	return &fakeMessageStore{
+		seqs:     make(map[int64]int64),
+		inbox:    make(map[int64][]types.Message),
+		acks:     make(map[int64]int64),
+		roomMsgs: make(map[int64][]types.Message),
+		roomAcks: make(map[int64]map[int64]int64),
+	}
+}
+
+func (s *fakeMessageStore) SaveRoom(ctx context.Context, msg *types.Message) (int64, int64, error) {
+	roomId, _ := strconv.ParseInt(msg.Room, 10, 64)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextId++
+	stored := *msg
+	stored.ID, stored.Seq = s.nextId, int64(len(s.roomMsgs[roomId])+1)
+	s.roomMsgs[roomId] = append(s.roomMsgs[roomId], stored)
+	return stored.ID, stored.Seq, nil
+}
+
+func (s *fakeMessageStore) SaveDirect(ctx context.Context, msg *types.Message) (int64, map[string]int64, error) {
+	from, _ := strconv.ParseInt(msg.From, 10, 64)
+	to, _ := strconv.ParseInt(msg.To, 10, 64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextId++
+	seqs := make(map[string]int64, 2)
+	for _, userId := range []int64{from, to} {
+		s.seqs[userId]++
+		stored := *msg
+		stored.ID, stored.Seq = s.nextId, s.seqs[userId]
+		s.inbox[userId] = append(s.inbox[userId], stored)
+		seqs[strconv.FormatInt(userId, 10)] = s.seqs[userId]
+	}
+	return s.nextId, seqs, nil
+}
+
+func (s *fakeMessageStore) Since(ctx context.Context, userId, lastSeq, limit int64) ([]*types.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return since(s.inbox[userId], lastSeq, limit), nil
+}
+
+func (s *fakeMessageStore) RoomSince(ctx context.Context, roomId, lastSeq, limit int64) ([]*types.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return since(s.roomMsgs[roomId], lastSeq, limit), nil
+}
+
+func since(stored []types.Message, lastSeq, limit int64) []*types.Message {
+	var messages []*types.Message
+	for i := range stored {
+		if msg := stored[i]; msg.Seq > lastSeq && int64(len(messages)) < limit {
+			messages = append(messages, &msg)
+		}
+	}
+	return messages
+}
+
+func (s *fakeMessageStore) Ack(ctx context.Context, userId, seq int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if seq > s.acks[userId] {
+		s.acks[userId] = seq
+	}
+	return nil
+}
+
+func (s *fakeMessageStore) LastAck(ctx context.Context, userId int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acks[userId], nil
+}
+
+func (s *fakeMessageStore) AckRoom(ctx context.Context, userId, roomId, seq int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.roomAcks[userId] == nil {
+		s.roomAcks[userId] = make(map[int64]int64)
+	}
+	if seq > s.roomAcks[userId][roomId] {
+		s.roomAcks[userId][roomId] = seq
+	}
+	return nil
+}
+
+func (s *fakeMessageStore) LastRoomAcks(ctx context.Context, userId int64) (map[int64]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	acks := make(map[int64]int64, len(s.roomAcks[userId]))
+	for roomId, seq := range s.roomAcks[userId] {
+		acks[roomId] = seq
+	}
+	return acks, nil
+}
+
+// waitSeqs 按顺序收到指定序号的聊天消息，之后没有多余的聊天消息
+func waitSeqs(t *testing.T, conn *Connection, seqs ...int64) {
+	t.Helper()
+	for _, seq := range seqs {
+		if msg := waitMessage(t, conn, "chat"); msg.Seq != seq || msg.ID == 0 {
+			t.Fatalf("connection %s got %+v, want seq %d", conn.ID, msg, seq)
+		}
+	}
+	timeout := time.After(50 * time.Millisecond)
+	for {
+		select {
+		case data := <-conn.Send:
+			var msg types.Message
+			if err := json.Unmarshal(data, &msg); err == nil && msg.Type == "chat" {
+				t.Fatalf("connection %s got unexpected message: %s", conn.ID, data)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// waitRoomSeqs 按顺序收到房间中指定房间序号的聊天消息，之后没有多余的聊天消息
+func waitRoomSeqs(t *testing.T, conn *Connection, room string, seqs ...int64) {
+	t.Helper()
+	for _, seq := range seqs {
+		if msg := waitMessage(t, conn, "chat"); msg.Room != room || msg.Seq != seq || msg.ID == 0 {
+			t.Fatalf("connection %s got %+v, want room %s seq %d", conn.ID, msg, room, seq)
+		}
+	}
+	waitSeqs(t, conn)
+}
+
+func TestOfflineReplay(t *testing.T) {
+	rooms := &fakeRoomStore{rooms: map[int64]map[int64]string{
+		7: {1: model.RoomRoleOwner, 2: model.RoomRoleMember},
+	}}
+	store := newFakeMessageStore()
+	m := newTestManager(rooms)
+	m.MessageStore = store
+	defer m.Cancel()
+
+	c1 := newTestConnection(t, m, "c1", "1")
+	waitUntil(t, func() bool { return len(m.RoomOnlineUsers("7")) == 1 })
+
+	// 用户 2 离线时的私聊消息会保存，发送者收到带序号的回执
+	c1.handleChat(types.Message{Type: "chat", From: "1", To: "2", Data: map[string]interface{}{"text": "direct"}})
+	c1.handleChat(types.Message{Type: "chat", From: "1", To: "2", Data: map[string]interface{}{"text": "direct again"}})
+	waitSeqs(t, c1, 1, 2)
+
+	// 首次连接没有确认记录，补发全部消息
+	c2 := newTestConnection(t, m, "c2", "2")
+	waitSeqs(t, c2, 1, 2)
+	c2.handleAck(types.Message{Type: MessageTypeAck, Seq: 2})
+	if ack, _ := store.LastAck(context.Background(), 2); ack != 2 {
+		t.Fatalf("ack = %d, want 2", ack)
+	}
+	m.Unregister <- c2
+	waitUntil(t, func() bool { return !m.OnlineUsers(context.Background(), []string{"2"})["2"] })
+
+	c1.handleChat(types.Message{Type: "chat", From: "1", To: "2", Data: map[string]interface{}{"text": "again"}})
+	waitSeqs(t, c1, 3)
+
+	// 没有带 lastSeq 时从已确认的位置继续
+	c3 := newTestConnection(t, m, "c3", "2")
+	waitSeqs(t, c3, 3)
+
+	// 带 lastSeq 时从客户端指定的位置继续
+	c4 := newResumeConnection(t, m, "c4", "2", 1)
+	waitSeqs(t, c4, 2, 3)
+
+	// 在线时直接收到新消息，序号连续
+	c1.handleChat(types.Message{Type: "chat", From: "1", To: "2", Data: map[string]interface{}{"text": "live"}})
+	waitSeqs(t, c3, 4)
+	waitSeqs(t, c4, 4)
+
+	// 客户端发现缺失时用 sync 请求补发
+	c4.handleSync(types.Message{Type: MessageTypeSync, Seq: 2})
+	waitSeqs(t, c4, 3, 4)
+}
+
+func TestRoomSeqReplay(t *testing.T) {
+	rooms := &fakeRoomStore{rooms: map[int64]map[int64]string{
+		7: {1: model.RoomRoleOwner, 2: model.RoomRoleMember, 3: model.RoomRoleMember},
+	}}
+	store := newFakeMessageStore()
+	m := newTestManager(rooms)
+	m.MessageStore = store
+	defer m.Cancel()
+
+	c1 := newTestConnection(t, m, "c1", "1")
+	c2 := newTestConnection(t, m, "c2", "2")
+	waitUntil(t, func() bool { return len(m.RoomOnlineUsers("7")) == 2 })
+
+	// 房间消息只保存一次，所有成员收到同一个房间序号，不占用私聊收件箱的序号
+	c1.handleChat(types.Message{Type: "chat", From: "1", Room: "7", Data: map[string]interface{}{"text": "one"}})
+	c1.handleChat(types.Message{Type: "chat", From: "1", Room: "7", Data: map[string]interface{}{"text": "two"}})
+	waitRoomSeqs(t, c1, "7", 1, 2)
+	waitRoomSeqs(t, c2, "7", 1, 2)
+	c1.handleChat(types.Message{Type: "chat", From: "1", To: "2", Data: map[string]interface{}{"text": "direct"}})
+	waitSeqs(t, c1, 1)
+	waitSeqs(t, c2, 1)
+
+	c2.handleAck(types.Message{Type: MessageTypeAck, Room: "7", Seq: 1})
+	if acks, _ := store.LastRoomAcks(context.Background(), 2); acks[7] != 1 {
+		t.Fatalf("room acks = %v, want room 7 at 1", acks)
+	}
+	c2.handleAck(types.Message{Type: MessageTypeAck, Seq: 1})
+	m.Unregister <- c2
+	waitUntil(t, func() bool { return !m.OnlineUsers(context.Background(), []string{"2"})["2"] })
+
+	c1.handleChat(types.Message{Type: "chat", From: "1", Room: "7", Data: map[string]interface{}{"text": "three"}})
+	waitRoomSeqs(t, c1, "7", 3)
+
+	// 重连后从房间确认的位置补发
+	c3 := newTestConnection(t, m, "c3", "2")
+	waitRoomSeqs(t, c3, "7", 2, 3)
+
+	// 没有确认记录的成员不补发房间消息
+	c4 := newTestConnection(t, m, "c4", "3")
+	waitSeqs(t, c4)
+
+	// sync 带 room 时补发房间消息，seq 为 0 时从已确认的位置开始
+	waitUntil(t, func() bool { _, ok := m.roomRole(c3, "7"); return ok })
+	c3.handleSync(types.Message{Type: MessageTypeSync, Room: "7", Seq: 2})
+	waitRoomSeqs(t, c3, "7", 3)
+	c3.handleSync(types.Message{Type: MessageTypeSync, Room: "7"})
+	waitRoomSeqs(t, c3, "7", 2, 3)
+
+	// 被移出房间后不再补发该房间的消息
+	m.Unregister <- c3
+	rooms.Leave(context.Background(), 7, 2)
+	c5 := newTestConnection(t, m, "c5", "2")
+	waitSeqs(t, c5)
+	c5.handleSync(types.Message{Type: MessageTypeSync, Room: "7", Seq: 1})
+	if msg := waitMessage(t, c5, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_MEMBER_NOT_FOUND_ERROR) {
+		t.Fatalf("sync after removal: unexpected message %+v", msg)
+	}
+}
@@ -0,0 +1,295 @@
+package websocket
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+// 用户的在线状态，由其所有连接聚合：任一连接在线即在线，所有连接都离开为离开，没有连接为离线
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceOffline = "offline"
+)
+
+// maxPresenceSubscriptions 单个连接最多订阅的用户数
+const maxPresenceSubscriptions = 1000
+
+// UserPresenceEvent 用户在线状态变化，推送给订阅了该用户的连接
+type UserPresenceEvent struct {
+	UserID string `json:"userId"`
+	Status string `json:"status"`
+}
+
+// aggregatePresence 合并多个连接或节点上的状态
+func aggregatePresence(statuses ...string) string {
+	result := PresenceOffline
+	for _, status := range statuses {
+		switch status {
+		case PresenceOnline:
+			return PresenceOnline
+		case PresenceAway:
+			result = PresenceAway
+		}
+	}
+	return result
+}
+
+// localPresenceLocked 用户在本节点上的聚合状态，调用方需持有 Mu
+func (m *WsManager) localPresenceLocked(userID string) string {
+	var statuses []string
+	for connID := range m.UserConnections[userID] {
+		if conn, ok := m.Connections[connID]; ok {
+			if conn.Status == PresenceAway {
+				statuses = append(statuses, PresenceAway)
+			} else {
+				statuses = append(statuses, PresenceOnline)
+			}
+		}
+	}
+	return aggregatePresence(statuses...)
+}
+
+// refreshPresenceLocked 用户的连接或连接状态变化后重新聚合，状态变化时通知订阅者，调用方需持有 Mu
+func (m *WsManager) refreshPresenceLocked(userID string) {
+	status := m.localPresenceLocked(userID)
+	prev, ok := m.presence[userID]
+	if !ok {
+		prev = PresenceOffline
+	}
+	if status == prev {
+		return
+	}
+	if status == PresenceOffline {
+		delete(m.presence, userID)
+	} else {
+		m.presence[userID] = status
+	}
+
+	// 在线与离开之间切换时通知所在房间，上下线由房间的订阅变化通知
+	if prev != PresenceOffline && status != PresenceOffline {
+		rooms := make(map[string]bool)
+		for connID := range m.UserConnections[userID] {
+			if conn, ok := m.Connections[connID]; ok {
+				for room := range conn.Rooms {
+					rooms[room] = true
+				}
+			}
+		}
+		for room := range rooms {
+			m.emitRoomPresence(room, userID, status)
+		}
+	}
+
+	// 集群模式下由 Cluster 合并各节点的状态后通知
+	if m.Cluster != nil {
+		m.Cluster.updatePresence(userID, status)
+		return
+	}
+	m.notifyPresenceLocked(userID, status)
+}
+
+// Presence 查询用户的在线状态，集群模式下包括其他节点上的连接
+func (m *WsManager) Presence(ctx context.Context, userIDs []string) map[string]string {
+	result := make(map[string]string, len(userIDs))
+	m.Mu.RLock()
+	for _, userID := range userIDs {
+		result[userID] = m.localPresenceLocked(userID)
+	}
+	m.Mu.RUnlock()
+
+	if m.Cluster != nil && len(userIDs) > 0 {
+		others, err := m.Cluster.presence(ctx, userIDs)
+		if err != nil {
+			m.logx.Errorf("failed to query cluster presence: %v", err)
+		}
+		for userID, status := range others {
+			result[userID] = aggregatePresence(result[userID], status)
+		}
+	}
+	return result
+}
+
+// notifyPresence 把用户的状态推送给本节点上订阅了该用户的连接
+func (m *WsManager) notifyPresence(userID, status string) {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+	m.notifyPresenceLocked(userID, status)
+}
+
+func (m *WsManager) notifyPresenceLocked(userID, status string) {
+	subscribers := m.PresenceSubscribers[userID]
+	if len(subscribers) == 0 {
+		return
+	}
+	f := newFrame(&types.Message{
+		Type:      MessageTypeUserPresence,
+		Data:      UserPresenceEvent{UserID: userID, Status: status},
+		Timestamp: time.Now().Unix(),
+	})
+	for connID := range subscribers {
+		if conn, ok := m.Connections[connID]; ok && conn.IsAlive {
+			m.trySend(conn, f, func() {
+				m.logx.Infof("warning: connection %s send buffer is full, dropping presence", conn.ID)
+			})
+		}
+	}
+}
+
+// unsubscribePresenceLocked 连接断开时取消其所有订阅，调用方需持有 Mu
+func (m *WsManager) unsubscribePresenceLocked(conn *Connection) {
+	for userID := range conn.presenceSubs {
+		if subscribers, ok := m.PresenceSubscribers[userID]; ok {
+			delete(subscribers, conn.ID)
+			if len(subscribers) == 0 {
+				delete(m.PresenceSubscribers, userID)
+			}
+		}
+	}
+	conn.presenceSubs = nil
+}
+
+// handleStatus 客户端上报连接状态，data.status 为 online 或 away，其他字段保存在连接的 Metadata 中
+func (c *Connection) handleStatus(msg types.Message) {
+	if statusData, ok := msg.Data.(map[string]interface{}); ok {
+		if status, ok := statusData["status"]; ok {
+			if status != PresenceOnline && status != PresenceAway {
+				c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+				return
+			}
+			c.WsManager.setConnectionStatus(c, status.(string))
+		}
+
+		c.mu.Lock()
+		for key, value := range statusData {
+			c.Metadata[key] = value
+		}
+		c.mu.Unlock()
+	}
+
+	// 发送状态确认
+	response := types.Message{
+		Type:      MessageTypeStatusUpdated,
+		Timestamp: time.Now().Unix(),
+	}
+
+	data, _ := c.codec.Marshal(&response)
+	if c.enqueue(data, 0) == errSendBufferFull {
+		c.WsManager.logx.Infof("warn: Connection %s send buffer is full", c.ID)
+	}
+}
+
+func (m *WsManager) setConnectionStatus(conn *Connection, status string) {
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+	conn.Status = status
+	if _, ok := m.Connections[conn.ID]; ok {
+		m.refreshPresenceLocked(conn.UserID)
+	}
+}
+
+// handlePresenceSubscribe 订阅 data.users 中用户的在线状态，订阅后立即收到这些用户的当前状态
+func (c *Connection) handlePresenceSubscribe(msg types.Message) {
+	userIDs, ok := presenceUsers(msg.Data)
+	if !ok {
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+
+	m := c.WsManager
+	m.Mu.Lock()
+	if _, registered := m.Connections[c.ID]; !registered {
+		m.Mu.Unlock()
+		return
+	}
+	if c.presenceSubs == nil {
+		c.presenceSubs = make(map[string]bool)
+	}
+	added := 0
+	for _, userID := range userIDs {
+		if !c.presenceSubs[userID] {
+			added++
+		}
+	}
+	if len(c.presenceSubs)+added > maxPresenceSubscriptions {
+		m.Mu.Unlock()
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+	for _, userID := range userIDs {
+		c.presenceSubs[userID] = true
+		if m.PresenceSubscribers[userID] == nil {
+			m.PresenceSubscribers[userID] = make(map[string]bool)
+		}
+		m.PresenceSubscribers[userID][c.ID] = true
+	}
+	m.Mu.Unlock()
+
+	statuses := m.Presence(m.Ctx, userIDs)
+	for _, userID := range userIDs {
+		_ = c.SendMessage(&types.Message{
+			Type:      MessageTypeUserPresence,
+			Data:      UserPresenceEvent{UserID: userID, Status: statuses[userID]},
+			Timestamp: time.Now().Unix(),
+		})
+	}
+}
+
+// handlePresenceUnsubscribe 取消订阅 data.users 中用户的在线状态
+func (c *Connection) handlePresenceUnsubscribe(msg types.Message) {
+	userIDs, ok := presenceUsers(msg.Data)
+	if !ok {
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+
+	m := c.WsManager
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+	for _, userID := range userIDs {
+		delete(c.presenceSubs, userID)
+		if subscribers, ok := m.PresenceSubscribers[userID]; ok {
+			delete(subscribers, c.ID)
+			if len(subscribers) == 0 {
+				delete(m.PresenceSubscribers, userID)
+			}
+		}
+	}
+}
+
+// presenceUsers 解析 data.users，用户 id 可以是字符串或数字
+func presenceUsers(data interface{}) ([]string, bool) {
+	fields, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	items, ok := fields["users"].([]interface{})
+	if !ok || len(items) == 0 || len(items) > maxPresenceSubscriptions {
+		return nil, false
+	}
+	userIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		var userId int64
+		switch v := item.(type) {
+		case string:
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			userId = id
+		case float64:
+			userId = int64(v)
+		default:
+			return nil, false
+		}
+		if userId <= 0 {
+			return nil, false
+		}
+		userIDs = append(userIDs, strconv.FormatInt(userId, 10))
+	}
+	return userIDs, true
+}
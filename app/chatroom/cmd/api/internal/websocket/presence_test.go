@@ -0,0 +1,118 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+type fakeReadStore struct {
+	cursors map[[3]int64]int64
+}
+
+func (s *fakeReadStore) MarkRead(ctx context.Context, userId, roomId, peerId, messageId int64) (bool, error) {
+	key := [3]int64{userId, roomId, peerId}
+	if messageId <= s.cursors[key] {
+		return false, nil
+	}
+	s.cursors[key] = messageId
+	return true, nil
+}
+
+// waitPresence 等待订阅的用户状态推送
+func waitPresence(t *testing.T, conn *Connection, userID, status string) {
+	t.Helper()
+	msg := waitMessage(t, conn, MessageTypeUserPresence)
+	data, _ := json.Marshal(msg.Data)
+	var event UserPresenceEvent
+	_ = json.Unmarshal(data, &event)
+	if event.UserID != userID || event.Status != status {
+		t.Fatalf("expected %s %s, got %+v", userID, status, event)
+	}
+}
+
+func TestPresenceAggregation(t *testing.T) {
+	m := newTestManager(nil)
+	defer m.Cancel()
+
+	watcher := newTestConnection(t, m, "w", "1")
+	watcher.handlePresenceSubscribe(types.Message{Data: map[string]interface{}{"users": []interface{}{"2", float64(3)}}})
+	waitPresence(t, watcher, "2", PresenceOffline)
+	waitPresence(t, watcher, "3", PresenceOffline)
+
+	phone := newTestConnection(t, m, "p", "2")
+	waitPresence(t, watcher, "2", PresenceOnline)
+
+	// 唯一的连接离开后状态为离开，另一个连接上线后恢复在线
+	phone.handleStatus(types.Message{Data: map[string]interface{}{"status": PresenceAway}})
+	waitPresence(t, watcher, "2", PresenceAway)
+	desktop := newTestConnection(t, m, "d", "2")
+	waitPresence(t, watcher, "2", PresenceOnline)
+
+	m.Unregister <- desktop
+	waitPresence(t, watcher, "2", PresenceAway)
+	m.Unregister <- phone
+	waitPresence(t, watcher, "2", PresenceOffline)
+
+	if statuses := m.Presence(context.Background(), []string{"1", "2"}); statuses["1"] != PresenceOnline || statuses["2"] != PresenceOffline {
+		t.Fatalf("unexpected presence: %v", statuses)
+	}
+
+	watcher.handleStatus(types.Message{Data: map[string]interface{}{"status": "busy"}})
+	if msg := waitMessage(t, watcher, MessageTypeError); !hasErrorCode(msg, xerr.REQUEST_PARAM_ERROR) {
+		t.Fatalf("unexpected error message: %+v", msg)
+	}
+
+	// 取消订阅后不再推送
+	watcher.handlePresenceUnsubscribe(types.Message{Data: map[string]interface{}{"users": []interface{}{"2"}}})
+	m.Mu.RLock()
+	_, subscribed := m.PresenceSubscribers["2"]
+	m.Mu.RUnlock()
+	if subscribed {
+		t.Fatal("expected subscription to be removed")
+	}
+}
+
+func TestTypingAndReadReceipts(t *testing.T) {
+	store := &fakeRoomStore{rooms: map[int64]map[int64]string{
+		7: {1: model.RoomRoleOwner, 2: model.RoomRoleMember},
+	}}
+	m := newTestManager(store)
+	m.ReadStore = &fakeReadStore{cursors: make(map[[3]int64]int64)}
+	defer m.Cancel()
+
+	c1 := newTestConnection(t, m, "c1", "1")
+	c2 := newTestConnection(t, m, "c2", "2")
+	waitUntil(t, func() bool { return len(m.RoomOnlineUsers("7")) == 2 })
+
+	c1.handleTyping(types.Message{To: "2"})
+	if msg := waitMessage(t, c2, MessageTypeTyping); msg.From != "1" || msg.Room != "" {
+		t.Fatalf("unexpected typing: %+v", msg)
+	}
+	c1.handleTyping(types.Message{Room: "7", Data: map[string]interface{}{"typing": false}})
+	msg := waitMessage(t, c2, MessageTypeTyping)
+	if event := msg.Data.(map[string]interface{}); msg.Room != "7" || event["typing"] != false {
+		t.Fatalf("unexpected room typing: %+v", msg)
+	}
+
+	c2.handleRead(types.Message{Room: "7", ID: 42})
+	if msg := waitMessage(t, c1, MessageTypeRead); msg.Data.(map[string]interface{})["messageId"] != float64(42) {
+		t.Fatalf("unexpected read receipt: %+v", msg)
+	}
+	// 已读位置没有前进时不通知
+	c2.handleRead(types.Message{Room: "7", ID: 40})
+	c2.handleRead(types.Message{To: "1", ID: 50})
+	if msg := waitMessage(t, c1, MessageTypeRead); msg.Room != "" || msg.Data.(map[string]interface{})["messageId"] != float64(50) {
+		t.Fatalf("unexpected direct read receipt: %+v", msg)
+	}
+	waitMessage(t, c2, MessageTypeRead)
+
+	c2.handleRead(types.Message{Room: "8", ID: 1})
+	if msg := waitMessage(t, c2, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_MEMBER_NOT_FOUND_ERROR) {
+		t.Fatalf("unexpected error message: %+v", msg)
+	}
+}
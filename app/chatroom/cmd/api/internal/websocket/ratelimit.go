@@ -0,0 +1,55 @@
+package websocket
+
+import (
+	"sync"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitDefaultKey 未单独配置的消息类型共用的限流配置
+const rateLimitDefaultKey = "default"
+
+// messageLimiter 单个连接按消息类型的令牌桶，首次收到某类型消息时创建
+type messageLimiter struct {
+	mu       sync.Mutex
+	conf     map[string]config.RateLimitConf
+	limiters map[string]*rate.Limiter
+}
+
+func newMessageLimiter(conf map[string]config.RateLimitConf) *messageLimiter {
+	return &messageLimiter{
+		conf:     conf,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Allow 是否允许处理该类型的消息，未配置或 Rate 为 0 时不限流
+func (l *messageLimiter) Allow(msgType string) bool {
+	if l == nil || len(l.conf) == 0 {
+		return true
+	}
+	key := msgType
+	conf, ok := l.conf[key]
+	if !ok {
+		key = rateLimitDefaultKey
+		conf = l.conf[key]
+	}
+	if conf.Rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.limiters[key]
+	if !ok {
+		burst := conf.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(conf.Rate), burst)
+		l.limiters[key] = limiter
+	}
+	return limiter.Allow()
+}
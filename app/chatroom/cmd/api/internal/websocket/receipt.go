@@ -0,0 +1,149 @@
+package websocket
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+)
+
+// ReadEvent 用户在房间或私聊中的已读位置前进
+type ReadEvent struct {
+	Room      string `json:"room,omitempty"`
+	UserID    string `json:"userId"`
+	MessageID int64  `json:"messageId"`
+}
+
+// TypingEvent 用户在房间或私聊中正在输入，只转发不保存
+type TypingEvent struct {
+	Room   string `json:"room,omitempty"`
+	UserID string `json:"userId"`
+	Typing bool   `json:"typing"`
+}
+
+// ReadStore 已读位置的持久化存储
+type ReadStore interface {
+	// MarkRead 推进用户在房间（peerId 为 0）或私聊（roomId 为 0）中的已读位置，返回位置是否前进
+	MarkRead(ctx context.Context, userId, roomId, peerId, messageId int64) (bool, error)
+}
+
+type modelReadStore struct {
+	readCursorModel  model.ReadCursorModel
+	chatMessageModel model.ChatMessageModel
+}
+
+// NewModelReadStore 基于 MySQL 的已读位置存储，只接受属于该会话的消息
+func NewModelReadStore(readCursorModel model.ReadCursorModel, chatMessageModel model.ChatMessageModel) ReadStore {
+	return &modelReadStore{
+		readCursorModel:  readCursorModel,
+		chatMessageModel: chatMessageModel,
+	}
+}
+
+func (s *modelReadStore) MarkRead(ctx context.Context, userId, roomId, peerId, messageId int64) (bool, error) {
+	msg, err := s.chatMessageModel.FindOne(ctx, messageId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return false, xerr.NewErrCode(xerr.CHAT_MESSAGE_NOT_FOUND_ERROR)
+		}
+		return false, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find message %d failed: %v", messageId, err)
+	}
+	if roomId > 0 {
+		if msg.RoomId != roomId {
+			return false, xerr.NewErrCode(xerr.CHAT_MESSAGE_NOT_FOUND_ERROR)
+		}
+	} else if msg.RoomId != 0 || !((msg.FromUserId == userId && msg.ToUserId == peerId) || (msg.FromUserId == peerId && msg.ToUserId == userId)) {
+		return false, xerr.NewErrCode(xerr.CHAT_MESSAGE_NOT_FOUND_ERROR)
+	}
+
+	advanced, err := s.readCursorModel.Advance(ctx, userId, roomId, peerId, messageId)
+	if err != nil {
+		return false, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "advance read cursor of user %d failed: %v", userId, err)
+	}
+	return advanced, nil
+}
+
+// handleRead 客户端上报房间或私聊中已读到的消息 id，位置前进时通知房间成员或私聊双方
+func (c *Connection) handleRead(msg types.Message) {
+	if c.WsManager.ReadStore == nil {
+		c.sendError(xerr.NewErrMsg("read receipts are not enabled"))
+		return
+	}
+	userId, err := strconv.ParseInt(c.UserID, 10, 64)
+	if err != nil || msg.ID <= 0 || (msg.Room == "") == (msg.To == "") {
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+
+	var roomId, peerId int64
+	if msg.Room != "" {
+		if _, ok := c.WsManager.roomRole(c, msg.Room); !ok {
+			c.sendError(xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR))
+			return
+		}
+		roomId, err = strconv.ParseInt(msg.Room, 10, 64)
+	} else {
+		peerId, err = strconv.ParseInt(msg.To, 10, 64)
+	}
+	if err != nil {
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+
+	advanced, err := c.WsManager.ReadStore.MarkRead(c.WsManager.Ctx, userId, roomId, peerId, msg.ID)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+	if !advanced {
+		return
+	}
+
+	event := ReadEvent{Room: msg.Room, UserID: c.UserID, MessageID: msg.ID}
+	now := time.Now().Unix()
+	if msg.Room != "" {
+		c.WsManager.emit(&types.Message{Type: MessageTypeRead, From: c.UserID, Room: msg.Room, Data: event, Timestamp: now})
+		return
+	}
+	// 私聊同时通知自己的其他连接，多端同步未读数
+	c.WsManager.emit(&types.Message{Type: MessageTypeRead, From: c.UserID, To: msg.To, Data: event, Timestamp: now})
+	c.WsManager.emit(&types.Message{Type: MessageTypeRead, From: c.UserID, To: c.UserID, Data: event, Timestamp: now})
+}
+
+// handleTyping 转发正在输入状态，data.typing 为 false 表示停止输入
+func (c *Connection) handleTyping(msg types.Message) {
+	if (msg.Room == "") == (msg.To == "") {
+		c.sendError(xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR))
+		return
+	}
+	if err := checkTarget(msg.To); err != nil {
+		c.sendError(err)
+		return
+	}
+	if msg.Room != "" {
+		if err := c.checkRoomSend(msg.Room); err != nil {
+			c.sendError(err)
+			return
+		}
+	}
+
+	typing := true
+	if data, ok := msg.Data.(map[string]interface{}); ok {
+		if value, ok := data["typing"].(bool); ok {
+			typing = value
+		}
+	}
+	c.WsManager.emit(&types.Message{
+		Type:      MessageTypeTyping,
+		From:      c.UserID,
+		To:        msg.To,
+		Room:      msg.Room,
+		Data:      TypingEvent{Room: msg.Room, UserID: c.UserID, Typing: typing},
+		Timestamp: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,341 @@
+package websocket
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+)
+
+// RoomMemberEvent 成员加入、退出或角色变化，发给房间内所有成员
+type RoomMemberEvent struct {
+	Room   string `json:"room"`
+	UserID string `json:"userId"`
+	Role   string `json:"role,omitempty"`
+}
+
+// PresenceEvent 成员上线（第一个连接订阅房间）或下线（最后一个连接断开）
+type PresenceEvent struct {
+	Room   string `json:"room"`
+	UserID string `json:"userId"`
+	Online bool   `json:"online"`
+	Status string `json:"status"` // online/away/offline
+}
+
+// ErrorEvent 请求处理失败时发给当前连接
+type ErrorEvent struct {
+	Code    uint32 `json:"code"`
+	Message string `json:"message"`
+}
+
+// restoreRooms 连接注册后订阅用户已加入的房间
+func (m *WsManager) restoreRooms(conn *Connection) {
+	userId, err := strconv.ParseInt(conn.UserID, 10, 64)
+	if err != nil {
+		return
+	}
+	rooms, err := m.RoomStore.Rooms(m.Ctx, userId)
+	if err != nil {
+		m.logx.Errorf("failed to restore rooms for user %s: %v", conn.UserID, err)
+		return
+	}
+	for roomId, role := range rooms {
+		room := strconv.FormatInt(roomId, 10)
+		if m.subscribeRoom(conn, room, role) {
+			m.emitPresence(room, conn.UserID, true)
+		}
+	}
+}
+
+// subscribeRoom 连接订阅房间消息，返回该用户是否因此上线（此前没有连接在房间中）
+func (m *WsManager) subscribeRoom(conn *Connection, room, role string) bool {
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+
+	// 连接已注销（或尚未注册）时不订阅，注册后会从存储恢复
+	if _, ok := m.Connections[conn.ID]; !ok {
+		return false
+	}
+	if _, ok := conn.Rooms[room]; ok {
+		conn.Rooms[room] = role
+		return false
+	}
+
+	online := m.userInRoomLocked(room, conn.UserID)
+	if m.GroupConnections[room] == nil {
+		m.GroupConnections[room] = make(map[string]bool)
+		if m.Cluster != nil {
+			m.Cluster.roomActive(room)
+		}
+	}
+	m.GroupConnections[room][conn.ID] = true
+	conn.Rooms[room] = role
+	return !online
+}
+
+// unsubscribeAllLocked 连接断开时退订所有房间，用户在房间中没有其他连接时通知下线，调用方需持有 Mu
+func (m *WsManager) unsubscribeAllLocked(conn *Connection) {
+	for room := range conn.Rooms {
+		m.removeFromRoomLocked(conn, room)
+		if !m.userInRoomLocked(room, conn.UserID) {
+			m.emitPresence(room, conn.UserID, false)
+		}
+	}
+}
+
+func (m *WsManager) removeFromRoomLocked(conn *Connection, room string) {
+	delete(conn.Rooms, room)
+	if conns, ok := m.GroupConnections[room]; ok {
+		delete(conns, conn.ID)
+		if len(conns) == 0 {
+			delete(m.GroupConnections, room)
+			if m.Cluster != nil {
+				m.Cluster.roomInactive(room)
+			}
+		}
+	}
+}
+
+// userInRoomLocked 用户是否有连接订阅了房间，调用方需持有 Mu
+func (m *WsManager) userInRoomLocked(room, userID string) bool {
+	for connID := range m.UserConnections[userID] {
+		if conn, ok := m.Connections[connID]; ok {
+			if _, in := conn.Rooms[room]; in {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// roomRole 连接在房间中的角色，未订阅时返回 false
+func (m *WsManager) roomRole(conn *Connection, room string) (string, bool) {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+	role, ok := conn.Rooms[room]
+	return role, ok
+}
+
+// sendToRoom 按分片把房间消息交给广播 worker，每个分片只投递给其中订阅了房间的连接
+func (m *WsManager) sendToRoom(room string, f *frame) {
+	m.Mu.RLock()
+	buckets := make(map[int][]string)
+	for connID := range m.GroupConnections[room] {
+		sh := m.shardIndex(connID)
+		buckets[sh] = append(buckets[sh], connID)
+	}
+	m.Mu.RUnlock()
+
+	for sh, conns := range buckets {
+		if !m.enqueueJob(broadcastJob{kind: broadcastJobRoom, shard: sh, frame: f, conns: conns}) {
+			m.logx.Infof("warning: broadcast job queue is full, dropping message for room %s", room)
+		}
+	}
+}
+
+// RoomOnlineUsers 房间内在线（有连接订阅）的用户
+func (m *WsManager) RoomOnlineUsers(room string) map[string]bool {
+	m.Mu.RLock()
+	defer m.Mu.RUnlock()
+
+	users := make(map[string]bool)
+	for connID := range m.GroupConnections[room] {
+		if conn, ok := m.Connections[connID]; ok {
+			users[conn.UserID] = true
+		}
+	}
+	return users
+}
+
+// OnlineUsers 用户是否在线，集群模式下包括其他节点上的连接
+func (m *WsManager) OnlineUsers(ctx context.Context, userIDs []string) map[string]bool {
+	online := make(map[string]bool, len(userIDs))
+	var remote []string
+	m.Mu.RLock()
+	for _, userID := range userIDs {
+		if len(m.UserConnections[userID]) > 0 {
+			online[userID] = true
+		} else {
+			remote = append(remote, userID)
+		}
+	}
+	m.Mu.RUnlock()
+
+	if m.Cluster != nil && len(remote) > 0 {
+		others, err := m.Cluster.onlineUsers(ctx, remote)
+		if err != nil {
+			m.logx.Errorf("failed to query cluster presence: %v", err)
+		}
+		for userID, ok := range others {
+			online[userID] = ok
+		}
+	}
+	return online
+}
+
+// SetRoomMemberRole 更新用户所有连接（包括其他节点上的）在房间中的角色并通知房间成员
+func (m *WsManager) SetRoomMemberRole(room, userID, role string) {
+	m.setLocalRoomRole(room, userID, role)
+	if m.Cluster != nil {
+		m.Cluster.forwardRoomRole(room, userID, role)
+	}
+
+	m.emit(&types.Message{
+		Type: MessageTypeMemberRole,
+		Room: room,
+		Data: RoomMemberEvent{Room: room, UserID: userID, Role: role},
+	})
+}
+
+// RemoveRoomMember 用户退出或被移出房间：通知房间成员和该用户，并退订该用户的所有连接
+func (m *WsManager) RemoveRoomMember(room, userID string) {
+	event := RoomMemberEvent{Room: room, UserID: userID}
+	m.emit(&types.Message{Type: MessageTypeMemberLeave, Room: room, Data: event})
+	m.emit(&types.Message{Type: MessageTypeMemberLeave, To: userID, Data: event})
+
+	m.removeLocalRoomMember(room, userID)
+	if m.Cluster != nil {
+		m.Cluster.forwardRoomRemove(room, userID)
+	}
+}
+
+func (m *WsManager) setLocalRoomRole(room, userID, role string) {
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+	for connID := range m.UserConnections[userID] {
+		if conn, ok := m.Connections[connID]; ok {
+			if _, in := conn.Rooms[room]; in {
+				conn.Rooms[room] = role
+			}
+		}
+	}
+}
+
+func (m *WsManager) removeLocalRoomMember(room, userID string) {
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+	for connID := range m.UserConnections[userID] {
+		if conn, ok := m.Connections[connID]; ok {
+			m.removeFromRoomLocked(conn, room)
+		}
+	}
+}
+
+// emitPresence 通知房间成员上下线
+func (m *WsManager) emitPresence(room, userID string, online bool) {
+	status := PresenceOffline
+	if online {
+		status = PresenceOnline
+	}
+	m.emitRoomPresence(room, userID, status)
+}
+
+// emitRoomPresence 通知房间成员用户的状态，包括在线与离开之间的切换
+func (m *WsManager) emitRoomPresence(room, userID, status string) {
+	m.emit(&types.Message{
+		Type: MessageTypePresence,
+		Room: room,
+		Data: PresenceEvent{Room: room, UserID: userID, Online: status != PresenceOffline, Status: status},
+	})
+}
+
+// emit 投递系统消息，运行循环内也会调用，队列满时丢弃而不是阻塞
+func (m *WsManager) emit(msg *types.Message) {
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().Unix()
+	}
+	select {
+	case m.Broadcast <- msg:
+	default:
+		m.logx.Infof("warning: broadcast queue is full, dropping %s message", msg.Type)
+	}
+}
+
+// handleJoin 进入房间：校验成员关系后订阅，成员加入的通知在邀请时已发出，这里只回复当前连接
+func (c *Connection) handleJoin(msg types.Message) {
+	roomId, userId, err := c.roomIdentity(msg.Room)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+	room := strconv.FormatInt(roomId, 10)
+	role, err := c.WsManager.RoomStore.Join(c.WsManager.Ctx, roomId, userId)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+
+	online := c.WsManager.subscribeRoom(c, room, role)
+	event := RoomMemberEvent{Room: room, UserID: c.UserID, Role: role}
+	_ = c.SendMessage(&types.Message{Type: MessageTypeMemberJoin, Room: room, Data: event, Timestamp: time.Now().Unix()})
+	if online {
+		c.WsManager.emitPresence(room, c.UserID, true)
+	}
+}
+
+// handleLeave 退出房间并删除成员关系
+func (c *Connection) handleLeave(msg types.Message) {
+	roomId, userId, err := c.roomIdentity(msg.Room)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+	room := strconv.FormatInt(roomId, 10)
+	if err := c.WsManager.RoomStore.Leave(c.WsManager.Ctx, roomId, userId); err != nil {
+		c.sendError(err)
+		return
+	}
+	c.WsManager.RemoveRoomMember(room, c.UserID)
+}
+
+// checkRoomSend 房间消息只能由房间内未被禁言的成员发送
+func (c *Connection) checkRoomSend(room string) error {
+	role, ok := c.WsManager.roomRole(c, room)
+	if !ok {
+		return xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+	}
+	if role == model.RoomRoleMuted {
+		return xerr.NewErrCode(xerr.ROOM_MEMBER_MUTED_ERROR)
+	}
+	return nil
+}
+
+func (c *Connection) roomIdentity(room string) (int64, int64, error) {
+	if c.WsManager.RoomStore == nil {
+		return 0, 0, xerr.NewErrMsg("rooms are not enabled")
+	}
+	roomId, err := strconv.ParseInt(room, 10, 64)
+	if err != nil {
+		return 0, 0, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	userId, err := strconv.ParseInt(c.UserID, 10, 64)
+	if err != nil {
+		return 0, 0, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	return roomId, userId, nil
+}
+
+// errorEvent 业务错误返回错误码和提示，其他错误统一为服务器通用错误
+func errorEvent(err error) ErrorEvent {
+	if e, ok := errors.Cause(err).(*xerr.CodeError); ok {
+		return ErrorEvent{Code: e.GetErrCode(), Message: e.GetErrMsg()}
+	}
+	return ErrorEvent{Code: xerr.SERVER_COMMON_ERROR, Message: xerr.MapErrMsg(xerr.SERVER_COMMON_ERROR)}
+}
+
+// sendError 把错误作为 error 消息发给当前连接
+func (c *Connection) sendError(err error) {
+	event := errorEvent(err)
+	if event.Code == xerr.SERVER_COMMON_ERROR {
+		c.WsManager.logx.Errorf("connection %s request failed: %v", c.ID, err)
+	}
+	data, _ := c.codec.Marshal(&types.Message{Type: MessageTypeError, Data: event, Timestamp: time.Now().Unix()})
+	if c.enqueue(data, 0) == errSendBufferFull {
+		c.WsManager.logx.Infof("warn: Connection %s send buffer is full", c.ID)
+	}
+}
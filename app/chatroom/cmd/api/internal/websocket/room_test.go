@@ -0,0 +1,237 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/config"
+	"go-zero-voice-agent/app/chatroom/cmd/api/internal/types"
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+type fakeRoomStore struct {
+	mu    sync.Mutex
+	rooms map[int64]map[int64]string
+}
+
+func (s *fakeRoomStore) Join(ctx context.Context, roomId, userId int64) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members, ok := s.rooms[roomId]
+	if !ok {
+		return "", xerr.NewErrCode(xerr.ROOM_NOT_FOUND_ERROR)
+	}
+	role, ok := members[userId]
+	if !ok {
+		return "", xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+	}
+	return role, nil
+}
+
+func (s *fakeRoomStore) Leave(ctx context.Context, roomId, userId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rooms[roomId], userId)
+	return nil
+}
+
+func (s *fakeRoomStore) Rooms(ctx context.Context, userId int64) (map[int64]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rooms := make(map[int64]string)
+	for roomId, members := range s.rooms {
+		if role, ok := members[userId]; ok {
+			rooms[roomId] = role
+		}
+	}
+	return rooms, nil
+}
+
+func newTestManager(store RoomStore) *WsManager {
+	m := NewWsManager(&config.WsConfig{
+		MaxConnections:       16,
+		HeartbeatInterval:    time.Hour,
+		ConnectionTimeout:    time.Hour,
+		MessageBufferSize:    64,
+		MessageQueueSize:     64,
+		ShardCount:           4,
+		BroadcastWorkerCount: 1,
+		DropOnFull:           true,
+	})
+	m.RoomStore = store
+	return m
+}
+
+func newTestConnection(t *testing.T, m *WsManager, id, userID string) *Connection {
+	return newResumeConnection(t, m, id, userID, -1)
+}
+
+// newResumeConnection 模拟客户端重连时带上 lastSeq
+func newResumeConnection(t *testing.T, m *WsManager, id, userID string, lastSeq int64) *Connection {
+	conn := &Connection{
+		ID:           id,
+		UserID:       userID,
+		Send:         make(chan []byte, m.Config.MessageBufferSize),
+		WsManager:    m,
+		LastPingTime: time.Now(),
+		IsAlive:      true,
+		Metadata:     make(map[string]interface{}),
+		Rooms:        make(map[string]string),
+		LastSeq:      lastSeq,
+		codec:        JSONCodec,
+	}
+	m.Register <- conn
+	waitUntil(t, func() bool {
+		m.Mu.RLock()
+		defer m.Mu.RUnlock()
+		_, ok := m.Connections[id]
+		return ok
+	})
+	return conn
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// waitMessage 跳过其他类型的消息，直到收到指定类型
+func waitMessage(t *testing.T, conn *Connection, msgType string) types.Message {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case data := <-conn.Send:
+			var msg types.Message
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("invalid message: %v", err)
+			}
+			if msg.Type == msgType {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("connection %s timeout waiting for %s message", conn.ID, msgType)
+			return types.Message{}
+		}
+	}
+}
+
+func TestRoomFanoutAndPermissions(t *testing.T) {
+	store := &fakeRoomStore{rooms: map[int64]map[int64]string{
+		7: {1: model.RoomRoleOwner, 2: model.RoomRoleMuted},
+	}}
+	m := newTestManager(store)
+	defer m.Cancel()
+
+	c1 := newTestConnection(t, m, "c1", "1")
+	c2 := newTestConnection(t, m, "c2", "2")
+	c3 := newTestConnection(t, m, "c3", "3")
+	outsider := newTestConnection(t, m, "c4", "4")
+
+	// 已加入的房间在连接注册后自动订阅
+	waitUntil(t, func() bool { return len(m.RoomOnlineUsers("7")) == 2 })
+
+	// 被邀请后进入房间，其他成员收到上线通知
+	store.mu.Lock()
+	store.rooms[7][3] = model.RoomRoleMember
+	store.mu.Unlock()
+	c3.handleJoin(types.Message{Type: MessageTypeJoin, Room: "7"})
+	if msg := waitMessage(t, c3, MessageTypeMemberJoin); msg.Room != "7" {
+		t.Fatalf("unexpected member_join: %+v", msg)
+	}
+	waitMessage(t, c1, MessageTypePresence)
+
+	// 被禁言的成员和非成员不能发房间消息
+	c2.handleChat(types.Message{Type: "chat", From: "2", Room: "7", Data: map[string]interface{}{"text": "muted"}})
+	if msg := waitMessage(t, c2, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_MEMBER_MUTED_ERROR) {
+		t.Fatalf("unexpected error message: %+v", msg)
+	}
+	outsider.handleChat(types.Message{Type: "chat", From: "4", Room: "7", Data: map[string]interface{}{"text": "outsider"}})
+	if msg := waitMessage(t, outsider, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_MEMBER_NOT_FOUND_ERROR) {
+		t.Fatalf("unexpected error message: %+v", msg)
+	}
+
+	c1.handleChat(types.Message{Type: "chat", From: "1", Room: "7", Data: map[string]interface{}{"text": "hello"}})
+	for _, conn := range []*Connection{c2, c3} {
+		if msg := waitMessage(t, conn, "chat"); msg.From != "1" || msg.Room != "7" {
+			t.Fatalf("connection %s got unexpected chat: %+v", conn.ID, msg)
+		}
+	}
+	select {
+	case data := <-outsider.Send:
+		t.Fatalf("outsider should not receive room messages: %s", data)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// 解除禁言后可以发言
+	m.SetRoomMemberRole("7", "2", model.RoomRoleMember)
+	waitMessage(t, c3, MessageTypeMemberRole)
+	if err := c2.checkRoomSend("7"); err != nil {
+		t.Fatalf("unmuted member should be able to send: %v", err)
+	}
+
+	// 最后一个连接断开时通知下线
+	m.Unregister <- c3
+	for {
+		msg := waitMessage(t, c1, MessageTypePresence)
+		data, _ := json.Marshal(msg.Data)
+		var presence PresenceEvent
+		_ = json.Unmarshal(data, &presence)
+		if presence.UserID == "3" && !presence.Online {
+			break
+		}
+	}
+}
+
+func hasErrorCode(msg types.Message, code uint32) bool {
+	data, _ := json.Marshal(msg.Data)
+	var event ErrorEvent
+	_ = json.Unmarshal(data, &event)
+	return event.Code == code
+}
+
+func TestJoinRequiresMembership(t *testing.T) {
+	store := &fakeRoomStore{rooms: map[int64]map[int64]string{
+		7: {1: model.RoomRoleOwner, 2: model.RoomRoleMember},
+	}}
+	m := newTestManager(store)
+	defer m.Cancel()
+
+	outsider := newTestConnection(t, m, "c3", "3")
+	outsider.handleJoin(types.Message{Type: MessageTypeJoin, Room: "7"})
+	if msg := waitMessage(t, outsider, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_MEMBER_NOT_FOUND_ERROR) {
+		t.Fatalf("outsider join: unexpected message %+v", msg)
+	}
+	outsider.handleJoin(types.Message{Type: MessageTypeJoin, Room: "8"})
+	if msg := waitMessage(t, outsider, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_NOT_FOUND_ERROR) {
+		t.Fatalf("unknown room join: unexpected message %+v", msg)
+	}
+	if _, ok := m.roomRole(outsider, "7"); ok {
+		t.Fatal("outsider subscribed to the room")
+	}
+
+	// 被移出的成员不能自行重新进入
+	c2 := newTestConnection(t, m, "c2", "2")
+	waitUntil(t, func() bool { _, ok := m.roomRole(c2, "7"); return ok })
+	store.Leave(context.Background(), 7, 2)
+	m.RemoveRoomMember("7", "2")
+	waitUntil(t, func() bool { _, ok := m.roomRole(c2, "7"); return !ok })
+
+	c2.handleJoin(types.Message{Type: MessageTypeJoin, Room: "7"})
+	if msg := waitMessage(t, c2, MessageTypeError); !hasErrorCode(msg, xerr.ROOM_MEMBER_NOT_FOUND_ERROR) {
+		t.Fatalf("removed member join: unexpected message %+v", msg)
+	}
+	if _, ok := m.roomRole(c2, "7"); ok {
+		t.Fatal("removed member rejoined the room")
+	}
+}
@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/globalkey"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+)
+
+// RoomStore 房间成员的持久化存储
+type RoomStore interface {
+	// Join 用户进入房间，只有已是成员（房主创建或被邀请）的用户可以进入，返回成员角色
+	Join(ctx context.Context, roomId, userId int64) (role string, err error)
+	// Leave 用户退出房间，房主不能退出
+	Leave(ctx context.Context, roomId, userId int64) error
+	// Rooms 用户加入的所有房间及其角色
+	Rooms(ctx context.Context, userId int64) (map[int64]string, error)
+}
+
+type modelRoomStore struct {
+	roomModel       model.RoomModel
+	roomMemberModel model.RoomMemberModel
+}
+
+// NewModelRoomStore 基于 MySQL 的房间成员存储
+func NewModelRoomStore(roomModel model.RoomModel, roomMemberModel model.RoomMemberModel) RoomStore {
+	return &modelRoomStore{
+		roomModel:       roomModel,
+		roomMemberModel: roomMemberModel,
+	}
+}
+
+func (s *modelRoomStore) Join(ctx context.Context, roomId, userId int64) (string, error) {
+	room, err := s.roomModel.FindOne(ctx, roomId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return "", xerr.NewErrCode(xerr.ROOM_NOT_FOUND_ERROR)
+		}
+		return "", errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d failed: %v", roomId, err)
+	}
+	if room.DelState != globalkey.DelStateNo {
+		return "", xerr.NewErrCode(xerr.ROOM_NOT_FOUND_ERROR)
+	}
+
+	// 不再自动写入成员关系：非成员（包括被移出、已退出的成员）需要房主或管理员重新邀请
+	member, err := s.roomMemberModel.FindOneByRoomIdUserId(ctx, roomId, userId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return "", xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+		}
+		return "", errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", roomId, userId, err)
+	}
+	return member.Role, nil
+}
+
+func (s *modelRoomStore) Leave(ctx context.Context, roomId, userId int64) error {
+	member, err := s.roomMemberModel.FindOneByRoomIdUserId(ctx, roomId, userId)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+		}
+		return errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find room %d member %d failed: %v", roomId, userId, err)
+	}
+	if member.Role == model.RoomRoleOwner {
+		return xerr.NewErrMsg("房主不能退出房间")
+	}
+	if err := s.roomMemberModel.Delete(ctx, nil, member.Id); err != nil {
+		return errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "delete room %d member %d failed: %v", roomId, userId, err)
+	}
+	return nil
+}
+
+func (s *modelRoomStore) Rooms(ctx context.Context, userId int64) (map[int64]string, error) {
+	members, err := s.roomMemberModel.FindAllByUserId(ctx, userId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.DB_ERROR), "find rooms of user %d failed: %v", userId, err)
+	}
+	rooms := make(map[int64]string, len(members))
+	for _, member := range members {
+		rooms[member.RoomId] = member.Role
+	}
+	return rooms, nil
+}
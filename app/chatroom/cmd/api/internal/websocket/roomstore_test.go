@@ -0,0 +1,99 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+
+	"go-zero-voice-agent/app/chatroom/model"
+	"go-zero-voice-agent/pkg/globalkey"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// fakeRoomModel 只实现 Join 用到的查询，其他方法调用时 panic
+type fakeRoomModel struct {
+	model.RoomModel
+	rooms map[int64]*model.Room
+}
+
+func (m *fakeRoomModel) FindOne(ctx context.Context, id int64) (*model.Room, error) {
+	if room, ok := m.rooms[id]; ok {
+		return room, nil
+	}
+	return nil, model.ErrNotFound
+}
+
+type fakeRoomMemberModel struct {
+	model.RoomMemberModel
+	members map[[2]int64]*model.RoomMember
+}
+
+func (m *fakeRoomMemberModel) FindOneByRoomIdUserId(ctx context.Context, roomId, userId int64) (*model.RoomMember, error) {
+	if member, ok := m.members[[2]int64{roomId, userId}]; ok {
+		return member, nil
+	}
+	return nil, model.ErrNotFound
+}
+
+func (m *fakeRoomMemberModel) Delete(ctx context.Context, session sqlx.Session, id int64) error {
+	for key, member := range m.members {
+		if member.Id == id {
+			delete(m.members, key)
+		}
+	}
+	return nil
+}
+
+func TestModelRoomStoreJoin(t *testing.T) {
+	members := &fakeRoomMemberModel{members: map[[2]int64]*model.RoomMember{
+		{7, 1}: {Id: 1, RoomId: 7, UserId: 1, Role: model.RoomRoleOwner},
+		{7, 2}: {Id: 2, RoomId: 7, UserId: 2, Role: model.RoomRoleMuted},
+		{7, 3}: {Id: 3, RoomId: 7, UserId: 3, Role: model.RoomRoleMember},
+		{9, 1}: {Id: 4, RoomId: 9, UserId: 1, Role: model.RoomRoleOwner},
+	}}
+	store := NewModelRoomStore(&fakeRoomModel{rooms: map[int64]*model.Room{
+		7: {Id: 7, OwnerId: 1},
+		9: {Id: 9, OwnerId: 1, DelState: globalkey.DelStateYes},
+	}}, members)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		roomId   int64
+		userId   int64
+		wantRole string
+		wantCode uint32
+	}{
+		{"owner", 7, 1, model.RoomRoleOwner, 0},
+		{"muted member keeps role", 7, 2, model.RoomRoleMuted, 0},
+		{"not invited", 7, 4, "", xerr.ROOM_MEMBER_NOT_FOUND_ERROR},
+		{"unknown room", 8, 1, "", xerr.ROOM_NOT_FOUND_ERROR},
+		{"deleted room", 9, 1, "", xerr.ROOM_NOT_FOUND_ERROR},
+	}
+	for _, tt := range tests {
+		role, err := store.Join(ctx, tt.roomId, tt.userId)
+		if tt.wantCode != 0 {
+			if e, ok := errors.Cause(err).(*xerr.CodeError); !ok || e.GetErrCode() != tt.wantCode {
+				t.Errorf("%s: Join err = %v, want code %d", tt.name, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil || role != tt.wantRole {
+			t.Errorf("%s: Join = %q, %v, want %q", tt.name, role, err, tt.wantRole)
+		}
+	}
+	if len(members.members) != 4 {
+		t.Errorf("Join must not create members, got %d", len(members.members))
+	}
+
+	// 退出（与被移出一样删除成员记录）后不能自行重新进入
+	if err := store.Leave(ctx, 7, 3); err != nil {
+		t.Fatalf("Leave failed: %v", err)
+	}
+	_, err := store.Join(ctx, 7, 3)
+	if e, ok := errors.Cause(err).(*xerr.CodeError); !ok || e.GetErrCode() != xerr.ROOM_MEMBER_NOT_FOUND_ERROR {
+		t.Errorf("rejoin after leave: err = %v, want ROOM_MEMBER_NOT_FOUND_ERROR", err)
+	}
+}
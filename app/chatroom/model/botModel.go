@@ -0,0 +1,35 @@
+package model
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ BotModel = (*customBotModel)(nil)
+
+type (
+	// BotModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customBotModel.
+	BotModel interface {
+		botModel
+		FindAllByOwnerId(ctx context.Context, ownerId int64) ([]*Bot, error)
+	}
+
+	customBotModel struct {
+		*defaultBotModel
+	}
+)
+
+// NewBotModel returns a model for the database table.
+func NewBotModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) BotModel {
+	return &customBotModel{
+		defaultBotModel: newBotModel(conn, c, opts...),
+	}
+}
+
+// FindAllByOwnerId 用户创建的所有机器人
+func (m *customBotModel) FindAllByOwnerId(ctx context.Context, ownerId int64) ([]*Bot, error) {
+	return m.FindAll(ctx, m.SelectBuilder().Where("owner_id = ?", ownerId), "id ASC")
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ ChatMessageModel = (*customChatMessageModel)(nil)
+
+type (
+	// ChatMessageModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customChatMessageModel.
+	ChatMessageModel interface {
+		chatMessageModel
+		FindByIds(ctx context.Context, ids []int64) ([]*ChatMessage, error)
+		FindRoomHistory(ctx context.Context, roomId, cursor, limit int64) ([]*ChatMessage, error)
+		FindDirectHistory(ctx context.Context, userId, peerId, cursor, limit int64) ([]*ChatMessage, error)
+		FindRoomSince(ctx context.Context, roomId, lastSeq, limit int64) ([]*ChatMessage, error)
+		NextRoomSeq(ctx context.Context, session sqlx.Session, roomId int64) (int64, error)
+	}
+
+	customChatMessageModel struct {
+		*defaultChatMessageModel
+	}
+)
+
+// NewChatMessageModel returns a model for the database table.
+func NewChatMessageModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) ChatMessageModel {
+	return &customChatMessageModel{
+		defaultChatMessageModel: newChatMessageModel(conn, c, opts...),
+	}
+}
+
+// FindByIds 按 id 批量查询消息
+func (m *customChatMessageModel) FindByIds(ctx context.Context, ids []int64) ([]*ChatMessage, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return m.FindAll(ctx, m.SelectBuilder().Where(squirrel.Eq{"id": ids}), "id ASC")
+}
+
+// FindRoomHistory 房间消息，cursor 为上一页最小的消息 id（0 表示从最新开始），按 id 倒序
+func (m *customChatMessageModel) FindRoomHistory(ctx context.Context, roomId, cursor, limit int64) ([]*ChatMessage, error) {
+	builder := m.SelectBuilder().Where("room_id = ?", roomId)
+	return m.FindPageListByIdDESC(ctx, builder, cursor, limit)
+}
+
+// FindDirectHistory 两个用户之间的私聊消息，分页方式同 FindRoomHistory
+func (m *customChatMessageModel) FindDirectHistory(ctx context.Context, userId, peerId, cursor, limit int64) ([]*ChatMessage, error) {
+	builder := m.SelectBuilder().Where("room_id = 0").Where(squirrel.Or{
+		squirrel.Eq{"from_user_id": userId, "to_user_id": peerId},
+		squirrel.Eq{"from_user_id": peerId, "to_user_id": userId},
+	})
+	return m.FindPageListByIdDESC(ctx, builder, cursor, limit)
+}
+
+// FindRoomSince 房间中序号大于 lastSeq 的消息，按序号升序
+func (m *customChatMessageModel) FindRoomSince(ctx context.Context, roomId, lastSeq, limit int64) ([]*ChatMessage, error) {
+	var resp []*ChatMessage
+	query := fmt.Sprintf("select %s from %s where `room_id` = ? and `seq` > ? and del_state = ? order by `seq` asc limit ?", chatMessageRows, m.table)
+	if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, roomId, lastSeq, globalkey.DelStateNo, limit); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NextRoomSeq 在事务中锁定房间行后取房间的下一个序号，同一房间的写入依次提交，事务回滚时序号不留空洞
+func (m *customChatMessageModel) NextRoomSeq(ctx context.Context, session sqlx.Session, roomId int64) (int64, error) {
+	var locked int64
+	if err := session.QueryRowCtx(ctx, &locked, "select `id` from `room` where `id` = ? for update", roomId); err != nil {
+		return 0, err
+	}
+	var seq int64
+	query := fmt.Sprintf("select ifnull(max(`seq`), 0) + 1 from %s where `room_id` = ?", m.table)
+	if err := session.QueryRowCtx(ctx, &seq, query, roomId); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
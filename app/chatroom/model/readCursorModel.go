@@ -0,0 +1,63 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ ReadCursorModel = (*customReadCursorModel)(nil)
+
+type (
+	// ReadCursorModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customReadCursorModel.
+	ReadCursorModel interface {
+		readCursorModel
+		Advance(ctx context.Context, userId, roomId, peerId, messageId int64) (bool, error)
+		FindAllByRoomId(ctx context.Context, roomId int64) ([]*ReadCursor, error)
+		FindDirect(ctx context.Context, userId, peerId int64) ([]*ReadCursor, error)
+	}
+
+	customReadCursorModel struct {
+		*defaultReadCursorModel
+	}
+)
+
+// NewReadCursorModel returns a model for the database table.
+func NewReadCursorModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) ReadCursorModel {
+	return &customReadCursorModel{
+		defaultReadCursorModel: newReadCursorModel(conn, c, opts...),
+	}
+}
+
+// Advance 推进用户在房间（peerId 为 0）或私聊（roomId 为 0）中的已读位置，只前进不后退，返回位置是否变化
+func (m *customReadCursorModel) Advance(ctx context.Context, userId, roomId, peerId, messageId int64) (bool, error) {
+	query := fmt.Sprintf("insert into %s (`del_state`, `version`, `user_id`, `room_id`, `peer_id`, `message_id`) values (?, 0, ?, ?, ?, ?) "+
+		"on duplicate key update `message_id` = greatest(`message_id`, values(`message_id`))", m.table)
+	result, err := m.ExecNoCacheCtx(ctx, query, globalkey.DelStateNo, userId, roomId, peerId, messageId)
+	if err != nil {
+		return false, err
+	}
+	// 新插入为 1，更新为 2，位置没有前进时为 0
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindAllByRoomId 房间成员的已读位置
+func (m *customReadCursorModel) FindAllByRoomId(ctx context.Context, roomId int64) ([]*ReadCursor, error) {
+	return m.FindAll(ctx, m.SelectBuilder().Where("room_id = ? AND peer_id = 0", roomId), "id ASC")
+}
+
+// FindDirect 私聊双方的已读位置
+func (m *customReadCursorModel) FindDirect(ctx context.Context, userId, peerId int64) ([]*ReadCursor, error) {
+	builder := m.SelectBuilder().Where("room_id = 0 AND ((user_id = ? AND peer_id = ?) OR (user_id = ? AND peer_id = ?))",
+		userId, peerId, peerId, userId)
+	return m.FindAll(ctx, builder, "id ASC")
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlc"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ RoomMemberModel = (*customRoomMemberModel)(nil)
+
+type (
+	// RoomMemberModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customRoomMemberModel.
+	RoomMemberModel interface {
+		roomMemberModel
+		FindOneByRoomIdUserId(ctx context.Context, roomId, userId int64) (*RoomMember, error)
+		FindAllByRoomId(ctx context.Context, roomId int64) ([]*RoomMember, error)
+		FindAllByUserId(ctx context.Context, userId int64) ([]*RoomMember, error)
+	}
+
+	customRoomMemberModel struct {
+		*defaultRoomMemberModel
+	}
+)
+
+// NewRoomMemberModel returns a model for the database table.
+func NewRoomMemberModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) RoomMemberModel {
+	return &customRoomMemberModel{
+		defaultRoomMemberModel: newRoomMemberModel(conn, c, opts...),
+	}
+}
+
+// FindOneByRoomIdUserId 查询用户在房间中的成员记录
+func (m *customRoomMemberModel) FindOneByRoomIdUserId(ctx context.Context, roomId, userId int64) (*RoomMember, error) {
+	var resp RoomMember
+	query := fmt.Sprintf("select %s from %s where `room_id` = ? and `user_id` = ? and del_state = ? limit 1", roomMemberRows, m.table)
+	err := m.QueryRowNoCacheCtx(ctx, &resp, query, roomId, userId, globalkey.DelStateNo)
+	switch err {
+	case nil:
+		return &resp, nil
+	case sqlc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// FindAllByRoomId 查询房间的所有成员，按加入顺序排列
+func (m *customRoomMemberModel) FindAllByRoomId(ctx context.Context, roomId int64) ([]*RoomMember, error) {
+	return m.FindAll(ctx, m.SelectBuilder().Where("room_id = ?", roomId), "id ASC")
+}
+
+// FindAllByUserId 查询用户加入的所有房间
+func (m *customRoomMemberModel) FindAllByUserId(ctx context.Context, userId int64) ([]*RoomMember, error) {
+	return m.FindAll(ctx, m.SelectBuilder().Where("user_id = ?", userId), "id ASC")
+}
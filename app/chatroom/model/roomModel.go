@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ RoomModel = (*customRoomModel)(nil)
+
+type (
+	// RoomModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customRoomModel.
+	RoomModel interface {
+		roomModel
+	}
+
+	customRoomModel struct {
+		*defaultRoomModel
+	}
+)
+
+// NewRoomModel returns a model for the database table.
+func NewRoomModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) RoomModel {
+	return &customRoomModel{
+		defaultRoomModel: newRoomModel(conn, c, opts...),
+	}
+}
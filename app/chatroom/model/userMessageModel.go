@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ UserMessageModel = (*customUserMessageModel)(nil)
+
+type (
+	// UserMessageModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserMessageModel.
+	UserMessageModel interface {
+		userMessageModel
+		InsertBatch(ctx context.Context, session sqlx.Session, data []*UserMessage) error
+		FindSince(ctx context.Context, userId, lastSeq, limit int64) ([]*UserMessage, error)
+		FindMaxSeq(ctx context.Context, userId int64) (int64, error)
+	}
+
+	customUserMessageModel struct {
+		*defaultUserMessageModel
+	}
+)
+
+// NewUserMessageModel returns a model for the database table.
+func NewUserMessageModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) UserMessageModel {
+	return &customUserMessageModel{
+		defaultUserMessageModel: newUserMessageModel(conn, c, opts...),
+	}
+}
+
+// InsertBatch 一条语句写入多个接收者的收件记录
+func (m *customUserMessageModel) InsertBatch(ctx context.Context, session sqlx.Session, data []*UserMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(data))
+	args := make([]any, 0, len(data)*5)
+	for _, row := range data {
+		row.DelState = globalkey.DelStateNo
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?)")
+		args = append(args, row.DelState, row.Version, row.UserId, row.Seq, row.MessageId)
+	}
+	query := fmt.Sprintf("insert into %s (%s) values %s", m.table, userMessageRowsExpectAutoSet, strings.Join(placeholders, ", "))
+	var err error
+	if session != nil {
+		_, err = session.ExecCtx(ctx, query, args...)
+	} else {
+		_, err = m.ExecNoCacheCtx(ctx, query, args...)
+	}
+	return err
+}
+
+// FindSince 用户序号大于 lastSeq 的收件记录，按序号升序
+func (m *customUserMessageModel) FindSince(ctx context.Context, userId, lastSeq, limit int64) ([]*UserMessage, error) {
+	var resp []*UserMessage
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? and `seq` > ? and del_state = ? order by `seq` asc limit ?", userMessageRows, m.table)
+	if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, userId, lastSeq, globalkey.DelStateNo, limit); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FindMaxSeq 用户当前最大的序号，没有消息时返回 0
+func (m *customUserMessageModel) FindMaxSeq(ctx context.Context, userId int64) (int64, error) {
+	var seq int64
+	query := fmt.Sprintf("select ifnull(max(`seq`), 0) from %s where `user_id` = ?", m.table)
+	if err := m.QueryRowNoCacheCtx(ctx, &seq, query, userId); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+    "errors"
+    "github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var ErrNotFound = sqlx.ErrNotFound
+var ErrNoRowsUpdate = errors.New("update db no rows change")
+
+// 房间成员角色
+const (
+	RoomRoleOwner  = "owner"  // 房主，创建房间的用户
+	RoomRoleAdmin  = "admin"  // 管理员，可禁言或移出普通成员
+	RoomRoleMember = "member" // 普通成员
+	RoomRoleMuted  = "muted"  // 被禁言的成员，只能接收消息
+)
+
+// BotUserId 机器人在聊天室中的用户 id，取机器人 id 的相反数，与真实用户 id 区分
+func BotUserId(botId int64) int64 {
+	return -botId
+}
+
+// IsBotUserId 用户 id 是否属于机器人
+func IsBotUserId(userId int64) bool {
+	return userId < 0
+}
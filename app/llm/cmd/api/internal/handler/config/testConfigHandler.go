@@ -0,0 +1,33 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package config
+
+import (
+	"net/http"
+
+	"go-zero-voice-agent/app/llm/cmd/api/internal/logic/config"
+	"go-zero-voice-agent/app/llm/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/llm/cmd/api/internal/types"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// 测试配置,检查密钥、地址与模型是否可用
+func TestConfigHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.TestConfigReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := config.NewTestConfigLogic(r.Context(), svcCtx)
+		resp, err := l.TestConfig(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -90,6 +90,12 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/:id",
 				Handler: config.GetConfigHandler(serverCtx),
 			},
+			{
+				// 测试配置,检查密钥、地址与模型是否可用
+				Method:  http.MethodPost,
+				Path:    "/:id/test",
+				Handler: config.TestConfigHandler(serverCtx),
+			},
 			{
 				// 创建配置
 				Method:  http.MethodPost,
@@ -57,12 +57,13 @@ func (l *TextChatLogic) TextChat(req *types.TextChatReq) (resp *types.TextChatRe
 
 	// 发起对话请求
 	chatReq := &llmchatservice.ChatReq{
-		UserId:          req.UserId,
-		ConversationId:  convID,
-		LlmConfig:       llmCfg,
-		Messages:        messages,
-		AutoFillHistory: req.AutoFillHistory,
-		RagFileIds:      int64SliceToStringSlice(req.RagFileIds),
+		UserId:           req.UserId,
+		ConversationId:   convID,
+		LlmConfig:        llmCfg,
+		Messages:         messages,
+		AutoFillHistory:  req.AutoFillHistory,
+		RagFileIds:       int64SliceToStringSlice(req.RagFileIds),
+		KnowledgeBaseIds: chatKnowledgeBaseIds(req, fetchLlmConfig),
 	}
 
 	chatResp, err := l.svcCtx.LlmChatRpc.Chat(l.ctx, chatReq)
@@ -131,12 +132,13 @@ func (l *TextChatLogic) TextChatStream(req *types.TextChatReq) (pb.LlmChatServic
 
 	// 发起流式对话请求，并返回流式响应客户端
 	chatStreamClient, err := l.svcCtx.LlmChatRpc.ChatStream(l.ctx, &llmchatservice.ChatStreamReq{
-		UserId:          req.UserId,
-		ConversationId:  convID,
-		LlmConfig:       llmCfg,
-		Messages:        messages,
-		AutoFillHistory: req.AutoFillHistory,
-		RagFileIds:      int64SliceToStringSlice(req.RagFileIds),
+		UserId:           req.UserId,
+		ConversationId:   convID,
+		LlmConfig:        llmCfg,
+		Messages:         messages,
+		AutoFillHistory:  req.AutoFillHistory,
+		RagFileIds:       int64SliceToStringSlice(req.RagFileIds),
+		KnowledgeBaseIds: chatKnowledgeBaseIds(req, fetchLlmConfig),
 	})
 	return chatStreamClient, err
 }
@@ -270,6 +272,14 @@ func toRpcToolCalls(toolCalls []types.ToolCall) []*llmchatservice.ToolCall {
 }
 
 // 将 []int64 转换为 []string
+// chatKnowledgeBaseIds 请求未指定知识库时使用对话配置中的默认知识库
+func chatKnowledgeBaseIds(req *types.TextChatReq, cfg *llmconfigservice.ChatConfig) []int64 {
+	if len(req.KnowledgeBaseIds) > 0 {
+		return req.KnowledgeBaseIds
+	}
+	return cfg.GetKnowledgeBaseIds()
+}
+
 func int64SliceToStringSlice(ids []int64) []string {
 	if len(ids) == 0 {
 		return nil
@@ -3,6 +3,7 @@ package config
 import (
 	"go-zero-voice-agent/app/llm/cmd/api/internal/types"
 	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/pkg/secretbox"
 )
 
 func toRpcCreateConfigReq(req *types.CreateConfigReq) *llmconfigservice.CreateConfigReq {
@@ -28,6 +29,7 @@ func toRpcCreateConfigReq(req *types.CreateConfigReq) *llmconfigservice.CreateCo
 		Seed:              req.Seed,
 		EnableSearch:      req.EnableSearch,
 		ContextLength:     req.ContextLength,
+		KnowledgeBaseIds:  req.KnowledgeBaseIds,
 	}
 }
 
@@ -55,6 +57,7 @@ func toRpcUpdateConfigReq(req *types.UpdateConfigReq) *llmconfigservice.UpdateCo
 		Seed:              req.Seed,
 		EnableSearch:      req.EnableSearch,
 		ContextLength:     req.ContextLength,
+		KnowledgeBaseIds:  req.KnowledgeBaseIds,
 	}
 }
 
@@ -105,7 +108,7 @@ func toTypesChatConfig(cfg *llmconfigservice.ChatConfig) types.ChatConfig {
 		Description:       cfg.Description,
 		UserId:            cfg.UserId,
 		BaseUrl:           cfg.BaseUrl,
-		ApiKey:            cfg.ApiKey,
+		ApiKey:            secretbox.Mask(cfg.ApiKey),
 		Model:             cfg.Model,
 		Stream:            cfg.Stream,
 		Temperature:       cfg.Temperature,
@@ -118,5 +121,6 @@ func toTypesChatConfig(cfg *llmconfigservice.ChatConfig) types.ChatConfig {
 		Seed:              cfg.Seed,
 		EnableSearch:      cfg.EnableSearch,
 		ContextLength:     cfg.ContextLength,
+		KnowledgeBaseIds:  cfg.KnowledgeBaseIds,
 	}
 }
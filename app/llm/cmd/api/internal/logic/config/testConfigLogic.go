@@ -0,0 +1,54 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package config
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/llm/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/llm/cmd/api/internal/types"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type TestConfigLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 测试配置,检查密钥、地址与模型是否可用
+func NewTestConfigLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TestConfigLogic {
+	return &TestConfigLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *TestConfigLogic) TestConfig(req *types.TestConfigReq) (resp *types.TestConfigResp, err error) {
+	configResp, err := l.svcCtx.LlmConfigRpc.GetConfig(l.ctx, toRpcGetConfigReq(req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	if configResp.Config.UserId != req.UserId {
+		return nil, errors.New("Not authorized to access this config")
+	}
+
+	r, err := l.svcCtx.LlmConfigRpc.TestConfig(l.ctx, &llmconfigservice.TestConfigReq{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TestConfigResp{
+		Ok:             r.Ok,
+		LatencyMs:      r.LatencyMs,
+		ModelAvailable: r.ModelAvailable,
+		ErrorCategory:  r.ErrorCategory,
+		ErrorMessage:   r.ErrorMessage,
+	}, nil
+}
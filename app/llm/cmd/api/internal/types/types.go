@@ -22,6 +22,7 @@ type ChatConfig struct {
 	Seed              int64   `json:"seed"`
 	EnableSearch      int64   `json:"enableSearch"`
 	ContextLength     int64   `json:"contextLength"`
+	KnowledgeBaseIds  []int64 `json:"knowledgeBaseIds"` // 对话默认检索的rag知识库
 }
 
 type ChatConfigQueryFilter struct {
@@ -73,6 +74,7 @@ type CreateConfigReq struct {
 	Seed              int64   `json:"seed,optional"`
 	EnableSearch      int64   `json:"enableSearch,optional"`
 	ContextLength     int64   `json:"contextLength,optional"`
+	KnowledgeBaseIds  []int64 `json:"knowledgeBaseIds,optional"` // 对话默认检索的rag知识库
 }
 
 type CreateConfigResp struct {
@@ -167,6 +169,19 @@ type StreamChatResp struct {
 	IsComplete     bool            `json:"isComplete,optional"`
 }
 
+type TestConfigReq struct {
+	Id     int64 `path:"id"`
+	UserId int64 `header:"X-User-Id"`
+}
+
+type TestConfigResp struct {
+	Ok             bool   `json:"ok"`
+	LatencyMs      int64  `json:"latencyMs"`
+	ModelAvailable bool   `json:"modelAvailable"`
+	ErrorCategory  string `json:"errorCategory"` // auth_failed / not_found / rate_limited / timeout / network_error / invalid_config / provider_error
+	ErrorMessage   string `json:"errorMessage"`
+}
+
 type TextChatMessage struct {
 	Role       string     `json:"role"`
 	Content    string     `json:"content"`
@@ -175,17 +190,18 @@ type TextChatMessage struct {
 }
 
 type TextChatReq struct {
-	UserId          int64      `header:"X-User-Id"`
-	ConfigId        int64      `json:"configId"`
-	ConversationId  string     `json:"conversationId,optional"`
-	RagFileIds      []int64    `json:"ragFileIds,optional"`
-	Message         string     `json:"message"`
-	Role            string     `json:"role,optional"`
-	ToolCalls       []ToolCall `json:"toolCalls,optional"`
-	ToolCallId      string     `json:"toolCallId,optional"`
-	SystemPrompt    string     `json:"systemPrompt,optional"`
-	AutoFillHistory bool       `json:"autoFillHistory,optional"`
-	IsStream        bool       `json:"isStream,optional"`
+	UserId           int64      `header:"X-User-Id"`
+	ConfigId         int64      `json:"configId"`
+	ConversationId   string     `json:"conversationId,optional"`
+	RagFileIds       []int64    `json:"ragFileIds,optional"`
+	KnowledgeBaseIds []int64    `json:"knowledgeBaseIds,optional"` // 为空时使用对话配置中的默认知识库
+	Message          string     `json:"message"`
+	Role             string     `json:"role,optional"`
+	ToolCalls        []ToolCall `json:"toolCalls,optional"`
+	ToolCallId       string     `json:"toolCallId,optional"`
+	SystemPrompt     string     `json:"systemPrompt,optional"`
+	AutoFillHistory  bool       `json:"autoFillHistory,optional"`
+	IsStream         bool       `json:"isStream,optional"`
 }
 
 type TextChatResp struct {
@@ -228,6 +244,7 @@ type UpdateConfigReq struct {
 	Seed              int64   `json:"seed,optional"`
 	EnableSearch      int64   `json:"enableSearch,optional"`
 	ContextLength     int64   `json:"contextLength,optional"`
+	KnowledgeBaseIds  []int64 `json:"knowledgeBaseIds,optional"` // 对话默认检索的rag知识库
 }
 
 type UpdateConfigResp struct {
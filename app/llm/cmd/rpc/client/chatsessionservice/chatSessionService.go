@@ -53,6 +53,8 @@ type (
 	LlmConfig                 = pb.LlmConfig
 	PageQuery                 = pb.PageQuery
 	StreamOptions             = pb.StreamOptions
+	TestConfigReq             = pb.TestConfigReq
+	TestConfigResp            = pb.TestConfigResp
 	ToolCall                  = pb.ToolCall
 	ToolCallInfo              = pb.ToolCallInfo
 	UpdateChatMessageReq      = pb.UpdateChatMessageReq
@@ -53,6 +53,8 @@ type (
 	LlmConfig                 = pb.LlmConfig
 	PageQuery                 = pb.PageQuery
 	StreamOptions             = pb.StreamOptions
+	TestConfigReq             = pb.TestConfigReq
+	TestConfigResp            = pb.TestConfigResp
 	ToolCall                  = pb.ToolCall
 	ToolCallInfo              = pb.ToolCallInfo
 	UpdateChatMessageReq      = pb.UpdateChatMessageReq
@@ -68,6 +70,7 @@ type (
 		UpdateConfig(ctx context.Context, in *UpdateConfigReq, opts ...grpc.CallOption) (*UpdateConfigResp, error)
 		GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigResp, error)
 		ListConfig(ctx context.Context, in *ListConfigReq, opts ...grpc.CallOption) (*ListConfigResp, error)
+		TestConfig(ctx context.Context, in *TestConfigReq, opts ...grpc.CallOption) (*TestConfigResp, error)
 	}
 
 	defaultLlmConfigService struct {
@@ -105,3 +108,8 @@ func (m *defaultLlmConfigService) ListConfig(ctx context.Context, in *ListConfig
 	client := pb.NewLlmConfigServiceClient(m.cli.Conn())
 	return client.ListConfig(ctx, in, opts...)
 }
+
+func (m *defaultLlmConfigService) TestConfig(ctx context.Context, in *TestConfigReq, opts ...grpc.CallOption) (*TestConfigResp, error) {
+	client := pb.NewLlmConfigServiceClient(m.cli.Conn())
+	return client.TestConfig(ctx, in, opts...)
+}
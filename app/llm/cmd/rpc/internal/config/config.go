@@ -1,6 +1,8 @@
 package config
 
 import (
+	"go-zero-voice-agent/pkg/secretbox"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -11,6 +13,8 @@ type Config struct {
 		DataSource string
 	}
 	Cache cache.CacheConf
+	// 大模型 api key 加密的主密钥
+	SecretConfig secretbox.Config
 	Asynq struct {
 		Host string
 		Pass string
@@ -3,6 +3,7 @@ package llmchatservicelogic
 import (
 	"context"
 	"encoding/json"
+	"slices"
 
 	"go-zero-voice-agent/app/llm/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
@@ -64,6 +65,7 @@ func (l *ChatLogic) Chat(in *pb.ChatReq) (*pb.ChatResp, error) {
 	l.Logger.Infof("History messages: %+v", historyMsgs)
 
 	// 获取工具调用相关，并处理工具调用
+	var updatedAssistantMsgs []*pb.ChatMsg
 	for _, msg := range in.Messages {
 		if msg.GetRole() != chatconsts.ChatMessageRoleTool {
 			// 普通消息，直接加入历史
@@ -159,7 +161,10 @@ func (l *ChatLogic) Chat(in *pb.ChatReq) (*pb.ChatResp, error) {
 
 		if shouldCacheToolMsg {
 			if updatedAssistantMsg != nil {
-				go l.svcCtx.UpdateAssistantToolCalls(chatSession.ConvId, updatedAssistantMsg)
+				// 同一条 assistant 消息可能对应多条工具结果，全部处理完后再统一更新缓存
+				if !slices.Contains(updatedAssistantMsgs, updatedAssistantMsg) {
+					updatedAssistantMsgs = append(updatedAssistantMsgs, updatedAssistantMsg)
+				}
 			} else {
 				go l.svcCtx.CacheConversation(chatSession.ConvId, nil, msg)
 			}
@@ -167,6 +172,10 @@ func (l *ChatLogic) Chat(in *pb.ChatReq) (*pb.ChatResp, error) {
 
 	}
 
+	for _, updated := range updatedAssistantMsgs {
+		go l.svcCtx.UpdateAssistantToolCalls(chatSession.ConvId, updated)
+	}
+
 	// 构建 OpenAI 格式的消息列表
 	openaiMsgs := BuildOpenAIMessages(historyMsgs)
 
@@ -278,7 +287,7 @@ func (l *ChatLogic) handleChatInteraction(
 		}
 
 		// 不需要确认的工具调用，直接执行
-		// 如果是rag工具，注入用户ID和文件ID列表
+		// 如果是rag工具，注入用户ID、文件ID和知识库ID列表
 		if toolCall.Function.Name == chatconsts.TOOL_CALLING_SELF_RAG {
 			var argsMap map[string]interface{}
 			err := json.Unmarshal([]byte(toolCall.Function.Arguments), &argsMap)
@@ -287,6 +296,7 @@ func (l *ChatLogic) handleChatInteraction(
 			} else {
 				argsMap["user_id"] = in.UserId
 				argsMap["file_ids"] = in.RagFileIds
+				argsMap["knowledge_base_ids"] = in.KnowledgeBaseIds
 				newArgs, err := json.Marshal(argsMap)
 				if err != nil {
 					l.Logger.Errorf("failed to marshal updated rag tool arguments: %v", err)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"slices"
 	"strings"
 
 	"go-zero-voice-agent/app/llm/cmd/rpc/internal/svc"
@@ -37,7 +38,7 @@ func NewChatStreamLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ChatSt
 // 1. 校验请求参数
 // 2. 获取或创建会话
 // 3. 收集历史消息
-// 4. 处理输入消息中的工具调用结果（确认/拒绝/完成）
+// 4. 处理输入消息中的工具调用结果（确认/拒绝/完成），recordOnly 时到此结束
 // 5. 构建 OpenAI 消息列表并初始化客户端
 // 6. 调用 handleChatStreamInteraction 进行流式交互
 func (l *ChatStreamLogic) ChatStream(in *pb.ChatStreamReq, stream pb.LlmChatService_ChatStreamServer) error {
@@ -62,6 +63,7 @@ func (l *ChatStreamLogic) ChatStream(in *pb.ChatStreamReq, stream pb.LlmChatServ
 
 	// 获取工具调用相关，并处理工具调用
 	// 遍历输入消息，处理 Tool 类型的消息
+	var updatedAssistantMsgs []*pb.ChatMsg
 	for _, msg := range in.Messages {
 		if msg.GetRole() != consts.ChatMessageRoleTool {
 			// 普通消息，直接加入历史并缓存
@@ -101,7 +103,7 @@ func (l *ChatStreamLogic) ChatStream(in *pb.ChatStreamReq, stream pb.LlmChatServ
 			}
 			if toolCall.Status == consts.TOOL_CALLING_CONFIRMED {
 				// 用户确认工具调用，执行它，并将结果加入历史消息
-				tool, ok := l.svcCtx.ToolRegistry[toolCall.Info.Name]
+				tool, ok := l.svcCtx.GetTool(toolCall.Info.Name)
 				if !ok {
 					l.Logger.Errorf("unknown tool called: %s", toolCall.Info.Name)
 					continue
@@ -164,13 +166,25 @@ func (l *ChatStreamLogic) ChatStream(in *pb.ChatStreamReq, stream pb.LlmChatServ
 
 		if shouldCacheToolMsg {
 			if updatedAssistantMsg != nil {
-				go l.svcCtx.UpdateAssistantToolCalls(chatSession.ConvId, updatedAssistantMsg)
+				// 同一条 assistant 消息可能对应多条工具结果，全部处理完后再统一更新缓存
+				if !slices.Contains(updatedAssistantMsgs, updatedAssistantMsg) {
+					updatedAssistantMsgs = append(updatedAssistantMsgs, updatedAssistantMsg)
+				}
 			} else {
 				go l.svcCtx.CacheConversation(chatSession.ConvId, nil, msg)
 			}
 		}
 	}
 
+	for _, updated := range updatedAssistantMsgs {
+		go l.svcCtx.UpdateAssistantToolCalls(chatSession.ConvId, updated)
+	}
+
+	// 只补齐工具调用结果，不再请求模型
+	if in.RecordOnly {
+		return nil
+	}
+
 	// 构建 OpenAI 格式的消息列表
 	openaiMsgs := BuildOpenAIMessages(historyMsgs)
 
@@ -203,7 +217,10 @@ func (l *ChatStreamLogic) handleChatStreamInteraction(
 
 	// 获取不需要确认即可执行的工具列表（用于 OpenAI 请求）
 	// OpenaiToolListWithoutConfirm := l.svcCtx.OpenaiToolListWithoutConfirm
-	OpenaiToolList := l.svcCtx.OpenaiToolList
+	var OpenaiToolList []openai.Tool
+	if !in.DisableTools {
+		OpenaiToolList = l.svcCtx.GetOpenaiToolList(in.EnabledTools)
+	}
 
 	// 构建并发送聊天完成请求
 	// stream=true 开启流式模式
@@ -347,7 +364,7 @@ func (l *ChatStreamLogic) handleChatStreamInteraction(
 
 	// 遍历所有工具调用，决定是自动执行还是请求确认
 	for _, toolCall := range toolCalls {
-		tool, ok := l.svcCtx.ToolRegistry[toolCall.Function.Name]
+		tool, ok := l.svcCtx.GetTool(toolCall.Function.Name)
 		if !ok {
 			continue
 		}
@@ -381,7 +398,7 @@ func (l *ChatStreamLogic) handleChatStreamInteraction(
 		}
 
 		// 自动执行工具
-		// 特殊处理 RAG 工具：注入用户ID和文件ID
+		// 特殊处理 RAG 工具：注入用户ID、文件ID和知识库ID
 		if toolCall.Function.Name == consts.TOOL_CALLING_SELF_RAG {
 			var argsMap map[string]interface{}
 			err := json.Unmarshal([]byte(toolCall.Function.Arguments), &argsMap)
@@ -390,6 +407,7 @@ func (l *ChatStreamLogic) handleChatStreamInteraction(
 			} else {
 				argsMap["user_id"] = in.UserId
 				argsMap["file_ids"] = in.RagFileIds
+				argsMap["knowledge_base_ids"] = in.KnowledgeBaseIds
 				newArgs, err := json.Marshal(argsMap)
 				if err != nil {
 					l.Logger.Errorf("failed to marshal updated rag tool arguments: %v", err)
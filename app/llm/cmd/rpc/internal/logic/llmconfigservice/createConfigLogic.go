@@ -58,5 +58,6 @@ func createConfigReqToModel(cfg *pb.CreateConfigReq) *model.ChatConfig {
         Seed:              tool.Int64ToNullInt64(cfg.Seed),
         EnableSearch:      tool.Int64ToNullInt64(cfg.EnableSearch),
 		ContextLength:     tool.Int64ToNullInt64(cfg.ContextLength),
+		KnowledgeBaseIds:  tool.Int64sToNullString(cfg.KnowledgeBaseIds),
     }
 }
\ No newline at end of file
@@ -63,5 +63,6 @@ func chatConfigToPb(cfg *model.ChatConfig) *pb.ChatConfig {
         Seed:              tool.NullInt64ToInt64(cfg.Seed),
         EnableSearch:      tool.NullInt64ToInt64(cfg.EnableSearch),
         ContextLength:     tool.NullInt64ToInt64(cfg.ContextLength),
+        KnowledgeBaseIds:  tool.NullStringToInt64s(cfg.KnowledgeBaseIds),
     }
 }
\ No newline at end of file
@@ -0,0 +1,146 @@
+package llmconfigservicelogic
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	llmchatservicelogic "go-zero-voice-agent/app/llm/cmd/rpc/internal/logic/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
+	"go-zero-voice-agent/app/llm/model"
+	"go-zero-voice-agent/pkg/consts"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/sashabaranov/go-openai"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 单次连通性测试的超时时间
+const testConfigTimeout = 15 * time.Second
+
+type TestConfigLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewTestConfigLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TestConfigLogic {
+	return &TestConfigLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// TestConfig 先查询模型列表确认密钥与模型；服务商不支持 /models 时退化为 1 token 的对话请求
+func (l *TestConfigLogic) TestConfig(in *pb.TestConfigReq) (*pb.TestConfigResp, error) {
+	cfg, err := l.svcCtx.ChatConfigModel.FindOne(l.ctx, in.Id)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, pkgerrors.Wrapf(model.ErrNotFound, "config not found, id: %d", in.Id)
+		}
+		return nil, pkgerrors.Wrapf(err, "FindOne config failed, id: %d", in.Id)
+	}
+
+	modelName := cfg.Model.String
+	client, err := llmchatservicelogic.NewOpenAIClient(&pb.LlmConfig{
+		BaseUrl: cfg.BaseUrl.String,
+		ApiKey:  cfg.ApiKey.String,
+		Model:   modelName,
+	})
+	if err != nil || modelName == "" {
+		return &pb.TestConfigResp{
+			ErrorCategory: consts.CREDENTIAL_ERROR_INVALID_CONFIG,
+			ErrorMessage:  "api key and model are required",
+		}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(l.ctx, testConfigTimeout)
+	defer cancel()
+
+	start := time.Now()
+	models, err := client.ListModels(ctx)
+	if err == nil {
+		resp := &pb.TestConfigResp{LatencyMs: time.Since(start).Milliseconds()}
+		for _, m := range models.Models {
+			if m.ID == modelName {
+				resp.ModelAvailable = true
+				break
+			}
+		}
+		resp.Ok = resp.ModelAvailable
+		if !resp.Ok {
+			resp.ErrorCategory = consts.CREDENTIAL_ERROR_NOT_FOUND
+			resp.ErrorMessage = "model not found: " + modelName
+		}
+		return resp, nil
+	}
+	if classifyOpenAIError(err) != consts.CREDENTIAL_ERROR_NOT_FOUND {
+		return testConfigFailed(start, err), nil
+	}
+
+	start = time.Now()
+	_, err = client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:     modelName,
+		Messages:  []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		MaxTokens: 1,
+	})
+	if err != nil {
+		return testConfigFailed(start, err), nil
+	}
+	return &pb.TestConfigResp{
+		Ok:             true,
+		LatencyMs:      time.Since(start).Milliseconds(),
+		ModelAvailable: true,
+	}, nil
+}
+
+func testConfigFailed(start time.Time, err error) *pb.TestConfigResp {
+	return &pb.TestConfigResp{
+		LatencyMs:     time.Since(start).Milliseconds(),
+		ErrorCategory: classifyOpenAIError(err),
+		ErrorMessage:  err.Error(),
+	}
+}
+
+// classifyOpenAIError 将 OpenAI 兼容接口的错误归一化
+func classifyOpenAIError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return consts.CREDENTIAL_ERROR_TIMEOUT
+	}
+
+	status := 0
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		status = reqErr.HTTPStatusCode
+	}
+	switch status {
+	case 0:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return consts.CREDENTIAL_ERROR_AUTH_FAILED
+	case http.StatusNotFound:
+		return consts.CREDENTIAL_ERROR_NOT_FOUND
+	case http.StatusTooManyRequests, http.StatusPaymentRequired:
+		return consts.CREDENTIAL_ERROR_RATE_LIMITED
+	default:
+		return consts.CREDENTIAL_ERROR_PROVIDER
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return consts.CREDENTIAL_ERROR_TIMEOUT
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return consts.CREDENTIAL_ERROR_NETWORK
+	}
+	return consts.CREDENTIAL_ERROR_PROVIDER
+}
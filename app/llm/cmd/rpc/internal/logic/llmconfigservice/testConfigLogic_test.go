@@ -0,0 +1,146 @@
+package llmconfigservicelogic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
+	"go-zero-voice-agent/app/llm/model"
+	"go-zero-voice-agent/pkg/consts"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassifyOpenAIError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"401", &openai.APIError{HTTPStatusCode: http.StatusUnauthorized}, consts.CREDENTIAL_ERROR_AUTH_FAILED},
+		{"403", &openai.RequestError{HTTPStatusCode: http.StatusForbidden}, consts.CREDENTIAL_ERROR_AUTH_FAILED},
+		{"404", &openai.RequestError{HTTPStatusCode: http.StatusNotFound}, consts.CREDENTIAL_ERROR_NOT_FOUND},
+		{"wrapped 404", fmt.Errorf("list models: %w", &openai.APIError{HTTPStatusCode: http.StatusNotFound}), consts.CREDENTIAL_ERROR_NOT_FOUND},
+		{"429", &openai.APIError{HTTPStatusCode: http.StatusTooManyRequests}, consts.CREDENTIAL_ERROR_RATE_LIMITED},
+		{"402", &openai.APIError{HTTPStatusCode: http.StatusPaymentRequired}, consts.CREDENTIAL_ERROR_RATE_LIMITED},
+		{"500", &openai.APIError{HTTPStatusCode: http.StatusInternalServerError}, consts.CREDENTIAL_ERROR_PROVIDER},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded), consts.CREDENTIAL_ERROR_TIMEOUT},
+		{"net timeout", &url.Error{Op: "Get", URL: "http://llm", Err: timeoutError{}}, consts.CREDENTIAL_ERROR_TIMEOUT},
+		{"connection refused", &url.Error{Op: "Get", URL: "http://llm", Err: errors.New("connection refused")}, consts.CREDENTIAL_ERROR_NETWORK},
+		{"other", errors.New("unexpected"), consts.CREDENTIAL_ERROR_PROVIDER},
+	}
+	for _, tt := range tests {
+		if got := classifyOpenAIError(tt.err); got != tt.want {
+			t.Errorf("%s: classifyOpenAIError() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+type fakeChatConfigModel struct {
+	model.ChatConfigModel
+	cfg *model.ChatConfig
+}
+
+func (m *fakeChatConfigModel) FindOne(ctx context.Context, id int64) (*model.ChatConfig, error) {
+	if m.cfg == nil || m.cfg.Id != id {
+		return nil, model.ErrNotFound
+	}
+	return m.cfg, nil
+}
+
+// newTestConfigLogic 配置指向 handler 模拟的 OpenAI 兼容服务
+func newTestConfigLogic(t *testing.T, handler http.HandlerFunc) *TestConfigLogic {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	svcCtx := &svc.ServiceContext{ChatConfigModel: &fakeChatConfigModel{cfg: &model.ChatConfig{
+		Id:      1,
+		BaseUrl: sql.NullString{String: server.URL + "/v1", Valid: true},
+		ApiKey:  sql.NullString{String: "sk-test", Valid: true},
+		Model:   sql.NullString{String: "gpt-test", Valid: true},
+	}}}
+	return NewTestConfigLogic(context.Background(), svcCtx)
+}
+
+func TestTestConfigFallsBackToChatCompletion(t *testing.T) {
+	var completions int
+	logic := newTestConfigLogic(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/models":
+			http.Error(w, `{"error":{"message":"not found"}}`, http.StatusNotFound)
+		case "/v1/chat/completions":
+			completions++
+			var req openai.ChatCompletionRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "gpt-test" || req.MaxTokens != 1 {
+				t.Errorf("unexpected chat completion request: %+v %v", req, err)
+			}
+			_, _ = w.Write([]byte(`{"id":"c1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"h"},"finish_reason":"length"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	resp, err := logic.TestConfig(&pb.TestConfigReq{Id: 1})
+	if err != nil {
+		t.Fatalf("TestConfig failed: %v", err)
+	}
+	if !resp.Ok || !resp.ModelAvailable || resp.ErrorCategory != consts.CREDENTIAL_ERROR_NONE {
+		t.Fatalf("unexpected resp: %+v", resp)
+	}
+	if completions != 1 {
+		t.Fatalf("chat completions called %d times, want 1", completions)
+	}
+}
+
+func TestTestConfigModelNotInList(t *testing.T) {
+	logic := newTestConfigLogic(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, `{"error":{"message":"invalid api key"}}`, http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"gpt-other","object":"model"}]}`))
+	})
+
+	resp, err := logic.TestConfig(&pb.TestConfigReq{Id: 1})
+	if err != nil {
+		t.Fatalf("TestConfig failed: %v", err)
+	}
+	if resp.Ok || resp.ModelAvailable || resp.ErrorCategory != consts.CREDENTIAL_ERROR_NOT_FOUND || resp.ErrorMessage != "model not found: gpt-test" {
+		t.Fatalf("unexpected resp: %+v", resp)
+	}
+}
+
+func TestTestConfigAuthFailed(t *testing.T) {
+	logic := newTestConfigLogic(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("auth failure should not fall back to %s", r.URL.Path)
+		}
+		http.Error(w, `{"error":{"message":"invalid api key"}}`, http.StatusUnauthorized)
+	})
+
+	resp, err := logic.TestConfig(&pb.TestConfigReq{Id: 1})
+	if err != nil {
+		t.Fatalf("TestConfig failed: %v", err)
+	}
+	if resp.Ok || resp.ErrorCategory != consts.CREDENTIAL_ERROR_AUTH_FAILED {
+		t.Fatalf("unexpected resp: %+v", resp)
+	}
+}
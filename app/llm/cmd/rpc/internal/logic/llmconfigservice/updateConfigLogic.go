@@ -6,6 +6,7 @@ import (
 	"go-zero-voice-agent/app/llm/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
 	"go-zero-voice-agent/app/llm/model"
+	"go-zero-voice-agent/pkg/secretbox"
 	"go-zero-voice-agent/pkg/tool"
 
 	"github.com/pkg/errors"
@@ -27,7 +28,7 @@ func NewUpdateConfigLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Upda
 }
 
 func (l *UpdateConfigLogic) UpdateConfig(in *pb.UpdateConfigReq) (*pb.UpdateConfigResp, error) {
-	_, err := l.svcCtx.ChatConfigModel.FindOne(l.ctx, in.Id)
+	old, err := l.svcCtx.ChatConfigModel.FindOne(l.ctx, in.Id)
     if err != nil {
         if err == model.ErrNotFound {
             return nil, errors.Wrapf(model.ErrNotFound, "config not found, id: %d", in.Id)
@@ -36,6 +37,10 @@ func (l *UpdateConfigLogic) UpdateConfig(in *pb.UpdateConfigReq) (*pb.UpdateConf
     }
 
     chatConfig := updateConfigReqToModel(in)
+    // api key 只写不读：未传或传回脱敏值时沿用已保存的 key
+    if secretbox.Unchanged(in.ApiKey) {
+        chatConfig.ApiKey = old.ApiKey
+    }
     // err = l.svcCtx.ChatConfigModel.Update(l.ctx, chatConfig)
 	err = l.svcCtx.ChatConfigModel.UpdateWithVersion(l.ctx, nil, chatConfig)
     if err != nil {
@@ -66,5 +71,6 @@ func updateConfigReqToModel(cfg *pb.UpdateConfigReq) *model.ChatConfig {
         Seed:              tool.Int64ToNullInt64(cfg.Seed),
         EnableSearch:      tool.Int64ToNullInt64(cfg.EnableSearch),
         ContextLength:     tool.Int64ToNullInt64(cfg.ContextLength),
+        KnowledgeBaseIds:  tool.Int64sToNullString(cfg.KnowledgeBaseIds),
     }
 }
\ No newline at end of file
@@ -47,3 +47,8 @@ func (s *LlmConfigServiceServer) ListConfig(ctx context.Context, in *pb.ListConf
 	l := llmconfigservicelogic.NewListConfigLogic(ctx, s.svcCtx)
 	return l.ListConfig(in)
 }
+
+func (s *LlmConfigServiceServer) TestConfig(ctx context.Context, in *pb.TestConfigReq) (*pb.TestConfigResp, error) {
+	l := llmconfigservicelogic.NewTestConfigLogic(ctx, s.svcCtx)
+	return l.TestConfig(in)
+}
@@ -9,6 +9,7 @@ import (
 	"go-zero-voice-agent/app/llm/model"
 	"go-zero-voice-agent/app/mqueue/cmd/job/jobtype"
 	"go-zero-voice-agent/app/rag/cmd/rpc/client/ragservice"
+	"go-zero-voice-agent/pkg/secretbox"
 	"go-zero-voice-agent/pkg/uniqueid"
 	"time"
 
@@ -36,6 +37,7 @@ type ServiceContext struct {
 	RagRpc ragservice.RagService
 
 	ToolRegistry                 map[string]toolcall.Tool
+	OptionalToolRegistry         map[string]toolcall.Tool // 按需启用的工具，仅在请求声明 enabledTools 时提供给模型
 	OpenaiToolList               []openai.Tool
 	OpenaiToolListWithoutConfirm []openai.Tool
 }
@@ -61,19 +63,22 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		DB:       c.Asynq.DB,
 	})
 
+	secrets := secretbox.MustNewKeyring(c.SecretConfig)
+
 	ragRpcClient := ragservice.NewRagService(zrpc.MustNewClient(c.RagRpcConf))
 
 	svcCtx := &ServiceContext{
 		Config:           c,
 		RedisClient:      redisClient,
 		AsynqClient:      asynqClient,
-		ChatConfigModel:  model.NewChatConfigModel(sqlConn, c.Cache),
+		ChatConfigModel:  model.NewChatConfigModel(sqlConn, c.Cache, secrets),
 		ChatSessionModel: model.NewChatSessionModel(sqlConn, c.Cache),
 		ChatMessageModel: model.NewChatMessageModel(sqlConn, c.Cache),
 		RagRpc:           ragRpcClient,
 	}
 
 	svcCtx.ToolRegistry = newToolRegistry(svcCtx)
+	svcCtx.OptionalToolRegistry = newOptionalToolRegistry()
 	svcCtx.OpenaiToolList = svcCtx.getOpenaiToolList()
 	svcCtx.OpenaiToolListWithoutConfirm = svcCtx.getOpenaiToolListWithoutConfirm()
 
@@ -101,6 +106,50 @@ func newToolRegistry(svcCtx *ServiceContext) map[string]toolcall.Tool {
 	return registry
 }
 
+func newOptionalToolRegistry() map[string]toolcall.Tool {
+	registry := make(map[string]toolcall.Tool)
+
+	endCallTool := toolcall.NewEndCallTool()
+	registry[endCallTool.Name()] = endCallTool
+
+	return registry
+}
+
+// GetTool 按名称查找工具，包括按需启用的工具
+func (svc *ServiceContext) GetTool(name string) (toolcall.Tool, bool) {
+	if tool, ok := svc.ToolRegistry[name]; ok {
+		return tool, true
+	}
+	tool, ok := svc.OptionalToolRegistry[name]
+	return tool, ok
+}
+
+// GetOpenaiToolList 返回默认工具列表，并追加请求中声明启用的按需工具
+func (svc *ServiceContext) GetOpenaiToolList(enabledTools []string) []openai.Tool {
+	if len(enabledTools) == 0 {
+		return svc.OpenaiToolList
+	}
+
+	toolList := make([]openai.Tool, 0, len(svc.OpenaiToolList)+len(enabledTools))
+	toolList = append(toolList, svc.OpenaiToolList...)
+	for _, name := range enabledTools {
+		tool, ok := svc.OptionalToolRegistry[name]
+		if !ok {
+			continue
+		}
+		toolList = append(toolList, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  tool.ArgumentsJson(),
+			},
+		})
+	}
+
+	return toolList
+}
+
 func (svc *ServiceContext) getOpenaiToolList() []openai.Tool {
 	toolList := make([]openai.Tool, 0, len(svc.ToolRegistry))
 	for _, tool := range svc.ToolRegistry {
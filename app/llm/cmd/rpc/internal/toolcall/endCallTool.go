@@ -0,0 +1,46 @@
+package toolcall
+
+import (
+	"context"
+	"go-zero-voice-agent/app/llm/pkg/consts"
+)
+
+// EndCallTool 语音通话中由模型主动结束通话，由语音客户端执行挂断
+type EndCallTool struct{}
+
+func NewEndCallTool() *EndCallTool {
+	return &EndCallTool{}
+}
+
+func (t *EndCallTool) Name() string {
+	return consts.TOOL_CALLING_END_CALL
+}
+
+func (t *EndCallTool) Description() string {
+	return "结束当前语音通话。当用户明确表示要结束通话（如说再见、挂了吧）或对话已经完成时调用，调用前请先用一句话和用户道别。"
+}
+
+func (t *EndCallTool) ArgumentsJson() string {
+	return `{
+  "type": "object",
+  "properties": {
+    "reason": {
+      "type": "string",
+      "description": "结束通话的原因，简要说明"
+    }
+  },
+  "required": []
+}`
+}
+
+func (t *EndCallTool) Scope() string {
+	return consts.TOOL_CALLING_SCOPE_CLIENT
+}
+
+func (t *EndCallTool) RequiresConfirmation() bool {
+	return false
+}
+
+func (t *EndCallTool) Execute(ctx context.Context, argsJson string) (string, error) {
+	return "Call ended by client.", nil
+}
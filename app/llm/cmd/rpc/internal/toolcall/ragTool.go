@@ -18,7 +18,9 @@ type RagToolParams struct {
 	Query   string   `json:"query"`
 	UserId  int64    `json:"user_id"`
 	FileIds []string `json:"file_ids"`
-	TopK    int32    `json:"top_k"`
+	// 知识库由 RAG 服务解析为其中的文件并校验归属
+	KnowledgeBaseIds []int64 `json:"knowledge_base_ids"`
+	TopK             int32   `json:"top_k"`
 }
 
 func NewRagTool(ragClient ragservice.RagService) *RagTool {
@@ -60,10 +62,11 @@ func (t *RagTool) Execute(ctx context.Context, argsJson string) (string, error)
 
 	// 调用 RAG RPC 服务
 	resp, err := t.ragClient.QueryMultiple(ctx, &pb.QueryMultipleReq{
-		Query:   params.Query,
-		UserId:  params.UserId,
-		FileIds: params.FileIds,
-		TopK:    params.TopK,
+		Query:            params.Query,
+		UserId:           params.UserId,
+		FileIds:          params.FileIds,
+		KnowledgeBaseIds: params.KnowledgeBaseIds,
+		TopK:             params.TopK,
 	})
 	if err != nil {
 		return "", fmt.Errorf("调用 RAG 服务失败: %w", err)
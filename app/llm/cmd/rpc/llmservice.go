@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 
@@ -22,6 +23,7 @@ import (
 )
 
 var configFile = flag.String("f", "etc/llmservice.yaml", "the config file")
+var rotateSecrets = flag.Bool("rotate-secrets", false, "re-encrypt stored secrets with the current master key and exit")
 
 func main() {
 	flag.Parse()
@@ -34,6 +36,15 @@ func main() {
 	conf.MustLoad(*configFile, &c, conf.UseEnv())
 	ctx := svc.NewServiceContext(c)
 
+	if *rotateSecrets {
+		n, err := ctx.ChatConfigModel.RotateSecrets(context.Background())
+		if err != nil {
+			logx.Must(fmt.Errorf("rotate chat_config secrets failed after %d rows: %w", n, err))
+		}
+		fmt.Printf("rotated %d chat_config rows\n", n)
+		return
+	}
+
 	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		pb.RegisterLlmChatServiceServer(grpcServer, llmchatserviceServer.NewLlmChatServiceServer(ctx))
 		pb.RegisterLlmConfigServiceServer(grpcServer, llmconfigserviceServer.NewLlmConfigServiceServer(ctx))
@@ -543,9 +543,11 @@ type ChatReq struct {
 	// 当创建新会话时的上下文消息（继续会话时可选）
 	Messages []*ChatMsg `protobuf:"bytes,5,rep,name=messages,proto3" json:"messages,omitempty"`
 	// 指定的rag知识库文件ID列表（可选）
-	RagFileIds    []string `protobuf:"bytes,6,rep,name=ragFileIds,proto3" json:"ragFileIds,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	RagFileIds []string `protobuf:"bytes,6,rep,name=ragFileIds,proto3" json:"ragFileIds,omitempty"`
+	// 指定的rag知识库ID列表，服务端解析为其中的文件并校验归属（可选）
+	KnowledgeBaseIds []int64 `protobuf:"varint,7,rep,packed,name=knowledgeBaseIds,proto3" json:"knowledgeBaseIds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ChatReq) Reset() {
@@ -620,6 +622,13 @@ func (x *ChatReq) GetRagFileIds() []string {
 	return nil
 }
 
+func (x *ChatReq) GetKnowledgeBaseIds() []int64 {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
 type ChatResp struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
@@ -685,7 +694,15 @@ type ChatStreamReq struct {
 	// 当创建新会话时的上下文消息（继续会话时可选）
 	Messages []*ChatMsg `protobuf:"bytes,5,rep,name=messages,proto3" json:"messages,omitempty"`
 	// 指定的rag知识库文件ID列表（可选）
-	RagFileIds    []string `protobuf:"bytes,6,rep,name=ragFileIds,proto3" json:"ragFileIds,omitempty"`
+	RagFileIds []string `protobuf:"bytes,6,rep,name=ragFileIds,proto3" json:"ragFileIds,omitempty"`
+	// 额外启用的按需工具，如语音通话中的 end_call（可选）
+	EnabledTools []string `protobuf:"bytes,7,rep,name=enabledTools,proto3" json:"enabledTools,omitempty"`
+	// 指定的rag知识库ID列表，服务端解析为其中的文件并校验归属（可选）
+	KnowledgeBaseIds []int64 `protobuf:"varint,8,rep,packed,name=knowledgeBaseIds,proto3" json:"knowledgeBaseIds,omitempty"`
+	// 只记录 messages 中的工具调用结果、不请求模型（可选），如语音通话 end_call 挂断前补齐工具结果
+	RecordOnly bool `protobuf:"varint,9,opt,name=recordOnly,proto3" json:"recordOnly,omitempty"`
+	// 不携带任何工具（可选），如语音房间里智能体只做问答、无法逐个征求确认
+	DisableTools  bool `protobuf:"varint,10,opt,name=disableTools,proto3" json:"disableTools,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -762,6 +779,34 @@ func (x *ChatStreamReq) GetRagFileIds() []string {
 	return nil
 }
 
+func (x *ChatStreamReq) GetEnabledTools() []string {
+	if x != nil {
+		return x.EnabledTools
+	}
+	return nil
+}
+
+func (x *ChatStreamReq) GetKnowledgeBaseIds() []int64 {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
+func (x *ChatStreamReq) GetRecordOnly() bool {
+	if x != nil {
+		return x.RecordOnly
+	}
+	return false
+}
+
+func (x *ChatStreamReq) GetDisableTools() bool {
+	if x != nil {
+		return x.DisableTools
+	}
+	return false
+}
+
 type ChatStreamResp struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
@@ -851,8 +896,10 @@ type ChatConfig struct {
 	Seed              int64                  `protobuf:"varint,16,opt,name=seed,proto3" json:"seed,omitempty"`
 	EnableSearch      int64                  `protobuf:"varint,17,opt,name=enableSearch,proto3" json:"enableSearch,omitempty"`
 	ContextLength     int64                  `protobuf:"varint,18,opt,name=contextLength,proto3" json:"contextLength,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// 对话默认检索的rag知识库ID列表
+	KnowledgeBaseIds []int64 `protobuf:"varint,19,rep,packed,name=knowledgeBaseIds,proto3" json:"knowledgeBaseIds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ChatConfig) Reset() {
@@ -1011,6 +1058,13 @@ func (x *ChatConfig) GetContextLength() int64 {
 	return 0
 }
 
+func (x *ChatConfig) GetKnowledgeBaseIds() []int64 {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
 // Create
 type CreateConfigReq struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -1031,6 +1085,7 @@ type CreateConfigReq struct {
 	Seed              int64                  `protobuf:"varint,15,opt,name=seed,proto3" json:"seed,omitempty"`
 	EnableSearch      int64                  `protobuf:"varint,16,opt,name=enableSearch,proto3" json:"enableSearch,omitempty"`
 	ContextLength     int64                  `protobuf:"varint,17,opt,name=contextLength,proto3" json:"contextLength,omitempty"`
+	KnowledgeBaseIds  []int64                `protobuf:"varint,18,rep,packed,name=knowledgeBaseIds,proto3" json:"knowledgeBaseIds,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -1184,6 +1239,13 @@ func (x *CreateConfigReq) GetContextLength() int64 {
 	return 0
 }
 
+func (x *CreateConfigReq) GetKnowledgeBaseIds() []int64 {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
 type CreateConfigResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1330,6 +1392,7 @@ type UpdateConfigReq struct {
 	Seed              int64                  `protobuf:"varint,16,opt,name=seed,proto3" json:"seed,omitempty"`
 	EnableSearch      int64                  `protobuf:"varint,17,opt,name=enableSearch,proto3" json:"enableSearch,omitempty"`
 	ContextLength     int64                  `protobuf:"varint,18,opt,name=contextLength,proto3" json:"contextLength,omitempty"`
+	KnowledgeBaseIds  []int64                `protobuf:"varint,19,rep,packed,name=knowledgeBaseIds,proto3" json:"knowledgeBaseIds,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -1490,6 +1553,13 @@ func (x *UpdateConfigReq) GetContextLength() int64 {
 	return 0
 }
 
+func (x *UpdateConfigReq) GetKnowledgeBaseIds() []int64 {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
 type UpdateConfigResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1615,6 +1685,127 @@ func (x *GetConfigResp) GetConfig() *ChatConfig {
 	return nil
 }
 
+// Test 使用配置向模型服务发起最小请求，检查密钥、地址与模型是否可用
+type TestConfigReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestConfigReq) Reset() {
+	*x = TestConfigReq{}
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestConfigReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestConfigReq) ProtoMessage() {}
+
+func (x *TestConfigReq) ProtoReflect() protoreflect.Message {
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestConfigReq.ProtoReflect.Descriptor instead.
+func (*TestConfigReq) Descriptor() ([]byte, []int) {
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TestConfigReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type TestConfigResp struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ok             bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	LatencyMs      int64                  `protobuf:"varint,2,opt,name=latencyMs,proto3" json:"latencyMs,omitempty"`
+	ModelAvailable bool                   `protobuf:"varint,3,opt,name=modelAvailable,proto3" json:"modelAvailable,omitempty"` // 配置的模型是否在服务商的模型列表中
+	ErrorCategory  string                 `protobuf:"bytes,4,opt,name=errorCategory,proto3" json:"errorCategory,omitempty"`    // 归一化错误类型，见 pkg/consts/credential.go
+	ErrorMessage   string                 `protobuf:"bytes,5,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TestConfigResp) Reset() {
+	*x = TestConfigResp{}
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestConfigResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestConfigResp) ProtoMessage() {}
+
+func (x *TestConfigResp) ProtoReflect() protoreflect.Message {
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestConfigResp.ProtoReflect.Descriptor instead.
+func (*TestConfigResp) Descriptor() ([]byte, []int) {
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TestConfigResp) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *TestConfigResp) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *TestConfigResp) GetModelAvailable() bool {
+	if x != nil {
+		return x.ModelAvailable
+	}
+	return false
+}
+
+func (x *TestConfigResp) GetErrorCategory() string {
+	if x != nil {
+		return x.ErrorCategory
+	}
+	return ""
+}
+
+func (x *TestConfigResp) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
 // List
 type ListConfigFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1628,7 +1819,7 @@ type ListConfigFilter struct {
 
 func (x *ListConfigFilter) Reset() {
 	*x = ListConfigFilter{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[19]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1640,7 +1831,7 @@ func (x *ListConfigFilter) String() string {
 func (*ListConfigFilter) ProtoMessage() {}
 
 func (x *ListConfigFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[19]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1653,7 +1844,7 @@ func (x *ListConfigFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConfigFilter.ProtoReflect.Descriptor instead.
 func (*ListConfigFilter) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{19}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListConfigFilter) GetId() int64 {
@@ -1694,7 +1885,7 @@ type ListConfigReq struct {
 
 func (x *ListConfigReq) Reset() {
 	*x = ListConfigReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[20]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1706,7 +1897,7 @@ func (x *ListConfigReq) String() string {
 func (*ListConfigReq) ProtoMessage() {}
 
 func (x *ListConfigReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[20]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1719,7 +1910,7 @@ func (x *ListConfigReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConfigReq.ProtoReflect.Descriptor instead.
 func (*ListConfigReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{20}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ListConfigReq) GetPageQuery() *PageQuery {
@@ -1746,7 +1937,7 @@ type ListConfigResp struct {
 
 func (x *ListConfigResp) Reset() {
 	*x = ListConfigResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[21]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1758,7 +1949,7 @@ func (x *ListConfigResp) String() string {
 func (*ListConfigResp) ProtoMessage() {}
 
 func (x *ListConfigResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[21]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1771,7 +1962,7 @@ func (x *ListConfigResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConfigResp.ProtoReflect.Descriptor instead.
 func (*ListConfigResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{21}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListConfigResp) GetTotal() int64 {
@@ -1805,7 +1996,7 @@ type ChatSession struct {
 
 func (x *ChatSession) Reset() {
 	*x = ChatSession{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[22]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1817,7 +2008,7 @@ func (x *ChatSession) String() string {
 func (*ChatSession) ProtoMessage() {}
 
 func (x *ChatSession) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[22]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1830,7 +2021,7 @@ func (x *ChatSession) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatSession.ProtoReflect.Descriptor instead.
 func (*ChatSession) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{22}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ChatSession) GetId() int64 {
@@ -1907,7 +2098,7 @@ type CreateChatSessionReq struct {
 
 func (x *CreateChatSessionReq) Reset() {
 	*x = CreateChatSessionReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[23]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1919,7 +2110,7 @@ func (x *CreateChatSessionReq) String() string {
 func (*CreateChatSessionReq) ProtoMessage() {}
 
 func (x *CreateChatSessionReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[23]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1932,7 +2123,7 @@ func (x *CreateChatSessionReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateChatSessionReq.ProtoReflect.Descriptor instead.
 func (*CreateChatSessionReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{23}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CreateChatSessionReq) GetConvId() string {
@@ -1965,7 +2156,7 @@ type CreateChatSessionResp struct {
 
 func (x *CreateChatSessionResp) Reset() {
 	*x = CreateChatSessionResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[24]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1977,7 +2168,7 @@ func (x *CreateChatSessionResp) String() string {
 func (*CreateChatSessionResp) ProtoMessage() {}
 
 func (x *CreateChatSessionResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[24]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1990,7 +2181,7 @@ func (x *CreateChatSessionResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateChatSessionResp.ProtoReflect.Descriptor instead.
 func (*CreateChatSessionResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{24}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CreateChatSessionResp) GetId() int64 {
@@ -2009,7 +2200,7 @@ type DeleteChatSessionReq struct {
 
 func (x *DeleteChatSessionReq) Reset() {
 	*x = DeleteChatSessionReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[25]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2021,7 +2212,7 @@ func (x *DeleteChatSessionReq) String() string {
 func (*DeleteChatSessionReq) ProtoMessage() {}
 
 func (x *DeleteChatSessionReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[25]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2034,7 +2225,7 @@ func (x *DeleteChatSessionReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteChatSessionReq.ProtoReflect.Descriptor instead.
 func (*DeleteChatSessionReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{25}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DeleteChatSessionReq) GetId() int64 {
@@ -2052,7 +2243,7 @@ type DeleteChatSessionResp struct {
 
 func (x *DeleteChatSessionResp) Reset() {
 	*x = DeleteChatSessionResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[26]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2064,7 +2255,7 @@ func (x *DeleteChatSessionResp) String() string {
 func (*DeleteChatSessionResp) ProtoMessage() {}
 
 func (x *DeleteChatSessionResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[26]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2077,7 +2268,7 @@ func (x *DeleteChatSessionResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteChatSessionResp.ProtoReflect.Descriptor instead.
 func (*DeleteChatSessionResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{26}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{28}
 }
 
 type UpdateChatSessionReq struct {
@@ -2093,7 +2284,7 @@ type UpdateChatSessionReq struct {
 
 func (x *UpdateChatSessionReq) Reset() {
 	*x = UpdateChatSessionReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[27]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2105,7 +2296,7 @@ func (x *UpdateChatSessionReq) String() string {
 func (*UpdateChatSessionReq) ProtoMessage() {}
 
 func (x *UpdateChatSessionReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[27]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2118,7 +2309,7 @@ func (x *UpdateChatSessionReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateChatSessionReq.ProtoReflect.Descriptor instead.
 func (*UpdateChatSessionReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{27}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *UpdateChatSessionReq) GetId() int64 {
@@ -2164,7 +2355,7 @@ type UpdateChatSessionResp struct {
 
 func (x *UpdateChatSessionResp) Reset() {
 	*x = UpdateChatSessionResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[28]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2176,7 +2367,7 @@ func (x *UpdateChatSessionResp) String() string {
 func (*UpdateChatSessionResp) ProtoMessage() {}
 
 func (x *UpdateChatSessionResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[28]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2189,7 +2380,7 @@ func (x *UpdateChatSessionResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateChatSessionResp.ProtoReflect.Descriptor instead.
 func (*UpdateChatSessionResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{28}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{30}
 }
 
 type GetChatSessionReq struct {
@@ -2201,7 +2392,7 @@ type GetChatSessionReq struct {
 
 func (x *GetChatSessionReq) Reset() {
 	*x = GetChatSessionReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[29]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2213,7 +2404,7 @@ func (x *GetChatSessionReq) String() string {
 func (*GetChatSessionReq) ProtoMessage() {}
 
 func (x *GetChatSessionReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[29]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2226,7 +2417,7 @@ func (x *GetChatSessionReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChatSessionReq.ProtoReflect.Descriptor instead.
 func (*GetChatSessionReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{29}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetChatSessionReq) GetId() int64 {
@@ -2245,7 +2436,7 @@ type GetChatSessionByConvIdReq struct {
 
 func (x *GetChatSessionByConvIdReq) Reset() {
 	*x = GetChatSessionByConvIdReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[30]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2257,7 +2448,7 @@ func (x *GetChatSessionByConvIdReq) String() string {
 func (*GetChatSessionByConvIdReq) ProtoMessage() {}
 
 func (x *GetChatSessionByConvIdReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[30]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2270,7 +2461,7 @@ func (x *GetChatSessionByConvIdReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChatSessionByConvIdReq.ProtoReflect.Descriptor instead.
 func (*GetChatSessionByConvIdReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{30}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetChatSessionByConvIdReq) GetConvId() string {
@@ -2289,7 +2480,7 @@ type GetChatSessionResp struct {
 
 func (x *GetChatSessionResp) Reset() {
 	*x = GetChatSessionResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[31]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2301,7 +2492,7 @@ func (x *GetChatSessionResp) String() string {
 func (*GetChatSessionResp) ProtoMessage() {}
 
 func (x *GetChatSessionResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[31]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2314,7 +2505,7 @@ func (x *GetChatSessionResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChatSessionResp.ProtoReflect.Descriptor instead.
 func (*GetChatSessionResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{31}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetChatSessionResp) GetSession() *ChatSession {
@@ -2336,7 +2527,7 @@ type ListChatSessionFilter struct {
 
 func (x *ListChatSessionFilter) Reset() {
 	*x = ListChatSessionFilter{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[32]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2348,7 +2539,7 @@ func (x *ListChatSessionFilter) String() string {
 func (*ListChatSessionFilter) ProtoMessage() {}
 
 func (x *ListChatSessionFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[32]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2361,7 +2552,7 @@ func (x *ListChatSessionFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListChatSessionFilter.ProtoReflect.Descriptor instead.
 func (*ListChatSessionFilter) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{32}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ListChatSessionFilter) GetId() int64 {
@@ -2402,7 +2593,7 @@ type ListChatSessionReq struct {
 
 func (x *ListChatSessionReq) Reset() {
 	*x = ListChatSessionReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[33]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2414,7 +2605,7 @@ func (x *ListChatSessionReq) String() string {
 func (*ListChatSessionReq) ProtoMessage() {}
 
 func (x *ListChatSessionReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[33]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2427,7 +2618,7 @@ func (x *ListChatSessionReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListChatSessionReq.ProtoReflect.Descriptor instead.
 func (*ListChatSessionReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{33}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ListChatSessionReq) GetPageQuery() *PageQuery {
@@ -2454,7 +2645,7 @@ type ListChatSessionResp struct {
 
 func (x *ListChatSessionResp) Reset() {
 	*x = ListChatSessionResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[34]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2466,7 +2657,7 @@ func (x *ListChatSessionResp) String() string {
 func (*ListChatSessionResp) ProtoMessage() {}
 
 func (x *ListChatSessionResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[34]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2479,7 +2670,7 @@ func (x *ListChatSessionResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListChatSessionResp.ProtoReflect.Descriptor instead.
 func (*ListChatSessionResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{34}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ListChatSessionResp) GetTotal() int64 {
@@ -2512,7 +2703,7 @@ type ChatMessage struct {
 
 func (x *ChatMessage) Reset() {
 	*x = ChatMessage{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[35]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2524,7 +2715,7 @@ func (x *ChatMessage) String() string {
 func (*ChatMessage) ProtoMessage() {}
 
 func (x *ChatMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[35]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2537,7 +2728,7 @@ func (x *ChatMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
 func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{35}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ChatMessage) GetId() int64 {
@@ -2611,7 +2802,7 @@ type CreateChatMessageReq struct {
 
 func (x *CreateChatMessageReq) Reset() {
 	*x = CreateChatMessageReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[36]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2623,7 +2814,7 @@ func (x *CreateChatMessageReq) String() string {
 func (*CreateChatMessageReq) ProtoMessage() {}
 
 func (x *CreateChatMessageReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[36]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2636,7 +2827,7 @@ func (x *CreateChatMessageReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateChatMessageReq.ProtoReflect.Descriptor instead.
 func (*CreateChatMessageReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{36}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *CreateChatMessageReq) GetId() int64 {
@@ -2697,7 +2888,7 @@ type CreateChatMessageResp struct {
 
 func (x *CreateChatMessageResp) Reset() {
 	*x = CreateChatMessageResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[37]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2709,7 +2900,7 @@ func (x *CreateChatMessageResp) String() string {
 func (*CreateChatMessageResp) ProtoMessage() {}
 
 func (x *CreateChatMessageResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[37]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2722,7 +2913,7 @@ func (x *CreateChatMessageResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateChatMessageResp.ProtoReflect.Descriptor instead.
 func (*CreateChatMessageResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{37}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *CreateChatMessageResp) GetId() int64 {
@@ -2742,7 +2933,7 @@ type DeleteChatMessageReq struct {
 
 func (x *DeleteChatMessageReq) Reset() {
 	*x = DeleteChatMessageReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[38]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2754,7 +2945,7 @@ func (x *DeleteChatMessageReq) String() string {
 func (*DeleteChatMessageReq) ProtoMessage() {}
 
 func (x *DeleteChatMessageReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[38]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2767,7 +2958,7 @@ func (x *DeleteChatMessageReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteChatMessageReq.ProtoReflect.Descriptor instead.
 func (*DeleteChatMessageReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{38}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *DeleteChatMessageReq) GetId() int64 {
@@ -2792,7 +2983,7 @@ type DeleteChatMessageResp struct {
 
 func (x *DeleteChatMessageResp) Reset() {
 	*x = DeleteChatMessageResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[39]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2804,7 +2995,7 @@ func (x *DeleteChatMessageResp) String() string {
 func (*DeleteChatMessageResp) ProtoMessage() {}
 
 func (x *DeleteChatMessageResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[39]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2817,7 +3008,7 @@ func (x *DeleteChatMessageResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteChatMessageResp.ProtoReflect.Descriptor instead.
 func (*DeleteChatMessageResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{39}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{41}
 }
 
 type UpdateChatMessageReq struct {
@@ -2835,7 +3026,7 @@ type UpdateChatMessageReq struct {
 
 func (x *UpdateChatMessageReq) Reset() {
 	*x = UpdateChatMessageReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[40]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2847,7 +3038,7 @@ func (x *UpdateChatMessageReq) String() string {
 func (*UpdateChatMessageReq) ProtoMessage() {}
 
 func (x *UpdateChatMessageReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[40]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2860,7 +3051,7 @@ func (x *UpdateChatMessageReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateChatMessageReq.ProtoReflect.Descriptor instead.
 func (*UpdateChatMessageReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{40}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *UpdateChatMessageReq) GetId() int64 {
@@ -2920,7 +3111,7 @@ type UpdateChatMessageResp struct {
 
 func (x *UpdateChatMessageResp) Reset() {
 	*x = UpdateChatMessageResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[41]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2932,7 +3123,7 @@ func (x *UpdateChatMessageResp) String() string {
 func (*UpdateChatMessageResp) ProtoMessage() {}
 
 func (x *UpdateChatMessageResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[41]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2945,7 +3136,7 @@ func (x *UpdateChatMessageResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateChatMessageResp.ProtoReflect.Descriptor instead.
 func (*UpdateChatMessageResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{41}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{43}
 }
 
 type GetChatMessageReq struct {
@@ -2957,7 +3148,7 @@ type GetChatMessageReq struct {
 
 func (x *GetChatMessageReq) Reset() {
 	*x = GetChatMessageReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[42]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2969,7 +3160,7 @@ func (x *GetChatMessageReq) String() string {
 func (*GetChatMessageReq) ProtoMessage() {}
 
 func (x *GetChatMessageReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[42]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2982,7 +3173,7 @@ func (x *GetChatMessageReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChatMessageReq.ProtoReflect.Descriptor instead.
 func (*GetChatMessageReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{42}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *GetChatMessageReq) GetId() int64 {
@@ -3001,7 +3192,7 @@ type GetChatMessageResp struct {
 
 func (x *GetChatMessageResp) Reset() {
 	*x = GetChatMessageResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[43]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3013,7 +3204,7 @@ func (x *GetChatMessageResp) String() string {
 func (*GetChatMessageResp) ProtoMessage() {}
 
 func (x *GetChatMessageResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[43]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3026,7 +3217,7 @@ func (x *GetChatMessageResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChatMessageResp.ProtoReflect.Descriptor instead.
 func (*GetChatMessageResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{43}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *GetChatMessageResp) GetMessage() *ChatMessage {
@@ -3047,7 +3238,7 @@ type ListChatMessageFilter struct {
 
 func (x *ListChatMessageFilter) Reset() {
 	*x = ListChatMessageFilter{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[44]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3059,7 +3250,7 @@ func (x *ListChatMessageFilter) String() string {
 func (*ListChatMessageFilter) ProtoMessage() {}
 
 func (x *ListChatMessageFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[44]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3072,7 +3263,7 @@ func (x *ListChatMessageFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListChatMessageFilter.ProtoReflect.Descriptor instead.
 func (*ListChatMessageFilter) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{44}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *ListChatMessageFilter) GetId() int64 {
@@ -3106,7 +3297,7 @@ type ListChatMessageReq struct {
 
 func (x *ListChatMessageReq) Reset() {
 	*x = ListChatMessageReq{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[45]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3118,7 +3309,7 @@ func (x *ListChatMessageReq) String() string {
 func (*ListChatMessageReq) ProtoMessage() {}
 
 func (x *ListChatMessageReq) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[45]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3131,7 +3322,7 @@ func (x *ListChatMessageReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListChatMessageReq.ProtoReflect.Descriptor instead.
 func (*ListChatMessageReq) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{45}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *ListChatMessageReq) GetPageQuery() *PageQuery {
@@ -3158,7 +3349,7 @@ type ListChatMessageResp struct {
 
 func (x *ListChatMessageResp) Reset() {
 	*x = ListChatMessageResp{}
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[46]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3170,7 +3361,7 @@ func (x *ListChatMessageResp) String() string {
 func (*ListChatMessageResp) ProtoMessage() {}
 
 func (x *ListChatMessageResp) ProtoReflect() protoreflect.Message {
-	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[46]
+	mi := &file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3183,7 +3374,7 @@ func (x *ListChatMessageResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListChatMessageResp.ProtoReflect.Descriptor instead.
 func (*ListChatMessageResp) Descriptor() ([]byte, []int) {
-	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{46}
+	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *ListChatMessageResp) GetTotal() int64 {
@@ -3247,7 +3438,7 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\n" +
 	"toolCallId\x18\x04 \x01(\tR\n" +
 	"toolCallId\x12\x1c\n" +
-	"\tmessageId\x18\x05 \x01(\x03R\tmessageId\"\xeb\x01\n" +
+	"\tmessageId\x18\x05 \x01(\x03R\tmessageId\"\x97\x02\n" +
 	"\aChatReq\x12&\n" +
 	"\x0econversationId\x18\x01 \x01(\tR\x0econversationId\x12\x16\n" +
 	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12,\n" +
@@ -3256,10 +3447,11 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\bmessages\x18\x05 \x03(\v2\f.llm.ChatMsgR\bmessages\x12\x1e\n" +
 	"\n" +
 	"ragFileIds\x18\x06 \x03(\tR\n" +
-	"ragFileIds\"Z\n" +
+	"ragFileIds\x12*\n" +
+	"\x10knowledgeBaseIds\x18\a \x03(\x03R\x10knowledgeBaseIds\"Z\n" +
 	"\bChatResp\x12&\n" +
 	"\x0econversationId\x18\x01 \x01(\tR\x0econversationId\x12&\n" +
-	"\arespMsg\x18\x02 \x01(\v2\f.llm.ChatMsgR\arespMsg\"\xf1\x01\n" +
+	"\arespMsg\x18\x02 \x01(\v2\f.llm.ChatMsgR\arespMsg\"\x85\x03\n" +
 	"\rChatStreamReq\x12&\n" +
 	"\x0econversationId\x18\x01 \x01(\tR\x0econversationId\x12\x16\n" +
 	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12,\n" +
@@ -3268,14 +3460,21 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\bmessages\x18\x05 \x03(\v2\f.llm.ChatMsgR\bmessages\x12\x1e\n" +
 	"\n" +
 	"ragFileIds\x18\x06 \x03(\tR\n" +
-	"ragFileIds\"\x96\x01\n" +
+	"ragFileIds\x12\"\n" +
+	"\fenabledTools\x18\a \x03(\tR\fenabledTools\x12*\n" +
+	"\x10knowledgeBaseIds\x18\b \x03(\x03R\x10knowledgeBaseIds\x12\x1e\n" +
+	"\n" +
+	"recordOnly\x18\t \x01(\bR\n" +
+	"recordOnly\x12\"\n" +
+	"\fdisableTools\x18\n" +
+	" \x01(\bR\fdisableTools\"\x96\x01\n" +
 	"\x0eChatStreamResp\x12&\n" +
 	"\x0econversationId\x18\x01 \x01(\tR\x0econversationId\x12&\n" +
 	"\arespMsg\x18\x02 \x01(\v2\f.llm.ChatMsgR\arespMsg\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1e\n" +
 	"\n" +
 	"isComplete\x18\x04 \x01(\bR\n" +
-	"isComplete\"\x90\x04\n" +
+	"isComplete\"\xbc\x04\n" +
 	"\n" +
 	"ChatConfig\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
@@ -3296,7 +3495,8 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\tmaxTokens\x18\x0f \x01(\x03R\tmaxTokens\x12\x12\n" +
 	"\x04seed\x18\x10 \x01(\x03R\x04seed\x12\"\n" +
 	"\fenableSearch\x18\x11 \x01(\x03R\fenableSearch\x12$\n" +
-	"\rcontextLength\x18\x12 \x01(\x03R\rcontextLength\"\x85\x04\n" +
+	"\rcontextLength\x18\x12 \x01(\x03R\rcontextLength\x12*\n" +
+	"\x10knowledgeBaseIds\x18\x13 \x03(\x03R\x10knowledgeBaseIds\"\xb1\x04\n" +
 	"\x0fCreateConfigReq\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
@@ -3315,12 +3515,13 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\tmaxTokens\x18\x0e \x01(\x03R\tmaxTokens\x12\x12\n" +
 	"\x04seed\x18\x0f \x01(\x03R\x04seed\x12\"\n" +
 	"\fenableSearch\x18\x10 \x01(\x03R\fenableSearch\x12$\n" +
-	"\rcontextLength\x18\x11 \x01(\x03R\rcontextLength\"\"\n" +
+	"\rcontextLength\x18\x11 \x01(\x03R\rcontextLength\x12*\n" +
+	"\x10knowledgeBaseIds\x18\x12 \x03(\x03R\x10knowledgeBaseIds\"\"\n" +
 	"\x10CreateConfigResp\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"!\n" +
 	"\x0fDeleteConfigReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"\x12\n" +
-	"\x10DeleteConfigResp\"\x95\x04\n" +
+	"\x10DeleteConfigResp\"\xc1\x04\n" +
 	"\x0fUpdateConfigReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -3340,12 +3541,21 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\tmaxTokens\x18\x0f \x01(\x03R\tmaxTokens\x12\x12\n" +
 	"\x04seed\x18\x10 \x01(\x03R\x04seed\x12\"\n" +
 	"\fenableSearch\x18\x11 \x01(\x03R\fenableSearch\x12$\n" +
-	"\rcontextLength\x18\x12 \x01(\x03R\rcontextLength\"\x12\n" +
+	"\rcontextLength\x18\x12 \x01(\x03R\rcontextLength\x12*\n" +
+	"\x10knowledgeBaseIds\x18\x13 \x03(\x03R\x10knowledgeBaseIds\"\x12\n" +
 	"\x10UpdateConfigResp\"\x1e\n" +
 	"\fGetConfigReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"8\n" +
 	"\rGetConfigResp\x12'\n" +
-	"\x06config\x18\x01 \x01(\v2\x0f.llm.ChatConfigR\x06config\"p\n" +
+	"\x06config\x18\x01 \x01(\v2\x0f.llm.ChatConfigR\x06config\"\x1f\n" +
+	"\rTestConfigReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb0\x01\n" +
+	"\x0eTestConfigResp\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x1c\n" +
+	"\tlatencyMs\x18\x02 \x01(\x03R\tlatencyMs\x12&\n" +
+	"\x0emodelAvailable\x18\x03 \x01(\bR\x0emodelAvailable\x12$\n" +
+	"\rerrorCategory\x18\x04 \x01(\tR\rerrorCategory\x12\"\n" +
+	"\ferrorMessage\x18\x05 \x01(\tR\ferrorMessage\"p\n" +
 	"\x10ListConfigFilter\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
 	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
@@ -3463,14 +3673,16 @@ const file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc = "" +
 	"\x0eLlmChatService\x12#\n" +
 	"\x04Chat\x12\f.llm.ChatReq\x1a\r.llm.ChatResp\x127\n" +
 	"\n" +
-	"ChatStream\x12\x12.llm.ChatStreamReq\x1a\x13.llm.ChatStreamResp0\x012\xb4\x02\n" +
+	"ChatStream\x12\x12.llm.ChatStreamReq\x1a\x13.llm.ChatStreamResp0\x012\xeb\x02\n" +
 	"\x10LlmConfigService\x12;\n" +
 	"\fCreateConfig\x12\x14.llm.CreateConfigReq\x1a\x15.llm.CreateConfigResp\x12;\n" +
 	"\fDeleteConfig\x12\x14.llm.DeleteConfigReq\x1a\x15.llm.DeleteConfigResp\x12;\n" +
 	"\fUpdateConfig\x12\x14.llm.UpdateConfigReq\x1a\x15.llm.UpdateConfigResp\x122\n" +
 	"\tGetConfig\x12\x11.llm.GetConfigReq\x1a\x12.llm.GetConfigResp\x125\n" +
 	"\n" +
-	"ListConfig\x12\x12.llm.ListConfigReq\x1a\x13.llm.ListConfigResp2\xd4\x03\n" +
+	"ListConfig\x12\x12.llm.ListConfigReq\x1a\x13.llm.ListConfigResp\x125\n" +
+	"\n" +
+	"TestConfig\x12\x12.llm.TestConfigReq\x1a\x13.llm.TestConfigResp2\xd4\x03\n" +
 	"\x12ChatSessionService\x12J\n" +
 	"\x11CreateChatSession\x12\x19.llm.CreateChatSessionReq\x1a\x1a.llm.CreateChatSessionResp\x12J\n" +
 	"\x11DeleteChatSession\x12\x19.llm.DeleteChatSessionReq\x1a\x1a.llm.DeleteChatSessionResp\x12J\n" +
@@ -3497,7 +3709,7 @@ func file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescGZIP() []byte {
 	return file_app_llm_cmd_rpc_pb_llmservice_proto_rawDescData
 }
 
-var file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_app_llm_cmd_rpc_pb_llmservice_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_app_llm_cmd_rpc_pb_llmservice_proto_goTypes = []any{
 	(*PageQuery)(nil),                 // 0: llm.PageQuery
 	(*LlmConfig)(nil),                 // 1: llm.LlmConfig
@@ -3518,34 +3730,36 @@ var file_app_llm_cmd_rpc_pb_llmservice_proto_goTypes = []any{
 	(*UpdateConfigResp)(nil),          // 16: llm.UpdateConfigResp
 	(*GetConfigReq)(nil),              // 17: llm.GetConfigReq
 	(*GetConfigResp)(nil),             // 18: llm.GetConfigResp
-	(*ListConfigFilter)(nil),          // 19: llm.ListConfigFilter
-	(*ListConfigReq)(nil),             // 20: llm.ListConfigReq
-	(*ListConfigResp)(nil),            // 21: llm.ListConfigResp
-	(*ChatSession)(nil),               // 22: llm.ChatSession
-	(*CreateChatSessionReq)(nil),      // 23: llm.CreateChatSessionReq
-	(*CreateChatSessionResp)(nil),     // 24: llm.CreateChatSessionResp
-	(*DeleteChatSessionReq)(nil),      // 25: llm.DeleteChatSessionReq
-	(*DeleteChatSessionResp)(nil),     // 26: llm.DeleteChatSessionResp
-	(*UpdateChatSessionReq)(nil),      // 27: llm.UpdateChatSessionReq
-	(*UpdateChatSessionResp)(nil),     // 28: llm.UpdateChatSessionResp
-	(*GetChatSessionReq)(nil),         // 29: llm.GetChatSessionReq
-	(*GetChatSessionByConvIdReq)(nil), // 30: llm.GetChatSessionByConvIdReq
-	(*GetChatSessionResp)(nil),        // 31: llm.GetChatSessionResp
-	(*ListChatSessionFilter)(nil),     // 32: llm.ListChatSessionFilter
-	(*ListChatSessionReq)(nil),        // 33: llm.ListChatSessionReq
-	(*ListChatSessionResp)(nil),       // 34: llm.ListChatSessionResp
-	(*ChatMessage)(nil),               // 35: llm.ChatMessage
-	(*CreateChatMessageReq)(nil),      // 36: llm.CreateChatMessageReq
-	(*CreateChatMessageResp)(nil),     // 37: llm.CreateChatMessageResp
-	(*DeleteChatMessageReq)(nil),      // 38: llm.DeleteChatMessageReq
-	(*DeleteChatMessageResp)(nil),     // 39: llm.DeleteChatMessageResp
-	(*UpdateChatMessageReq)(nil),      // 40: llm.UpdateChatMessageReq
-	(*UpdateChatMessageResp)(nil),     // 41: llm.UpdateChatMessageResp
-	(*GetChatMessageReq)(nil),         // 42: llm.GetChatMessageReq
-	(*GetChatMessageResp)(nil),        // 43: llm.GetChatMessageResp
-	(*ListChatMessageFilter)(nil),     // 44: llm.ListChatMessageFilter
-	(*ListChatMessageReq)(nil),        // 45: llm.ListChatMessageReq
-	(*ListChatMessageResp)(nil),       // 46: llm.ListChatMessageResp
+	(*TestConfigReq)(nil),             // 19: llm.TestConfigReq
+	(*TestConfigResp)(nil),            // 20: llm.TestConfigResp
+	(*ListConfigFilter)(nil),          // 21: llm.ListConfigFilter
+	(*ListConfigReq)(nil),             // 22: llm.ListConfigReq
+	(*ListConfigResp)(nil),            // 23: llm.ListConfigResp
+	(*ChatSession)(nil),               // 24: llm.ChatSession
+	(*CreateChatSessionReq)(nil),      // 25: llm.CreateChatSessionReq
+	(*CreateChatSessionResp)(nil),     // 26: llm.CreateChatSessionResp
+	(*DeleteChatSessionReq)(nil),      // 27: llm.DeleteChatSessionReq
+	(*DeleteChatSessionResp)(nil),     // 28: llm.DeleteChatSessionResp
+	(*UpdateChatSessionReq)(nil),      // 29: llm.UpdateChatSessionReq
+	(*UpdateChatSessionResp)(nil),     // 30: llm.UpdateChatSessionResp
+	(*GetChatSessionReq)(nil),         // 31: llm.GetChatSessionReq
+	(*GetChatSessionByConvIdReq)(nil), // 32: llm.GetChatSessionByConvIdReq
+	(*GetChatSessionResp)(nil),        // 33: llm.GetChatSessionResp
+	(*ListChatSessionFilter)(nil),     // 34: llm.ListChatSessionFilter
+	(*ListChatSessionReq)(nil),        // 35: llm.ListChatSessionReq
+	(*ListChatSessionResp)(nil),       // 36: llm.ListChatSessionResp
+	(*ChatMessage)(nil),               // 37: llm.ChatMessage
+	(*CreateChatMessageReq)(nil),      // 38: llm.CreateChatMessageReq
+	(*CreateChatMessageResp)(nil),     // 39: llm.CreateChatMessageResp
+	(*DeleteChatMessageReq)(nil),      // 40: llm.DeleteChatMessageReq
+	(*DeleteChatMessageResp)(nil),     // 41: llm.DeleteChatMessageResp
+	(*UpdateChatMessageReq)(nil),      // 42: llm.UpdateChatMessageReq
+	(*UpdateChatMessageResp)(nil),     // 43: llm.UpdateChatMessageResp
+	(*GetChatMessageReq)(nil),         // 44: llm.GetChatMessageReq
+	(*GetChatMessageResp)(nil),        // 45: llm.GetChatMessageResp
+	(*ListChatMessageFilter)(nil),     // 46: llm.ListChatMessageFilter
+	(*ListChatMessageReq)(nil),        // 47: llm.ListChatMessageReq
+	(*ListChatMessageResp)(nil),       // 48: llm.ListChatMessageResp
 }
 var file_app_llm_cmd_rpc_pb_llmservice_proto_depIdxs = []int32{
 	2,  // 0: llm.LlmConfig.streamOptions:type_name -> llm.StreamOptions
@@ -3559,57 +3773,59 @@ var file_app_llm_cmd_rpc_pb_llmservice_proto_depIdxs = []int32{
 	5,  // 8: llm.ChatStreamResp.respMsg:type_name -> llm.ChatMsg
 	10, // 9: llm.GetConfigResp.config:type_name -> llm.ChatConfig
 	0,  // 10: llm.ListConfigReq.pageQuery:type_name -> llm.PageQuery
-	19, // 11: llm.ListConfigReq.filter:type_name -> llm.ListConfigFilter
+	21, // 11: llm.ListConfigReq.filter:type_name -> llm.ListConfigFilter
 	10, // 12: llm.ListConfigResp.configs:type_name -> llm.ChatConfig
-	22, // 13: llm.GetChatSessionResp.session:type_name -> llm.ChatSession
+	24, // 13: llm.GetChatSessionResp.session:type_name -> llm.ChatSession
 	0,  // 14: llm.ListChatSessionReq.pageQuery:type_name -> llm.PageQuery
-	32, // 15: llm.ListChatSessionReq.filter:type_name -> llm.ListChatSessionFilter
-	22, // 16: llm.ListChatSessionResp.sessions:type_name -> llm.ChatSession
+	34, // 15: llm.ListChatSessionReq.filter:type_name -> llm.ListChatSessionFilter
+	24, // 16: llm.ListChatSessionResp.sessions:type_name -> llm.ChatSession
 	4,  // 17: llm.ChatMessage.toolCalls:type_name -> llm.ToolCall
 	4,  // 18: llm.CreateChatMessageReq.toolCalls:type_name -> llm.ToolCall
 	4,  // 19: llm.UpdateChatMessageReq.toolCalls:type_name -> llm.ToolCall
-	35, // 20: llm.GetChatMessageResp.message:type_name -> llm.ChatMessage
+	37, // 20: llm.GetChatMessageResp.message:type_name -> llm.ChatMessage
 	0,  // 21: llm.ListChatMessageReq.pageQuery:type_name -> llm.PageQuery
-	44, // 22: llm.ListChatMessageReq.filter:type_name -> llm.ListChatMessageFilter
-	35, // 23: llm.ListChatMessageResp.messages:type_name -> llm.ChatMessage
+	46, // 22: llm.ListChatMessageReq.filter:type_name -> llm.ListChatMessageFilter
+	37, // 23: llm.ListChatMessageResp.messages:type_name -> llm.ChatMessage
 	6,  // 24: llm.LlmChatService.Chat:input_type -> llm.ChatReq
 	8,  // 25: llm.LlmChatService.ChatStream:input_type -> llm.ChatStreamReq
 	11, // 26: llm.LlmConfigService.CreateConfig:input_type -> llm.CreateConfigReq
 	13, // 27: llm.LlmConfigService.DeleteConfig:input_type -> llm.DeleteConfigReq
 	15, // 28: llm.LlmConfigService.UpdateConfig:input_type -> llm.UpdateConfigReq
 	17, // 29: llm.LlmConfigService.GetConfig:input_type -> llm.GetConfigReq
-	20, // 30: llm.LlmConfigService.ListConfig:input_type -> llm.ListConfigReq
-	23, // 31: llm.ChatSessionService.CreateChatSession:input_type -> llm.CreateChatSessionReq
-	25, // 32: llm.ChatSessionService.DeleteChatSession:input_type -> llm.DeleteChatSessionReq
-	27, // 33: llm.ChatSessionService.UpdateChatSession:input_type -> llm.UpdateChatSessionReq
-	29, // 34: llm.ChatSessionService.GetChatSession:input_type -> llm.GetChatSessionReq
-	30, // 35: llm.ChatSessionService.GetChatSessionByConvId:input_type -> llm.GetChatSessionByConvIdReq
-	33, // 36: llm.ChatSessionService.ListChatSession:input_type -> llm.ListChatSessionReq
-	36, // 37: llm.ChatMessageService.CreateChatMessage:input_type -> llm.CreateChatMessageReq
-	38, // 38: llm.ChatMessageService.DeleteChatMessage:input_type -> llm.DeleteChatMessageReq
-	40, // 39: llm.ChatMessageService.UpdateChatMessage:input_type -> llm.UpdateChatMessageReq
-	42, // 40: llm.ChatMessageService.GetChatMessage:input_type -> llm.GetChatMessageReq
-	45, // 41: llm.ChatMessageService.ListChatMessage:input_type -> llm.ListChatMessageReq
-	7,  // 42: llm.LlmChatService.Chat:output_type -> llm.ChatResp
-	9,  // 43: llm.LlmChatService.ChatStream:output_type -> llm.ChatStreamResp
-	12, // 44: llm.LlmConfigService.CreateConfig:output_type -> llm.CreateConfigResp
-	14, // 45: llm.LlmConfigService.DeleteConfig:output_type -> llm.DeleteConfigResp
-	16, // 46: llm.LlmConfigService.UpdateConfig:output_type -> llm.UpdateConfigResp
-	18, // 47: llm.LlmConfigService.GetConfig:output_type -> llm.GetConfigResp
-	21, // 48: llm.LlmConfigService.ListConfig:output_type -> llm.ListConfigResp
-	24, // 49: llm.ChatSessionService.CreateChatSession:output_type -> llm.CreateChatSessionResp
-	26, // 50: llm.ChatSessionService.DeleteChatSession:output_type -> llm.DeleteChatSessionResp
-	28, // 51: llm.ChatSessionService.UpdateChatSession:output_type -> llm.UpdateChatSessionResp
-	31, // 52: llm.ChatSessionService.GetChatSession:output_type -> llm.GetChatSessionResp
-	31, // 53: llm.ChatSessionService.GetChatSessionByConvId:output_type -> llm.GetChatSessionResp
-	34, // 54: llm.ChatSessionService.ListChatSession:output_type -> llm.ListChatSessionResp
-	37, // 55: llm.ChatMessageService.CreateChatMessage:output_type -> llm.CreateChatMessageResp
-	39, // 56: llm.ChatMessageService.DeleteChatMessage:output_type -> llm.DeleteChatMessageResp
-	41, // 57: llm.ChatMessageService.UpdateChatMessage:output_type -> llm.UpdateChatMessageResp
-	43, // 58: llm.ChatMessageService.GetChatMessage:output_type -> llm.GetChatMessageResp
-	46, // 59: llm.ChatMessageService.ListChatMessage:output_type -> llm.ListChatMessageResp
-	42, // [42:60] is the sub-list for method output_type
-	24, // [24:42] is the sub-list for method input_type
+	22, // 30: llm.LlmConfigService.ListConfig:input_type -> llm.ListConfigReq
+	19, // 31: llm.LlmConfigService.TestConfig:input_type -> llm.TestConfigReq
+	25, // 32: llm.ChatSessionService.CreateChatSession:input_type -> llm.CreateChatSessionReq
+	27, // 33: llm.ChatSessionService.DeleteChatSession:input_type -> llm.DeleteChatSessionReq
+	29, // 34: llm.ChatSessionService.UpdateChatSession:input_type -> llm.UpdateChatSessionReq
+	31, // 35: llm.ChatSessionService.GetChatSession:input_type -> llm.GetChatSessionReq
+	32, // 36: llm.ChatSessionService.GetChatSessionByConvId:input_type -> llm.GetChatSessionByConvIdReq
+	35, // 37: llm.ChatSessionService.ListChatSession:input_type -> llm.ListChatSessionReq
+	38, // 38: llm.ChatMessageService.CreateChatMessage:input_type -> llm.CreateChatMessageReq
+	40, // 39: llm.ChatMessageService.DeleteChatMessage:input_type -> llm.DeleteChatMessageReq
+	42, // 40: llm.ChatMessageService.UpdateChatMessage:input_type -> llm.UpdateChatMessageReq
+	44, // 41: llm.ChatMessageService.GetChatMessage:input_type -> llm.GetChatMessageReq
+	47, // 42: llm.ChatMessageService.ListChatMessage:input_type -> llm.ListChatMessageReq
+	7,  // 43: llm.LlmChatService.Chat:output_type -> llm.ChatResp
+	9,  // 44: llm.LlmChatService.ChatStream:output_type -> llm.ChatStreamResp
+	12, // 45: llm.LlmConfigService.CreateConfig:output_type -> llm.CreateConfigResp
+	14, // 46: llm.LlmConfigService.DeleteConfig:output_type -> llm.DeleteConfigResp
+	16, // 47: llm.LlmConfigService.UpdateConfig:output_type -> llm.UpdateConfigResp
+	18, // 48: llm.LlmConfigService.GetConfig:output_type -> llm.GetConfigResp
+	23, // 49: llm.LlmConfigService.ListConfig:output_type -> llm.ListConfigResp
+	20, // 50: llm.LlmConfigService.TestConfig:output_type -> llm.TestConfigResp
+	26, // 51: llm.ChatSessionService.CreateChatSession:output_type -> llm.CreateChatSessionResp
+	28, // 52: llm.ChatSessionService.DeleteChatSession:output_type -> llm.DeleteChatSessionResp
+	30, // 53: llm.ChatSessionService.UpdateChatSession:output_type -> llm.UpdateChatSessionResp
+	33, // 54: llm.ChatSessionService.GetChatSession:output_type -> llm.GetChatSessionResp
+	33, // 55: llm.ChatSessionService.GetChatSessionByConvId:output_type -> llm.GetChatSessionResp
+	36, // 56: llm.ChatSessionService.ListChatSession:output_type -> llm.ListChatSessionResp
+	39, // 57: llm.ChatMessageService.CreateChatMessage:output_type -> llm.CreateChatMessageResp
+	41, // 58: llm.ChatMessageService.DeleteChatMessage:output_type -> llm.DeleteChatMessageResp
+	43, // 59: llm.ChatMessageService.UpdateChatMessage:output_type -> llm.UpdateChatMessageResp
+	45, // 60: llm.ChatMessageService.GetChatMessage:output_type -> llm.GetChatMessageResp
+	48, // 61: llm.ChatMessageService.ListChatMessage:output_type -> llm.ListChatMessageResp
+	43, // [43:62] is the sub-list for method output_type
+	24, // [24:43] is the sub-list for method input_type
 	24, // [24:24] is the sub-list for extension type_name
 	24, // [24:24] is the sub-list for extension extendee
 	0,  // [0:24] is the sub-list for field type_name
@@ -3626,7 +3842,7 @@ func file_app_llm_cmd_rpc_pb_llmservice_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc), len(file_app_llm_cmd_rpc_pb_llmservice_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   47,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   4,
 		},
@@ -168,6 +168,7 @@ const (
 	LlmConfigService_UpdateConfig_FullMethodName = "/llm.LlmConfigService/UpdateConfig"
 	LlmConfigService_GetConfig_FullMethodName    = "/llm.LlmConfigService/GetConfig"
 	LlmConfigService_ListConfig_FullMethodName   = "/llm.LlmConfigService/ListConfig"
+	LlmConfigService_TestConfig_FullMethodName   = "/llm.LlmConfigService/TestConfig"
 )
 
 // LlmConfigServiceClient is the client API for LlmConfigService service.
@@ -179,6 +180,7 @@ type LlmConfigServiceClient interface {
 	UpdateConfig(ctx context.Context, in *UpdateConfigReq, opts ...grpc.CallOption) (*UpdateConfigResp, error)
 	GetConfig(ctx context.Context, in *GetConfigReq, opts ...grpc.CallOption) (*GetConfigResp, error)
 	ListConfig(ctx context.Context, in *ListConfigReq, opts ...grpc.CallOption) (*ListConfigResp, error)
+	TestConfig(ctx context.Context, in *TestConfigReq, opts ...grpc.CallOption) (*TestConfigResp, error)
 }
 
 type llmConfigServiceClient struct {
@@ -239,6 +241,16 @@ func (c *llmConfigServiceClient) ListConfig(ctx context.Context, in *ListConfigR
 	return out, nil
 }
 
+func (c *llmConfigServiceClient) TestConfig(ctx context.Context, in *TestConfigReq, opts ...grpc.CallOption) (*TestConfigResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestConfigResp)
+	err := c.cc.Invoke(ctx, LlmConfigService_TestConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LlmConfigServiceServer is the server API for LlmConfigService service.
 // All implementations must embed UnimplementedLlmConfigServiceServer
 // for forward compatibility.
@@ -248,6 +260,7 @@ type LlmConfigServiceServer interface {
 	UpdateConfig(context.Context, *UpdateConfigReq) (*UpdateConfigResp, error)
 	GetConfig(context.Context, *GetConfigReq) (*GetConfigResp, error)
 	ListConfig(context.Context, *ListConfigReq) (*ListConfigResp, error)
+	TestConfig(context.Context, *TestConfigReq) (*TestConfigResp, error)
 	mustEmbedUnimplementedLlmConfigServiceServer()
 }
 
@@ -273,6 +286,9 @@ func (UnimplementedLlmConfigServiceServer) GetConfig(context.Context, *GetConfig
 func (UnimplementedLlmConfigServiceServer) ListConfig(context.Context, *ListConfigReq) (*ListConfigResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListConfig not implemented")
 }
+func (UnimplementedLlmConfigServiceServer) TestConfig(context.Context, *TestConfigReq) (*TestConfigResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestConfig not implemented")
+}
 func (UnimplementedLlmConfigServiceServer) mustEmbedUnimplementedLlmConfigServiceServer() {}
 func (UnimplementedLlmConfigServiceServer) testEmbeddedByValue()                          {}
 
@@ -384,6 +400,24 @@ func _LlmConfigService_ListConfig_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LlmConfigService_TestConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LlmConfigServiceServer).TestConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LlmConfigService_TestConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LlmConfigServiceServer).TestConfig(ctx, req.(*TestConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LlmConfigService_ServiceDesc is the grpc.ServiceDesc for LlmConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -411,6 +445,10 @@ var LlmConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListConfig",
 			Handler:    _LlmConfigService_ListConfig_Handler,
 		},
+		{
+			MethodName: "TestConfig",
+			Handler:    _LlmConfigService_TestConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "app/llm/cmd/rpc/pb/llmservice.proto",
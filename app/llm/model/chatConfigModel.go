@@ -1,6 +1,13 @@
 package model
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/secretbox"
+
+	"github.com/Masterminds/squirrel"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -12,16 +19,99 @@ type (
 	// and implement the added methods in customChatConfigModel.
 	ChatConfigModel interface {
 		chatConfigModel
+		// RotateSecrets 使用当前主密钥重新加密所有行（包括历史明文），返回更新的行数
+		RotateSecrets(ctx context.Context) (int, error)
 	}
 
+	// customChatConfigModel 写入前加密 chat_config.api_key，读取后解密，上层只接触明文
 	customChatConfigModel struct {
 		*defaultChatConfigModel
+		secret secretbox.Column[ChatConfig]
 	}
 )
 
 // NewChatConfigModel returns a model for the database table.
-func NewChatConfigModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) ChatConfigModel {
+func NewChatConfigModel(conn sqlx.SqlConn, c cache.CacheConf, secrets *secretbox.Keyring, opts ...cache.Option) ChatConfigModel {
 	return &customChatConfigModel{
 		defaultChatConfigModel: newChatConfigModel(conn, c, opts...),
+		secret: secretbox.Column[ChatConfig]{
+			Keyring: secrets,
+			Name:    "chat_config.api_key",
+			Field:   func(row *ChatConfig) *sql.NullString { return &row.ApiKey },
+			Id:      func(row *ChatConfig) int64 { return row.Id },
+		},
+	}
+}
+
+func (m *customChatConfigModel) Insert(ctx context.Context, session sqlx.Session, data *ChatConfig) (sql.Result, error) {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return m.defaultChatConfigModel.Insert(ctx, session, data)
+}
+
+func (m *customChatConfigModel) Update(ctx context.Context, session sqlx.Session, data *ChatConfig) (sql.Result, error) {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return m.defaultChatConfigModel.Update(ctx, session, data)
+}
+
+func (m *customChatConfigModel) UpdateWithVersion(ctx context.Context, session sqlx.Session, data *ChatConfig) error {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return m.defaultChatConfigModel.UpdateWithVersion(ctx, session, data)
+}
+
+func (m *customChatConfigModel) FindOne(ctx context.Context, id int64) (*ChatConfig, error) {
+	data, err := m.defaultChatConfigModel.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return data, m.secret.Open(data)
+}
+
+func (m *customChatConfigModel) FindAll(ctx context.Context, builder squirrel.SelectBuilder, orderBy string) ([]*ChatConfig, error) {
+	return m.secret.OpenList(m.defaultChatConfigModel.FindAll(ctx, builder, orderBy))
+}
+
+func (m *customChatConfigModel) FindPageListByPage(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*ChatConfig, error) {
+	return m.secret.OpenList(m.defaultChatConfigModel.FindPageListByPage(ctx, builder, page, pageSize, orderBy))
+}
+
+func (m *customChatConfigModel) FindPageListByPageWithTotal(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*ChatConfig, int64, error) {
+	list, total, err := m.defaultChatConfigModel.FindPageListByPageWithTotal(ctx, builder, page, pageSize, orderBy)
+	if err != nil {
+		return nil, 0, err
+	}
+	list, err = m.secret.OpenList(list, nil)
+	return list, total, err
+}
+
+func (m *customChatConfigModel) FindPageListByIdDESC(ctx context.Context, builder squirrel.SelectBuilder, preMinId, pageSize int64) ([]*ChatConfig, error) {
+	return m.secret.OpenList(m.defaultChatConfigModel.FindPageListByIdDESC(ctx, builder, preMinId, pageSize))
+}
+
+func (m *customChatConfigModel) FindPageListByIdASC(ctx context.Context, builder squirrel.SelectBuilder, preMaxId, pageSize int64) ([]*ChatConfig, error) {
+	return m.secret.OpenList(m.defaultChatConfigModel.FindPageListByIdASC(ctx, builder, preMaxId, pageSize))
+}
+
+func (m *customChatConfigModel) RotateSecrets(ctx context.Context) (int, error) {
+	// 直接读取原始密文，已软删除的行也一并处理
+	var rows []*ChatConfig
+	query := fmt.Sprintf("select %s from %s", chatConfigRows, m.table)
+	if err := m.QueryRowsNoCacheCtx(ctx, &rows, query); err != nil {
+		return 0, err
 	}
+	return m.secret.Rotate(ctx, rows, func(ctx context.Context, row *ChatConfig) error {
+		_, err := m.Update(ctx, nil, row)
+		return err
+	})
 }
@@ -80,6 +80,7 @@ type (
 		Seed              sql.NullInt64   `db:"seed"`
 		EnableSearch      sql.NullInt64   `db:"enable_search"`
 		ContextLength     sql.NullInt64   `db:"context_length"`
+		KnowledgeBaseIds  sql.NullString  `db:"knowledge_base_ids"`
 	}
 )
 
@@ -122,11 +123,11 @@ func (m *defaultChatConfigModel) Insert(ctx context.Context, session sqlx.Sessio
 	data.DelState = globalkey.DelStateNo
 	gzvaLlmserviceChatConfigIdKey := fmt.Sprintf("%s%v", cacheGzvaLlmserviceChatConfigIdPrefix, data.Id)
 	ret, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
-		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, chatConfigRowsExpectAutoSet)
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, chatConfigRowsExpectAutoSet)
 		if session != nil {
-			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength)
+			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.KnowledgeBaseIds)
 		}
-		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength)
+		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.KnowledgeBaseIds)
 	}, gzvaLlmserviceChatConfigIdKey)
 	return ret, err
 }
@@ -136,9 +137,9 @@ func (m *defaultChatConfigModel) Update(ctx context.Context, session sqlx.Sessio
 	return m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, chatConfigRowsWithPlaceHolder)
 		if session != nil {
-			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.Id)
+			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.KnowledgeBaseIds, data.Id)
 		}
-		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.Id)
+		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.KnowledgeBaseIds, data.Id)
 	}, gzvaLlmserviceChatConfigIdKey)
 }
 
@@ -154,9 +155,9 @@ func (m *defaultChatConfigModel) UpdateWithVersion(ctx context.Context, session
 	sqlResult, err = m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("update %s set %s where `id` = ? and version = ? ", m.table, chatConfigRowsWithPlaceHolder)
 		if session != nil {
-			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.Id, oldVersion)
+			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.KnowledgeBaseIds, data.Id, oldVersion)
 		}
-		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.Id, oldVersion)
+		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.Name, data.Description, data.UserId, data.BaseUrl, data.ApiKey, data.Model, data.Stream, data.Temperature, data.TopP, data.TopK, data.EnableThinking, data.RepetitionPenalty, data.PresencePenalty, data.MaxTokens, data.Seed, data.EnableSearch, data.ContextLength, data.KnowledgeBaseIds, data.Id, oldVersion)
 	}, gzvaLlmserviceChatConfigIdKey)
 	if err != nil {
 		return err
@@ -12,6 +12,7 @@ const (
 
 const (
 	TOOL_CALLING_SELF_RAG = "self_rag"
+	TOOL_CALLING_END_CALL = "end_call"
 )
 
 const (
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"time"
+
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type Config struct {
@@ -18,4 +21,12 @@ type Config struct {
 	DB struct {
 		DataSource string
 	}
+
+	RagRpcConf zrpc.RpcClientConf
+	// 定时把卡在非终态的文件重新入队
+	RagEmbedSweeper struct {
+		Cron       string        // asynq cron 表达式
+		StaleAfter time.Duration `json:",default=30m"`
+		Limit      int64         `json:",default=100"`
+	}
 }
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/mqueue/cmd/job/internal/svc"
+	"go-zero-voice-agent/app/mqueue/cmd/job/jobtype"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/hibiken/asynq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RagEmbedLogic 调用 rag rpc 执行文件向量化，并定时清扫卡住的文件
+type RagEmbedLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRagEmbedLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RagEmbedLogic {
+	return &RagEmbedLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Embed 执行向量化任务：失败时返回错误由 asynq 重试，重试用完后任务进入归档队列（死信队列），
+// 最后一次执行时通知 rag rpc 把文件标记为失败。文件已删除或不在排队中时任务直接结束
+func (l *RagEmbedLogic) Embed(payload *jobtype.RagEmbedPayload) error {
+	if payload == nil || payload.FileID <= 0 {
+		return fmt.Errorf("invalid rag embed payload: %w", asynq.SkipRetry)
+	}
+
+	retried, _ := asynq.GetRetryCount(l.ctx)
+	maxRetry, _ := asynq.GetMaxRetry(l.ctx)
+	return l.embed(payload, retried, maxRetry)
+}
+
+// embed 按已重试次数调用 rag rpc，并把 rpc 的错误码转换为任务是否重试
+func (l *RagEmbedLogic) embed(payload *jobtype.RagEmbedPayload, retried, maxRetry int) error {
+	resp, err := l.svcCtx.DocRpc.EmbedDocument(l.ctx, &pb.EmbedDocumentReq{
+		Id:            payload.FileID,
+		CleanupMethod: payload.CleanupMethod,
+		LastAttempt:   retried >= maxRetry,
+	}, zrpc.WithCallTimeout(jobtype.RagEmbedTimeout))
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.FailedPrecondition:
+			l.Logger.Infof("skip rag embed of file %d: %v", payload.FileID, err)
+			return nil
+		case codes.InvalidArgument:
+			return fmt.Errorf("rag embed file %d: %v: %w", payload.FileID, err, asynq.SkipRetry)
+		default:
+			return fmt.Errorf("rag embed file %d (retried %d/%d): %w", payload.FileID, retried, maxRetry, err)
+		}
+	}
+
+	l.Logger.Infof("rag embed of file %d finished, %d chunks", resp.GetId(), resp.GetChunkCount())
+	return nil
+}
+
+// Sweep 把处理状态超过 StaleAfter 未变化的文件重新入队
+func (l *RagEmbedLogic) Sweep() error {
+	conf := l.svcCtx.Config.RagEmbedSweeper
+	resp, err := l.svcCtx.DocRpc.RequeueStaleDocuments(l.ctx, &pb.RequeueStaleDocumentsReq{
+		StaleSeconds: int64(conf.StaleAfter.Seconds()),
+		Limit:        conf.Limit,
+	})
+	if err != nil {
+		return fmt.Errorf("requeue stale rag documents: %w", err)
+	}
+
+	if resp.GetRequeued() > 0 {
+		l.Logger.Infof("requeued %d stale rag documents", resp.GetRequeued())
+	}
+	return nil
+}
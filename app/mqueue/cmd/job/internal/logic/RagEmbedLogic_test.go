@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/mqueue/cmd/job/internal/svc"
+	"go-zero-voice-agent/app/mqueue/cmd/job/jobtype"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/docservice"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeDocService 只实现任务用到的方法
+type fakeDocService struct {
+	docservice.DocService
+	embedErr    error
+	embedReqs   []*pb.EmbedDocumentReq
+	requeueReqs []*pb.RequeueStaleDocumentsReq
+}
+
+func (f *fakeDocService) EmbedDocument(ctx context.Context, in *pb.EmbedDocumentReq, opts ...grpc.CallOption) (*pb.EmbedDocumentResp, error) {
+	f.embedReqs = append(f.embedReqs, in)
+	if f.embedErr != nil {
+		return nil, f.embedErr
+	}
+	return &pb.EmbedDocumentResp{Id: in.Id, ChunkCount: 3}, nil
+}
+
+func (f *fakeDocService) RequeueStaleDocuments(ctx context.Context, in *pb.RequeueStaleDocumentsReq, opts ...grpc.CallOption) (*pb.RequeueStaleDocumentsResp, error) {
+	f.requeueReqs = append(f.requeueReqs, in)
+	return &pb.RequeueStaleDocumentsResp{Requeued: 2}, nil
+}
+
+func newTestRagEmbedLogic(doc *fakeDocService) *RagEmbedLogic {
+	svcCtx := &svc.ServiceContext{DocRpc: doc}
+	svcCtx.Config.RagEmbedSweeper.StaleAfter = 30 * time.Minute
+	svcCtx.Config.RagEmbedSweeper.Limit = 50
+	return NewRagEmbedLogic(context.Background(), svcCtx)
+}
+
+func TestRagEmbedLogic_Embed(t *testing.T) {
+	tests := []struct {
+		name      string
+		embedErr  error
+		retried   int
+		wantErr   bool
+		skipRetry bool
+		wantLast  bool
+	}{
+		{name: "success", retried: 0},
+		{name: "not found finishes task", embedErr: status.Error(codes.NotFound, "document not found")},
+		{name: "not queued finishes task", embedErr: status.Error(codes.FailedPrecondition, "document is ready, not queued")},
+		{name: "invalid argument skips retry", embedErr: status.Error(codes.InvalidArgument, "invalid cleanup_method"), wantErr: true, skipRetry: true},
+		{name: "internal error retries", embedErr: status.Error(codes.Internal, "embed document failed"), retried: 1, wantErr: true},
+		{name: "last attempt", embedErr: status.Error(codes.Internal, "embed document failed"), retried: jobtype.RagEmbedMaxRetry, wantErr: true, wantLast: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &fakeDocService{embedErr: tt.embedErr}
+			payload := &jobtype.RagEmbedPayload{FileID: 7, CleanupMethod: "full"}
+			err := newTestRagEmbedLogic(doc).embed(payload, tt.retried, jobtype.RagEmbedMaxRetry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if errors.Is(err, asynq.SkipRetry) != tt.skipRetry {
+				t.Fatalf("skip retry = %v, want %v", errors.Is(err, asynq.SkipRetry), tt.skipRetry)
+			}
+			if len(doc.embedReqs) != 1 {
+				t.Fatalf("expected one rpc call, got %d", len(doc.embedReqs))
+			}
+			req := doc.embedReqs[0]
+			if req.Id != 7 || req.CleanupMethod != "full" || req.LastAttempt != tt.wantLast {
+				t.Fatalf("unexpected rpc request: %+v", req)
+			}
+		})
+	}
+}
+
+func TestRagEmbedLogic_InvalidPayload(t *testing.T) {
+	doc := &fakeDocService{}
+	for _, payload := range []*jobtype.RagEmbedPayload{nil, {FileID: 0}} {
+		if err := newTestRagEmbedLogic(doc).Embed(payload); !errors.Is(err, asynq.SkipRetry) {
+			t.Fatalf("expected SkipRetry for %+v, got %v", payload, err)
+		}
+	}
+	if len(doc.embedReqs) != 0 {
+		t.Fatalf("invalid payload should not call rpc: %d", len(doc.embedReqs))
+	}
+}
+
+func TestRagEmbedLogic_Sweep(t *testing.T) {
+	doc := &fakeDocService{}
+	if err := newTestRagEmbedLogic(doc).Sweep(); err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+	if len(doc.requeueReqs) != 1 || doc.requeueReqs[0].StaleSeconds != 1800 || doc.requeueReqs[0].Limit != 50 {
+		t.Fatalf("unexpected requeue request: %+v", doc.requeueReqs)
+	}
+}
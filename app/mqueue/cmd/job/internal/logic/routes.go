@@ -28,6 +28,8 @@ func (l *CronJob) Register() *asynq.ServeMux {
 	mux := asynq.NewServeMux()
 	
 	mux.HandleFunc(jobtype.SyncChatMsgToDb, l.handleSyncChatMsgToDb)
+	mux.HandleFunc(jobtype.RagEmbed, l.handleRagEmbed)
+	mux.HandleFunc(jobtype.RagEmbedSweep, l.handleRagEmbedSweep)
 
 	return mux
 }
@@ -40,3 +42,22 @@ func (l *CronJob) handleSyncChatMsgToDb(ctx context.Context, task *asynq.Task) e
 
 	return NewSyncChatMsgToDbLogic(ctx, l.svcCtx).Sync(&payload)
 }
+
+func (l *CronJob) handleRagEmbed(ctx context.Context, task *asynq.Task) error {
+	var payload jobtype.RagEmbedPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return err
+	}
+
+	return NewRagEmbedLogic(ctx, l.svcCtx).Embed(&payload)
+}
+
+func (l *CronJob) handleRagEmbedSweep(ctx context.Context, task *asynq.Task) error {
+	return NewRagEmbedLogic(ctx, l.svcCtx).Sweep()
+}
+
+// RegisterSchedule 注册定时任务
+func (l *CronJob) RegisterSchedule() error {
+	_, err := l.svcCtx.Scheduler.Register(l.svcCtx.Config.RagEmbedSweeper.Cron, jobtype.NewRagEmbedSweepTask())
+	return err
+}
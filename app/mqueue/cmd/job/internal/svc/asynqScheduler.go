@@ -0,0 +1,23 @@
+package svc
+
+import (
+	"go-zero-voice-agent/app/mqueue/cmd/job/internal/config"
+
+	"github.com/hibiken/asynq"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+func newAsynqScheduler(c config.Config) *asynq.Scheduler {
+	return asynq.NewScheduler(
+		asynq.RedisClientOpt{
+			Addr:     c.Asynq.Host,
+			Password: c.Asynq.Pass,
+			DB:       c.Asynq.DB,
+		},
+		&asynq.SchedulerOpts{
+			EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+				logx.Errorf("asynq scheduler enqueue task %s err: %+v", task.Type(), err)
+			},
+		},
+	)
+}
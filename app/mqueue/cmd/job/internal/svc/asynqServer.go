@@ -2,6 +2,7 @@ package svc
 
 import (
 	"go-zero-voice-agent/app/mqueue/cmd/job/internal/config"
+	"go-zero-voice-agent/app/mqueue/cmd/job/jobtype"
 
 	"github.com/hibiken/asynq"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -20,6 +21,11 @@ func newAsynqServer(c config.Config) *asynq.Server {
                 return true
             },
             Concurrency: 20,
+            // 向量化任务单独排队，与默认队列按相同权重取任务，避免长任务堆积时饿死其他任务
+            Queues: map[string]int{
+                jobtype.QueueDefault:  1,
+                jobtype.QueueRagEmbed: 1,
+            },
         },
     )
 }
@@ -3,18 +3,22 @@ package svc
 import (
 	"go-zero-voice-agent/app/llm/model"
 	"go-zero-voice-agent/app/mqueue/cmd/job/internal/config"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/docservice"
 
 	"github.com/hibiken/asynq"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
 	Config           config.Config
 	AsynqServer      *asynq.Server
+	Scheduler        *asynq.Scheduler
 	RedisClient   	 *redis.Redis
 	ChatSessionModel model.ChatSessionModel
 	ChatMessageModel model.ChatMessageModel
+	DocRpc           docservice.DocService
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -23,8 +27,10 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	return &ServiceContext{
 		Config:           c,
 		AsynqServer:      newAsynqServer(c),
+		Scheduler:        newAsynqScheduler(c),
 		RedisClient:      redisClient,
 		ChatSessionModel: model.NewChatSessionModel(sqlConn, c.Cache),
 		ChatMessageModel: model.NewChatMessageModel(sqlConn, c.Cache),
+		DocRpc:           docservice.NewDocService(zrpc.MustNewClient(c.RagRpcConf)),
 	}
 }
@@ -2,6 +2,7 @@ package jobtype
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/hibiken/asynq"
 )
@@ -9,6 +10,20 @@ import (
 const (
 	QueueDefault    = "default"
 	SyncChatMsgToDb = "task:chat:msg:sync_to_db"
+
+	// QueueRagEmbed 向量化任务耗时较长，单独排队以免阻塞其他任务
+	QueueRagEmbed = "rag_embed"
+	RagEmbed      = "task:rag:embed"
+	RagEmbedSweep = "task:rag:embed:sweep"
+)
+
+const (
+	// RagEmbedMaxRetry 向量化任务的重试次数，用完后任务进入归档队列（死信队列）
+	RagEmbedMaxRetry = 5
+	// RagEmbedTimeout 单次向量化的最长时间
+	RagEmbedTimeout = 10 * time.Minute
+	// RagEmbedUniqueTTL 同一文件同一清理方式的任务在该时间内只入队一次，任务完成或归档后提前释放
+	RagEmbedUniqueTTL = time.Hour
 )
 
 type SyncChatMsgPayload struct {
@@ -23,3 +38,28 @@ func NewSyncChatMsgTask(conversationID string) (*asynq.Task, error) {
 
 	return asynq.NewTask(SyncChatMsgToDb, payload), nil
 }
+
+// RagEmbedPayload 向量化任务，CleanupMethod 为 incremental 或 full
+type RagEmbedPayload struct {
+	FileID        int64  `json:"file_id"`
+	CleanupMethod string `json:"cleanup_method"`
+}
+
+// NewRagEmbedTask 创建向量化任务，队列、重试次数和超时时间由任务自带，各处入队保持一致
+func NewRagEmbedTask(fileID int64, cleanupMethod string) (*asynq.Task, error) {
+	payload, err := json.Marshal(RagEmbedPayload{FileID: fileID, CleanupMethod: cleanupMethod})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(RagEmbed, payload,
+		asynq.Queue(QueueRagEmbed),
+		asynq.MaxRetry(RagEmbedMaxRetry),
+		asynq.Timeout(RagEmbedTimeout),
+	), nil
+}
+
+// NewRagEmbedSweepTask 定时把卡在非终态的文件重新入队
+func NewRagEmbedSweepTask() *asynq.Task {
+	return asynq.NewTask(RagEmbedSweep, nil, asynq.Queue(QueueDefault), asynq.MaxRetry(0))
+}
@@ -41,6 +41,16 @@ func main() {
 	cronJob := logic.NewCronJob(ctx, svcContext)
 	mux := cronJob.Register()
 
+	if err := cronJob.RegisterSchedule(); err != nil {
+		logx.WithContext(ctx).Errorf("!!!CronJobErr!!! register schedule err:%+v", err)
+		os.Exit(1)
+	}
+	if err := svcContext.Scheduler.Start(); err != nil {
+		logx.WithContext(ctx).Errorf("!!!CronJobErr!!! start scheduler err:%+v", err)
+		os.Exit(1)
+	}
+	defer svcContext.Scheduler.Shutdown()
+
 	if err := svcContext.AsynqServer.Run(mux); err != nil {
 		logx.WithContext(ctx).Errorf("!!!CronJobErr!!! run err:%+v", err)
 		os.Exit(1)
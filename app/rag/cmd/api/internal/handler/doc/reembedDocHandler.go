@@ -0,0 +1,32 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package doc
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/logic/doc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+)
+
+// 重新向量化上传文件
+func ReembedDocHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ReembedDocReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := doc.NewReembedDocLogic(r.Context(), svcCtx)
+		resp, err := l.ReembedDoc(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
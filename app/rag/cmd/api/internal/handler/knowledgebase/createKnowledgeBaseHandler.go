@@ -0,0 +1,32 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/logic/knowledgebase"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+)
+
+// 创建知识库
+func CreateKnowledgeBaseHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CreateKnowledgeBaseReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := knowledgebase.NewCreateKnowledgeBaseLogic(r.Context(), svcCtx)
+		resp, err := l.CreateKnowledgeBase(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	doc "go-zero-voice-agent/app/rag/cmd/api/internal/handler/doc"
+	knowledgebase "go-zero-voice-agent/app/rag/cmd/api/internal/handler/knowledgebase"
 	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
 
 	"github.com/zeromicro/go-zero/rest"
@@ -27,6 +28,18 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/:id",
 				Handler: doc.DeleteDocHandler(serverCtx),
 			},
+			{
+				// 重新向量化上传文件
+				Method:  http.MethodPost,
+				Path:    "/:id/reembed",
+				Handler: doc.ReembedDocHandler(serverCtx),
+			},
+			{
+				// 查询上传文件的处理状态
+				Method:  http.MethodGet,
+				Path:    "/:id/status",
+				Handler: doc.GetDocStatusHandler(serverCtx),
+			},
 			{
 				// 分页查询上传文件
 				Method:  http.MethodPost,
@@ -42,4 +55,52 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		},
 		rest.WithPrefix("/rag/v1/doc"),
 	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				// 删除知识库，其中的文件移出后保留
+				Method:  http.MethodDelete,
+				Path:    "/:id",
+				Handler: knowledgebase.DeleteKnowledgeBaseHandler(serverCtx),
+			},
+			{
+				// 查询知识库
+				Method:  http.MethodGet,
+				Path:    "/:id",
+				Handler: knowledgebase.GetKnowledgeBaseHandler(serverCtx),
+			},
+			{
+				// 修改知识库
+				Method:  http.MethodPut,
+				Path:    "/:id",
+				Handler: knowledgebase.UpdateKnowledgeBaseHandler(serverCtx),
+			},
+			{
+				// 把文件移入知识库
+				Method:  http.MethodPost,
+				Path:    "/:id/docs/add",
+				Handler: knowledgebase.AddKnowledgeBaseDocsHandler(serverCtx),
+			},
+			{
+				// 把文件移出知识库
+				Method:  http.MethodPost,
+				Path:    "/:id/docs/remove",
+				Handler: knowledgebase.RemoveKnowledgeBaseDocsHandler(serverCtx),
+			},
+			{
+				// 创建知识库
+				Method:  http.MethodPost,
+				Path:    "/create",
+				Handler: knowledgebase.CreateKnowledgeBaseHandler(serverCtx),
+			},
+			{
+				// 分页查询知识库
+				Method:  http.MethodPost,
+				Path:    "/list",
+				Handler: knowledgebase.ListKnowledgeBaseHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/rag/v1/kb"),
+	)
 }
@@ -0,0 +1,63 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package doc
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/docservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetDocStatusLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询上传文件的处理状态
+func NewGetDocStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetDocStatusLogic {
+	return &GetDocStatusLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetDocStatusLogic) GetDocStatus(req *types.GetDocStatusReq) (resp *types.GetDocStatusResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("invalid document id")
+	}
+
+	rpcResp, err := l.svcCtx.DocService.GetDocumentStatus(l.ctx, &docservice.GetDocumentStatusReq{
+		UserId: req.UserId,
+		Id:     req.Id,
+	})
+	if err != nil {
+		l.Logger.Errorf("get document status rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.GetDocStatusResp{
+		Id:         rpcResp.GetId(),
+		FileName:   rpcResp.GetFileName(),
+		Status:     rpcResp.GetStatus(),
+		StatusName: rpcResp.GetStatusName(),
+		ErrorMsg:   rpcResp.GetErrorMsg(),
+		ChunkCount: rpcResp.GetChunkCount(),
+		UpdateTime: rpcResp.GetUpdateTime(),
+	}, nil
+}
@@ -87,9 +87,10 @@ func (l *ListDocChunksLogic) ListDocChunks(req *types.ListDocChunksReq) (resp *t
 			}
 
 			out.ChunkList = append(out.ChunkList, types.DocChunkItem{
-				CustomId:    record.GetCustomId(),
-				PageContent: record.GetPageContent(),
-				Metadata:    mdCopy,
+				CustomId:      record.GetCustomId(),
+				PageContent:   record.GetPageContent(),
+				Metadata:      mdCopy,
+				ChunkStrategy: record.GetChunkStrategy(),
 			})
 		}
 	}
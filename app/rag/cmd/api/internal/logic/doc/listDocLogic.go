@@ -54,10 +54,11 @@ func (l *ListDocLogic) ListDoc(req *types.ListDocReq) (resp *types.ListDocResp,
 
 	trimmedName := strings.TrimSpace(req.Filter.FileName)
 	trimmedFormat := strings.TrimSpace(req.Filter.FileFormat)
-	if trimmedName != "" || trimmedFormat != "" {
+	if trimmedName != "" || trimmedFormat != "" || req.Filter.KnowledgeBaseId > 0 {
 		rpcReq.Filter = &docservice.ListDocumentsFilter{
-			FileName:   trimmedName,
-			FileFormat: trimmedFormat,
+			FileName:        trimmedName,
+			FileFormat:      trimmedFormat,
+			KnowledgeBaseId: req.Filter.KnowledgeBaseId,
 		}
 	}
 
@@ -84,10 +85,14 @@ func (l *ListDocLogic) ListDoc(req *types.ListDocReq) (resp *types.ListDocResp,
 			}
 
 			out.DocumentList = append(out.DocumentList, types.DocumentItem{
-				Id:         item.GetId(),
-				FileName:   item.GetFileName(),
-				FileFormat: item.GetFileFormat(),
-				Status:     item.GetStatus(),
+				Id:              item.GetId(),
+				FileName:        item.GetFileName(),
+				FileFormat:      item.GetFileFormat(),
+				Status:          item.GetStatus(),
+				StatusName:      item.GetStatusName(),
+				ErrorMsg:        item.GetErrorMsg(),
+				ChunkCount:      item.GetChunkCount(),
+				KnowledgeBaseId: item.GetKnowledgeBaseId(),
 			})
 		}
 	}
@@ -0,0 +1,60 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package doc
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/docservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ReembedDocLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 重新向量化上传文件
+func NewReembedDocLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReembedDocLogic {
+	return &ReembedDocLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ReembedDocLogic) ReembedDoc(req *types.ReembedDocReq) (resp *types.ReembedDocResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("invalid document id")
+	}
+
+	rpcResp, err := l.svcCtx.DocService.ReembedDocument(l.ctx, &docservice.ReembedDocumentReq{
+		UserId:        req.UserId,
+		Id:            req.Id,
+		CleanupMethod: req.CleanupMethod,
+	})
+	if err != nil {
+		l.Logger.Errorf("reembed document rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.ReembedDocResp{
+		Id:         rpcResp.GetId(),
+		Status:     rpcResp.GetStatus(),
+		StatusName: rpcResp.GetStatusName(),
+	}, nil
+}
@@ -79,11 +79,15 @@ func (l *UploadAndEmbedLogic) UploadAndEmbed(req *types.UploadDocReq, file multi
 	}
 
 	firstChunk := &docservice.UploadFileReq{
-		UserId:      req.UserId,
-		FileName:    filename,
-		FilePath:    objectKey,
-		ContentType: contentType,
-		FileSize:    sizeHint,
+		UserId:          req.UserId,
+		FileName:        filename,
+		FilePath:        objectKey,
+		ContentType:     contentType,
+		FileSize:        sizeHint,
+		KnowledgeBaseId: req.KnowledgeBaseId,
+		ChunkStrategy:   req.ChunkStrategy,
+		ChunkSize:       req.ChunkSize,
+		ChunkOverlap:    req.ChunkOverlap,
 	}
 	if n > 0 {
 		firstChunk.Chunk = append([]byte(nil), buffer[:n]...)
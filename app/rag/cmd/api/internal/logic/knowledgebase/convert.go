@@ -0,0 +1,24 @@
+package knowledgebase
+
+import (
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+)
+
+func toKnowledgeBaseItem(kb *knowledgebaseservice.KnowledgeBase) types.KnowledgeBaseItem {
+	if kb == nil {
+		return types.KnowledgeBaseItem{}
+	}
+	return types.KnowledgeBaseItem{
+		Id:             kb.GetId(),
+		Name:           kb.GetName(),
+		Description:    kb.GetDescription(),
+		EmbeddingModel: kb.GetEmbeddingModel(),
+		ChunkSize:      kb.GetChunkSize(),
+		ChunkOverlap:   kb.GetChunkOverlap(),
+		ChunkStrategy:  kb.GetChunkStrategy(),
+		DocumentCount:  kb.GetDocumentCount(),
+		CreateTime:     kb.GetCreateTime(),
+		UpdateTime:     kb.GetUpdateTime(),
+	}
+}
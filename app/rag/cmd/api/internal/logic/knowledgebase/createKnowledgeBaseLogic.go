@@ -0,0 +1,56 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateKnowledgeBaseLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 创建知识库
+func NewCreateKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateKnowledgeBaseLogic {
+	return &CreateKnowledgeBaseLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateKnowledgeBaseLogic) CreateKnowledgeBase(req *types.CreateKnowledgeBaseReq) (resp *types.CreateKnowledgeBaseResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	rpcResp, err := l.svcCtx.KnowledgeBaseService.CreateKnowledgeBase(l.ctx, &knowledgebaseservice.CreateKnowledgeBaseReq{
+		UserId:         req.UserId,
+		Name:           req.Name,
+		Description:    req.Description,
+		EmbeddingModel: req.EmbeddingModel,
+		ChunkSize:      req.ChunkSize,
+		ChunkOverlap:   req.ChunkOverlap,
+		ChunkStrategy:  req.ChunkStrategy,
+	})
+	if err != nil {
+		l.Logger.Errorf("create knowledge base rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.CreateKnowledgeBaseResp{Id: rpcResp.GetId()}, nil
+}
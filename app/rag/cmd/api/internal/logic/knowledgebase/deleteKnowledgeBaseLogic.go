@@ -0,0 +1,55 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteKnowledgeBaseLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 删除知识库，其中的文件移出后保留
+func NewDeleteKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteKnowledgeBaseLogic {
+	return &DeleteKnowledgeBaseLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteKnowledgeBaseLogic) DeleteKnowledgeBase(req *types.DeleteKnowledgeBaseReq) (resp *types.DeleteKnowledgeBaseResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("invalid knowledge base id")
+	}
+
+	rpcResp, err := l.svcCtx.KnowledgeBaseService.DeleteKnowledgeBase(l.ctx, &knowledgebaseservice.DeleteKnowledgeBaseReq{
+		UserId: req.UserId,
+		Id:     req.Id,
+	})
+	if err != nil {
+		l.Logger.Errorf("delete knowledge base rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.DeleteKnowledgeBaseResp{DetachedCount: rpcResp.GetDetachedCount()}, nil
+}
@@ -0,0 +1,55 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetKnowledgeBaseLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 查询知识库
+func NewGetKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetKnowledgeBaseLogic {
+	return &GetKnowledgeBaseLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetKnowledgeBaseLogic) GetKnowledgeBase(req *types.GetKnowledgeBaseReq) (resp *types.GetKnowledgeBaseResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("invalid knowledge base id")
+	}
+
+	rpcResp, err := l.svcCtx.KnowledgeBaseService.GetKnowledgeBase(l.ctx, &knowledgebaseservice.GetKnowledgeBaseReq{
+		UserId: req.UserId,
+		Id:     req.Id,
+	})
+	if err != nil {
+		l.Logger.Errorf("get knowledge base rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.GetKnowledgeBaseResp{KnowledgeBase: toKnowledgeBaseItem(rpcResp.GetKnowledgeBase())}, nil
+}
@@ -0,0 +1,71 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListKnowledgeBaseLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 分页查询知识库
+func NewListKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListKnowledgeBaseLogic {
+	return &ListKnowledgeBaseLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListKnowledgeBaseLogic) ListKnowledgeBase(req *types.ListKnowledgeBaseReq) (resp *types.ListKnowledgeBaseResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	rpcReq := &knowledgebaseservice.ListKnowledgeBasesReq{
+		UserId: req.UserId,
+		Name:   strings.TrimSpace(req.Name),
+	}
+	if req.PageQuery.Page > 0 || req.PageQuery.PageSize > 0 {
+		rpcReq.PageQuery = &knowledgebaseservice.PageQuery{
+			Page:     req.PageQuery.Page,
+			PageSize: req.PageQuery.PageSize,
+		}
+	}
+
+	rpcResp, err := l.svcCtx.KnowledgeBaseService.ListKnowledgeBases(l.ctx, rpcReq)
+	if err != nil {
+		l.Logger.Errorf("list knowledge bases rpc failed: %v", err)
+		return nil, err
+	}
+
+	out := &types.ListKnowledgeBaseResp{
+		Total:             rpcResp.GetTotal(),
+		KnowledgeBaseList: make([]types.KnowledgeBaseItem, 0, len(rpcResp.GetKnowledgeBases())),
+	}
+	for _, item := range rpcResp.GetKnowledgeBases() {
+		if item == nil {
+			continue
+		}
+		out.KnowledgeBaseList = append(out.KnowledgeBaseList, toKnowledgeBaseItem(item))
+	}
+
+	return out, nil
+}
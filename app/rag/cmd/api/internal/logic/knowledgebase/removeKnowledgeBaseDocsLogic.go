@@ -0,0 +1,60 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RemoveKnowledgeBaseDocsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 把文件移出知识库
+func NewRemoveKnowledgeBaseDocsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RemoveKnowledgeBaseDocsLogic {
+	return &RemoveKnowledgeBaseDocsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RemoveKnowledgeBaseDocsLogic) RemoveKnowledgeBaseDocs(req *types.KnowledgeBaseDocsReq) (resp *types.KnowledgeBaseDocsResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("invalid knowledge base id")
+	}
+
+	if len(req.FileIds) == 0 {
+		return nil, fmt.Errorf("fileIds must not be empty")
+	}
+
+	rpcResp, err := l.svcCtx.KnowledgeBaseService.RemoveDocuments(l.ctx, &knowledgebaseservice.KnowledgeBaseDocumentsReq{
+		UserId:  req.UserId,
+		Id:      req.Id,
+		FileIds: req.FileIds,
+	})
+	if err != nil {
+		l.Logger.Errorf("remove knowledge base documents rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.KnowledgeBaseDocsResp{Affected: rpcResp.GetAffected()}, nil
+}
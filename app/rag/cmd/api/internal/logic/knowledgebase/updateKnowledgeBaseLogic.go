@@ -0,0 +1,61 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-voice-agent/app/rag/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/api/internal/types"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateKnowledgeBaseLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 修改知识库
+func NewUpdateKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateKnowledgeBaseLogic {
+	return &UpdateKnowledgeBaseLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateKnowledgeBaseLogic) UpdateKnowledgeBase(req *types.UpdateKnowledgeBaseReq) (resp *types.UpdateKnowledgeBaseResp, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("request must not be nil")
+	}
+
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("invalid knowledge base id")
+	}
+
+	_, err = l.svcCtx.KnowledgeBaseService.UpdateKnowledgeBase(l.ctx, &knowledgebaseservice.UpdateKnowledgeBaseReq{
+		UserId:         req.UserId,
+		Id:             req.Id,
+		Name:           req.Name,
+		Description:    req.Description,
+		EmbeddingModel: req.EmbeddingModel,
+		ChunkSize:      req.ChunkSize,
+		ChunkOverlap:   req.ChunkOverlap,
+		ChunkStrategy:  req.ChunkStrategy,
+	})
+	if err != nil {
+		l.Logger.Errorf("update knowledge base rpc failed: %v", err)
+		return nil, err
+	}
+
+	return &types.UpdateKnowledgeBaseResp{Id: req.Id}, nil
+}
@@ -6,6 +6,7 @@ package svc
 import (
 	"go-zero-voice-agent/app/rag/cmd/api/internal/config"
 	"go-zero-voice-agent/app/rag/cmd/rpc/client/docservice"
+	"go-zero-voice-agent/app/rag/cmd/rpc/client/knowledgebaseservice"
 
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -13,11 +14,14 @@ import (
 type ServiceContext struct {
 	Config config.Config
 	DocService docservice.DocService
+	KnowledgeBaseService knowledgebaseservice.KnowledgeBaseService
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	ragRpc := zrpc.MustNewClient(c.RagRpcConf)
 	return &ServiceContext{
 		Config: c,
-		DocService: docservice.NewDocService(zrpc.MustNewClient(c.RagRpcConf)),
+		DocService: docservice.NewDocService(ragRpc),
+		KnowledgeBaseService: knowledgebaseservice.NewKnowledgeBaseService(ragRpc),
 	}
 }
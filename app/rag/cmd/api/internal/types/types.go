@@ -3,6 +3,20 @@
 
 package types
 
+type CreateKnowledgeBaseReq struct {
+	UserId         int64  `header:"X-User-Id"`
+	Name           string `json:"name"`
+	Description    string `json:"description,optional"`
+	EmbeddingModel string `json:"embeddingModel,optional"`
+	ChunkSize      int64  `json:"chunkSize,optional"`
+	ChunkOverlap   int64  `json:"chunkOverlap,optional"`
+	ChunkStrategy  string `json:"chunkStrategy,optional,options=fixed_tokens|recursive|markdown|sentence"`
+}
+
+type CreateKnowledgeBaseResp struct {
+	Id int64 `json:"id"`
+}
+
 type DeleteDocReq struct {
 	UserId int64 `header:"X-User-Id"`
 	Id     int64 `path:"id"`
@@ -12,17 +26,78 @@ type DeleteDocResp struct {
 	DeletedCount int32 `json:"deletedCount"`
 }
 
+type DeleteKnowledgeBaseReq struct {
+	UserId int64 `header:"X-User-Id"`
+	Id     int64 `path:"id"`
+}
+
+type DeleteKnowledgeBaseResp struct {
+	DetachedCount int64 `json:"detachedCount"` // 移出知识库的文件数，文件本身不删除
+}
+
 type DocChunkItem struct {
-	CustomId    string            `json:"customId"`
-	PageContent string            `json:"pageContent"`
-	Metadata    map[string]string `json:"metadata"`
+	CustomId      string            `json:"customId"`
+	PageContent   string            `json:"pageContent"`
+	Metadata      map[string]string `json:"metadata"`
+	ChunkStrategy string            `json:"chunkStrategy"` // 生成该切片的策略，早于切片策略写入的切片为空
 }
 
 type DocumentItem struct {
+	Id              int64  `json:"id"`
+	FileName        string `json:"fileName"`
+	FileFormat      string `json:"fileFormat"`
+	Status          int64  `json:"status"`     // 0已上传 1可检索 2排队中 3解析中 4向量化中 5失败
+	StatusName      string `json:"statusName"` // uploaded/ready/queued/parsing/embedding/failed
+	ErrorMsg        string `json:"errorMsg"`
+	ChunkCount      int64  `json:"chunkCount"`
+	KnowledgeBaseId int64  `json:"knowledgeBaseId"` // 0 表示不属于任何知识库
+}
+
+type GetDocStatusReq struct {
+	UserId int64 `header:"X-User-Id"`
+	Id     int64 `path:"id"`
+}
+
+type GetDocStatusResp struct {
 	Id         int64  `json:"id"`
 	FileName   string `json:"fileName"`
-	FileFormat string `json:"fileFormat"`
 	Status     int64  `json:"status"`
+	StatusName string `json:"statusName"`
+	ErrorMsg   string `json:"errorMsg"`   // 处理失败的原因
+	ChunkCount int64  `json:"chunkCount"` // 向量化后的切片数
+	UpdateTime int64  `json:"updateTime"` // 状态最后变化的时间(unix秒)
+}
+
+type GetKnowledgeBaseReq struct {
+	UserId int64 `header:"X-User-Id"`
+	Id     int64 `path:"id"`
+}
+
+type GetKnowledgeBaseResp struct {
+	KnowledgeBase KnowledgeBaseItem `json:"knowledgeBase"`
+}
+
+type KnowledgeBaseDocsReq struct {
+	UserId  int64   `header:"X-User-Id"`
+	Id      int64   `path:"id"`
+	FileIds []int64 `json:"fileIds"`
+}
+
+type KnowledgeBaseDocsResp struct {
+	Affected int64 `json:"affected"`
+}
+
+type KnowledgeBaseItem struct {
+	Id             int64  `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	EmbeddingModel string `json:"embeddingModel"` // 为空时使用 RAG 服务的默认模型
+	ChunkSize      int64  `json:"chunkSize"`      // 0 表示使用默认值
+	ChunkOverlap   int64  `json:"chunkOverlap"`
+	ChunkStrategy  string `json:"chunkStrategy"`
+	DocumentCount  int64  `json:"documentCount"`
+	CreateTime     int64  `json:"createTime"`
+	UpdateTime     int64  `json:"updateTime"`
 }
 
 type ListDocChunksReq struct {
@@ -37,8 +112,9 @@ type ListDocChunksResp struct {
 }
 
 type ListDocFilter struct {
-	FileName   string `json:"fileName,optional"`
-	FileFormat string `json:"fileFormat,optional"`
+	FileName        string `json:"fileName,optional"`
+	FileFormat      string `json:"fileFormat,optional"`
+	KnowledgeBaseId int64  `json:"knowledgeBaseId,optional"`
 }
 
 type ListDocReq struct {
@@ -52,15 +128,57 @@ type ListDocResp struct {
 	DocumentList []DocumentItem `json:"documentList"`
 }
 
+type ListKnowledgeBaseReq struct {
+	UserId    int64     `header:"X-User-Id"`
+	PageQuery PageQuery `json:"pageQuery"`
+	Name      string    `json:"name,optional"` // 按名称模糊查询
+}
+
+type ListKnowledgeBaseResp struct {
+	Total             int64               `json:"total"`
+	KnowledgeBaseList []KnowledgeBaseItem `json:"knowledgeBaseList"`
+}
+
 type PageQuery struct {
 	Page     int64  `json:"page"`
 	PageSize int64  `json:"pageSize"`
 	OrderBy  string `json:"orderBy,optional"`
 }
 
+type ReembedDocReq struct {
+	UserId        int64  `header:"X-User-Id"`
+	Id            int64  `path:"id"`
+	CleanupMethod string `json:"cleanupMethod,optional,options=incremental|full"` // incremental(默认) 只写入变化的切片，full 先删除已有切片
+}
+
+type ReembedDocResp struct {
+	Id         int64  `json:"id"`
+	Status     int64  `json:"status"`
+	StatusName string `json:"statusName"`
+}
+
+type UpdateKnowledgeBaseReq struct {
+	UserId         int64  `header:"X-User-Id"`
+	Id             int64  `path:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description,optional"`
+	EmbeddingModel string `json:"embeddingModel,optional"` // 修改模型或切片设置后需要重新向量化已有文件
+	ChunkSize      int64  `json:"chunkSize,optional"`
+	ChunkOverlap   int64  `json:"chunkOverlap,optional"`
+	ChunkStrategy  string `json:"chunkStrategy,optional,options=fixed_tokens|recursive|markdown|sentence"`
+}
+
+type UpdateKnowledgeBaseResp struct {
+	Id int64 `json:"id"`
+}
+
 type UploadDocReq struct {
-	UserId   int64  `header:"X-User-Id"`
-	FileName string `form:"filename"`
+	UserId          int64  `header:"X-User-Id"`
+	FileName        string `form:"filename"`
+	KnowledgeBaseId int64  `form:"knowledgeBaseId,optional"`                                                // 上传到自己的知识库
+	ChunkStrategy   string `form:"chunkStrategy,optional,options=fixed_tokens|recursive|markdown|sentence"` // 为空时使用知识库或默认策略 recursive
+	ChunkSize       int64  `form:"chunkSize,optional"`                                                      // 0 表示使用知识库或默认值
+	ChunkOverlap    int64  `form:"chunkOverlap,optional"`
 }
 
 type UploadDocResp struct {
@@ -14,24 +14,45 @@ import (
 )
 
 type (
-	DeleteDocumentsReq  = pb.DeleteDocumentsReq
-	DeleteDocumentsResp = pb.DeleteDocumentsResp
-	DocumentRecord      = pb.DocumentRecord
-	FetchDocumentsReq   = pb.FetchDocumentsReq
-	FetchDocumentsResp  = pb.FetchDocumentsResp
-	ListChunksReq       = pb.ListChunksReq
-	ListChunksResp      = pb.ListChunksResp
-	ListDocumentsFilter = pb.ListDocumentsFilter
-	ListDocumentsItem   = pb.ListDocumentsItem
-	ListDocumentsReq    = pb.ListDocumentsReq
-	ListDocumentsResp   = pb.ListDocumentsResp
-	PageQuery           = pb.PageQuery
-	QueryMultipleReq    = pb.QueryMultipleReq
-	QueryReq            = pb.QueryReq
-	QueryResp           = pb.QueryResp
-	RetrievalResult     = pb.RetrievalResult
-	UploadFileReq       = pb.UploadFileReq
-	UploadFileResp      = pb.UploadFileResp
+	CreateKnowledgeBaseReq     = pb.CreateKnowledgeBaseReq
+	CreateKnowledgeBaseResp    = pb.CreateKnowledgeBaseResp
+	DeleteDocumentsReq         = pb.DeleteDocumentsReq
+	DeleteDocumentsResp        = pb.DeleteDocumentsResp
+	DeleteKnowledgeBaseReq     = pb.DeleteKnowledgeBaseReq
+	DeleteKnowledgeBaseResp    = pb.DeleteKnowledgeBaseResp
+	DocumentRecord             = pb.DocumentRecord
+	EmbedDocumentReq           = pb.EmbedDocumentReq
+	EmbedDocumentResp          = pb.EmbedDocumentResp
+	FetchDocumentsReq          = pb.FetchDocumentsReq
+	FetchDocumentsResp         = pb.FetchDocumentsResp
+	GetDocumentStatusReq       = pb.GetDocumentStatusReq
+	GetDocumentStatusResp      = pb.GetDocumentStatusResp
+	GetKnowledgeBaseReq        = pb.GetKnowledgeBaseReq
+	GetKnowledgeBaseResp       = pb.GetKnowledgeBaseResp
+	KnowledgeBase              = pb.KnowledgeBase
+	KnowledgeBaseDocumentsReq  = pb.KnowledgeBaseDocumentsReq
+	KnowledgeBaseDocumentsResp = pb.KnowledgeBaseDocumentsResp
+	ListChunksReq              = pb.ListChunksReq
+	ListChunksResp             = pb.ListChunksResp
+	ListDocumentsFilter        = pb.ListDocumentsFilter
+	ListDocumentsItem          = pb.ListDocumentsItem
+	ListDocumentsReq           = pb.ListDocumentsReq
+	ListDocumentsResp          = pb.ListDocumentsResp
+	ListKnowledgeBasesReq      = pb.ListKnowledgeBasesReq
+	ListKnowledgeBasesResp     = pb.ListKnowledgeBasesResp
+	PageQuery                  = pb.PageQuery
+	QueryMultipleReq           = pb.QueryMultipleReq
+	QueryReq                   = pb.QueryReq
+	QueryResp                  = pb.QueryResp
+	ReembedDocumentReq         = pb.ReembedDocumentReq
+	ReembedDocumentResp        = pb.ReembedDocumentResp
+	RequeueStaleDocumentsReq   = pb.RequeueStaleDocumentsReq
+	RequeueStaleDocumentsResp  = pb.RequeueStaleDocumentsResp
+	RetrievalResult            = pb.RetrievalResult
+	UpdateKnowledgeBaseReq     = pb.UpdateKnowledgeBaseReq
+	UpdateKnowledgeBaseResp    = pb.UpdateKnowledgeBaseResp
+	UploadFileReq              = pb.UploadFileReq
+	UploadFileResp             = pb.UploadFileResp
 
 	DocService interface {
 		UploadFile(ctx context.Context, opts ...grpc.CallOption) (pb.DocService_UploadFileClient, error)
@@ -39,6 +60,10 @@ type (
 		FetchDocuments(ctx context.Context, in *FetchDocumentsReq, opts ...grpc.CallOption) (*FetchDocumentsResp, error)
 		DeleteDocuments(ctx context.Context, in *DeleteDocumentsReq, opts ...grpc.CallOption) (*DeleteDocumentsResp, error)
 		ListChunks(ctx context.Context, in *ListChunksReq, opts ...grpc.CallOption) (*ListChunksResp, error)
+		GetDocumentStatus(ctx context.Context, in *GetDocumentStatusReq, opts ...grpc.CallOption) (*GetDocumentStatusResp, error)
+		ReembedDocument(ctx context.Context, in *ReembedDocumentReq, opts ...grpc.CallOption) (*ReembedDocumentResp, error)
+		EmbedDocument(ctx context.Context, in *EmbedDocumentReq, opts ...grpc.CallOption) (*EmbedDocumentResp, error)
+		RequeueStaleDocuments(ctx context.Context, in *RequeueStaleDocumentsReq, opts ...grpc.CallOption) (*RequeueStaleDocumentsResp, error)
 	}
 
 	defaultDocService struct {
@@ -76,3 +101,23 @@ func (m *defaultDocService) ListChunks(ctx context.Context, in *ListChunksReq, o
 	client := pb.NewDocServiceClient(m.cli.Conn())
 	return client.ListChunks(ctx, in, opts...)
 }
+
+func (m *defaultDocService) GetDocumentStatus(ctx context.Context, in *GetDocumentStatusReq, opts ...grpc.CallOption) (*GetDocumentStatusResp, error) {
+	client := pb.NewDocServiceClient(m.cli.Conn())
+	return client.GetDocumentStatus(ctx, in, opts...)
+}
+
+func (m *defaultDocService) ReembedDocument(ctx context.Context, in *ReembedDocumentReq, opts ...grpc.CallOption) (*ReembedDocumentResp, error) {
+	client := pb.NewDocServiceClient(m.cli.Conn())
+	return client.ReembedDocument(ctx, in, opts...)
+}
+
+func (m *defaultDocService) EmbedDocument(ctx context.Context, in *EmbedDocumentReq, opts ...grpc.CallOption) (*EmbedDocumentResp, error) {
+	client := pb.NewDocServiceClient(m.cli.Conn())
+	return client.EmbedDocument(ctx, in, opts...)
+}
+
+func (m *defaultDocService) RequeueStaleDocuments(ctx context.Context, in *RequeueStaleDocumentsReq, opts ...grpc.CallOption) (*RequeueStaleDocumentsResp, error) {
+	client := pb.NewDocServiceClient(m.cli.Conn())
+	return client.RequeueStaleDocuments(ctx, in, opts...)
+}
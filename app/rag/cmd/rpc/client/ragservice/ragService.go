@@ -14,24 +14,45 @@ import (
 )
 
 type (
-	DeleteDocumentsReq  = pb.DeleteDocumentsReq
-	DeleteDocumentsResp = pb.DeleteDocumentsResp
-	DocumentRecord      = pb.DocumentRecord
-	FetchDocumentsReq   = pb.FetchDocumentsReq
-	FetchDocumentsResp  = pb.FetchDocumentsResp
-	ListChunksReq       = pb.ListChunksReq
-	ListChunksResp      = pb.ListChunksResp
-	ListDocumentsFilter = pb.ListDocumentsFilter
-	ListDocumentsItem   = pb.ListDocumentsItem
-	ListDocumentsReq    = pb.ListDocumentsReq
-	ListDocumentsResp   = pb.ListDocumentsResp
-	PageQuery           = pb.PageQuery
-	QueryMultipleReq    = pb.QueryMultipleReq
-	QueryReq            = pb.QueryReq
-	QueryResp           = pb.QueryResp
-	RetrievalResult     = pb.RetrievalResult
-	UploadFileReq       = pb.UploadFileReq
-	UploadFileResp      = pb.UploadFileResp
+	CreateKnowledgeBaseReq     = pb.CreateKnowledgeBaseReq
+	CreateKnowledgeBaseResp    = pb.CreateKnowledgeBaseResp
+	DeleteDocumentsReq         = pb.DeleteDocumentsReq
+	DeleteDocumentsResp        = pb.DeleteDocumentsResp
+	DeleteKnowledgeBaseReq     = pb.DeleteKnowledgeBaseReq
+	DeleteKnowledgeBaseResp    = pb.DeleteKnowledgeBaseResp
+	DocumentRecord             = pb.DocumentRecord
+	EmbedDocumentReq           = pb.EmbedDocumentReq
+	EmbedDocumentResp          = pb.EmbedDocumentResp
+	FetchDocumentsReq          = pb.FetchDocumentsReq
+	FetchDocumentsResp         = pb.FetchDocumentsResp
+	GetDocumentStatusReq       = pb.GetDocumentStatusReq
+	GetDocumentStatusResp      = pb.GetDocumentStatusResp
+	GetKnowledgeBaseReq        = pb.GetKnowledgeBaseReq
+	GetKnowledgeBaseResp       = pb.GetKnowledgeBaseResp
+	KnowledgeBase              = pb.KnowledgeBase
+	KnowledgeBaseDocumentsReq  = pb.KnowledgeBaseDocumentsReq
+	KnowledgeBaseDocumentsResp = pb.KnowledgeBaseDocumentsResp
+	ListChunksReq              = pb.ListChunksReq
+	ListChunksResp             = pb.ListChunksResp
+	ListDocumentsFilter        = pb.ListDocumentsFilter
+	ListDocumentsItem          = pb.ListDocumentsItem
+	ListDocumentsReq           = pb.ListDocumentsReq
+	ListDocumentsResp          = pb.ListDocumentsResp
+	ListKnowledgeBasesReq      = pb.ListKnowledgeBasesReq
+	ListKnowledgeBasesResp     = pb.ListKnowledgeBasesResp
+	PageQuery                  = pb.PageQuery
+	QueryMultipleReq           = pb.QueryMultipleReq
+	QueryReq                   = pb.QueryReq
+	QueryResp                  = pb.QueryResp
+	ReembedDocumentReq         = pb.ReembedDocumentReq
+	ReembedDocumentResp        = pb.ReembedDocumentResp
+	RequeueStaleDocumentsReq   = pb.RequeueStaleDocumentsReq
+	RequeueStaleDocumentsResp  = pb.RequeueStaleDocumentsResp
+	RetrievalResult            = pb.RetrievalResult
+	UpdateKnowledgeBaseReq     = pb.UpdateKnowledgeBaseReq
+	UpdateKnowledgeBaseResp    = pb.UpdateKnowledgeBaseResp
+	UploadFileReq              = pb.UploadFileReq
+	UploadFileResp             = pb.UploadFileResp
 
 	RagService interface {
 		Query(ctx context.Context, in *QueryReq, opts ...grpc.CallOption) (*QueryResp, error)
@@ -14,6 +14,13 @@ type Config struct {
 
 	Cache cache.CacheConf
 
+	// 向量化任务投递到 mqueue 的 asynq
+	Asynq struct {
+		Host string
+		Pass string
+		DB   int
+	}
+
 	MinioConfig struct {
 		Endpoint  string
 		AccessKey string
@@ -0,0 +1,51 @@
+package docservicelogic
+
+import (
+	"context"
+	"strconv"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/chatbridge"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// DocStatusEvent 经聊天室推送给上传者的文档处理进度
+type DocStatusEvent struct {
+	FileId     int64  `json:"fileId"`
+	FileName   string `json:"fileName"`
+	Status     string `json:"status"`
+	ErrorMsg   string `json:"errorMsg,omitempty"`
+	ChunkCount int64  `json:"chunkCount"`
+}
+
+// transitStatus 切换文件的处理状态，成功后推送给上传者；状态机不允许切换时返回 model.ErrNoRowsUpdate
+func transitStatus(ctx context.Context, svcCtx *svc.ServiceContext, record *model.FileUpload, status int64, errorMsg string, chunkCount int64) error {
+	if err := svcCtx.FileUploadModel.TransitStatus(ctx, record, status, errorMsg, chunkCount); err != nil {
+		return err
+	}
+	notifyStatus(ctx, svcCtx, record)
+	return nil
+}
+
+// notifyStatus 推送失败只记录日志，客户端仍可通过状态接口查询
+func notifyStatus(ctx context.Context, svcCtx *svc.ServiceContext, record *model.FileUpload) {
+	if !record.UserId.Valid || svcCtx.RedisClient == nil {
+		return
+	}
+	err := chatbridge.Publish(ctx, svcCtx.RedisClient, &chatbridge.Message{
+		Type: chatbridge.MESSAGE_TYPE_DOC_STATUS,
+		To:   strconv.FormatInt(record.UserId.Int64, 10),
+		Data: DocStatusEvent{
+			FileId:     record.Id,
+			FileName:   record.FileName,
+			Status:     model.FileStatusName(record.Status),
+			ErrorMsg:   record.ErrorMsg,
+			ChunkCount: record.ChunkCount,
+		},
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("publish status of file %d failed: %v", record.Id, err)
+	}
+}
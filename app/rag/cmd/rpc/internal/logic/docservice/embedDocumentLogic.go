@@ -0,0 +1,178 @@
+package docservicelogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/consts"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/ragclient"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EmbedDocumentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewEmbedDocumentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EmbedDocumentLogic {
+	return &EmbedDocumentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// EmbedDocument 由 mqueue job 服务调用，推进排队中文件的处理状态：parsing 校验 MinIO 中的文件，
+// embedding 调用 RAG 服务切片并写入向量库，成功后为 ready 并记录切片数。
+// 失败时记录原因并回到排队中等待任务重试，最后一次重试失败后切换为 failed。
+// 文件不在排队中（已删除、正在被其他任务处理或已完成）时返回 FailedPrecondition，任务不再重试
+func (l *EmbedDocumentLogic) EmbedDocument(in *pb.EmbedDocumentReq) (*pb.EmbedDocumentResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	if in.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	cleanup, ok := parseCleanupMethod(in.GetCleanupMethod())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid cleanup_method")
+	}
+
+	record, err := l.svcCtx.FileUploadModel.FindOne(l.ctx, in.GetId())
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+	if err != nil {
+		l.Logger.Errorf("find file upload %d failed: %v", in.GetId(), err)
+		return nil, status.Error(codes.Internal, "query document failed")
+	}
+	if record.DelState != globalkey.DelStateNo || !record.UserId.Valid {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+
+	bucketName := consts.MINIO_BUCKETNAME_RAG_DOCUMENT
+	if record.BucketName.Valid && record.BucketName.String != "" {
+		bucketName = record.BucketName.String
+	}
+	embedReq := &ragclient.EmbedRequest{
+		FileID:        strconv.FormatInt(record.Id, 10),
+		BucketName:    bucketName,
+		ObjectPath:    record.FilePath,
+		Filename:      record.FileName,
+		CleanupMethod: cleanup,
+	}
+	if err := l.applyChunking(record, embedReq); err != nil {
+		return nil, err
+	}
+
+	// 只有一个任务能把文件从排队中切换为解析中
+	err = transitStatus(l.ctx, l.svcCtx, record, model.FileStatusParsing, "", 0)
+	if errors.Is(err, model.ErrNoRowsUpdate) {
+		return nil, status.Errorf(codes.FailedPrecondition, "document is %s, not queued", model.FileStatusName(record.Status))
+	}
+	if err != nil {
+		l.Logger.Errorf("switch file %d to parsing failed: %v", record.Id, err)
+		return nil, status.Error(codes.Internal, "update document status failed")
+	}
+
+	if err := l.embed(record, embedReq); err != nil {
+		l.Logger.Errorf("embed file %s failed: %v", record.FilePath, err)
+		next := model.FileStatusQueued
+		if in.GetLastAttempt() {
+			next = model.FileStatusFailed
+		}
+		// 超时后 ctx 已结束，状态仍需写入
+		if err := transitStatus(context.WithoutCancel(l.ctx), l.svcCtx, record, next, errorMessage(err), 0); err != nil {
+			l.Logger.Errorf("switch file %d to %s failed: %v", record.Id, model.FileStatusName(next), err)
+		}
+		return nil, status.Errorf(codes.Internal, "embed document failed: %v", err)
+	}
+
+	l.Logger.Infof("embed succeeded for file %s, %d chunks", record.FilePath, record.ChunkCount)
+	return &pb.EmbedDocumentResp{
+		Id:         record.Id,
+		Status:     record.Status,
+		StatusName: model.FileStatusName(record.Status),
+		ChunkCount: record.ChunkCount,
+	}, nil
+}
+
+// applyChunking 确定向量化模型和切片设置：先取文件所属知识库的设置（知识库已删除时忽略），
+// 再由上传时指定的切片策略、长度覆盖，都未指定时使用 RAG 服务的默认值。
+// 在切换为解析中之前调用，查询失败时文件仍在排队中，由任务重试
+func (l *EmbedDocumentLogic) applyChunking(record *model.FileUpload, req *ragclient.EmbedRequest) error {
+	if record.KnowledgeBaseId > 0 {
+		kb, err := l.svcCtx.KnowledgeBaseModel.FindOne(l.ctx, record.KnowledgeBaseId)
+		if err != nil && !errors.Is(err, model.ErrNotFound) {
+			l.Logger.Errorf("find knowledge base %d of file %d failed: %v", record.KnowledgeBaseId, record.Id, err)
+			return status.Error(codes.Internal, "query knowledge base failed")
+		}
+		if err == nil && kb.DelState == globalkey.DelStateNo {
+			req.EmbeddingModel = kb.EmbeddingModel
+			req.ChunkStrategy = ragclient.ChunkStrategy(kb.ChunkStrategy)
+			req.ChunkSize = int(kb.ChunkSize)
+			req.ChunkOverlap = int(kb.ChunkOverlap)
+		}
+	}
+
+	if record.ChunkStrategy != "" {
+		req.ChunkStrategy = ragclient.ChunkStrategy(record.ChunkStrategy)
+	}
+	// 长度和重叠一起覆盖，避免与知识库的设置组合出重叠不小于长度的情况
+	if record.ChunkSize > 0 {
+		req.ChunkSize = int(record.ChunkSize)
+		req.ChunkOverlap = int(record.ChunkOverlap)
+	}
+	return nil
+}
+
+func (l *EmbedDocumentLogic) embed(record *model.FileUpload, req *ragclient.EmbedRequest) error {
+	contentType, err := l.checkObject(req)
+	if err != nil {
+		return err
+	}
+	req.ContentType = contentType
+
+	if err := transitStatus(l.ctx, l.svcCtx, record, model.FileStatusEmbedding, "", 0); err != nil {
+		return fmt.Errorf("switch to embedding: %w", err)
+	}
+	resp, err := l.svcCtx.RagClient.Embed(l.ctx, strconv.FormatInt(record.UserId.Int64, 10), req)
+	if err != nil {
+		return err
+	}
+
+	// 增量向量化时未变化的切片被跳过，仍计入文件的切片数
+	chunkCount := int64(resp.EmbeddedChunks + resp.SkippedChunks)
+	if err := transitStatus(l.ctx, l.svcCtx, record, model.FileStatusReady, "", chunkCount); err != nil {
+		return fmt.Errorf("switch to ready: %w", err)
+	}
+	return nil
+}
+
+// checkObject 确认文件已写入 MinIO 且不为空，返回文件的 Content-Type
+func (l *EmbedDocumentLogic) checkObject(req *ragclient.EmbedRequest) (string, error) {
+	object, err := l.svcCtx.MinioClient.Download(l.ctx, req.BucketName, req.ObjectPath)
+	if err != nil {
+		return "", fmt.Errorf("open object: %w", err)
+	}
+	defer object.Close()
+
+	info, err := object.Stat()
+	if err != nil {
+		return "", fmt.Errorf("stat object: %w", err)
+	}
+	if info.Size == 0 {
+		return "", errors.New("file is empty")
+	}
+	return info.ContentType, nil
+}
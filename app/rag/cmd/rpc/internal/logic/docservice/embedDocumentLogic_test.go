@@ -0,0 +1,240 @@
+package docservicelogic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/consts"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/ragclient"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/minioutil"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeFileUploadModel 只实现处理状态相关的方法，按记录 id 拒绝切换模拟状态已被其他任务改变
+type fakeFileUploadModel struct {
+	model.FileUploadModel
+	records  map[int64]*model.FileUpload
+	reject   map[int64]bool
+	transits []int64
+	builder  squirrel.SelectBuilder
+}
+
+func (m *fakeFileUploadModel) FindOne(ctx context.Context, id int64) (*model.FileUpload, error) {
+	record, ok := m.records[id]
+	if !ok {
+		return nil, model.ErrNotFound
+	}
+	return record, nil
+}
+
+func (m *fakeFileUploadModel) TransitStatus(ctx context.Context, data *model.FileUpload, status int64, errorMsg string, chunkCount int64) error {
+	if m.reject[data.Id] {
+		return model.ErrNoRowsUpdate
+	}
+	m.transits = append(m.transits, status)
+	data.Status = status
+	data.ErrorMsg = errorMsg
+	data.ChunkCount = chunkCount
+	data.Version++
+	return nil
+}
+
+func (m *fakeFileUploadModel) SelectBuilder() squirrel.SelectBuilder {
+	return squirrel.Select("*").From("file_upload")
+}
+
+func (m *fakeFileUploadModel) FindPageListByIdASC(ctx context.Context, rowBuilder squirrel.SelectBuilder, preMaxId, pageSize int64) ([]*model.FileUpload, error) {
+	m.builder = rowBuilder
+	var list []*model.FileUpload
+	for id := int64(1); id <= int64(len(m.records)); id++ {
+		list = append(list, m.records[id])
+	}
+	return list, nil
+}
+
+// newTestObjectStore 模拟 MinIO 的 S3 接口，objects 的键为 /bucket/path
+func newTestObjectStore(t *testing.T, objects map[string]string) *minioutil.MinioClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("location") {
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+			return
+		}
+		body, ok := objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("ETag", `"test"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method != http.MethodHead {
+			_, _ = w.Write([]byte(body))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := minioutil.NewMinioClient(minioutil.MinioConfig{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		AccessKey: "test",
+		SecretKey: "testsecret",
+	})
+	if err != nil {
+		t.Fatalf("minio client failed: %v", err)
+	}
+	return client
+}
+
+// newTestRagClient 模拟 RAG 服务的 /embed，fail 为真时返回 500
+func newTestRagClient(t *testing.T, fail bool, calls *int) *ragclient.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if fail {
+			http.Error(w, `{"detail":"embedding backend unavailable"}`, http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ragclient.EmbedResponse{FileID: r.FormValue("file_id"), EmbeddedChunks: 3, SkippedChunks: 2})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ragclient.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("rag client failed: %v", err)
+	}
+	return client
+}
+
+func newTestAsynq(t *testing.T) (*asynq.Client, *asynq.Inspector) {
+	mr := miniredis.RunT(t)
+	opt := asynq.RedisClientOpt{Addr: mr.Addr()}
+	client := asynq.NewClient(opt)
+	inspector := asynq.NewInspector(opt)
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = inspector.Close()
+	})
+	return client, inspector
+}
+
+func newTestServiceContext(files *fakeFileUploadModel) *svc.ServiceContext {
+	return &svc.ServiceContext{FileUploadModel: files}
+}
+
+func TestEmbedDocumentLogic_EmbedDocument(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *pb.EmbedDocumentReq
+		object       string
+		ragFail      bool
+		reject       bool
+		wantCode     codes.Code
+		wantTransits []int64
+		wantRagCalls int
+	}{
+		{
+			name:         "success",
+			req:          &pb.EmbedDocumentReq{Id: 1},
+			object:       "hello",
+			wantCode:     codes.OK,
+			wantTransits: []int64{model.FileStatusParsing, model.FileStatusEmbedding, model.FileStatusReady},
+			wantRagCalls: 1,
+		},
+		{
+			name:         "embed failure requeues",
+			req:          &pb.EmbedDocumentReq{Id: 1},
+			object:       "hello",
+			ragFail:      true,
+			wantCode:     codes.Internal,
+			wantTransits: []int64{model.FileStatusParsing, model.FileStatusEmbedding, model.FileStatusQueued},
+			wantRagCalls: 1,
+		},
+		{
+			name:         "embed failure on last attempt fails",
+			req:          &pb.EmbedDocumentReq{Id: 1, LastAttempt: true},
+			object:       "hello",
+			ragFail:      true,
+			wantCode:     codes.Internal,
+			wantTransits: []int64{model.FileStatusParsing, model.FileStatusEmbedding, model.FileStatusFailed},
+			wantRagCalls: 1,
+		},
+		{
+			name:         "empty object requeues before embedding",
+			req:          &pb.EmbedDocumentReq{Id: 1},
+			object:       "",
+			wantCode:     codes.Internal,
+			wantTransits: []int64{model.FileStatusParsing, model.FileStatusQueued},
+		},
+		{
+			name:     "not queued",
+			req:      &pb.EmbedDocumentReq{Id: 1},
+			object:   "hello",
+			reject:   true,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "not found",
+			req:      &pb.EmbedDocumentReq{Id: 2},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "invalid cleanup method",
+			req:      &pb.EmbedDocumentReq{Id: 1, CleanupMethod: "all"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &model.FileUpload{
+				Id:       1,
+				UserId:   sql.NullInt64{Int64: 9, Valid: true},
+				FileName: "a.txt",
+				FilePath: "docs/a.txt",
+				Status:   model.FileStatusQueued,
+			}
+			files := &fakeFileUploadModel{
+				records: map[int64]*model.FileUpload{1: record},
+				reject:  map[int64]bool{1: tt.reject},
+			}
+			var ragCalls int
+			svcCtx := newTestServiceContext(files)
+			svcCtx.MinioClient = newTestObjectStore(t, map[string]string{"/" + consts.MINIO_BUCKETNAME_RAG_DOCUMENT + "/docs/a.txt": tt.object})
+			svcCtx.RagClient = newTestRagClient(t, tt.ragFail, &ragCalls)
+
+			resp, err := NewEmbedDocumentLogic(context.Background(), svcCtx).EmbedDocument(tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err %v)", code, tt.wantCode, err)
+			}
+			if fmt.Sprint(files.transits) != fmt.Sprint(tt.wantTransits) {
+				t.Fatalf("transits = %v, want %v", files.transits, tt.wantTransits)
+			}
+			if ragCalls != tt.wantRagCalls {
+				t.Fatalf("rag calls = %d, want %d", ragCalls, tt.wantRagCalls)
+			}
+
+			switch {
+			case tt.wantCode == codes.OK:
+				if resp.StatusName != "ready" || resp.ChunkCount != 5 {
+					t.Fatalf("unexpected response: %+v", resp)
+				}
+			case len(tt.wantTransits) > 1 && record.ErrorMsg == "":
+				t.Fatal("expected failure reason to be recorded")
+			}
+		})
+	}
+}
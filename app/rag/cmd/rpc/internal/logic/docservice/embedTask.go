@@ -0,0 +1,51 @@
+package docservicelogic
+
+import (
+	"context"
+	"errors"
+
+	"go-zero-voice-agent/app/mqueue/cmd/job/jobtype"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/ragclient"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+
+	"github.com/hibiken/asynq"
+)
+
+// maxErrorMsgLen error_msg 列的长度
+const maxErrorMsgLen = 512
+
+// enqueueEmbed 提交向量化任务由 mqueue job 服务执行，同一文件同一清理方式的任务在队列中只保留一个，
+// 是否允许执行以数据库中的处理状态为准
+func enqueueEmbed(ctx context.Context, svcCtx *svc.ServiceContext, fileID int64, cleanup ragclient.EmbedCleanupMethod) error {
+	task, err := jobtype.NewRagEmbedTask(fileID, string(cleanup))
+	if err != nil {
+		return err
+	}
+
+	_, err = svcCtx.AsynqClient.EnqueueContext(ctx, task, asynq.Unique(jobtype.RagEmbedUniqueTTL))
+	if errors.Is(err, asynq.ErrDuplicateTask) {
+		return nil
+	}
+	return err
+}
+
+// parseCleanupMethod 为空时默认增量清理
+func parseCleanupMethod(method string) (ragclient.EmbedCleanupMethod, bool) {
+	switch cleanup := ragclient.EmbedCleanupMethod(method); cleanup {
+	case "":
+		return ragclient.EmbedCleanupIncremental, true
+	case ragclient.EmbedCleanupIncremental, ragclient.EmbedCleanupFull:
+		return cleanup, true
+	default:
+		return "", false
+	}
+}
+
+// errorMessage 错误信息按 error_msg 列的长度截断
+func errorMessage(err error) string {
+	msg := []rune(err.Error())
+	if len(msg) > maxErrorMsgLen {
+		msg = msg[:maxErrorMsgLen]
+	}
+	return string(msg)
+}
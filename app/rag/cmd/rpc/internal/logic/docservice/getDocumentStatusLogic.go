@@ -0,0 +1,64 @@
+package docservicelogic
+
+import (
+	"context"
+	"errors"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetDocumentStatusLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetDocumentStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetDocumentStatusLogic {
+	return &GetDocumentStatusLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetDocumentStatus 查询上传文件的处理状态，只能查询自己上传的文件
+func (l *GetDocumentStatusLogic) GetDocumentStatus(in *pb.GetDocumentStatusReq) (*pb.GetDocumentStatusResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	if in.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if in.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	record, err := l.svcCtx.FileUploadModel.FindOne(l.ctx, in.GetId())
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+	if err != nil {
+		l.Logger.Errorf("find file upload %d failed: %v", in.GetId(), err)
+		return nil, status.Error(codes.Internal, "query document failed")
+	}
+	if record.DelState != globalkey.DelStateNo || !record.UserId.Valid || record.UserId.Int64 != in.GetUserId() {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+
+	return &pb.GetDocumentStatusResp{
+		Id:         record.Id,
+		FileName:   record.FileName,
+		Status:     record.Status,
+		StatusName: model.FileStatusName(record.Status),
+		ErrorMsg:   record.ErrorMsg,
+		ChunkCount: record.ChunkCount,
+		UpdateTime: record.UpdateTime.Unix(),
+	}, nil
+}
@@ -84,10 +84,12 @@ func (l *ListChunksLogic) ListChunks(in *pb.ListChunksReq) (*pb.ListChunksResp,
 	records := make([]*pb.DocumentRecord, 0, len(resp.Items))
 
 	for _, item := range resp.Items {
+		metadata := convertMapString(item.Metadata)
 		records = append(records, &pb.DocumentRecord{
-			CustomId:    item.CustomID,
-			PageContent: item.PageContent,
-			Metadata:    convertMapString(item.Metadata),
+			CustomId:      item.CustomID,
+			PageContent:   item.PageContent,
+			Metadata:      metadata,
+			ChunkStrategy: metadata[chunkStrategyMetadataKey],
 		})
 	}
 
@@ -97,6 +99,9 @@ func (l *ListChunksLogic) ListChunks(in *pb.ListChunksReq) (*pb.ListChunksResp,
 	}, nil
 }
 
+// chunkStrategyMetadataKey RAG 服务在切片元数据中记录切片策略的字段
+const chunkStrategyMetadataKey = "chunk_strategy"
+
 func convertMapString(input map[string]any) map[string]string {
 	result := make(map[string]string)
 	for k, v := range input {
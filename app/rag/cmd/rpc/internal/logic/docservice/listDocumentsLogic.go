@@ -6,6 +6,7 @@ import (
 
 	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
 
 	"github.com/zeromicro/go-zero/core/logx"
 	"google.golang.org/grpc/codes"
@@ -47,6 +48,10 @@ func (l *ListDocumentsLogic) ListDocuments(in *pb.ListDocumentsReq) (*pb.ListDoc
 		if format := strings.TrimSpace(filter.GetFileFormat()); format != "" {
 			builder = builder.Where("LOWER(file_format) = ?", strings.ToLower(format))
 		}
+
+		if kbID := filter.GetKnowledgeBaseId(); kbID > 0 {
+			builder = builder.Where("knowledge_base_id = ?", kbID)
+		}
 	}
 
 	page := int64(1)
@@ -87,9 +92,13 @@ func (l *ListDocumentsLogic) ListDocuments(in *pb.ListDocumentsReq) (*pb.ListDoc
 
 	for _, record := range records {
 		result := &pb.ListDocumentsItem{
-			Id:       record.Id,
-			FileName: record.FileName,
-			Status: record.Status,
+			Id:              record.Id,
+			FileName:        record.FileName,
+			Status:          record.Status,
+			StatusName:      model.FileStatusName(record.Status),
+			ErrorMsg:        record.ErrorMsg,
+			ChunkCount:      record.ChunkCount,
+			KnowledgeBaseId: record.KnowledgeBaseId,
 		}
 
 		if record.FileFormat.Valid {
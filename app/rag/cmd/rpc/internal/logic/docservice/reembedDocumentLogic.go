@@ -0,0 +1,85 @@
+package docservicelogic
+
+import (
+	"context"
+	"errors"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ReembedDocumentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewReembedDocumentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReembedDocumentLogic {
+	return &ReembedDocumentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ReembedDocument 重新向量化自己上传的文件，full 先删除已有切片，incremental 只写入变化的切片。
+// 只有已上传、已完成或失败的文件可以重新排队，处理中的文件返回 FailedPrecondition
+func (l *ReembedDocumentLogic) ReembedDocument(in *pb.ReembedDocumentReq) (*pb.ReembedDocumentResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	if in.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if in.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	cleanup, ok := parseCleanupMethod(in.GetCleanupMethod())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid cleanup_method")
+	}
+
+	record, err := l.svcCtx.FileUploadModel.FindOne(l.ctx, in.GetId())
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+	if err != nil {
+		l.Logger.Errorf("find file upload %d failed: %v", in.GetId(), err)
+		return nil, status.Error(codes.Internal, "query document failed")
+	}
+	if record.DelState != globalkey.DelStateNo || !record.UserId.Valid || record.UserId.Int64 != in.GetUserId() {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+
+	// 处理中的文件不能从这些状态切换为排队中
+	switch record.Status {
+	case model.FileStatusUploaded, model.FileStatusReady, model.FileStatusFailed:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "document is %s", model.FileStatusName(record.Status))
+	}
+	err = transitStatus(l.ctx, l.svcCtx, record, model.FileStatusQueued, "", 0)
+	if errors.Is(err, model.ErrNoRowsUpdate) {
+		return nil, status.Error(codes.FailedPrecondition, "document is being processed")
+	}
+	if err != nil {
+		l.Logger.Errorf("queue file %d for re-embedding failed: %v", record.Id, err)
+		return nil, status.Error(codes.Internal, "update document status failed")
+	}
+
+	// 入队失败时文件停留在排队中，由定时清扫重新入队
+	if err := enqueueEmbed(l.ctx, l.svcCtx, record.Id, cleanup); err != nil {
+		l.Logger.Errorf("enqueue re-embed task for file %d failed: %v", record.Id, err)
+	}
+
+	return &pb.ReembedDocumentResp{
+		Id:         record.Id,
+		Status:     record.Status,
+		StatusName: model.FileStatusName(record.Status),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package docservicelogic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/ragclient"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRequeueLimit 单次清扫最多重新入队的文件数
+const defaultRequeueLimit = 100
+
+type RequeueStaleDocumentsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRequeueStaleDocumentsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RequeueStaleDocumentsLogic {
+	return &RequeueStaleDocumentsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RequeueStaleDocuments 由 mqueue 定时清扫调用，把处理状态长时间未变化的排队中、解析中、向量化中的文件重新入队，
+// 处理中的文件先切换回排队中。任务的清理方式没有持久化，重新入队时统一使用增量清理
+func (l *RequeueStaleDocumentsLogic) RequeueStaleDocuments(in *pb.RequeueStaleDocumentsReq) (*pb.RequeueStaleDocumentsResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	if in.GetStaleSeconds() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid stale_seconds")
+	}
+	limit := in.GetLimit()
+	if limit <= 0 {
+		limit = defaultRequeueLimit
+	}
+
+	before := time.Now().Add(-time.Duration(in.GetStaleSeconds()) * time.Second)
+	builder := l.svcCtx.FileUploadModel.SelectBuilder().
+		Where(squirrel.Eq{"status": []int64{model.FileStatusQueued, model.FileStatusParsing, model.FileStatusEmbedding}}).
+		Where(squirrel.Lt{"update_time": before})
+	records, err := l.svcCtx.FileUploadModel.FindPageListByIdASC(l.ctx, builder, 0, limit)
+	if err != nil {
+		l.Logger.Errorf("find stale file uploads failed: %v", err)
+		return nil, status.Error(codes.Internal, "query documents failed")
+	}
+
+	var requeued int64
+	for _, record := range records {
+		if record.Status != model.FileStatusQueued {
+			stalled := fmt.Sprintf("requeued after stalled in %s", model.FileStatusName(record.Status))
+			if err := transitStatus(l.ctx, l.svcCtx, record, model.FileStatusQueued, stalled, 0); err != nil {
+				// 查询后状态已变化，说明文件仍在被处理
+				l.Logger.Infof("skip requeue of file %d: %v", record.Id, err)
+				continue
+			}
+		}
+		if err := enqueueEmbed(l.ctx, l.svcCtx, record.Id, ragclient.EmbedCleanupIncremental); err != nil {
+			l.Logger.Errorf("requeue embed task for file %d failed: %v", record.Id, err)
+			continue
+		}
+		requeued++
+	}
+	if requeued > 0 {
+		l.Logger.Infof("requeued %d stale documents", requeued)
+	}
+
+	return &pb.RequeueStaleDocumentsResp{Requeued: requeued}, nil
+}
@@ -0,0 +1,84 @@
+package docservicelogic
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go-zero-voice-agent/app/mqueue/cmd/job/jobtype"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequeueStaleDocumentsLogic_RequeueStaleDocuments(t *testing.T) {
+	files := &fakeFileUploadModel{
+		records: map[int64]*model.FileUpload{
+			1: {Id: 1, Status: model.FileStatusQueued},
+			2: {Id: 2, Status: model.FileStatusParsing},
+			3: {Id: 3, Status: model.FileStatusEmbedding},
+		},
+		// 查询后文件 3 已被其他任务推进，不重新入队
+		reject: map[int64]bool{3: true},
+	}
+	svcCtx := newTestServiceContext(files)
+	client, inspector := newTestAsynq(t)
+	svcCtx.AsynqClient = client
+
+	logic := NewRequeueStaleDocumentsLogic(context.Background(), svcCtx)
+	resp, err := logic.RequeueStaleDocuments(&pb.RequeueStaleDocumentsReq{StaleSeconds: 60})
+	if err != nil {
+		t.Fatalf("requeue failed: %v", err)
+	}
+	if resp.Requeued != 2 {
+		t.Fatalf("requeued = %d, want 2", resp.Requeued)
+	}
+
+	query, args, _ := files.builder.ToSql()
+	if !strings.Contains(query, "status IN (?,?,?)") || !strings.Contains(query, "update_time < ?") {
+		t.Fatalf("unexpected stale query: %s %v", query, args)
+	}
+	if files.records[1].Status != model.FileStatusQueued || files.records[2].Status != model.FileStatusQueued {
+		t.Fatalf("stalled file not switched back to queued: %+v %+v", files.records[1], files.records[2])
+	}
+	if files.records[2].ErrorMsg != "requeued after stalled in parsing" {
+		t.Fatalf("unexpected error msg: %q", files.records[2].ErrorMsg)
+	}
+	if files.records[3].Status != model.FileStatusEmbedding {
+		t.Fatalf("rejected file changed: %+v", files.records[3])
+	}
+
+	tasks, err := inspector.ListPendingTasks(jobtype.QueueRagEmbed)
+	if err != nil {
+		t.Fatalf("list tasks failed: %v", err)
+	}
+	queued := map[int64]bool{}
+	for _, task := range tasks {
+		var payload jobtype.RagEmbedPayload
+		_ = json.Unmarshal(task.Payload, &payload)
+		queued[payload.FileID] = true
+	}
+	if len(tasks) != 2 || !queued[1] || !queued[2] {
+		t.Fatalf("unexpected queued tasks: %v", queued)
+	}
+
+	// 任务仍在队列中时再次清扫不会重复入队
+	if _, err := logic.RequeueStaleDocuments(&pb.RequeueStaleDocumentsReq{StaleSeconds: 60}); err != nil {
+		t.Fatalf("second requeue failed: %v", err)
+	}
+	if tasks, _ := inspector.ListPendingTasks(jobtype.QueueRagEmbed); len(tasks) != 2 {
+		t.Fatalf("duplicate tasks enqueued: %d", len(tasks))
+	}
+}
+
+func TestRequeueStaleDocumentsLogic_InvalidArgument(t *testing.T) {
+	logic := NewRequeueStaleDocumentsLogic(context.Background(), newTestServiceContext(&fakeFileUploadModel{}))
+	for _, req := range []*pb.RequeueStaleDocumentsReq{nil, {StaleSeconds: 0}} {
+		if _, err := logic.RequeueStaleDocuments(req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for %+v, got %v", req, err)
+		}
+	}
+}
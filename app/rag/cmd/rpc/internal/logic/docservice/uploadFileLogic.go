@@ -16,8 +16,11 @@ import (
 	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
 	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UploadFileLogic struct {
@@ -54,6 +57,23 @@ func (l *UploadFileLogic) UploadFile(stream pb.DocService_UploadFileServer) erro
 		return fmt.Errorf("file path must not be empty")
 	}
 
+	chunkStrategy := strings.TrimSpace(firstChunk.GetChunkStrategy())
+	if err := ragclient.ValidateChunking(chunkStrategy, firstChunk.GetChunkSize(), firstChunk.GetChunkOverlap()); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 指定知识库时先校验归属，避免上传后才发现无权写入
+	knowledgeBaseID := firstChunk.GetKnowledgeBaseId()
+	if knowledgeBaseID > 0 {
+		kb, err := l.svcCtx.KnowledgeBaseModel.FindOne(l.ctx, knowledgeBaseID)
+		if err != nil && !errors.Is(err, model.ErrNotFound) {
+			return err
+		}
+		if err != nil || kb.DelState != globalkey.DelStateNo || kb.UserId != userID {
+			return status.Error(codes.NotFound, "knowledge base not found")
+		}
+	}
+
 	// Provide a size hint to MinIO when known; fall back to streaming uploads otherwise.
 	if fileSize <= 0 {
 		if len(firstChunk.GetChunk()) > 0 {
@@ -157,14 +177,18 @@ func (l *UploadFileLogic) UploadFile(stream pb.DocService_UploadFileServer) erro
 	bucketName := sql.NullString{String: consts.MINIO_BUCKETNAME_RAG_DOCUMENT, Valid: true}
 
 	record := &model.FileUpload{
-		Version:    1,
-		UserId:     userIDValue,
-		BucketName: bucketName,
-		FileName:   fileName,
-		FileFormat: fileFormat,
-		FilePath:   objectKey,
-		StoreType:  consts.STORE_TYPE_MINIO,
-		Status:     0,
+		Version:         1,
+		UserId:          userIDValue,
+		BucketName:      bucketName,
+		FileName:        fileName,
+		FileFormat:      fileFormat,
+		FilePath:        objectKey,
+		StoreType:       consts.STORE_TYPE_MINIO,
+		Status:          model.FileStatusUploaded,
+		KnowledgeBaseId: knowledgeBaseID,
+		ChunkStrategy:   chunkStrategy,
+		ChunkSize:       firstChunk.GetChunkSize(),
+		ChunkOverlap:    firstChunk.GetChunkOverlap(),
 	}
 
 	insertResult, err := l.svcCtx.FileUploadModel.Insert(l.ctx, nil, record)
@@ -187,79 +211,20 @@ func (l *UploadFileLogic) UploadFile(stream pb.DocService_UploadFileServer) erro
 		return err
 	}
 
-	if userID <= 0 {
-		l.Logger.Infof("skip embed: empty user id for file %s", objectKey)
+	if record.Id <= 0 {
+		l.Logger.Errorf("skip embed: missing record id for file %s", objectKey)
 		return nil
 	}
 
-	fileID := objectKey
-	if record.Id > 0 {
-		fileID = strconv.FormatInt(record.Id, 10)
+	// 切换为排队中后提交向量化任务，之后的状态由 mqueue job 服务推进
+	if err := transitStatus(l.ctx, l.svcCtx, record, model.FileStatusQueued, "", 0); err != nil {
+		l.Logger.Errorf("queue file %d for embedding failed: %v", record.Id, err)
+		return nil
 	}
-
-	embedReq := &ragclient.EmbedRequest{
-		FileID:      fileID,
-		BucketName:  consts.MINIO_BUCKETNAME_RAG_DOCUMENT,
-		ObjectPath:  objectKey,
-		Filename:    record.FileName,
-		ContentType: contentType,
+	// 入队失败时文件停留在排队中，由定时清扫重新入队
+	if err := enqueueEmbed(l.ctx, l.svcCtx, record.Id, ragclient.EmbedCleanupIncremental); err != nil {
+		l.Logger.Errorf("enqueue embed task for file %d failed: %v", record.Id, err)
 	}
 
-	go func(rec model.FileUpload, req *ragclient.EmbedRequest) {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
-		if err := l.embedWithRetry(ctx, strconv.FormatInt(userID, 10), &rec, req); err != nil {
-			l.Logger.Errorf("async embed failed for file %s: %v", req.ObjectPath, err)
-		} else {
-			l.Logger.Infof("async embed succeeded for file %s", req.ObjectPath)
-			record.Status = 1
-			l.svcCtx.FileUploadModel.Update(l.ctx, nil, record)
-		}
-	}(*record, embedReq)
-
 	return nil
 }
-
-func (l *UploadFileLogic) embedWithRetry(ctx context.Context, userID string, record *model.FileUpload, embedReq *ragclient.EmbedRequest) error {
-	const (
-		maxAttempts       = 3
-		initialBackoff    = 500 * time.Millisecond
-		backoffMultiplier = 2
-	)
-
-	var lastErr error
-	backoff := initialBackoff
-
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		if _, err := l.svcCtx.RagClient.Embed(ctx, userID, embedReq); err != nil {
-			lastErr = err
-			l.Logger.Errorf("embed attempt %d failed: %v", attempt, err)
-			if attempt == maxAttempts {
-				break
-			}
-
-			timer := time.NewTimer(backoff)
-			select {
-			case <-timer.C:
-				backoff *= backoffMultiplier
-			case <-ctx.Done():
-				timer.Stop()
-				return ctx.Err()
-			}
-			continue
-		}
-
-		lastErr = nil
-
-		if record.Id > 0 {
-			record.Status = 1
-			if _, err := l.svcCtx.FileUploadModel.Update(ctx, nil, record); err != nil {
-				l.Logger.Errorf("update file status failed: %v", err)
-			}
-		}
-
-		return nil
-	}
-
-	return fmt.Errorf("embed failed after retries: %w", lastErr)
-}
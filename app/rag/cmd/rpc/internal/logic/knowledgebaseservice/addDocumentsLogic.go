@@ -0,0 +1,61 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AddDocumentsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewAddDocumentsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AddDocumentsLogic {
+	return &AddDocumentsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// AddDocuments 把自己上传的文件移入知识库，已属于其他知识库的文件会被移过来。
+// 文件保留原有切片，按知识库的向量化设置处理需要重新向量化
+func (l *AddDocumentsLogic) AddDocuments(in *pb.KnowledgeBaseDocumentsReq) (*pb.KnowledgeBaseDocumentsResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	fileIDs, err := uniqueFileIDs(in.GetFileIds())
+	if err != nil {
+		return nil, err
+	}
+	kb, err := findOwned(l.ctx, l.svcCtx, in.GetUserId(), in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// 所有文件都必须是自己上传且未删除的
+	count, err := l.svcCtx.FileUploadModel.FindCount(l.ctx, l.svcCtx.FileUploadModel.SelectBuilder().
+		Where(squirrel.Eq{"id": fileIDs, "user_id": kb.UserId}), "id")
+	if err != nil {
+		l.Logger.Errorf("count files of user %d failed: %v", kb.UserId, err)
+		return nil, status.Error(codes.Internal, "query documents failed")
+	}
+	if count != int64(len(fileIDs)) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+
+	affected, err := l.svcCtx.FileUploadModel.SetKnowledgeBase(l.ctx, nil, fileIDs, squirrel.Eq{"user_id": kb.UserId}, kb.Id)
+	if err != nil {
+		l.Logger.Errorf("add files to knowledge base %d failed: %v", kb.Id, err)
+		return nil, status.Error(codes.Internal, "update documents failed")
+	}
+	return &pb.KnowledgeBaseDocumentsResp{Affected: affected}, nil
+}
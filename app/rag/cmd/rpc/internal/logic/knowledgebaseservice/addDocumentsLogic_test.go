@@ -0,0 +1,61 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"testing"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAddDocumentsLogic_AddDocuments(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *pb.KnowledgeBaseDocumentsReq
+		wantCode codes.Code
+		affected int64
+	}{
+		{name: "own files", req: &pb.KnowledgeBaseDocumentsReq{UserId: 1, Id: 1, FileIds: []int64{2, 2}}, affected: 1},
+		{name: "other user's file", req: &pb.KnowledgeBaseDocumentsReq{UserId: 1, Id: 1, FileIds: []int64{2, 3}}, wantCode: codes.NotFound},
+		{name: "missing file", req: &pb.KnowledgeBaseDocumentsReq{UserId: 1, Id: 1, FileIds: []int64{9}}, wantCode: codes.NotFound},
+		{name: "other user's knowledge base", req: &pb.KnowledgeBaseDocumentsReq{UserId: 2, Id: 1, FileIds: []int64{3}}, wantCode: codes.NotFound},
+		{name: "empty file ids", req: &pb.KnowledgeBaseDocumentsReq{UserId: 1, Id: 1}, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcCtx, _, files := newTestServiceContext()
+			resp, err := NewAddDocumentsLogic(context.Background(), svcCtx).AddDocuments(tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err %v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				if files.files[2].KnowledgeBaseId != 0 || files.files[3].KnowledgeBaseId != 0 {
+					t.Fatal("files moved on rejected request")
+				}
+				return
+			}
+			if resp.Affected != tt.affected || files.files[2].KnowledgeBaseId != 1 {
+				t.Fatalf("file not added: %+v %+v", resp, files.files[2])
+			}
+		})
+	}
+}
+
+func TestRemoveDocumentsLogic_RemoveDocuments(t *testing.T) {
+	svcCtx, _, files := newTestServiceContext()
+	logic := NewRemoveDocumentsLogic(context.Background(), svcCtx)
+
+	if _, err := logic.RemoveDocuments(&pb.KnowledgeBaseDocumentsReq{UserId: 2, Id: 1, FileIds: []int64{1}}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for other user, got %v", err)
+	}
+	// 不在该知识库中的文件忽略
+	resp, err := logic.RemoveDocuments(&pb.KnowledgeBaseDocumentsReq{UserId: 1, Id: 1, FileIds: []int64{1, 2}})
+	if err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if resp.Affected != 1 || files.files[1].KnowledgeBaseId != 0 {
+		t.Fatalf("file not removed: %+v %+v", resp, files.files[1])
+	}
+}
@@ -0,0 +1,72 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CreateKnowledgeBaseLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateKnowledgeBaseLogic {
+	return &CreateKnowledgeBaseLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CreateKnowledgeBase 创建知识库，同一用户的知识库不能重名
+func (l *CreateKnowledgeBaseLogic) CreateKnowledgeBase(in *pb.CreateKnowledgeBaseReq) (*pb.CreateKnowledgeBaseResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	if in.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	s := settings{
+		name:           in.GetName(),
+		description:    in.GetDescription(),
+		embeddingModel: in.GetEmbeddingModel(),
+		chunkSize:      in.GetChunkSize(),
+		chunkOverlap:   in.GetChunkOverlap(),
+		chunkStrategy:  in.GetChunkStrategy(),
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if err := checkNameUnique(l.ctx, l.svcCtx, in.GetUserId(), s.name, 0); err != nil {
+		return nil, err
+	}
+
+	result, err := l.svcCtx.KnowledgeBaseModel.Insert(l.ctx, nil, &model.KnowledgeBase{
+		UserId:         in.GetUserId(),
+		Name:           s.name,
+		Description:    s.description,
+		EmbeddingModel: s.embeddingModel,
+		ChunkSize:      s.chunkSize,
+		ChunkOverlap:   s.chunkOverlap,
+		ChunkStrategy:  s.chunkStrategy,
+	})
+	if err != nil {
+		l.Logger.Errorf("insert knowledge base failed: %v", err)
+		return nil, status.Error(codes.Internal, "create knowledge base failed")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		l.Logger.Errorf("fetch last insert id failed: %v", err)
+		return nil, status.Error(codes.Internal, "create knowledge base failed")
+	}
+	return &pb.CreateKnowledgeBaseResp{Id: id}, nil
+}
@@ -0,0 +1,39 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateKnowledgeBaseLogic_CreateKnowledgeBase(t *testing.T) {
+	svcCtx, kbs, _ := newTestServiceContext()
+	logic := NewCreateKnowledgeBaseLogic(context.Background(), svcCtx)
+
+	resp, err := logic.CreateKnowledgeBase(&pb.CreateKnowledgeBaseReq{UserId: 1, Name: " manuals ", ChunkStrategy: "markdown", ChunkSize: 800, ChunkOverlap: 80})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if resp.Id != 10 || kbs.inserted.UserId != 1 || kbs.inserted.Name != "manuals" || kbs.inserted.ChunkStrategy != "markdown" {
+		t.Fatalf("unexpected insert: %+v %+v", resp, kbs.inserted)
+	}
+	// 重名检查限定在当前用户
+	if !strings.Contains(kbs.queries[0], "name = ? AND user_id = ?") || kbs.args[0][0] != "manuals" || kbs.args[0][1] != int64(1) {
+		t.Fatalf("unexpected name check: %s %v", kbs.queries[0], kbs.args[0])
+	}
+
+	kbs.nameCount = 1
+	if _, err := logic.CreateKnowledgeBase(&pb.CreateKnowledgeBaseReq{UserId: 1, Name: "manuals"}); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+	for _, req := range []*pb.CreateKnowledgeBaseReq{nil, {Name: "x"}, {UserId: 1}, {UserId: 1, Name: "x", ChunkStrategy: "unknown"}} {
+		if _, err := logic.CreateKnowledgeBase(req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for %+v, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DeleteKnowledgeBaseLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteKnowledgeBaseLogic {
+	return &DeleteKnowledgeBaseLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// DeleteKnowledgeBase 删除知识库，其中的文件移出知识库后保留
+func (l *DeleteKnowledgeBaseLogic) DeleteKnowledgeBase(in *pb.DeleteKnowledgeBaseReq) (*pb.DeleteKnowledgeBaseResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	kb, err := findOwned(l.ctx, l.svcCtx, in.GetUserId(), in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := l.svcCtx.FileUploadModel.FindAll(l.ctx, l.svcCtx.FileUploadModel.SelectBuilder().Where("knowledge_base_id = ?", kb.Id), "")
+	if err != nil {
+		l.Logger.Errorf("find files of knowledge base %d failed: %v", kb.Id, err)
+		return nil, status.Error(codes.Internal, "query documents failed")
+	}
+	fileIDs := make([]int64, 0, len(files))
+	for _, file := range files {
+		fileIDs = append(fileIDs, file.Id)
+	}
+
+	var detached int64
+	err = l.svcCtx.KnowledgeBaseModel.Trans(l.ctx, func(ctx context.Context, session sqlx.Session) error {
+		if err := l.svcCtx.KnowledgeBaseModel.DeleteSoft(ctx, session, kb); err != nil {
+			return err
+		}
+		detached, err = l.svcCtx.FileUploadModel.SetKnowledgeBase(ctx, session, fileIDs, squirrel.Eq{"knowledge_base_id": kb.Id}, 0)
+		return err
+	})
+	if err != nil {
+		l.Logger.Errorf("delete knowledge base %d failed: %v", kb.Id, err)
+		return nil, status.Error(codes.Internal, "delete knowledge base failed")
+	}
+	return &pb.DeleteKnowledgeBaseResp{DetachedCount: detached}, nil
+}
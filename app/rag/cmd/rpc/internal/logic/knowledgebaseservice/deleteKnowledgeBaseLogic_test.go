@@ -0,0 +1,38 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"testing"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeleteKnowledgeBaseLogic_DeleteKnowledgeBase(t *testing.T) {
+	svcCtx, kbs, files := newTestServiceContext()
+	logic := NewDeleteKnowledgeBaseLogic(context.Background(), svcCtx)
+
+	if _, err := logic.DeleteKnowledgeBase(&pb.DeleteKnowledgeBaseReq{UserId: 2, Id: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for other user, got %v", err)
+	}
+	if kbs.kbs[1].DelState != globalkey.DelStateNo {
+		t.Fatal("knowledge base deleted by other user")
+	}
+
+	resp, err := logic.DeleteKnowledgeBase(&pb.DeleteKnowledgeBaseReq{UserId: 1, Id: 1})
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if resp.DetachedCount != 1 || files.files[1].KnowledgeBaseId != 0 {
+		t.Fatalf("files not detached: %+v %+v", resp, files.files[1])
+	}
+	if kbs.kbs[1].DelState != globalkey.DelStateYes || files.files[1].DelState != globalkey.DelStateNo {
+		t.Fatal("knowledge base should be deleted and its files kept")
+	}
+	if _, err := logic.DeleteKnowledgeBase(&pb.DeleteKnowledgeBaseReq{UserId: 1, Id: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for deleted knowledge base, got %v", err)
+	}
+}
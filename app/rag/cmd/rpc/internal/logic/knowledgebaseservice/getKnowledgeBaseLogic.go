@@ -0,0 +1,44 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetKnowledgeBaseLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetKnowledgeBaseLogic {
+	return &GetKnowledgeBaseLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetKnowledgeBase 查询自己的知识库及其中的文件数
+func (l *GetKnowledgeBaseLogic) GetKnowledgeBase(in *pb.GetKnowledgeBaseReq) (*pb.GetKnowledgeBaseResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	kb, err := findOwned(l.ctx, l.svcCtx, in.GetUserId(), in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := l.svcCtx.FileUploadModel.CountByKnowledgeBase(l.ctx, []int64{kb.Id})
+	if err != nil {
+		l.Logger.Errorf("count files of knowledge base %d failed: %v", kb.Id, err)
+		return nil, status.Error(codes.Internal, "query documents failed")
+	}
+	return &pb.GetKnowledgeBaseResp{KnowledgeBase: knowledgeBaseToPb(kb, counts[kb.Id])}, nil
+}
@@ -0,0 +1,138 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/ragclient"
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 与 knowledge_base 表的列长度一致
+const (
+	maxNameLen           = 64
+	maxDescriptionLen    = 512
+	maxEmbeddingModelLen = 128
+	// maxMembershipFiles 单次移入或移出知识库的文件数
+	maxMembershipFiles = 200
+)
+
+// findOwned 查询用户自己的知识库，不存在、已删除或属于其他用户时都返回 NotFound
+func findOwned(ctx context.Context, svcCtx *svc.ServiceContext, userID, id int64) (*model.KnowledgeBase, error) {
+	if userID <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	kb, err := svcCtx.KnowledgeBaseModel.FindOne(ctx, id)
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "knowledge base not found")
+	}
+	if err != nil {
+		logx.WithContext(ctx).Errorf("find knowledge base %d failed: %v", id, err)
+		return nil, status.Error(codes.Internal, "query knowledge base failed")
+	}
+	if kb.DelState != globalkey.DelStateNo || kb.UserId != userID {
+		return nil, status.Error(codes.NotFound, "knowledge base not found")
+	}
+	return kb, nil
+}
+
+// settings 知识库的可编辑字段
+type settings struct {
+	name           string
+	description    string
+	embeddingModel string
+	chunkStrategy  string
+	chunkSize      int64
+	chunkOverlap   int64
+}
+
+func (s *settings) validate() error {
+	s.name = strings.TrimSpace(s.name)
+	s.description = strings.TrimSpace(s.description)
+	s.embeddingModel = strings.TrimSpace(s.embeddingModel)
+	s.chunkStrategy = strings.TrimSpace(s.chunkStrategy)
+
+	if s.name == "" || utf8.RuneCountInString(s.name) > maxNameLen {
+		return status.Error(codes.InvalidArgument, "name is required and must not exceed 64 characters")
+	}
+	if utf8.RuneCountInString(s.description) > maxDescriptionLen {
+		return status.Error(codes.InvalidArgument, "description must not exceed 512 characters")
+	}
+	if len(s.embeddingModel) > maxEmbeddingModelLen {
+		return status.Error(codes.InvalidArgument, "embedding_model must not exceed 128 characters")
+	}
+	if err := ragclient.ValidateChunking(s.chunkStrategy, s.chunkSize, s.chunkOverlap); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// checkNameUnique 同一用户的知识库不能重名，excludeID 为更新时的知识库自身
+func checkNameUnique(ctx context.Context, svcCtx *svc.ServiceContext, userID int64, name string, excludeID int64) error {
+	builder := svcCtx.KnowledgeBaseModel.SelectBuilder().Where(squirrel.Eq{"user_id": userID, "name": name})
+	if excludeID > 0 {
+		builder = builder.Where(squirrel.NotEq{"id": excludeID})
+	}
+	count, err := svcCtx.KnowledgeBaseModel.FindCount(ctx, builder, "id")
+	if err != nil {
+		logx.WithContext(ctx).Errorf("count knowledge base by name failed: %v", err)
+		return status.Error(codes.Internal, "query knowledge base failed")
+	}
+	if count > 0 {
+		return status.Error(codes.AlreadyExists, "knowledge base name already exists")
+	}
+	return nil
+}
+
+// uniqueFileIDs 去重并校验文件 id
+func uniqueFileIDs(ids []int64) ([]int64, error) {
+	seen := make(map[int64]struct{}, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid file id")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	if len(result) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "file_ids must not be empty")
+	}
+	if len(result) > maxMembershipFiles {
+		return nil, status.Error(codes.InvalidArgument, "too many file_ids")
+	}
+	return result, nil
+}
+
+func knowledgeBaseToPb(kb *model.KnowledgeBase, documentCount int64) *pb.KnowledgeBase {
+	return &pb.KnowledgeBase{
+		Id:             kb.Id,
+		UserId:         kb.UserId,
+		Name:           kb.Name,
+		Description:    kb.Description,
+		EmbeddingModel: kb.EmbeddingModel,
+		ChunkSize:      kb.ChunkSize,
+		ChunkOverlap:   kb.ChunkOverlap,
+		ChunkStrategy:  kb.ChunkStrategy,
+		DocumentCount:  documentCount,
+		CreateTime:     kb.CreateTime.Unix(),
+		UpdateTime:     kb.UpdateTime.Unix(),
+	}
+}
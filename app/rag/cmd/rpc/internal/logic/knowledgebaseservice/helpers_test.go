@@ -0,0 +1,243 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeKnowledgeBaseModel 按 id 保存知识库，按条件查询的方法记录 SQL 并返回预设结果
+type fakeKnowledgeBaseModel struct {
+	model.KnowledgeBaseModel
+	kbs       map[int64]*model.KnowledgeBase
+	nameCount int64 // 同名知识库数
+	updateErr error
+	queries   []string
+	args      [][]interface{}
+	inserted  *model.KnowledgeBase
+}
+
+func (m *fakeKnowledgeBaseModel) record(builder squirrel.SelectBuilder) {
+	query, args, _ := builder.ToSql()
+	m.queries = append(m.queries, query)
+	m.args = append(m.args, args)
+}
+
+func (m *fakeKnowledgeBaseModel) FindOne(ctx context.Context, id int64) (*model.KnowledgeBase, error) {
+	kb, ok := m.kbs[id]
+	if !ok {
+		return nil, model.ErrNotFound
+	}
+	copied := *kb
+	return &copied, nil
+}
+
+func (m *fakeKnowledgeBaseModel) SelectBuilder() squirrel.SelectBuilder {
+	return squirrel.Select().From("knowledge_base")
+}
+
+func (m *fakeKnowledgeBaseModel) FindCount(ctx context.Context, builder squirrel.SelectBuilder, field string) (int64, error) {
+	m.record(builder.Columns("COUNT(" + field + ")"))
+	return m.nameCount, nil
+}
+
+func (m *fakeKnowledgeBaseModel) FindPageListByPageWithTotal(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*model.KnowledgeBase, int64, error) {
+	m.record(builder.Columns("*"))
+	var list []*model.KnowledgeBase
+	for _, kb := range m.kbs {
+		list = append(list, kb)
+	}
+	return list, int64(len(list)), nil
+}
+
+func (m *fakeKnowledgeBaseModel) Insert(ctx context.Context, session sqlx.Session, data *model.KnowledgeBase) (sql.Result, error) {
+	m.inserted = data
+	return fakeResult(10), nil
+}
+
+func (m *fakeKnowledgeBaseModel) UpdateWithVersion(ctx context.Context, session sqlx.Session, data *model.KnowledgeBase) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	data.Version++
+	m.kbs[data.Id] = data
+	return nil
+}
+
+func (m *fakeKnowledgeBaseModel) Trans(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) error {
+	return fn(ctx, nil)
+}
+
+func (m *fakeKnowledgeBaseModel) DeleteSoft(ctx context.Context, session sqlx.Session, data *model.KnowledgeBase) error {
+	data.DelState = globalkey.DelStateYes
+	m.kbs[data.Id] = data
+	return nil
+}
+
+// fakeFileUploadModel 在内存中按 where 条件移动文件，计数与查询返回满足 user_id 或 knowledge_base_id 的文件
+type fakeFileUploadModel struct {
+	model.FileUploadModel
+	files map[int64]*model.FileUpload
+}
+
+func (m *fakeFileUploadModel) SelectBuilder() squirrel.SelectBuilder {
+	return squirrel.Select().From("file_upload")
+}
+
+// FindCount AddDocuments 统计 ids 中属于用户的文件，参数依次为文件 id 和 user_id
+func (m *fakeFileUploadModel) FindCount(ctx context.Context, builder squirrel.SelectBuilder, field string) (int64, error) {
+	_, args, _ := builder.Columns("COUNT(" + field + ")").ToSql()
+	userID := args[len(args)-1].(int64)
+	var count int64
+	for _, arg := range args[:len(args)-1] {
+		if file, ok := m.files[arg.(int64)]; ok && file.UserId.Int64 == userID && file.DelState == globalkey.DelStateNo {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindAll DeleteKnowledgeBase 查询知识库中的文件，参数为 knowledge_base_id
+func (m *fakeFileUploadModel) FindAll(ctx context.Context, builder squirrel.SelectBuilder, orderBy string) ([]*model.FileUpload, error) {
+	_, args, _ := builder.Columns("*").ToSql()
+	var list []*model.FileUpload
+	for _, file := range m.files {
+		if file.KnowledgeBaseId == args[0].(int64) {
+			list = append(list, file)
+		}
+	}
+	return list, nil
+}
+
+func (m *fakeFileUploadModel) SetKnowledgeBase(ctx context.Context, session sqlx.Session, ids []int64, where squirrel.Eq, knowledgeBaseId int64) (int64, error) {
+	var affected int64
+	for _, id := range ids {
+		file, ok := m.files[id]
+		if !ok || file.DelState != globalkey.DelStateNo {
+			continue
+		}
+		if userID, ok := where["user_id"]; ok && file.UserId.Int64 != userID.(int64) {
+			continue
+		}
+		if kbID, ok := where["knowledge_base_id"]; ok && file.KnowledgeBaseId != kbID.(int64) {
+			continue
+		}
+		file.KnowledgeBaseId = knowledgeBaseId
+		affected++
+	}
+	return affected, nil
+}
+
+func (m *fakeFileUploadModel) CountByKnowledgeBase(ctx context.Context, knowledgeBaseIds []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	for _, file := range m.files {
+		counts[file.KnowledgeBaseId]++
+	}
+	return counts, nil
+}
+
+type fakeResult int64
+
+func (r fakeResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+// newTestServiceContext 用户 1 拥有知识库 1 和已删除的知识库 2，用户 2 拥有知识库 3；
+// 文件 1、2 属于用户 1，文件 1 在知识库 1 中，文件 3 属于用户 2
+func newTestServiceContext() (*svc.ServiceContext, *fakeKnowledgeBaseModel, *fakeFileUploadModel) {
+	now := time.Now()
+	kbs := &fakeKnowledgeBaseModel{kbs: map[int64]*model.KnowledgeBase{
+		1: {Id: 1, UserId: 1, Name: "docs", CreateTime: now, UpdateTime: now},
+		2: {Id: 2, UserId: 1, Name: "old", DelState: globalkey.DelStateYes},
+		3: {Id: 3, UserId: 2, Name: "other"},
+	}}
+	files := &fakeFileUploadModel{files: map[int64]*model.FileUpload{
+		1: {Id: 1, UserId: sql.NullInt64{Int64: 1, Valid: true}, KnowledgeBaseId: 1},
+		2: {Id: 2, UserId: sql.NullInt64{Int64: 1, Valid: true}},
+		3: {Id: 3, UserId: sql.NullInt64{Int64: 2, Valid: true}},
+	}}
+	return &svc.ServiceContext{KnowledgeBaseModel: kbs, FileUploadModel: files}, kbs, files
+}
+
+func TestFindOwned(t *testing.T) {
+	svcCtx, _, _ := newTestServiceContext()
+	tests := []struct {
+		name     string
+		userID   int64
+		id       int64
+		wantCode codes.Code
+	}{
+		{name: "owned", userID: 1, id: 1, wantCode: codes.OK},
+		{name: "invalid user", userID: 0, id: 1, wantCode: codes.InvalidArgument},
+		{name: "invalid id", userID: 1, id: 0, wantCode: codes.InvalidArgument},
+		{name: "missing", userID: 1, id: 9, wantCode: codes.NotFound},
+		{name: "deleted", userID: 1, id: 2, wantCode: codes.NotFound},
+		{name: "other user", userID: 1, id: 3, wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb, err := findOwned(context.Background(), svcCtx, tt.userID, tt.id)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v", code, tt.wantCode)
+			}
+			if err == nil && kb.Id != tt.id {
+				t.Fatalf("unexpected knowledge base: %+v", kb)
+			}
+		})
+	}
+}
+
+func TestUniqueFileIDs(t *testing.T) {
+	ids, err := uniqueFileIDs([]int64{3, 1, 3})
+	if err != nil || len(ids) != 2 || ids[0] != 3 || ids[1] != 1 {
+		t.Fatalf("unexpected ids: %v %v", ids, err)
+	}
+	tooMany := make([]int64, maxMembershipFiles+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	for _, input := range [][]int64{nil, {0}, {1, -1}, tooMany} {
+		if _, err := uniqueFileIDs(input); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for %d ids, got %v", len(input), err)
+		}
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       settings
+		wantErr bool
+	}{
+		{name: "valid", s: settings{name: " docs ", chunkStrategy: "recursive", chunkSize: 500, chunkOverlap: 50}},
+		{name: "empty name", s: settings{name: "  "}, wantErr: true},
+		{name: "long name", s: settings{name: strings.Repeat("知", maxNameLen+1)}, wantErr: true},
+		{name: "long description", s: settings{name: "docs", description: strings.Repeat("a", maxDescriptionLen+1)}, wantErr: true},
+		{name: "long embedding model", s: settings{name: "docs", embeddingModel: strings.Repeat("a", maxEmbeddingModelLen+1)}, wantErr: true},
+		{name: "invalid chunking", s: settings{name: "docs", chunkSize: 100, chunkOverlap: 100}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.s.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("code = %v, want InvalidArgument", status.Code(err))
+			}
+			if err == nil && tt.s.name != "docs" {
+				t.Fatalf("name not trimmed: %q", tt.s.name)
+			}
+		})
+	}
+}
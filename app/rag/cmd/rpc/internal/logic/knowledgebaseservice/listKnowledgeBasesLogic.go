@@ -0,0 +1,77 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"strings"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListKnowledgeBasesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListKnowledgeBasesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListKnowledgeBasesLogic {
+	return &ListKnowledgeBasesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListKnowledgeBases 分页查询自己的知识库，按名称模糊过滤
+func (l *ListKnowledgeBasesLogic) ListKnowledgeBases(in *pb.ListKnowledgeBasesReq) (*pb.ListKnowledgeBasesResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	if in.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	builder := l.svcCtx.KnowledgeBaseModel.SelectBuilder().Where("user_id = ?", in.GetUserId())
+	if name := strings.TrimSpace(in.GetName()); name != "" {
+		builder = builder.Where("name LIKE ?", "%"+name+"%")
+	}
+
+	page, pageSize := int64(1), int64(20)
+	if pq := in.GetPageQuery(); pq != nil {
+		if pq.GetPage() > 0 {
+			page = pq.GetPage()
+		}
+		if pq.GetPageSize() > 0 {
+			pageSize = min(pq.GetPageSize(), 200)
+		}
+	}
+
+	records, total, err := l.svcCtx.KnowledgeBaseModel.FindPageListByPageWithTotal(l.ctx, builder, page, pageSize, "id DESC")
+	if err != nil {
+		l.Logger.Errorf("list knowledge bases failed: %v", err)
+		return nil, status.Error(codes.Internal, "query knowledge bases failed")
+	}
+	if len(records) == 0 {
+		return &pb.ListKnowledgeBasesResp{Total: total}, nil
+	}
+
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.Id)
+	}
+	counts, err := l.svcCtx.FileUploadModel.CountByKnowledgeBase(l.ctx, ids)
+	if err != nil {
+		l.Logger.Errorf("count files of knowledge bases failed: %v", err)
+		return nil, status.Error(codes.Internal, "query documents failed")
+	}
+
+	list := make([]*pb.KnowledgeBase, 0, len(records))
+	for _, record := range records {
+		list = append(list, knowledgeBaseToPb(record, counts[record.Id]))
+	}
+	return &pb.ListKnowledgeBasesResp{KnowledgeBases: list, Total: total}, nil
+}
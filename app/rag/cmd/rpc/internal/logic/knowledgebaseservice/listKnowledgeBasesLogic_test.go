@@ -0,0 +1,43 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListKnowledgeBasesLogic_ListKnowledgeBases(t *testing.T) {
+	svcCtx, kbs, _ := newTestServiceContext()
+	logic := NewListKnowledgeBasesLogic(context.Background(), svcCtx)
+
+	if _, err := logic.ListKnowledgeBases(&pb.ListKnowledgeBasesReq{UserId: 1, Name: " doc "}); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(kbs.queries[0], "user_id = ? AND name LIKE ?") || kbs.args[0][0] != int64(1) || kbs.args[0][1] != "%doc%" {
+		t.Fatalf("list should be limited to the user: %s %v", kbs.queries[0], kbs.args[0])
+	}
+	if _, err := logic.ListKnowledgeBases(&pb.ListKnowledgeBasesReq{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetKnowledgeBaseLogic_GetKnowledgeBase(t *testing.T) {
+	svcCtx, _, _ := newTestServiceContext()
+	logic := NewGetKnowledgeBaseLogic(context.Background(), svcCtx)
+
+	resp, err := logic.GetKnowledgeBase(&pb.GetKnowledgeBaseReq{UserId: 1, Id: 1})
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if resp.KnowledgeBase.Name != "docs" || resp.KnowledgeBase.DocumentCount != 1 {
+		t.Fatalf("unexpected knowledge base: %+v", resp.KnowledgeBase)
+	}
+	if _, err := logic.GetKnowledgeBase(&pb.GetKnowledgeBaseReq{UserId: 2, Id: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for other user, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RemoveDocumentsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRemoveDocumentsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RemoveDocumentsLogic {
+	return &RemoveDocumentsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RemoveDocuments 把文件移出知识库，不在该知识库中的文件忽略，文件本身保留
+func (l *RemoveDocumentsLogic) RemoveDocuments(in *pb.KnowledgeBaseDocumentsReq) (*pb.KnowledgeBaseDocumentsResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	fileIDs, err := uniqueFileIDs(in.GetFileIds())
+	if err != nil {
+		return nil, err
+	}
+	kb, err := findOwned(l.ctx, l.svcCtx, in.GetUserId(), in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := l.svcCtx.FileUploadModel.SetKnowledgeBase(l.ctx, nil, fileIDs, squirrel.Eq{"user_id": kb.UserId, "knowledge_base_id": kb.Id}, 0)
+	if err != nil {
+		l.Logger.Errorf("remove files from knowledge base %d failed: %v", kb.Id, err)
+		return nil, status.Error(codes.Internal, "update documents failed")
+	}
+	return &pb.KnowledgeBaseDocumentsResp{Affected: affected}, nil
+}
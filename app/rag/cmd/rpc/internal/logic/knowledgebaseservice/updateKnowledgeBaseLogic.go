@@ -0,0 +1,70 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"errors"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UpdateKnowledgeBaseLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUpdateKnowledgeBaseLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateKnowledgeBaseLogic {
+	return &UpdateKnowledgeBaseLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// UpdateKnowledgeBase 修改知识库，修改向量化模型或切片设置后，已有文件需要重新向量化才会生效
+func (l *UpdateKnowledgeBaseLogic) UpdateKnowledgeBase(in *pb.UpdateKnowledgeBaseReq) (*pb.UpdateKnowledgeBaseResp, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
+	}
+	s := settings{
+		name:           in.GetName(),
+		description:    in.GetDescription(),
+		embeddingModel: in.GetEmbeddingModel(),
+		chunkSize:      in.GetChunkSize(),
+		chunkOverlap:   in.GetChunkOverlap(),
+		chunkStrategy:  in.GetChunkStrategy(),
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	kb, err := findOwned(l.ctx, l.svcCtx, in.GetUserId(), in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNameUnique(l.ctx, l.svcCtx, kb.UserId, s.name, kb.Id); err != nil {
+		return nil, err
+	}
+
+	kb.Name = s.name
+	kb.Description = s.description
+	kb.EmbeddingModel = s.embeddingModel
+	kb.ChunkSize = s.chunkSize
+	kb.ChunkOverlap = s.chunkOverlap
+	kb.ChunkStrategy = s.chunkStrategy
+	err = l.svcCtx.KnowledgeBaseModel.UpdateWithVersion(l.ctx, nil, kb)
+	if errors.Is(err, model.ErrNoRowsUpdate) {
+		return nil, status.Error(codes.Aborted, "knowledge base was modified concurrently")
+	}
+	if err != nil {
+		l.Logger.Errorf("update knowledge base %d failed: %v", kb.Id, err)
+		return nil, status.Error(codes.Internal, "update knowledge base failed")
+	}
+	return &pb.UpdateKnowledgeBaseResp{}, nil
+}
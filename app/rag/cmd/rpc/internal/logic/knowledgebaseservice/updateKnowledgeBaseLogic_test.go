@@ -0,0 +1,53 @@
+package knowledgebaseservicelogic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
+	"go-zero-voice-agent/app/rag/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateKnowledgeBaseLogic_UpdateKnowledgeBase(t *testing.T) {
+	svcCtx, kbs, _ := newTestServiceContext()
+	logic := NewUpdateKnowledgeBaseLogic(context.Background(), svcCtx)
+
+	if _, err := logic.UpdateKnowledgeBase(&pb.UpdateKnowledgeBaseReq{UserId: 1, Id: 1, Name: "renamed", EmbeddingModel: "bge-m3"}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if kb := kbs.kbs[1]; kb.Name != "renamed" || kb.EmbeddingModel != "bge-m3" || kb.Version != 1 {
+		t.Fatalf("knowledge base not updated: %+v", kb)
+	}
+	// 重名检查排除知识库自身
+	if !strings.Contains(kbs.queries[0], "id <> ?") {
+		t.Fatalf("name check should exclude itself: %s", kbs.queries[0])
+	}
+
+	tests := []struct {
+		name     string
+		req      *pb.UpdateKnowledgeBaseReq
+		setup    func()
+		wantCode codes.Code
+	}{
+		{name: "other user", req: &pb.UpdateKnowledgeBaseReq{UserId: 1, Id: 3, Name: "x"}, wantCode: codes.NotFound},
+		{name: "deleted", req: &pb.UpdateKnowledgeBaseReq{UserId: 1, Id: 2, Name: "x"}, wantCode: codes.NotFound},
+		{name: "invalid name", req: &pb.UpdateKnowledgeBaseReq{UserId: 1, Id: 1}, wantCode: codes.InvalidArgument},
+		{name: "duplicate name", req: &pb.UpdateKnowledgeBaseReq{UserId: 1, Id: 1, Name: "x"}, setup: func() { kbs.nameCount = 1 }, wantCode: codes.AlreadyExists},
+		{name: "concurrent update", req: &pb.UpdateKnowledgeBaseReq{UserId: 1, Id: 1, Name: "x"}, setup: func() { kbs.updateErr = model.ErrNoRowsUpdate }, wantCode: codes.Aborted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kbs.nameCount, kbs.updateErr = 0, nil
+			if tt.setup != nil {
+				tt.setup()
+			}
+			if _, err := logic.UpdateKnowledgeBase(tt.req); status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package ragservicelogic
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxQueryKnowledgeBases 单次检索最多指定的知识库数
+const maxQueryKnowledgeBases = 20
+
+// resolveKnowledgeBases 把知识库 id 解析为其中的文件 id，知识库必须属于该用户，
+// 不存在或已删除时返回 NotFound，属于其他用户时返回 PermissionDenied
+func resolveKnowledgeBases(ctx context.Context, svcCtx *svc.ServiceContext, userID int64, kbIDs []int64) ([]string, error) {
+	if len(kbIDs) == 0 {
+		return nil, nil
+	}
+	if len(kbIDs) > maxQueryKnowledgeBases {
+		return nil, status.Error(codes.InvalidArgument, "too many knowledge_base_ids")
+	}
+
+	owned := make([]int64, 0, len(kbIDs))
+	seen := make(map[int64]struct{}, len(kbIDs))
+	for _, id := range kbIDs {
+		if id <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid knowledge_base_id")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		kb, err := svcCtx.KnowledgeBaseModel.FindOne(ctx, id)
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "knowledge base %d not found", id)
+		}
+		if err != nil {
+			logx.WithContext(ctx).Errorf("find knowledge base %d failed: %v", id, err)
+			return nil, status.Error(codes.Internal, "query knowledge base failed")
+		}
+		if kb.DelState != globalkey.DelStateNo {
+			return nil, status.Errorf(codes.NotFound, "knowledge base %d not found", id)
+		}
+		if kb.UserId != userID {
+			return nil, status.Errorf(codes.PermissionDenied, "knowledge base %d is not owned by user", id)
+		}
+		owned = append(owned, kb.Id)
+	}
+
+	builder := svcCtx.FileUploadModel.SelectBuilder().
+		Where(squirrel.Eq{"knowledge_base_id": owned, "user_id": userID})
+	files, err := svcCtx.FileUploadModel.FindAll(ctx, builder, "id ASC")
+	if err != nil {
+		logx.WithContext(ctx).Errorf("find files of knowledge bases %v failed: %v", owned, err)
+		return nil, status.Error(codes.Internal, "query documents failed")
+	}
+
+	fileIDs := make([]string, 0, len(files))
+	for _, file := range files {
+		fileIDs = append(fileIDs, strconv.FormatInt(file.Id, 10))
+	}
+	return fileIDs, nil
+}
@@ -0,0 +1,118 @@
+package ragservicelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/rag/model"
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeKnowledgeBaseModel struct {
+	model.KnowledgeBaseModel
+	kbs map[int64]*model.KnowledgeBase
+	err error
+}
+
+func (m *fakeKnowledgeBaseModel) FindOne(ctx context.Context, id int64) (*model.KnowledgeBase, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	kb, ok := m.kbs[id]
+	if !ok {
+		return nil, model.ErrNotFound
+	}
+	return kb, nil
+}
+
+// fakeFileUploadModel 按查询条件中的知识库 id 和 user_id 返回文件
+type fakeFileUploadModel struct {
+	model.FileUploadModel
+	files []*model.FileUpload
+	query string
+}
+
+func (m *fakeFileUploadModel) SelectBuilder() squirrel.SelectBuilder {
+	return squirrel.Select().From("file_upload")
+}
+
+func (m *fakeFileUploadModel) FindAll(ctx context.Context, builder squirrel.SelectBuilder, orderBy string) ([]*model.FileUpload, error) {
+	query, args, _ := builder.Columns("*").ToSql()
+	m.query = query
+	userID := args[len(args)-1].(int64)
+	kbIDs := make(map[int64]bool)
+	for _, arg := range args[:len(args)-1] {
+		kbIDs[arg.(int64)] = true
+	}
+	var list []*model.FileUpload
+	for _, file := range m.files {
+		if kbIDs[file.KnowledgeBaseId] && file.UserId.Int64 == userID {
+			list = append(list, file)
+		}
+	}
+	return list, nil
+}
+
+func TestResolveKnowledgeBases(t *testing.T) {
+	kbs := &fakeKnowledgeBaseModel{kbs: map[int64]*model.KnowledgeBase{
+		1: {Id: 1, UserId: 1},
+		2: {Id: 2, UserId: 1},
+		3: {Id: 3, UserId: 1, DelState: globalkey.DelStateYes},
+		4: {Id: 4, UserId: 2},
+	}}
+	files := &fakeFileUploadModel{files: []*model.FileUpload{
+		{Id: 10, UserId: sql.NullInt64{Int64: 1, Valid: true}, KnowledgeBaseId: 1},
+		{Id: 11, UserId: sql.NullInt64{Int64: 1, Valid: true}, KnowledgeBaseId: 2},
+		{Id: 12, UserId: sql.NullInt64{Int64: 1, Valid: true}},
+		// 其他用户的文件即使被移入同一知识库 id 也不返回
+		{Id: 13, UserId: sql.NullInt64{Int64: 2, Valid: true}, KnowledgeBaseId: 1},
+	}}
+	svcCtx := &svc.ServiceContext{KnowledgeBaseModel: kbs, FileUploadModel: files}
+
+	tooMany := make([]int64, maxQueryKnowledgeBases+1)
+	for i := range tooMany {
+		tooMany[i] = 1
+	}
+	tests := []struct {
+		name     string
+		kbIDs    []int64
+		want     []string
+		wantCode codes.Code
+	}{
+		{name: "empty", kbIDs: nil, want: nil},
+		{name: "owned", kbIDs: []int64{1, 2, 1}, want: []string{"10", "11"}},
+		{name: "too many", kbIDs: tooMany, wantCode: codes.InvalidArgument},
+		{name: "invalid id", kbIDs: []int64{1, 0}, wantCode: codes.InvalidArgument},
+		{name: "missing", kbIDs: []int64{1, 9}, wantCode: codes.NotFound},
+		{name: "deleted", kbIDs: []int64{3}, wantCode: codes.NotFound},
+		{name: "other user", kbIDs: []int64{1, 4}, wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveKnowledgeBases(context.Background(), svcCtx, 1, tt.kbIDs)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err %v)", code, tt.wantCode, err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("file ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !strings.Contains(files.query, "knowledge_base_id IN (?,?) AND user_id = ?") {
+		t.Fatalf("files should be limited to the user: %s", files.query)
+	}
+
+	kbs.err = errors.New("db down")
+	if _, err := resolveKnowledgeBases(context.Background(), svcCtx, 1, []int64{1}); status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+}
@@ -44,10 +44,16 @@ func (l *QueryLogic) Query(in *pb.QueryReq) (*pb.QueryResp, error) {
 		return nil, status.Error(codes.InvalidArgument, "query text is required")
 	}
 
+	opts, err := retrievalOptions(in.GetRetrievalMode(), in.VectorWeight, in.KeywordWeight, in.GetRrfK())
+	if err != nil {
+		return nil, err
+	}
+
 	req := &ragclient.QueryRequest{
-		Query:  queryText,
-		FileID: strings.TrimSpace(in.GetFileId()),
-		TopK:   int(in.GetTopK()),
+		Query:            queryText,
+		FileID:           strings.TrimSpace(in.GetFileId()),
+		TopK:             int(in.GetTopK()),
+		RetrievalOptions: opts,
 	}
 
 	if req.TopK < 0 {
@@ -67,6 +73,20 @@ func (l *QueryLogic) Query(in *pb.QueryReq) (*pb.QueryResp, error) {
 	return buildQueryResp(resp), nil
 }
 
+// retrievalOptions 校验检索方式与融合参数，未设置的字段交由 RAG 服务使用默认值（向量检索、权重 1、rrfK 60）
+func retrievalOptions(mode string, vectorWeight, keywordWeight *float64, rrfK int32) (ragclient.RetrievalOptions, error) {
+	opts := ragclient.RetrievalOptions{
+		Mode:          ragclient.RetrievalMode(strings.ToLower(strings.TrimSpace(mode))),
+		VectorWeight:  vectorWeight,
+		KeywordWeight: keywordWeight,
+		RRFK:          int(rrfK),
+	}
+	if err := ragclient.ValidateRetrieval(opts); err != nil {
+		return ragclient.RetrievalOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
+}
+
 func buildQueryResp(resp *ragclient.QueryResponse) *pb.QueryResp {
 	if resp == nil || len(resp.Results) == 0 {
 		return &pb.QueryResp{}
@@ -2,6 +2,7 @@ package ragservicelogic
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -28,6 +29,7 @@ func NewQueryMultipleLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Que
 	}
 }
 
+// QueryMultiple 在指定文件和知识库中的文件里检索，知识库在服务端解析为文件 id 并校验归属
 func (l *QueryMultipleLogic) QueryMultiple(in *pb.QueryMultipleReq) (*pb.QueryResp, error) {
 	if in == nil {
 		return nil, status.Error(codes.InvalidArgument, "request must not be nil")
@@ -43,21 +45,43 @@ func (l *QueryMultipleLogic) QueryMultiple(in *pb.QueryMultipleReq) (*pb.QueryRe
 		return nil, status.Error(codes.InvalidArgument, "query text is required")
 	}
 
-	cleanedIDs := make([]string, 0, len(in.GetFileIds()))
-	for _, id := range in.GetFileIds() {
-		if trimmed := strings.TrimSpace(id); trimmed != "" {
-			cleanedIDs = append(cleanedIDs, trimmed)
+	opts, err := retrievalOptions(in.GetRetrievalMode(), in.VectorWeight, in.KeywordWeight, in.GetRrfK())
+	if err != nil {
+		return nil, err
+	}
+
+	kbFileIDs, err := resolveKnowledgeBases(l.ctx, l.svcCtx, userID, in.GetKnowledgeBaseIds())
+	if err != nil {
+		return nil, err
+	}
+
+	cleanedIDs := make([]string, 0, len(in.GetFileIds())+len(kbFileIDs))
+	seen := make(map[string]struct{}, cap(cleanedIDs))
+	for _, id := range slices.Concat(in.GetFileIds(), kbFileIDs) {
+		trimmed := strings.TrimSpace(id)
+		if trimmed == "" {
+			continue
 		}
+		if _, ok := seen[trimmed]; ok {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		cleanedIDs = append(cleanedIDs, trimmed)
 	}
 
 	if len(cleanedIDs) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "file_ids must not be empty")
+		// 指定的知识库中还没有文件
+		if len(in.GetKnowledgeBaseIds()) > 0 {
+			return &pb.QueryResp{}, nil
+		}
+		return nil, status.Error(codes.InvalidArgument, "file_ids or knowledge_base_ids must not be empty")
 	}
 
 	req := &ragclient.QueryMultipleRequest{
-		Query:   queryText,
-		FileIDs: cleanedIDs,
-		TopK:    int(in.GetTopK()),
+		Query:            queryText,
+		FileIDs:          cleanedIDs,
+		TopK:             int(in.GetTopK()),
+		RetrievalOptions: opts,
 	}
 
 	if req.TopK < 0 {
@@ -298,6 +298,24 @@ func (c *Client) Embed(ctx context.Context, userID string, req *EmbedRequest) (*
 			return nil, fmt.Errorf("ragclient: write entity id failed: %w", err)
 		}
 	}
+	if strings.TrimSpace(req.EmbeddingModel) != "" {
+		if err := writer.WriteField("embedding_model", req.EmbeddingModel); err != nil {
+			return nil, fmt.Errorf("ragclient: write embedding model failed: %w", err)
+		}
+	}
+	if req.ChunkStrategy != "" {
+		if err := writer.WriteField("chunk_strategy", string(req.ChunkStrategy)); err != nil {
+			return nil, fmt.Errorf("ragclient: write chunk strategy failed: %w", err)
+		}
+	}
+	if req.ChunkSize > 0 {
+		if err := writer.WriteField("chunk_size", strconv.Itoa(req.ChunkSize)); err != nil {
+			return nil, fmt.Errorf("ragclient: write chunk size failed: %w", err)
+		}
+		if err := writer.WriteField("chunk_overlap", strconv.Itoa(req.ChunkOverlap)); err != nil {
+			return nil, fmt.Errorf("ragclient: write chunk overlap failed: %w", err)
+		}
+	}
 
 	if err := writer.Close(); err != nil {
 		return nil, fmt.Errorf("ragclient: close multipart writer failed: %w", err)
@@ -1,6 +1,10 @@
 package ragclient
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+)
 
 // ErrMissingUserID indicates the caller did not provide a required user identifier.
 var ErrMissingUserID = errors.New("ragclient: missing user id")
@@ -11,6 +15,7 @@ type QueryRequest struct {
 	FileID   string  `json:"file_id,omitempty"`
 	TopK     int     `json:"top_k"`
 	EntityID *string `json:"entity_id,omitempty"`
+	RetrievalOptions
 }
 
 // QueryMultipleRequest is sent to the /query-multiple endpoint for cross-file retrieval.
@@ -18,9 +23,65 @@ type QueryMultipleRequest struct {
 	Query   string   `json:"query"`
 	FileIDs []string `json:"file_ids"`
 	TopK    int      `json:"top_k"`
+	RetrievalOptions
+}
+
+// RetrievalMode mirrors the Python RetrievalMode enum and selects how chunks are retrieved.
+type RetrievalMode string
+
+const (
+	// RetrievalModeVector ranks chunks by embedding similarity only. It is the service default.
+	RetrievalModeVector RetrievalMode = "vector"
+	// RetrievalModeKeyword ranks chunks by BM25 keyword relevance only.
+	RetrievalModeKeyword RetrievalMode = "keyword"
+	// RetrievalModeHybrid fuses vector and keyword rankings with reciprocal-rank fusion.
+	RetrievalModeHybrid RetrievalMode = "hybrid"
+)
+
+// ParseRetrievalMode reports whether s names a known mode; an empty string selects the service default.
+func ParseRetrievalMode(s string) (RetrievalMode, bool) {
+	switch mode := RetrievalMode(s); mode {
+	case "", RetrievalModeVector, RetrievalModeKeyword, RetrievalModeHybrid:
+		return mode, true
+	default:
+		return "", false
+	}
 }
 
-// RetrievalResult captures a single vector similarity hit returned by the RAG service.
+// RetrievalOptions selects the retrieval mode and fusion parameters of a query.
+// Unset fields fall back to the service defaults: vector mode, weights of 1 and an RRF constant of 60.
+// Weights are pointers so that an explicit 0 is sent rather than dropped by omitempty.
+type RetrievalOptions struct {
+	Mode          RetrievalMode `json:"mode,omitempty"`
+	VectorWeight  *float64      `json:"vector_weight,omitempty"`
+	KeywordWeight *float64      `json:"keyword_weight,omitempty"`
+	RRFK          int           `json:"rrf_k,omitempty"`
+}
+
+// MaxRRFK bounds the reciprocal-rank fusion constant accepted by ValidateRetrieval.
+const MaxRRFK = 1000
+
+// ValidateRetrieval checks retrieval options before they are sent to /query or /query-multiple.
+func ValidateRetrieval(opts RetrievalOptions) error {
+	if _, ok := ParseRetrievalMode(string(opts.Mode)); !ok {
+		return fmt.Errorf("ragclient: unknown retrieval mode %q", opts.Mode)
+	}
+	for _, weight := range []*float64{opts.VectorWeight, opts.KeywordWeight} {
+		if weight == nil {
+			continue
+		}
+		if *weight < 0 || math.IsNaN(*weight) || math.IsInf(*weight, 0) {
+			return errors.New("ragclient: retrieval weights must be finite and not negative")
+		}
+	}
+	if opts.RRFK < 0 || opts.RRFK > MaxRRFK {
+		return fmt.Errorf("ragclient: rrf_k must be between 0 and %d", MaxRRFK)
+	}
+	return nil
+}
+
+// RetrievalResult captures a single hit returned by the RAG service.
+// Metadata carries the score breakdown (vector_rank, vector_score, keyword_rank, keyword_score, fused_score, retrieval_mode).
 type RetrievalResult struct {
 	PageContent string         `json:"page_content"`
 	Metadata    map[string]any `json:"metadata"`
@@ -73,6 +134,51 @@ type DeleteDocumentsResponse struct {
 	DeletedCount int `json:"deleted_count"`
 }
 
+// ChunkStrategy mirrors the Python ChunkStrategy enum and selects how a document is split.
+type ChunkStrategy string
+
+const (
+	// ChunkStrategyFixedTokens splits into windows of a fixed number of tokens.
+	ChunkStrategyFixedTokens ChunkStrategy = "fixed_tokens"
+	// ChunkStrategyRecursive splits by paragraphs, lines and spaces until chunks fit. It is the service default.
+	ChunkStrategyRecursive ChunkStrategy = "recursive"
+	// ChunkStrategyMarkdown splits by Markdown headings first, then recursively within long sections.
+	ChunkStrategyMarkdown ChunkStrategy = "markdown"
+	// ChunkStrategySentence splits on sentence boundaries, including Chinese punctuation.
+	ChunkStrategySentence ChunkStrategy = "sentence"
+)
+
+// MaxChunkSize bounds chunk_size accepted by ValidateChunking.
+const MaxChunkSize = 8192
+
+// ParseChunkStrategy reports whether s names a known strategy; an empty string selects the service default.
+func ParseChunkStrategy(s string) (ChunkStrategy, bool) {
+	switch strategy := ChunkStrategy(s); strategy {
+	case "", ChunkStrategyFixedTokens, ChunkStrategyRecursive, ChunkStrategyMarkdown, ChunkStrategySentence:
+		return strategy, true
+	default:
+		return "", false
+	}
+}
+
+// ValidateChunking checks chunking options before they are stored or sent to /embed.
+// A zero size keeps the service defaults for both size and overlap.
+func ValidateChunking(strategy string, size, overlap int64) error {
+	if _, ok := ParseChunkStrategy(strategy); !ok {
+		return fmt.Errorf("ragclient: unknown chunk strategy %q", strategy)
+	}
+	if size < 0 || overlap < 0 {
+		return errors.New("ragclient: chunk size and overlap must not be negative")
+	}
+	if size > MaxChunkSize {
+		return fmt.Errorf("ragclient: chunk size must not exceed %d", MaxChunkSize)
+	}
+	if size > 0 && overlap >= size {
+		return errors.New("ragclient: chunk overlap must be smaller than chunk size")
+	}
+	return nil
+}
+
 // EmbedCleanupMethod mirrors the Python CleanupMethod enum.
 type EmbedCleanupMethod string
 
@@ -92,6 +198,11 @@ type EmbedRequest struct {
 	ContentType   string
 	EntityID      string
 	CleanupMethod EmbedCleanupMethod
+	// Knowledge base or upload overrides; zero values fall back to the RAG service defaults.
+	EmbeddingModel string
+	ChunkStrategy  ChunkStrategy
+	ChunkSize      int
+	ChunkOverlap   int
 }
 
 // EmbedResponse holds the statistics reported after an embedding run.
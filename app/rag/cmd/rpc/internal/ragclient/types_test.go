@@ -0,0 +1,117 @@
+package ragclient
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseChunkStrategy(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ChunkStrategy
+		ok    bool
+	}{
+		{"", "", true},
+		{"fixed_tokens", ChunkStrategyFixedTokens, true},
+		{"recursive", ChunkStrategyRecursive, true},
+		{"markdown", ChunkStrategyMarkdown, true},
+		{"sentence", ChunkStrategySentence, true},
+		{"Markdown", "", false},
+		{"semantic", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseChunkStrategy(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseChunkStrategy(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValidateChunking(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		size     int64
+		overlap  int64
+		wantErr  bool
+	}{
+		{name: "defaults", strategy: "", size: 0, overlap: 0},
+		{name: "overlap ignored without size", strategy: "recursive", size: 0, overlap: 100},
+		{name: "valid", strategy: "sentence", size: 500, overlap: 50},
+		{name: "max size", strategy: "fixed_tokens", size: MaxChunkSize, overlap: MaxChunkSize - 1},
+		{name: "unknown strategy", strategy: "semantic", size: 500, overlap: 50, wantErr: true},
+		{name: "negative size", strategy: "recursive", size: -1, wantErr: true},
+		{name: "negative overlap", strategy: "recursive", size: 500, overlap: -1, wantErr: true},
+		{name: "size too large", strategy: "recursive", size: MaxChunkSize + 1, wantErr: true},
+		{name: "overlap equals size", strategy: "markdown", size: 100, overlap: 100, wantErr: true},
+		{name: "overlap exceeds size", strategy: "markdown", size: 100, overlap: 150, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChunking(tt.strategy, tt.size, tt.overlap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateChunking(%q, %d, %d) error = %v, wantErr %v", tt.strategy, tt.size, tt.overlap, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRetrieval(t *testing.T) {
+	weight := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name    string
+		opts    RetrievalOptions
+		wantErr bool
+	}{
+		{name: "defaults", opts: RetrievalOptions{}},
+		{name: "vector", opts: RetrievalOptions{Mode: RetrievalModeVector}},
+		{name: "keyword", opts: RetrievalOptions{Mode: RetrievalModeKeyword}},
+		{name: "hybrid weights", opts: RetrievalOptions{Mode: RetrievalModeHybrid, VectorWeight: weight(0.7), KeywordWeight: weight(0.3), RRFK: 60}},
+		{name: "explicit zero weight", opts: RetrievalOptions{Mode: RetrievalModeHybrid, VectorWeight: weight(0), KeywordWeight: weight(1)}},
+		{name: "max rrf_k", opts: RetrievalOptions{RRFK: MaxRRFK}},
+		{name: "unknown mode", opts: RetrievalOptions{Mode: "semantic"}, wantErr: true},
+		{name: "upper case mode", opts: RetrievalOptions{Mode: "Hybrid"}, wantErr: true},
+		{name: "negative vector weight", opts: RetrievalOptions{VectorWeight: weight(-0.1)}, wantErr: true},
+		{name: "negative keyword weight", opts: RetrievalOptions{KeywordWeight: weight(-1)}, wantErr: true},
+		{name: "nan weight", opts: RetrievalOptions{VectorWeight: weight(math.NaN())}, wantErr: true},
+		{name: "infinite weight", opts: RetrievalOptions{KeywordWeight: weight(math.Inf(1))}, wantErr: true},
+		{name: "negative rrf_k", opts: RetrievalOptions{RRFK: -1}, wantErr: true},
+		{name: "rrf_k too large", opts: RetrievalOptions{RRFK: MaxRRFK + 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRetrieval(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRetrieval(%+v) error = %v, wantErr %v", tt.opts, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetrievalOptionsJSON(t *testing.T) {
+	zero, one := 0.0, 1.0
+	tests := []struct {
+		name string
+		opts RetrievalOptions
+		want string
+	}{
+		{name: "unset", opts: RetrievalOptions{}, want: `{"query":"q","top_k":4}`},
+		{
+			name: "explicit zero weight",
+			opts: RetrievalOptions{Mode: RetrievalModeHybrid, VectorWeight: &zero, KeywordWeight: &one},
+			want: `{"query":"q","top_k":4,"mode":"hybrid","vector_weight":0,"keyword_weight":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(QueryRequest{Query: "q", TopK: 4, RetrievalOptions: tt.opts})
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if got := string(body); got != tt.want {
+				t.Fatalf("json = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
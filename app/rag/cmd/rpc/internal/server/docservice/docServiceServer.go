@@ -47,3 +47,23 @@ func (s *DocServiceServer) ListChunks(ctx context.Context, in *pb.ListChunksReq)
 	l := docservicelogic.NewListChunksLogic(ctx, s.svcCtx)
 	return l.ListChunks(in)
 }
+
+func (s *DocServiceServer) GetDocumentStatus(ctx context.Context, in *pb.GetDocumentStatusReq) (*pb.GetDocumentStatusResp, error) {
+	l := docservicelogic.NewGetDocumentStatusLogic(ctx, s.svcCtx)
+	return l.GetDocumentStatus(in)
+}
+
+func (s *DocServiceServer) ReembedDocument(ctx context.Context, in *pb.ReembedDocumentReq) (*pb.ReembedDocumentResp, error) {
+	l := docservicelogic.NewReembedDocumentLogic(ctx, s.svcCtx)
+	return l.ReembedDocument(in)
+}
+
+func (s *DocServiceServer) EmbedDocument(ctx context.Context, in *pb.EmbedDocumentReq) (*pb.EmbedDocumentResp, error) {
+	l := docservicelogic.NewEmbedDocumentLogic(ctx, s.svcCtx)
+	return l.EmbedDocument(in)
+}
+
+func (s *DocServiceServer) RequeueStaleDocuments(ctx context.Context, in *pb.RequeueStaleDocumentsReq) (*pb.RequeueStaleDocumentsResp, error) {
+	l := docservicelogic.NewRequeueStaleDocumentsLogic(ctx, s.svcCtx)
+	return l.RequeueStaleDocuments(in)
+}
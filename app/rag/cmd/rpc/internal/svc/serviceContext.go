@@ -8,14 +8,19 @@ import (
 	"go-zero-voice-agent/app/rag/model"
 	"go-zero-voice-agent/pkg/minioutil"
 
+	"github.com/hibiken/asynq"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
 type ServiceContext struct {
-	Config          config.Config
-	MinioClient     *minioutil.MinioClient
-	FileUploadModel model.FileUploadModel
-	RagClient       *ragclient.Client
+	Config             config.Config
+	MinioClient        *minioutil.MinioClient
+	FileUploadModel    model.FileUploadModel
+	KnowledgeBaseModel model.KnowledgeBaseModel
+	RagClient          *ragclient.Client
+	RedisClient        *redis.Redis
+	AsynqClient        *asynq.Client
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -36,10 +41,24 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		panic(fmt.Sprintf("init rag client failed: %v", err))
 	}
 
-	return &ServiceContext{
-		Config:          c,
-		MinioClient:     minioClient,
-		FileUploadModel: model.NewFileUploadModel(sqlConn, c.Cache),
-		RagClient:       ragClient,
+	svcCtx := &ServiceContext{
+		Config:             c,
+		MinioClient:        minioClient,
+		FileUploadModel:    model.NewFileUploadModel(sqlConn, c.Cache),
+		KnowledgeBaseModel: model.NewKnowledgeBaseModel(sqlConn, c.Cache),
+		RagClient:          ragClient,
+		AsynqClient: asynq.NewClient(asynq.RedisClientOpt{
+			Addr:     c.Asynq.Host,
+			Password: c.Asynq.Pass,
+			DB:       c.Asynq.DB,
+		}),
 	}
+	// 未配置 Redis 时不推送文档处理进度
+	if c.Redis.Host != "" {
+		svcCtx.RedisClient = redis.New(c.Redis.Host, func(r *redis.Redis) {
+			r.Type = c.Redis.Type
+			r.Pass = c.Redis.Pass
+		})
+	}
+	return svcCtx
 }
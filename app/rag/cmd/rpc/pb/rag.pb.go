@@ -83,15 +83,19 @@ func (x *PageQuery) GetOrderBy() string {
 }
 
 type UploadFileReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	FileName      string                 `protobuf:"bytes,2,opt,name=fileName,proto3" json:"fileName,omitempty"`
-	FilePath      string                 `protobuf:"bytes,3,opt,name=filePath,proto3" json:"filePath,omitempty"`
-	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	FileSize      int64                  `protobuf:"varint,5,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
-	Chunk         []byte                 `protobuf:"bytes,6,opt,name=chunk,proto3" json:"chunk,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	FileName        string                 `protobuf:"bytes,2,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	FilePath        string                 `protobuf:"bytes,3,opt,name=filePath,proto3" json:"filePath,omitempty"`
+	ContentType     string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	FileSize        int64                  `protobuf:"varint,5,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+	Chunk           []byte                 `protobuf:"bytes,6,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	KnowledgeBaseId int64                  `protobuf:"varint,7,opt,name=knowledgeBaseId,proto3" json:"knowledgeBaseId,omitempty"`                 //上传到自己的知识库（可选）
+	ChunkStrategy   string                 `protobuf:"bytes,8,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"` //切片策略 fixed_tokens/recursive/markdown/sentence，为空时使用知识库或默认策略
+	ChunkSize       int64                  `protobuf:"varint,9,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`            //切片长度，0 表示使用知识库或默认值
+	ChunkOverlap    int64                  `protobuf:"varint,10,opt,name=chunk_overlap,json=chunkOverlap,proto3" json:"chunk_overlap,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UploadFileReq) Reset() {
@@ -166,6 +170,34 @@ func (x *UploadFileReq) GetChunk() []byte {
 	return nil
 }
 
+func (x *UploadFileReq) GetKnowledgeBaseId() int64 {
+	if x != nil {
+		return x.KnowledgeBaseId
+	}
+	return 0
+}
+
+func (x *UploadFileReq) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
+func (x *UploadFileReq) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *UploadFileReq) GetChunkOverlap() int64 {
+	if x != nil {
+		return x.ChunkOverlap
+	}
+	return 0
+}
+
 type UploadFileResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FilePath      string                 `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
@@ -217,6 +249,10 @@ type QueryReq struct {
 	FileId        string                 `protobuf:"bytes,3,opt,name=fileId,proto3" json:"fileId,omitempty"`
 	TopK          int32                  `protobuf:"varint,4,opt,name=topK,proto3" json:"topK,omitempty"`
 	EntityId      string                 `protobuf:"bytes,5,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	RetrievalMode string                 `protobuf:"bytes,6,opt,name=retrievalMode,proto3" json:"retrievalMode,omitempty"`         //vector / keyword / hybrid，为空时使用 vector
+	VectorWeight  *float64               `protobuf:"fixed64,7,opt,name=vectorWeight,proto3,oneof" json:"vectorWeight,omitempty"`   //混合检索中向量排名的权重，未设置时使用默认值 1
+	KeywordWeight *float64               `protobuf:"fixed64,8,opt,name=keywordWeight,proto3,oneof" json:"keywordWeight,omitempty"` //混合检索中关键词排名的权重，未设置时使用默认值 1
+	RrfK          int32                  `protobuf:"varint,9,opt,name=rrfK,proto3" json:"rrfK,omitempty"`                          //倒数排名融合常数，为 0 时使用默认值 60
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -286,14 +322,47 @@ func (x *QueryReq) GetEntityId() string {
 	return ""
 }
 
+func (x *QueryReq) GetRetrievalMode() string {
+	if x != nil {
+		return x.RetrievalMode
+	}
+	return ""
+}
+
+func (x *QueryReq) GetVectorWeight() float64 {
+	if x != nil && x.VectorWeight != nil {
+		return *x.VectorWeight
+	}
+	return 0
+}
+
+func (x *QueryReq) GetKeywordWeight() float64 {
+	if x != nil && x.KeywordWeight != nil {
+		return *x.KeywordWeight
+	}
+	return 0
+}
+
+func (x *QueryReq) GetRrfK() int32 {
+	if x != nil {
+		return x.RrfK
+	}
+	return 0
+}
+
 type QueryMultipleReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	FileIds       []string               `protobuf:"bytes,3,rep,name=fileIds,proto3" json:"fileIds,omitempty"`
-	TopK          int32                  `protobuf:"varint,4,opt,name=topK,proto3" json:"topK,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Query            string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	FileIds          []string               `protobuf:"bytes,3,rep,name=fileIds,proto3" json:"fileIds,omitempty"`
+	TopK             int32                  `protobuf:"varint,4,opt,name=topK,proto3" json:"topK,omitempty"`
+	KnowledgeBaseIds []int64                `protobuf:"varint,5,rep,packed,name=knowledgeBaseIds,proto3" json:"knowledgeBaseIds,omitempty"` //检索知识库中的所有文件，与 fileIds 合并
+	RetrievalMode    string                 `protobuf:"bytes,6,opt,name=retrievalMode,proto3" json:"retrievalMode,omitempty"`               //vector / keyword / hybrid，为空时使用 vector
+	VectorWeight     *float64               `protobuf:"fixed64,7,opt,name=vectorWeight,proto3,oneof" json:"vectorWeight,omitempty"`         //混合检索中向量排名的权重，未设置时使用默认值 1
+	KeywordWeight    *float64               `protobuf:"fixed64,8,opt,name=keywordWeight,proto3,oneof" json:"keywordWeight,omitempty"`       //混合检索中关键词排名的权重，未设置时使用默认值 1
+	RrfK             int32                  `protobuf:"varint,9,opt,name=rrfK,proto3" json:"rrfK,omitempty"`                                //倒数排名融合常数，为 0 时使用默认值 60
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *QueryMultipleReq) Reset() {
@@ -354,11 +423,46 @@ func (x *QueryMultipleReq) GetTopK() int32 {
 	return 0
 }
 
+func (x *QueryMultipleReq) GetKnowledgeBaseIds() []int64 {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
+func (x *QueryMultipleReq) GetRetrievalMode() string {
+	if x != nil {
+		return x.RetrievalMode
+	}
+	return ""
+}
+
+func (x *QueryMultipleReq) GetVectorWeight() float64 {
+	if x != nil && x.VectorWeight != nil {
+		return *x.VectorWeight
+	}
+	return 0
+}
+
+func (x *QueryMultipleReq) GetKeywordWeight() float64 {
+	if x != nil && x.KeywordWeight != nil {
+		return *x.KeywordWeight
+	}
+	return 0
+}
+
+func (x *QueryMultipleReq) GetRrfK() int32 {
+	if x != nil {
+		return x.RrfK
+	}
+	return 0
+}
+
 type RetrievalResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PageContent   string                 `protobuf:"bytes,1,opt,name=pageContent,proto3" json:"pageContent,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` //含 retrieval_mode 及 vector_rank / vector_score / keyword_rank / keyword_score / fused_score 得分明细
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`                                                                               //混合检索为融合得分（越大越相关），单路检索为该路原始得分
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -459,11 +563,12 @@ func (x *QueryResp) GetResults() []*RetrievalResult {
 }
 
 type ListDocumentsFilter struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileFormat    string                 `protobuf:"bytes,2,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FileName        string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileFormat      string                 `protobuf:"bytes,2,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
+	KnowledgeBaseId int64                  `protobuf:"varint,3,opt,name=knowledge_base_id,json=knowledgeBaseId,proto3" json:"knowledge_base_id,omitempty"` //只查询该知识库中的文件
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListDocumentsFilter) Reset() {
@@ -510,6 +615,13 @@ func (x *ListDocumentsFilter) GetFileFormat() string {
 	return ""
 }
 
+func (x *ListDocumentsFilter) GetKnowledgeBaseId() int64 {
+	if x != nil {
+		return x.KnowledgeBaseId
+	}
+	return 0
+}
+
 type ListDocumentsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
@@ -571,13 +683,17 @@ func (x *ListDocumentsReq) GetFilter() *ListDocumentsFilter {
 }
 
 type ListDocumentsItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileFormat    string                 `protobuf:"bytes,3,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
-	Status        int64                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"` //未向量化:0  向量化:1
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileName        string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileFormat      string                 `protobuf:"bytes,3,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
+	Status          int64                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`                          //处理状态 0已上传 1可检索 2排队中 3解析中 4向量化中 5失败
+	StatusName      string                 `protobuf:"bytes,5,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"` //uploaded/ready/queued/parsing/embedding/failed
+	ErrorMsg        string                 `protobuf:"bytes,6,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`
+	ChunkCount      int64                  `protobuf:"varint,7,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	KnowledgeBaseId int64                  `protobuf:"varint,8,opt,name=knowledge_base_id,json=knowledgeBaseId,proto3" json:"knowledge_base_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListDocumentsItem) Reset() {
@@ -638,6 +754,34 @@ func (x *ListDocumentsItem) GetStatus() int64 {
 	return 0
 }
 
+func (x *ListDocumentsItem) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *ListDocumentsItem) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *ListDocumentsItem) GetChunkCount() int64 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *ListDocumentsItem) GetKnowledgeBaseId() int64 {
+	if x != nil {
+		return x.KnowledgeBaseId
+	}
+	return 0
+}
+
 type ListDocumentsResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Results       []*ListDocumentsItem   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
@@ -747,6 +891,7 @@ type DocumentRecord struct {
 	CustomId      string                 `protobuf:"bytes,1,opt,name=customId,proto3" json:"customId,omitempty"`
 	PageContent   string                 `protobuf:"bytes,2,opt,name=pageContent,proto3" json:"pageContent,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ChunkStrategy string                 `protobuf:"bytes,4,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"` //生成该切片的策略，早于切片策略写入的切片为空
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -802,6 +947,13 @@ func (x *DocumentRecord) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *DocumentRecord) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
 type FetchDocumentsResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Documents     []*DocumentRecord      `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
@@ -942,29 +1094,28 @@ func (x *DeleteDocumentsResp) GetDeletedCount() int32 {
 	return 0
 }
 
-type ListChunksReq struct {
+type GetDocumentStatusReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	FileId        string                 `protobuf:"bytes,2,opt,name=fileId,proto3" json:"fileId,omitempty"`
-	PageQuery     *PageQuery             `protobuf:"bytes,3,opt,name=pageQuery,proto3" json:"pageQuery,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChunksReq) Reset() {
-	*x = ListChunksReq{}
+func (x *GetDocumentStatusReq) Reset() {
+	*x = GetDocumentStatusReq{}
 	mi := &file_rag_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChunksReq) String() string {
+func (x *GetDocumentStatusReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChunksReq) ProtoMessage() {}
+func (*GetDocumentStatusReq) ProtoMessage() {}
 
-func (x *ListChunksReq) ProtoReflect() protoreflect.Message {
+func (x *GetDocumentStatusReq) ProtoReflect() protoreflect.Message {
 	mi := &file_rag_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -976,55 +1127,141 @@ func (x *ListChunksReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChunksReq.ProtoReflect.Descriptor instead.
-func (*ListChunksReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDocumentStatusReq.ProtoReflect.Descriptor instead.
+func (*GetDocumentStatusReq) Descriptor() ([]byte, []int) {
 	return file_rag_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListChunksReq) GetUserId() int64 {
+func (x *GetDocumentStatusReq) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *ListChunksReq) GetFileId() string {
+func (x *GetDocumentStatusReq) GetId() int64 {
 	if x != nil {
-		return x.FileId
+		return x.Id
+	}
+	return 0
+}
+
+type GetDocumentStatusResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Status        int64                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	StatusName    string                 `protobuf:"bytes,4,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	ErrorMsg      string                 `protobuf:"bytes,5,opt,name=error_msg,json=errorMsg,proto3" json:"error_msg,omitempty"`        //处理失败的原因
+	ChunkCount    int64                  `protobuf:"varint,6,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"` //向量化后的切片数
+	UpdateTime    int64                  `protobuf:"varint,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"` //状态最后变化的时间(unix秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentStatusResp) Reset() {
+	*x = GetDocumentStatusResp{}
+	mi := &file_rag_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentStatusResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentStatusResp) ProtoMessage() {}
+
+func (x *GetDocumentStatusResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentStatusResp.ProtoReflect.Descriptor instead.
+func (*GetDocumentStatusResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetDocumentStatusResp) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetDocumentStatusResp) GetFileName() string {
+	if x != nil {
+		return x.FileName
 	}
 	return ""
 }
 
-func (x *ListChunksReq) GetPageQuery() *PageQuery {
+func (x *GetDocumentStatusResp) GetStatus() int64 {
 	if x != nil {
-		return x.PageQuery
+		return x.Status
 	}
-	return nil
+	return 0
 }
 
-type ListChunksResp struct {
+func (x *GetDocumentStatusResp) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *GetDocumentStatusResp) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *GetDocumentStatusResp) GetChunkCount() int64 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *GetDocumentStatusResp) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+type ReembedDocumentReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunks        []*DocumentRecord      `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	CleanupMethod string                 `protobuf:"bytes,3,opt,name=cleanup_method,json=cleanupMethod,proto3" json:"cleanup_method,omitempty"` //incremental(默认) 只写入变化的切片，full 先删除已有切片
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChunksResp) Reset() {
-	*x = ListChunksResp{}
-	mi := &file_rag_proto_msgTypes[17]
+func (x *ReembedDocumentReq) Reset() {
+	*x = ReembedDocumentReq{}
+	mi := &file_rag_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChunksResp) String() string {
+func (x *ReembedDocumentReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChunksResp) ProtoMessage() {}
+func (*ReembedDocumentReq) ProtoMessage() {}
 
-func (x *ListChunksResp) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_proto_msgTypes[17]
+func (x *ReembedDocumentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1035,87 +1272,1321 @@ func (x *ListChunksResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChunksResp.ProtoReflect.Descriptor instead.
-func (*ListChunksResp) Descriptor() ([]byte, []int) {
-	return file_rag_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ReembedDocumentReq.ProtoReflect.Descriptor instead.
+func (*ReembedDocumentReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ListChunksResp) GetChunks() []*DocumentRecord {
+func (x *ReembedDocumentReq) GetUserId() int64 {
 	if x != nil {
-		return x.Chunks
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *ListChunksResp) GetTotal() int64 {
+func (x *ReembedDocumentReq) GetId() int64 {
 	if x != nil {
-		return x.Total
+		return x.Id
 	}
 	return 0
 }
 
-var File_rag_proto protoreflect.FileDescriptor
+func (x *ReembedDocumentReq) GetCleanupMethod() string {
+	if x != nil {
+		return x.CleanupMethod
+	}
+	return ""
+}
 
-const file_rag_proto_rawDesc = "" +
-	"\n" +
-	"\trag.proto\x12\x02pb\"W\n" +
-	"\tPageQuery\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x03R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x03R\bpageSize\x12\x19\n" +
-	"\border_by\x18\x03 \x01(\tR\aorderBy\"\xb4\x01\n" +
-	"\rUploadFileReq\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
-	"\bfileName\x18\x02 \x01(\tR\bfileName\x12\x1a\n" +
-	"\bfilePath\x18\x03 \x01(\tR\bfilePath\x12!\n" +
-	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1a\n" +
-	"\bfileSize\x18\x05 \x01(\x03R\bfileSize\x12\x14\n" +
-	"\x05chunk\x18\x06 \x01(\fR\x05chunk\"-\n" +
-	"\x0eUploadFileResp\x12\x1b\n" +
-	"\tfile_path\x18\x01 \x01(\tR\bfilePath\"\x80\x01\n" +
-	"\bQueryReq\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
-	"\x05query\x18\x02 \x01(\tR\x05query\x12\x16\n" +
-	"\x06fileId\x18\x03 \x01(\tR\x06fileId\x12\x12\n" +
-	"\x04topK\x18\x04 \x01(\x05R\x04topK\x12\x1a\n" +
-	"\bentityId\x18\x05 \x01(\tR\bentityId\"n\n" +
-	"\x10QueryMultipleReq\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
-	"\x05query\x18\x02 \x01(\tR\x05query\x12\x18\n" +
-	"\afileIds\x18\x03 \x03(\tR\afileIds\x12\x12\n" +
-	"\x04topK\x18\x04 \x01(\x05R\x04topK\"\xc5\x01\n" +
-	"\x0fRetrievalResult\x12 \n" +
-	"\vpageContent\x18\x01 \x01(\tR\vpageContent\x12=\n" +
-	"\bmetadata\x18\x02 \x03(\v2!.pb.RetrievalResult.MetadataEntryR\bmetadata\x12\x14\n" +
-	"\x05score\x18\x03 \x01(\x01R\x05score\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
-	"\tQueryResp\x12-\n" +
-	"\aresults\x18\x01 \x03(\v2\x13.pb.RetrievalResultR\aresults\"S\n" +
-	"\x13ListDocumentsFilter\x12\x1b\n" +
-	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x1f\n" +
-	"\vfile_format\x18\x02 \x01(\tR\n" +
-	"fileFormat\"\x88\x01\n" +
-	"\x10ListDocumentsReq\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12+\n" +
-	"\tpageQuery\x18\x02 \x01(\v2\r.pb.PageQueryR\tpageQuery\x12/\n" +
-	"\x06filter\x18\x03 \x01(\v2\x17.pb.ListDocumentsFilterR\x06filter\"y\n" +
-	"\x11ListDocumentsItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
-	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1f\n" +
+type ReembedDocumentResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        int64                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	StatusName    string                 `protobuf:"bytes,3,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReembedDocumentResp) Reset() {
+	*x = ReembedDocumentResp{}
+	mi := &file_rag_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReembedDocumentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReembedDocumentResp) ProtoMessage() {}
+
+func (x *ReembedDocumentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReembedDocumentResp.ProtoReflect.Descriptor instead.
+func (*ReembedDocumentResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReembedDocumentResp) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReembedDocumentResp) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ReembedDocumentResp) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+type EmbedDocumentReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CleanupMethod string                 `protobuf:"bytes,2,opt,name=cleanup_method,json=cleanupMethod,proto3" json:"cleanup_method,omitempty"`
+	LastAttempt   bool                   `protobuf:"varint,3,opt,name=last_attempt,json=lastAttempt,proto3" json:"last_attempt,omitempty"` //任务的最后一次重试，失败后标记为 failed，否则回到排队中等待重试
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedDocumentReq) Reset() {
+	*x = EmbedDocumentReq{}
+	mi := &file_rag_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedDocumentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedDocumentReq) ProtoMessage() {}
+
+func (x *EmbedDocumentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedDocumentReq.ProtoReflect.Descriptor instead.
+func (*EmbedDocumentReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *EmbedDocumentReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EmbedDocumentReq) GetCleanupMethod() string {
+	if x != nil {
+		return x.CleanupMethod
+	}
+	return ""
+}
+
+func (x *EmbedDocumentReq) GetLastAttempt() bool {
+	if x != nil {
+		return x.LastAttempt
+	}
+	return false
+}
+
+type EmbedDocumentResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        int64                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	StatusName    string                 `protobuf:"bytes,3,opt,name=status_name,json=statusName,proto3" json:"status_name,omitempty"`
+	ChunkCount    int64                  `protobuf:"varint,4,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbedDocumentResp) Reset() {
+	*x = EmbedDocumentResp{}
+	mi := &file_rag_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbedDocumentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedDocumentResp) ProtoMessage() {}
+
+func (x *EmbedDocumentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedDocumentResp.ProtoReflect.Descriptor instead.
+func (*EmbedDocumentResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EmbedDocumentResp) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EmbedDocumentResp) GetStatus() int64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *EmbedDocumentResp) GetStatusName() string {
+	if x != nil {
+		return x.StatusName
+	}
+	return ""
+}
+
+func (x *EmbedDocumentResp) GetChunkCount() int64 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+type RequeueStaleDocumentsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StaleSeconds  int64                  `protobuf:"varint,1,opt,name=stale_seconds,json=staleSeconds,proto3" json:"stale_seconds,omitempty"` //处理状态超过该时间未变化的文件视为卡住
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueStaleDocumentsReq) Reset() {
+	*x = RequeueStaleDocumentsReq{}
+	mi := &file_rag_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueStaleDocumentsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueStaleDocumentsReq) ProtoMessage() {}
+
+func (x *RequeueStaleDocumentsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueStaleDocumentsReq.ProtoReflect.Descriptor instead.
+func (*RequeueStaleDocumentsReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RequeueStaleDocumentsReq) GetStaleSeconds() int64 {
+	if x != nil {
+		return x.StaleSeconds
+	}
+	return 0
+}
+
+func (x *RequeueStaleDocumentsReq) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RequeueStaleDocumentsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requeued      int64                  `protobuf:"varint,1,opt,name=requeued,proto3" json:"requeued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueStaleDocumentsResp) Reset() {
+	*x = RequeueStaleDocumentsResp{}
+	mi := &file_rag_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueStaleDocumentsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueStaleDocumentsResp) ProtoMessage() {}
+
+func (x *RequeueStaleDocumentsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueStaleDocumentsResp.ProtoReflect.Descriptor instead.
+func (*RequeueStaleDocumentsResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RequeueStaleDocumentsResp) GetRequeued() int64 {
+	if x != nil {
+		return x.Requeued
+	}
+	return 0
+}
+
+type ListChunksReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=fileId,proto3" json:"fileId,omitempty"`
+	PageQuery     *PageQuery             `protobuf:"bytes,3,opt,name=pageQuery,proto3" json:"pageQuery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChunksReq) Reset() {
+	*x = ListChunksReq{}
+	mi := &file_rag_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChunksReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChunksReq) ProtoMessage() {}
+
+func (x *ListChunksReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChunksReq.ProtoReflect.Descriptor instead.
+func (*ListChunksReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListChunksReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListChunksReq) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ListChunksReq) GetPageQuery() *PageQuery {
+	if x != nil {
+		return x.PageQuery
+	}
+	return nil
+}
+
+type ListChunksResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunks        []*DocumentRecord      `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChunksResp) Reset() {
+	*x = ListChunksResp{}
+	mi := &file_rag_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChunksResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChunksResp) ProtoMessage() {}
+
+func (x *ListChunksResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChunksResp.ProtoReflect.Descriptor instead.
+func (*ListChunksResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListChunksResp) GetChunks() []*DocumentRecord {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *ListChunksResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type KnowledgeBase struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	EmbeddingModel string                 `protobuf:"bytes,5,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"` //为空时使用 RAG 服务的默认模型
+	ChunkSize      int64                  `protobuf:"varint,6,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`               //0 表示使用默认值
+	ChunkOverlap   int64                  `protobuf:"varint,7,opt,name=chunk_overlap,json=chunkOverlap,proto3" json:"chunk_overlap,omitempty"`
+	DocumentCount  int64                  `protobuf:"varint,8,opt,name=document_count,json=documentCount,proto3" json:"document_count,omitempty"`
+	CreateTime     int64                  `protobuf:"varint,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime     int64                  `protobuf:"varint,10,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	ChunkStrategy  string                 `protobuf:"bytes,11,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"` //为空时使用默认策略 recursive
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KnowledgeBase) Reset() {
+	*x = KnowledgeBase{}
+	mi := &file_rag_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnowledgeBase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnowledgeBase) ProtoMessage() {}
+
+func (x *KnowledgeBase) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnowledgeBase.ProtoReflect.Descriptor instead.
+func (*KnowledgeBase) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *KnowledgeBase) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KnowledgeBase) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *KnowledgeBase) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+func (x *KnowledgeBase) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetChunkOverlap() int64 {
+	if x != nil {
+		return x.ChunkOverlap
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetDocumentCount() int64 {
+	if x != nil {
+		return x.DocumentCount
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+func (x *KnowledgeBase) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
+type CreateKnowledgeBaseReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	EmbeddingModel string                 `protobuf:"bytes,4,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	ChunkSize      int64                  `protobuf:"varint,5,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	ChunkOverlap   int64                  `protobuf:"varint,6,opt,name=chunk_overlap,json=chunkOverlap,proto3" json:"chunk_overlap,omitempty"`
+	ChunkStrategy  string                 `protobuf:"bytes,7,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateKnowledgeBaseReq) Reset() {
+	*x = CreateKnowledgeBaseReq{}
+	mi := &file_rag_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKnowledgeBaseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKnowledgeBaseReq) ProtoMessage() {}
+
+func (x *CreateKnowledgeBaseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKnowledgeBaseReq.ProtoReflect.Descriptor instead.
+func (*CreateKnowledgeBaseReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateKnowledgeBaseReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateKnowledgeBaseReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateKnowledgeBaseReq) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateKnowledgeBaseReq) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+func (x *CreateKnowledgeBaseReq) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *CreateKnowledgeBaseReq) GetChunkOverlap() int64 {
+	if x != nil {
+		return x.ChunkOverlap
+	}
+	return 0
+}
+
+func (x *CreateKnowledgeBaseReq) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
+type CreateKnowledgeBaseResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKnowledgeBaseResp) Reset() {
+	*x = CreateKnowledgeBaseResp{}
+	mi := &file_rag_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKnowledgeBaseResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKnowledgeBaseResp) ProtoMessage() {}
+
+func (x *CreateKnowledgeBaseResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKnowledgeBaseResp.ProtoReflect.Descriptor instead.
+func (*CreateKnowledgeBaseResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateKnowledgeBaseResp) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateKnowledgeBaseReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id             int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	EmbeddingModel string                 `protobuf:"bytes,5,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	ChunkSize      int64                  `protobuf:"varint,6,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	ChunkOverlap   int64                  `protobuf:"varint,7,opt,name=chunk_overlap,json=chunkOverlap,proto3" json:"chunk_overlap,omitempty"`
+	ChunkStrategy  string                 `protobuf:"bytes,8,opt,name=chunk_strategy,json=chunkStrategy,proto3" json:"chunk_strategy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateKnowledgeBaseReq) Reset() {
+	*x = UpdateKnowledgeBaseReq{}
+	mi := &file_rag_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKnowledgeBaseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKnowledgeBaseReq) ProtoMessage() {}
+
+func (x *UpdateKnowledgeBaseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKnowledgeBaseReq.ProtoReflect.Descriptor instead.
+func (*UpdateKnowledgeBaseReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateKnowledgeBaseReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateKnowledgeBaseReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateKnowledgeBaseReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateKnowledgeBaseReq) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateKnowledgeBaseReq) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+func (x *UpdateKnowledgeBaseReq) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *UpdateKnowledgeBaseReq) GetChunkOverlap() int64 {
+	if x != nil {
+		return x.ChunkOverlap
+	}
+	return 0
+}
+
+func (x *UpdateKnowledgeBaseReq) GetChunkStrategy() string {
+	if x != nil {
+		return x.ChunkStrategy
+	}
+	return ""
+}
+
+type UpdateKnowledgeBaseResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateKnowledgeBaseResp) Reset() {
+	*x = UpdateKnowledgeBaseResp{}
+	mi := &file_rag_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateKnowledgeBaseResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateKnowledgeBaseResp) ProtoMessage() {}
+
+func (x *UpdateKnowledgeBaseResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateKnowledgeBaseResp.ProtoReflect.Descriptor instead.
+func (*UpdateKnowledgeBaseResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{30}
+}
+
+type DeleteKnowledgeBaseReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKnowledgeBaseReq) Reset() {
+	*x = DeleteKnowledgeBaseReq{}
+	mi := &file_rag_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKnowledgeBaseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKnowledgeBaseReq) ProtoMessage() {}
+
+func (x *DeleteKnowledgeBaseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKnowledgeBaseReq.ProtoReflect.Descriptor instead.
+func (*DeleteKnowledgeBaseReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteKnowledgeBaseReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteKnowledgeBaseReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteKnowledgeBaseResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DetachedCount int64                  `protobuf:"varint,1,opt,name=detached_count,json=detachedCount,proto3" json:"detached_count,omitempty"` //移出知识库的文件数，文件本身不删除
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteKnowledgeBaseResp) Reset() {
+	*x = DeleteKnowledgeBaseResp{}
+	mi := &file_rag_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteKnowledgeBaseResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteKnowledgeBaseResp) ProtoMessage() {}
+
+func (x *DeleteKnowledgeBaseResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteKnowledgeBaseResp.ProtoReflect.Descriptor instead.
+func (*DeleteKnowledgeBaseResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteKnowledgeBaseResp) GetDetachedCount() int64 {
+	if x != nil {
+		return x.DetachedCount
+	}
+	return 0
+}
+
+type GetKnowledgeBaseReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKnowledgeBaseReq) Reset() {
+	*x = GetKnowledgeBaseReq{}
+	mi := &file_rag_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKnowledgeBaseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKnowledgeBaseReq) ProtoMessage() {}
+
+func (x *GetKnowledgeBaseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKnowledgeBaseReq.ProtoReflect.Descriptor instead.
+func (*GetKnowledgeBaseReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetKnowledgeBaseReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetKnowledgeBaseReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetKnowledgeBaseResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KnowledgeBase *KnowledgeBase         `protobuf:"bytes,1,opt,name=knowledge_base,json=knowledgeBase,proto3" json:"knowledge_base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKnowledgeBaseResp) Reset() {
+	*x = GetKnowledgeBaseResp{}
+	mi := &file_rag_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKnowledgeBaseResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKnowledgeBaseResp) ProtoMessage() {}
+
+func (x *GetKnowledgeBaseResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKnowledgeBaseResp.ProtoReflect.Descriptor instead.
+func (*GetKnowledgeBaseResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetKnowledgeBaseResp) GetKnowledgeBase() *KnowledgeBase {
+	if x != nil {
+		return x.KnowledgeBase
+	}
+	return nil
+}
+
+type ListKnowledgeBasesReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	PageQuery     *PageQuery             `protobuf:"bytes,2,opt,name=pageQuery,proto3" json:"pageQuery,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKnowledgeBasesReq) Reset() {
+	*x = ListKnowledgeBasesReq{}
+	mi := &file_rag_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKnowledgeBasesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKnowledgeBasesReq) ProtoMessage() {}
+
+func (x *ListKnowledgeBasesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKnowledgeBasesReq.ProtoReflect.Descriptor instead.
+func (*ListKnowledgeBasesReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListKnowledgeBasesReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListKnowledgeBasesReq) GetPageQuery() *PageQuery {
+	if x != nil {
+		return x.PageQuery
+	}
+	return nil
+}
+
+func (x *ListKnowledgeBasesReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListKnowledgeBasesResp struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	KnowledgeBases []*KnowledgeBase       `protobuf:"bytes,1,rep,name=knowledge_bases,json=knowledgeBases,proto3" json:"knowledge_bases,omitempty"`
+	Total          int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListKnowledgeBasesResp) Reset() {
+	*x = ListKnowledgeBasesResp{}
+	mi := &file_rag_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKnowledgeBasesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKnowledgeBasesResp) ProtoMessage() {}
+
+func (x *ListKnowledgeBasesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKnowledgeBasesResp.ProtoReflect.Descriptor instead.
+func (*ListKnowledgeBasesResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListKnowledgeBasesResp) GetKnowledgeBases() []*KnowledgeBase {
+	if x != nil {
+		return x.KnowledgeBases
+	}
+	return nil
+}
+
+func (x *ListKnowledgeBasesResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type KnowledgeBaseDocumentsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	FileIds       []int64                `protobuf:"varint,3,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnowledgeBaseDocumentsReq) Reset() {
+	*x = KnowledgeBaseDocumentsReq{}
+	mi := &file_rag_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnowledgeBaseDocumentsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnowledgeBaseDocumentsReq) ProtoMessage() {}
+
+func (x *KnowledgeBaseDocumentsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnowledgeBaseDocumentsReq.ProtoReflect.Descriptor instead.
+func (*KnowledgeBaseDocumentsReq) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *KnowledgeBaseDocumentsReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *KnowledgeBaseDocumentsReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *KnowledgeBaseDocumentsReq) GetFileIds() []int64 {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+type KnowledgeBaseDocumentsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Affected      int64                  `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnowledgeBaseDocumentsResp) Reset() {
+	*x = KnowledgeBaseDocumentsResp{}
+	mi := &file_rag_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnowledgeBaseDocumentsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnowledgeBaseDocumentsResp) ProtoMessage() {}
+
+func (x *KnowledgeBaseDocumentsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnowledgeBaseDocumentsResp.ProtoReflect.Descriptor instead.
+func (*KnowledgeBaseDocumentsResp) Descriptor() ([]byte, []int) {
+	return file_rag_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *KnowledgeBaseDocumentsResp) GetAffected() int64 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+var File_rag_proto protoreflect.FileDescriptor
+
+const file_rag_proto_rawDesc = "" +
+	"\n" +
+	"\trag.proto\x12\x02pb\"W\n" +
+	"\tPageQuery\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x03R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x03R\bpageSize\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\"\xc9\x02\n" +
+	"\rUploadFileReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bfileName\x18\x02 \x01(\tR\bfileName\x12\x1a\n" +
+	"\bfilePath\x18\x03 \x01(\tR\bfilePath\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfileSize\x18\x05 \x01(\x03R\bfileSize\x12\x14\n" +
+	"\x05chunk\x18\x06 \x01(\fR\x05chunk\x12(\n" +
+	"\x0fknowledgeBaseId\x18\a \x01(\x03R\x0fknowledgeBaseId\x12%\n" +
+	"\x0echunk_strategy\x18\b \x01(\tR\rchunkStrategy\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\t \x01(\x03R\tchunkSize\x12#\n" +
+	"\rchunk_overlap\x18\n" +
+	" \x01(\x03R\fchunkOverlap\"-\n" +
+	"\x0eUploadFileResp\x12\x1b\n" +
+	"\tfile_path\x18\x01 \x01(\tR\bfilePath\"\xb1\x02\n" +
+	"\bQueryReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x16\n" +
+	"\x06fileId\x18\x03 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04topK\x18\x04 \x01(\x05R\x04topK\x12\x1a\n" +
+	"\bentityId\x18\x05 \x01(\tR\bentityId\x12$\n" +
+	"\rretrievalMode\x18\x06 \x01(\tR\rretrievalMode\x12'\n" +
+	"\fvectorWeight\x18\a \x01(\x01H\x00R\fvectorWeight\x88\x01\x01\x12)\n" +
+	"\rkeywordWeight\x18\b \x01(\x01H\x01R\rkeywordWeight\x88\x01\x01\x12\x12\n" +
+	"\x04rrfK\x18\t \x01(\x05R\x04rrfKB\x0f\n" +
+	"\r_vectorWeightB\x10\n" +
+	"\x0e_keywordWeight\"\xcb\x02\n" +
+	"\x10QueryMultipleReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x18\n" +
+	"\afileIds\x18\x03 \x03(\tR\afileIds\x12\x12\n" +
+	"\x04topK\x18\x04 \x01(\x05R\x04topK\x12*\n" +
+	"\x10knowledgeBaseIds\x18\x05 \x03(\x03R\x10knowledgeBaseIds\x12$\n" +
+	"\rretrievalMode\x18\x06 \x01(\tR\rretrievalMode\x12'\n" +
+	"\fvectorWeight\x18\a \x01(\x01H\x00R\fvectorWeight\x88\x01\x01\x12)\n" +
+	"\rkeywordWeight\x18\b \x01(\x01H\x01R\rkeywordWeight\x88\x01\x01\x12\x12\n" +
+	"\x04rrfK\x18\t \x01(\x05R\x04rrfKB\x0f\n" +
+	"\r_vectorWeightB\x10\n" +
+	"\x0e_keywordWeight\"\xc5\x01\n" +
+	"\x0fRetrievalResult\x12 \n" +
+	"\vpageContent\x18\x01 \x01(\tR\vpageContent\x12=\n" +
+	"\bmetadata\x18\x02 \x03(\v2!.pb.RetrievalResult.MetadataEntryR\bmetadata\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
+	"\tQueryResp\x12-\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.pb.RetrievalResultR\aresults\"\x7f\n" +
+	"\x13ListDocumentsFilter\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x1f\n" +
+	"\vfile_format\x18\x02 \x01(\tR\n" +
+	"fileFormat\x12*\n" +
+	"\x11knowledge_base_id\x18\x03 \x01(\x03R\x0fknowledgeBaseId\"\x88\x01\n" +
+	"\x10ListDocumentsReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12+\n" +
+	"\tpageQuery\x18\x02 \x01(\v2\r.pb.PageQueryR\tpageQuery\x12/\n" +
+	"\x06filter\x18\x03 \x01(\v2\x17.pb.ListDocumentsFilterR\x06filter\"\x84\x02\n" +
+	"\x11ListDocumentsItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1f\n" +
 	"\vfile_format\x18\x03 \x01(\tR\n" +
 	"fileFormat\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\x03R\x06status\"Z\n" +
+	"\x06status\x18\x04 \x01(\x03R\x06status\x12\x1f\n" +
+	"\vstatus_name\x18\x05 \x01(\tR\n" +
+	"statusName\x12\x1b\n" +
+	"\terror_msg\x18\x06 \x01(\tR\berrorMsg\x12\x1f\n" +
+	"\vchunk_count\x18\a \x01(\x03R\n" +
+	"chunkCount\x12*\n" +
+	"\x11knowledge_base_id\x18\b \x01(\x03R\x0fknowledgeBaseId\"Z\n" +
 	"\x11ListDocumentsResp\x12/\n" +
 	"\aresults\x18\x01 \x03(\v2\x15.pb.ListDocumentsItemR\aresults\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\"=\n" +
 	"\x11FetchDocumentsReq\x12\x16\n" +
 	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x10\n" +
-	"\x03ids\x18\x02 \x03(\tR\x03ids\"\xc9\x01\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"\xf0\x01\n" +
 	"\x0eDocumentRecord\x12\x1a\n" +
 	"\bcustomId\x18\x01 \x01(\tR\bcustomId\x12 \n" +
 	"\vpageContent\x18\x02 \x01(\tR\vpageContent\x12<\n" +
-	"\bmetadata\x18\x03 \x03(\v2 .pb.DocumentRecord.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x03 \x03(\v2 .pb.DocumentRecord.MetadataEntryR\bmetadata\x12%\n" +
+	"\x0echunk_strategy\x18\x04 \x01(\tR\rchunkStrategy\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"F\n" +
@@ -1125,14 +2596,114 @@ const file_rag_proto_rawDesc = "" +
 	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
 	"\x03ids\x18\x02 \x03(\tR\x03ids\"9\n" +
 	"\x13DeleteDocumentsResp\x12\"\n" +
-	"\fdeletedCount\x18\x01 \x01(\x05R\fdeletedCount\"l\n" +
+	"\fdeletedCount\x18\x01 \x01(\x05R\fdeletedCount\">\n" +
+	"\x14GetDocumentStatusReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"\xdc\x01\n" +
+	"\x15GetDocumentStatusResp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x03R\x06status\x12\x1f\n" +
+	"\vstatus_name\x18\x04 \x01(\tR\n" +
+	"statusName\x12\x1b\n" +
+	"\terror_msg\x18\x05 \x01(\tR\berrorMsg\x12\x1f\n" +
+	"\vchunk_count\x18\x06 \x01(\x03R\n" +
+	"chunkCount\x12\x1f\n" +
+	"\vupdate_time\x18\a \x01(\x03R\n" +
+	"updateTime\"c\n" +
+	"\x12ReembedDocumentReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12%\n" +
+	"\x0ecleanup_method\x18\x03 \x01(\tR\rcleanupMethod\"^\n" +
+	"\x13ReembedDocumentResp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x03R\x06status\x12\x1f\n" +
+	"\vstatus_name\x18\x03 \x01(\tR\n" +
+	"statusName\"l\n" +
+	"\x10EmbedDocumentReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
+	"\x0ecleanup_method\x18\x02 \x01(\tR\rcleanupMethod\x12!\n" +
+	"\flast_attempt\x18\x03 \x01(\bR\vlastAttempt\"}\n" +
+	"\x11EmbedDocumentResp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x03R\x06status\x12\x1f\n" +
+	"\vstatus_name\x18\x03 \x01(\tR\n" +
+	"statusName\x12\x1f\n" +
+	"\vchunk_count\x18\x04 \x01(\x03R\n" +
+	"chunkCount\"U\n" +
+	"\x18RequeueStaleDocumentsReq\x12#\n" +
+	"\rstale_seconds\x18\x01 \x01(\x03R\fstaleSeconds\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"7\n" +
+	"\x19RequeueStaleDocumentsResp\x12\x1a\n" +
+	"\brequeued\x18\x01 \x01(\x03R\brequeued\"l\n" +
 	"\rListChunksReq\x12\x16\n" +
 	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
 	"\x06fileId\x18\x02 \x01(\tR\x06fileId\x12+\n" +
 	"\tpageQuery\x18\x03 \x01(\v2\r.pb.PageQueryR\tpageQuery\"R\n" +
 	"\x0eListChunksResp\x12*\n" +
 	"\x06chunks\x18\x01 \x03(\v2\x12.pb.DocumentRecordR\x06chunks\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total2\xbb\x02\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xea\x02\n" +
+	"\rKnowledgeBase\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12'\n" +
+	"\x0fembedding_model\x18\x05 \x01(\tR\x0eembeddingModel\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x06 \x01(\x03R\tchunkSize\x12#\n" +
+	"\rchunk_overlap\x18\a \x01(\x03R\fchunkOverlap\x12%\n" +
+	"\x0edocument_count\x18\b \x01(\x03R\rdocumentCount\x12\x1f\n" +
+	"\vcreate_time\x18\t \x01(\x03R\n" +
+	"createTime\x12\x1f\n" +
+	"\vupdate_time\x18\n" +
+	" \x01(\x03R\n" +
+	"updateTime\x12%\n" +
+	"\x0echunk_strategy\x18\v \x01(\tR\rchunkStrategy\"\xfa\x01\n" +
+	"\x16CreateKnowledgeBaseReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12'\n" +
+	"\x0fembedding_model\x18\x04 \x01(\tR\x0eembeddingModel\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x05 \x01(\x03R\tchunkSize\x12#\n" +
+	"\rchunk_overlap\x18\x06 \x01(\x03R\fchunkOverlap\x12%\n" +
+	"\x0echunk_strategy\x18\a \x01(\tR\rchunkStrategy\")\n" +
+	"\x17CreateKnowledgeBaseResp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x8a\x02\n" +
+	"\x16UpdateKnowledgeBaseReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12'\n" +
+	"\x0fembedding_model\x18\x05 \x01(\tR\x0eembeddingModel\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x06 \x01(\x03R\tchunkSize\x12#\n" +
+	"\rchunk_overlap\x18\a \x01(\x03R\fchunkOverlap\x12%\n" +
+	"\x0echunk_strategy\x18\b \x01(\tR\rchunkStrategy\"\x19\n" +
+	"\x17UpdateKnowledgeBaseResp\"@\n" +
+	"\x16DeleteKnowledgeBaseReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"@\n" +
+	"\x17DeleteKnowledgeBaseResp\x12%\n" +
+	"\x0edetached_count\x18\x01 \x01(\x03R\rdetachedCount\"=\n" +
+	"\x13GetKnowledgeBaseReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"P\n" +
+	"\x14GetKnowledgeBaseResp\x128\n" +
+	"\x0eknowledge_base\x18\x01 \x01(\v2\x11.pb.KnowledgeBaseR\rknowledgeBase\"p\n" +
+	"\x15ListKnowledgeBasesReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12+\n" +
+	"\tpageQuery\x18\x02 \x01(\v2\r.pb.PageQueryR\tpageQuery\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"j\n" +
+	"\x16ListKnowledgeBasesResp\x12:\n" +
+	"\x0fknowledge_bases\x18\x01 \x03(\v2\x11.pb.KnowledgeBaseR\x0eknowledgeBases\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"^\n" +
+	"\x19KnowledgeBaseDocumentsReq\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x19\n" +
+	"\bfile_ids\x18\x03 \x03(\x03R\afileIds\"8\n" +
+	"\x1aKnowledgeBaseDocumentsResp\x12\x1a\n" +
+	"\baffected\x18\x01 \x01(\x03R\baffected2\xdd\x04\n" +
 	"\n" +
 	"DocService\x125\n" +
 	"\n" +
@@ -1141,11 +2712,23 @@ const file_rag_proto_rawDesc = "" +
 	"\x0eFetchDocuments\x12\x15.pb.FetchDocumentsReq\x1a\x16.pb.FetchDocumentsResp\x12B\n" +
 	"\x0fDeleteDocuments\x12\x16.pb.DeleteDocumentsReq\x1a\x17.pb.DeleteDocumentsResp\x123\n" +
 	"\n" +
-	"ListChunks\x12\x11.pb.ListChunksReq\x1a\x12.pb.ListChunksResp2h\n" +
+	"ListChunks\x12\x11.pb.ListChunksReq\x1a\x12.pb.ListChunksResp\x12H\n" +
+	"\x11GetDocumentStatus\x12\x18.pb.GetDocumentStatusReq\x1a\x19.pb.GetDocumentStatusResp\x12B\n" +
+	"\x0fReembedDocument\x12\x16.pb.ReembedDocumentReq\x1a\x17.pb.ReembedDocumentResp\x12<\n" +
+	"\rEmbedDocument\x12\x14.pb.EmbedDocumentReq\x1a\x15.pb.EmbedDocumentResp\x12T\n" +
+	"\x15RequeueStaleDocuments\x12\x1c.pb.RequeueStaleDocumentsReq\x1a\x1d.pb.RequeueStaleDocumentsResp2h\n" +
 	"\n" +
 	"RagService\x12$\n" +
 	"\x05Query\x12\f.pb.QueryReq\x1a\r.pb.QueryResp\x124\n" +
-	"\rQueryMultiple\x12\x14.pb.QueryMultipleReq\x1a\r.pb.QueryRespB\x06Z\x04./pbb\x06proto3"
+	"\rQueryMultiple\x12\x14.pb.QueryMultipleReq\x1a\r.pb.QueryResp2\xbb\x04\n" +
+	"\x14KnowledgeBaseService\x12N\n" +
+	"\x13CreateKnowledgeBase\x12\x1a.pb.CreateKnowledgeBaseReq\x1a\x1b.pb.CreateKnowledgeBaseResp\x12N\n" +
+	"\x13UpdateKnowledgeBase\x12\x1a.pb.UpdateKnowledgeBaseReq\x1a\x1b.pb.UpdateKnowledgeBaseResp\x12N\n" +
+	"\x13DeleteKnowledgeBase\x12\x1a.pb.DeleteKnowledgeBaseReq\x1a\x1b.pb.DeleteKnowledgeBaseResp\x12E\n" +
+	"\x10GetKnowledgeBase\x12\x17.pb.GetKnowledgeBaseReq\x1a\x18.pb.GetKnowledgeBaseResp\x12K\n" +
+	"\x12ListKnowledgeBases\x12\x19.pb.ListKnowledgeBasesReq\x1a\x1a.pb.ListKnowledgeBasesResp\x12M\n" +
+	"\fAddDocuments\x12\x1d.pb.KnowledgeBaseDocumentsReq\x1a\x1e.pb.KnowledgeBaseDocumentsResp\x12P\n" +
+	"\x0fRemoveDocuments\x12\x1d.pb.KnowledgeBaseDocumentsReq\x1a\x1e.pb.KnowledgeBaseDocumentsRespB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_rag_proto_rawDescOnce sync.Once
@@ -1159,58 +2742,104 @@ func file_rag_proto_rawDescGZIP() []byte {
 	return file_rag_proto_rawDescData
 }
 
-var file_rag_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_rag_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_rag_proto_goTypes = []any{
-	(*PageQuery)(nil),           // 0: pb.PageQuery
-	(*UploadFileReq)(nil),       // 1: pb.UploadFileReq
-	(*UploadFileResp)(nil),      // 2: pb.UploadFileResp
-	(*QueryReq)(nil),            // 3: pb.QueryReq
-	(*QueryMultipleReq)(nil),    // 4: pb.QueryMultipleReq
-	(*RetrievalResult)(nil),     // 5: pb.RetrievalResult
-	(*QueryResp)(nil),           // 6: pb.QueryResp
-	(*ListDocumentsFilter)(nil), // 7: pb.ListDocumentsFilter
-	(*ListDocumentsReq)(nil),    // 8: pb.ListDocumentsReq
-	(*ListDocumentsItem)(nil),   // 9: pb.ListDocumentsItem
-	(*ListDocumentsResp)(nil),   // 10: pb.ListDocumentsResp
-	(*FetchDocumentsReq)(nil),   // 11: pb.FetchDocumentsReq
-	(*DocumentRecord)(nil),      // 12: pb.DocumentRecord
-	(*FetchDocumentsResp)(nil),  // 13: pb.FetchDocumentsResp
-	(*DeleteDocumentsReq)(nil),  // 14: pb.DeleteDocumentsReq
-	(*DeleteDocumentsResp)(nil), // 15: pb.DeleteDocumentsResp
-	(*ListChunksReq)(nil),       // 16: pb.ListChunksReq
-	(*ListChunksResp)(nil),      // 17: pb.ListChunksResp
-	nil,                         // 18: pb.RetrievalResult.MetadataEntry
-	nil,                         // 19: pb.DocumentRecord.MetadataEntry
+	(*PageQuery)(nil),                  // 0: pb.PageQuery
+	(*UploadFileReq)(nil),              // 1: pb.UploadFileReq
+	(*UploadFileResp)(nil),             // 2: pb.UploadFileResp
+	(*QueryReq)(nil),                   // 3: pb.QueryReq
+	(*QueryMultipleReq)(nil),           // 4: pb.QueryMultipleReq
+	(*RetrievalResult)(nil),            // 5: pb.RetrievalResult
+	(*QueryResp)(nil),                  // 6: pb.QueryResp
+	(*ListDocumentsFilter)(nil),        // 7: pb.ListDocumentsFilter
+	(*ListDocumentsReq)(nil),           // 8: pb.ListDocumentsReq
+	(*ListDocumentsItem)(nil),          // 9: pb.ListDocumentsItem
+	(*ListDocumentsResp)(nil),          // 10: pb.ListDocumentsResp
+	(*FetchDocumentsReq)(nil),          // 11: pb.FetchDocumentsReq
+	(*DocumentRecord)(nil),             // 12: pb.DocumentRecord
+	(*FetchDocumentsResp)(nil),         // 13: pb.FetchDocumentsResp
+	(*DeleteDocumentsReq)(nil),         // 14: pb.DeleteDocumentsReq
+	(*DeleteDocumentsResp)(nil),        // 15: pb.DeleteDocumentsResp
+	(*GetDocumentStatusReq)(nil),       // 16: pb.GetDocumentStatusReq
+	(*GetDocumentStatusResp)(nil),      // 17: pb.GetDocumentStatusResp
+	(*ReembedDocumentReq)(nil),         // 18: pb.ReembedDocumentReq
+	(*ReembedDocumentResp)(nil),        // 19: pb.ReembedDocumentResp
+	(*EmbedDocumentReq)(nil),           // 20: pb.EmbedDocumentReq
+	(*EmbedDocumentResp)(nil),          // 21: pb.EmbedDocumentResp
+	(*RequeueStaleDocumentsReq)(nil),   // 22: pb.RequeueStaleDocumentsReq
+	(*RequeueStaleDocumentsResp)(nil),  // 23: pb.RequeueStaleDocumentsResp
+	(*ListChunksReq)(nil),              // 24: pb.ListChunksReq
+	(*ListChunksResp)(nil),             // 25: pb.ListChunksResp
+	(*KnowledgeBase)(nil),              // 26: pb.KnowledgeBase
+	(*CreateKnowledgeBaseReq)(nil),     // 27: pb.CreateKnowledgeBaseReq
+	(*CreateKnowledgeBaseResp)(nil),    // 28: pb.CreateKnowledgeBaseResp
+	(*UpdateKnowledgeBaseReq)(nil),     // 29: pb.UpdateKnowledgeBaseReq
+	(*UpdateKnowledgeBaseResp)(nil),    // 30: pb.UpdateKnowledgeBaseResp
+	(*DeleteKnowledgeBaseReq)(nil),     // 31: pb.DeleteKnowledgeBaseReq
+	(*DeleteKnowledgeBaseResp)(nil),    // 32: pb.DeleteKnowledgeBaseResp
+	(*GetKnowledgeBaseReq)(nil),        // 33: pb.GetKnowledgeBaseReq
+	(*GetKnowledgeBaseResp)(nil),       // 34: pb.GetKnowledgeBaseResp
+	(*ListKnowledgeBasesReq)(nil),      // 35: pb.ListKnowledgeBasesReq
+	(*ListKnowledgeBasesResp)(nil),     // 36: pb.ListKnowledgeBasesResp
+	(*KnowledgeBaseDocumentsReq)(nil),  // 37: pb.KnowledgeBaseDocumentsReq
+	(*KnowledgeBaseDocumentsResp)(nil), // 38: pb.KnowledgeBaseDocumentsResp
+	nil,                                // 39: pb.RetrievalResult.MetadataEntry
+	nil,                                // 40: pb.DocumentRecord.MetadataEntry
 }
 var file_rag_proto_depIdxs = []int32{
-	18, // 0: pb.RetrievalResult.metadata:type_name -> pb.RetrievalResult.MetadataEntry
+	39, // 0: pb.RetrievalResult.metadata:type_name -> pb.RetrievalResult.MetadataEntry
 	5,  // 1: pb.QueryResp.results:type_name -> pb.RetrievalResult
 	0,  // 2: pb.ListDocumentsReq.pageQuery:type_name -> pb.PageQuery
 	7,  // 3: pb.ListDocumentsReq.filter:type_name -> pb.ListDocumentsFilter
 	9,  // 4: pb.ListDocumentsResp.results:type_name -> pb.ListDocumentsItem
-	19, // 5: pb.DocumentRecord.metadata:type_name -> pb.DocumentRecord.MetadataEntry
+	40, // 5: pb.DocumentRecord.metadata:type_name -> pb.DocumentRecord.MetadataEntry
 	12, // 6: pb.FetchDocumentsResp.documents:type_name -> pb.DocumentRecord
 	0,  // 7: pb.ListChunksReq.pageQuery:type_name -> pb.PageQuery
 	12, // 8: pb.ListChunksResp.chunks:type_name -> pb.DocumentRecord
-	1,  // 9: pb.DocService.UploadFile:input_type -> pb.UploadFileReq
-	8,  // 10: pb.DocService.ListDocuments:input_type -> pb.ListDocumentsReq
-	11, // 11: pb.DocService.FetchDocuments:input_type -> pb.FetchDocumentsReq
-	14, // 12: pb.DocService.DeleteDocuments:input_type -> pb.DeleteDocumentsReq
-	16, // 13: pb.DocService.ListChunks:input_type -> pb.ListChunksReq
-	3,  // 14: pb.RagService.Query:input_type -> pb.QueryReq
-	4,  // 15: pb.RagService.QueryMultiple:input_type -> pb.QueryMultipleReq
-	2,  // 16: pb.DocService.UploadFile:output_type -> pb.UploadFileResp
-	10, // 17: pb.DocService.ListDocuments:output_type -> pb.ListDocumentsResp
-	13, // 18: pb.DocService.FetchDocuments:output_type -> pb.FetchDocumentsResp
-	15, // 19: pb.DocService.DeleteDocuments:output_type -> pb.DeleteDocumentsResp
-	17, // 20: pb.DocService.ListChunks:output_type -> pb.ListChunksResp
-	6,  // 21: pb.RagService.Query:output_type -> pb.QueryResp
-	6,  // 22: pb.RagService.QueryMultiple:output_type -> pb.QueryResp
-	16, // [16:23] is the sub-list for method output_type
-	9,  // [9:16] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	26, // 9: pb.GetKnowledgeBaseResp.knowledge_base:type_name -> pb.KnowledgeBase
+	0,  // 10: pb.ListKnowledgeBasesReq.pageQuery:type_name -> pb.PageQuery
+	26, // 11: pb.ListKnowledgeBasesResp.knowledge_bases:type_name -> pb.KnowledgeBase
+	1,  // 12: pb.DocService.UploadFile:input_type -> pb.UploadFileReq
+	8,  // 13: pb.DocService.ListDocuments:input_type -> pb.ListDocumentsReq
+	11, // 14: pb.DocService.FetchDocuments:input_type -> pb.FetchDocumentsReq
+	14, // 15: pb.DocService.DeleteDocuments:input_type -> pb.DeleteDocumentsReq
+	24, // 16: pb.DocService.ListChunks:input_type -> pb.ListChunksReq
+	16, // 17: pb.DocService.GetDocumentStatus:input_type -> pb.GetDocumentStatusReq
+	18, // 18: pb.DocService.ReembedDocument:input_type -> pb.ReembedDocumentReq
+	20, // 19: pb.DocService.EmbedDocument:input_type -> pb.EmbedDocumentReq
+	22, // 20: pb.DocService.RequeueStaleDocuments:input_type -> pb.RequeueStaleDocumentsReq
+	3,  // 21: pb.RagService.Query:input_type -> pb.QueryReq
+	4,  // 22: pb.RagService.QueryMultiple:input_type -> pb.QueryMultipleReq
+	27, // 23: pb.KnowledgeBaseService.CreateKnowledgeBase:input_type -> pb.CreateKnowledgeBaseReq
+	29, // 24: pb.KnowledgeBaseService.UpdateKnowledgeBase:input_type -> pb.UpdateKnowledgeBaseReq
+	31, // 25: pb.KnowledgeBaseService.DeleteKnowledgeBase:input_type -> pb.DeleteKnowledgeBaseReq
+	33, // 26: pb.KnowledgeBaseService.GetKnowledgeBase:input_type -> pb.GetKnowledgeBaseReq
+	35, // 27: pb.KnowledgeBaseService.ListKnowledgeBases:input_type -> pb.ListKnowledgeBasesReq
+	37, // 28: pb.KnowledgeBaseService.AddDocuments:input_type -> pb.KnowledgeBaseDocumentsReq
+	37, // 29: pb.KnowledgeBaseService.RemoveDocuments:input_type -> pb.KnowledgeBaseDocumentsReq
+	2,  // 30: pb.DocService.UploadFile:output_type -> pb.UploadFileResp
+	10, // 31: pb.DocService.ListDocuments:output_type -> pb.ListDocumentsResp
+	13, // 32: pb.DocService.FetchDocuments:output_type -> pb.FetchDocumentsResp
+	15, // 33: pb.DocService.DeleteDocuments:output_type -> pb.DeleteDocumentsResp
+	25, // 34: pb.DocService.ListChunks:output_type -> pb.ListChunksResp
+	17, // 35: pb.DocService.GetDocumentStatus:output_type -> pb.GetDocumentStatusResp
+	19, // 36: pb.DocService.ReembedDocument:output_type -> pb.ReembedDocumentResp
+	21, // 37: pb.DocService.EmbedDocument:output_type -> pb.EmbedDocumentResp
+	23, // 38: pb.DocService.RequeueStaleDocuments:output_type -> pb.RequeueStaleDocumentsResp
+	6,  // 39: pb.RagService.Query:output_type -> pb.QueryResp
+	6,  // 40: pb.RagService.QueryMultiple:output_type -> pb.QueryResp
+	28, // 41: pb.KnowledgeBaseService.CreateKnowledgeBase:output_type -> pb.CreateKnowledgeBaseResp
+	30, // 42: pb.KnowledgeBaseService.UpdateKnowledgeBase:output_type -> pb.UpdateKnowledgeBaseResp
+	32, // 43: pb.KnowledgeBaseService.DeleteKnowledgeBase:output_type -> pb.DeleteKnowledgeBaseResp
+	34, // 44: pb.KnowledgeBaseService.GetKnowledgeBase:output_type -> pb.GetKnowledgeBaseResp
+	36, // 45: pb.KnowledgeBaseService.ListKnowledgeBases:output_type -> pb.ListKnowledgeBasesResp
+	38, // 46: pb.KnowledgeBaseService.AddDocuments:output_type -> pb.KnowledgeBaseDocumentsResp
+	38, // 47: pb.KnowledgeBaseService.RemoveDocuments:output_type -> pb.KnowledgeBaseDocumentsResp
+	30, // [30:48] is the sub-list for method output_type
+	12, // [12:30] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_rag_proto_init() }
@@ -1218,15 +2847,17 @@ func file_rag_proto_init() {
 	if File_rag_proto != nil {
 		return
 	}
+	file_rag_proto_msgTypes[3].OneofWrappers = []any{}
+	file_rag_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_proto_rawDesc), len(file_rag_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   41,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_rag_proto_goTypes,
 		DependencyIndexes: file_rag_proto_depIdxs,
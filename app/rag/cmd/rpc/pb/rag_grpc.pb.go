@@ -19,11 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DocService_UploadFile_FullMethodName      = "/pb.DocService/UploadFile"
-	DocService_ListDocuments_FullMethodName   = "/pb.DocService/ListDocuments"
-	DocService_FetchDocuments_FullMethodName  = "/pb.DocService/FetchDocuments"
-	DocService_DeleteDocuments_FullMethodName = "/pb.DocService/DeleteDocuments"
-	DocService_ListChunks_FullMethodName      = "/pb.DocService/ListChunks"
+	DocService_UploadFile_FullMethodName            = "/pb.DocService/UploadFile"
+	DocService_ListDocuments_FullMethodName         = "/pb.DocService/ListDocuments"
+	DocService_FetchDocuments_FullMethodName        = "/pb.DocService/FetchDocuments"
+	DocService_DeleteDocuments_FullMethodName       = "/pb.DocService/DeleteDocuments"
+	DocService_ListChunks_FullMethodName            = "/pb.DocService/ListChunks"
+	DocService_GetDocumentStatus_FullMethodName     = "/pb.DocService/GetDocumentStatus"
+	DocService_ReembedDocument_FullMethodName       = "/pb.DocService/ReembedDocument"
+	DocService_EmbedDocument_FullMethodName         = "/pb.DocService/EmbedDocument"
+	DocService_RequeueStaleDocuments_FullMethodName = "/pb.DocService/RequeueStaleDocuments"
 )
 
 // DocServiceClient is the client API for DocService service.
@@ -35,6 +39,10 @@ type DocServiceClient interface {
 	FetchDocuments(ctx context.Context, in *FetchDocumentsReq, opts ...grpc.CallOption) (*FetchDocumentsResp, error)
 	DeleteDocuments(ctx context.Context, in *DeleteDocumentsReq, opts ...grpc.CallOption) (*DeleteDocumentsResp, error)
 	ListChunks(ctx context.Context, in *ListChunksReq, opts ...grpc.CallOption) (*ListChunksResp, error)
+	GetDocumentStatus(ctx context.Context, in *GetDocumentStatusReq, opts ...grpc.CallOption) (*GetDocumentStatusResp, error)
+	ReembedDocument(ctx context.Context, in *ReembedDocumentReq, opts ...grpc.CallOption) (*ReembedDocumentResp, error)
+	EmbedDocument(ctx context.Context, in *EmbedDocumentReq, opts ...grpc.CallOption) (*EmbedDocumentResp, error)
+	RequeueStaleDocuments(ctx context.Context, in *RequeueStaleDocumentsReq, opts ...grpc.CallOption) (*RequeueStaleDocumentsResp, error)
 }
 
 type docServiceClient struct {
@@ -98,6 +106,46 @@ func (c *docServiceClient) ListChunks(ctx context.Context, in *ListChunksReq, op
 	return out, nil
 }
 
+func (c *docServiceClient) GetDocumentStatus(ctx context.Context, in *GetDocumentStatusReq, opts ...grpc.CallOption) (*GetDocumentStatusResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDocumentStatusResp)
+	err := c.cc.Invoke(ctx, DocService_GetDocumentStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) ReembedDocument(ctx context.Context, in *ReembedDocumentReq, opts ...grpc.CallOption) (*ReembedDocumentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReembedDocumentResp)
+	err := c.cc.Invoke(ctx, DocService_ReembedDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) EmbedDocument(ctx context.Context, in *EmbedDocumentReq, opts ...grpc.CallOption) (*EmbedDocumentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbedDocumentResp)
+	err := c.cc.Invoke(ctx, DocService_EmbedDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docServiceClient) RequeueStaleDocuments(ctx context.Context, in *RequeueStaleDocumentsReq, opts ...grpc.CallOption) (*RequeueStaleDocumentsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequeueStaleDocumentsResp)
+	err := c.cc.Invoke(ctx, DocService_RequeueStaleDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocServiceServer is the server API for DocService service.
 // All implementations must embed UnimplementedDocServiceServer
 // for forward compatibility.
@@ -107,6 +155,10 @@ type DocServiceServer interface {
 	FetchDocuments(context.Context, *FetchDocumentsReq) (*FetchDocumentsResp, error)
 	DeleteDocuments(context.Context, *DeleteDocumentsReq) (*DeleteDocumentsResp, error)
 	ListChunks(context.Context, *ListChunksReq) (*ListChunksResp, error)
+	GetDocumentStatus(context.Context, *GetDocumentStatusReq) (*GetDocumentStatusResp, error)
+	ReembedDocument(context.Context, *ReembedDocumentReq) (*ReembedDocumentResp, error)
+	EmbedDocument(context.Context, *EmbedDocumentReq) (*EmbedDocumentResp, error)
+	RequeueStaleDocuments(context.Context, *RequeueStaleDocumentsReq) (*RequeueStaleDocumentsResp, error)
 	mustEmbedUnimplementedDocServiceServer()
 }
 
@@ -132,6 +184,18 @@ func (UnimplementedDocServiceServer) DeleteDocuments(context.Context, *DeleteDoc
 func (UnimplementedDocServiceServer) ListChunks(context.Context, *ListChunksReq) (*ListChunksResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListChunks not implemented")
 }
+func (UnimplementedDocServiceServer) GetDocumentStatus(context.Context, *GetDocumentStatusReq) (*GetDocumentStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentStatus not implemented")
+}
+func (UnimplementedDocServiceServer) ReembedDocument(context.Context, *ReembedDocumentReq) (*ReembedDocumentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReembedDocument not implemented")
+}
+func (UnimplementedDocServiceServer) EmbedDocument(context.Context, *EmbedDocumentReq) (*EmbedDocumentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmbedDocument not implemented")
+}
+func (UnimplementedDocServiceServer) RequeueStaleDocuments(context.Context, *RequeueStaleDocumentsReq) (*RequeueStaleDocumentsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueStaleDocuments not implemented")
+}
 func (UnimplementedDocServiceServer) mustEmbedUnimplementedDocServiceServer() {}
 func (UnimplementedDocServiceServer) testEmbeddedByValue()                    {}
 
@@ -232,6 +296,78 @@ func _DocService_ListChunks_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocService_GetDocumentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).GetDocumentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_GetDocumentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).GetDocumentStatus(ctx, req.(*GetDocumentStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_ReembedDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReembedDocumentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).ReembedDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_ReembedDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).ReembedDocument(ctx, req.(*ReembedDocumentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_EmbedDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedDocumentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).EmbedDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_EmbedDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).EmbedDocument(ctx, req.(*EmbedDocumentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocService_RequeueStaleDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueStaleDocumentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocServiceServer).RequeueStaleDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocService_RequeueStaleDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocServiceServer).RequeueStaleDocuments(ctx, req.(*RequeueStaleDocumentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DocService_ServiceDesc is the grpc.ServiceDesc for DocService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -255,6 +391,22 @@ var DocService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListChunks",
 			Handler:    _DocService_ListChunks_Handler,
 		},
+		{
+			MethodName: "GetDocumentStatus",
+			Handler:    _DocService_GetDocumentStatus_Handler,
+		},
+		{
+			MethodName: "ReembedDocument",
+			Handler:    _DocService_ReembedDocument_Handler,
+		},
+		{
+			MethodName: "EmbedDocument",
+			Handler:    _DocService_EmbedDocument_Handler,
+		},
+		{
+			MethodName: "RequeueStaleDocuments",
+			Handler:    _DocService_RequeueStaleDocuments_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -405,3 +557,333 @@ var RagService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rag.proto",
 }
+
+const (
+	KnowledgeBaseService_CreateKnowledgeBase_FullMethodName = "/pb.KnowledgeBaseService/CreateKnowledgeBase"
+	KnowledgeBaseService_UpdateKnowledgeBase_FullMethodName = "/pb.KnowledgeBaseService/UpdateKnowledgeBase"
+	KnowledgeBaseService_DeleteKnowledgeBase_FullMethodName = "/pb.KnowledgeBaseService/DeleteKnowledgeBase"
+	KnowledgeBaseService_GetKnowledgeBase_FullMethodName    = "/pb.KnowledgeBaseService/GetKnowledgeBase"
+	KnowledgeBaseService_ListKnowledgeBases_FullMethodName  = "/pb.KnowledgeBaseService/ListKnowledgeBases"
+	KnowledgeBaseService_AddDocuments_FullMethodName        = "/pb.KnowledgeBaseService/AddDocuments"
+	KnowledgeBaseService_RemoveDocuments_FullMethodName     = "/pb.KnowledgeBaseService/RemoveDocuments"
+)
+
+// KnowledgeBaseServiceClient is the client API for KnowledgeBaseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KnowledgeBaseServiceClient interface {
+	CreateKnowledgeBase(ctx context.Context, in *CreateKnowledgeBaseReq, opts ...grpc.CallOption) (*CreateKnowledgeBaseResp, error)
+	UpdateKnowledgeBase(ctx context.Context, in *UpdateKnowledgeBaseReq, opts ...grpc.CallOption) (*UpdateKnowledgeBaseResp, error)
+	DeleteKnowledgeBase(ctx context.Context, in *DeleteKnowledgeBaseReq, opts ...grpc.CallOption) (*DeleteKnowledgeBaseResp, error)
+	GetKnowledgeBase(ctx context.Context, in *GetKnowledgeBaseReq, opts ...grpc.CallOption) (*GetKnowledgeBaseResp, error)
+	ListKnowledgeBases(ctx context.Context, in *ListKnowledgeBasesReq, opts ...grpc.CallOption) (*ListKnowledgeBasesResp, error)
+	AddDocuments(ctx context.Context, in *KnowledgeBaseDocumentsReq, opts ...grpc.CallOption) (*KnowledgeBaseDocumentsResp, error)
+	RemoveDocuments(ctx context.Context, in *KnowledgeBaseDocumentsReq, opts ...grpc.CallOption) (*KnowledgeBaseDocumentsResp, error)
+}
+
+type knowledgeBaseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKnowledgeBaseServiceClient(cc grpc.ClientConnInterface) KnowledgeBaseServiceClient {
+	return &knowledgeBaseServiceClient{cc}
+}
+
+func (c *knowledgeBaseServiceClient) CreateKnowledgeBase(ctx context.Context, in *CreateKnowledgeBaseReq, opts ...grpc.CallOption) (*CreateKnowledgeBaseResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateKnowledgeBaseResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_CreateKnowledgeBase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeBaseServiceClient) UpdateKnowledgeBase(ctx context.Context, in *UpdateKnowledgeBaseReq, opts ...grpc.CallOption) (*UpdateKnowledgeBaseResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateKnowledgeBaseResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_UpdateKnowledgeBase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeBaseServiceClient) DeleteKnowledgeBase(ctx context.Context, in *DeleteKnowledgeBaseReq, opts ...grpc.CallOption) (*DeleteKnowledgeBaseResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteKnowledgeBaseResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_DeleteKnowledgeBase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeBaseServiceClient) GetKnowledgeBase(ctx context.Context, in *GetKnowledgeBaseReq, opts ...grpc.CallOption) (*GetKnowledgeBaseResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKnowledgeBaseResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_GetKnowledgeBase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeBaseServiceClient) ListKnowledgeBases(ctx context.Context, in *ListKnowledgeBasesReq, opts ...grpc.CallOption) (*ListKnowledgeBasesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListKnowledgeBasesResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_ListKnowledgeBases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeBaseServiceClient) AddDocuments(ctx context.Context, in *KnowledgeBaseDocumentsReq, opts ...grpc.CallOption) (*KnowledgeBaseDocumentsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KnowledgeBaseDocumentsResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_AddDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeBaseServiceClient) RemoveDocuments(ctx context.Context, in *KnowledgeBaseDocumentsReq, opts ...grpc.CallOption) (*KnowledgeBaseDocumentsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KnowledgeBaseDocumentsResp)
+	err := c.cc.Invoke(ctx, KnowledgeBaseService_RemoveDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KnowledgeBaseServiceServer is the server API for KnowledgeBaseService service.
+// All implementations must embed UnimplementedKnowledgeBaseServiceServer
+// for forward compatibility.
+type KnowledgeBaseServiceServer interface {
+	CreateKnowledgeBase(context.Context, *CreateKnowledgeBaseReq) (*CreateKnowledgeBaseResp, error)
+	UpdateKnowledgeBase(context.Context, *UpdateKnowledgeBaseReq) (*UpdateKnowledgeBaseResp, error)
+	DeleteKnowledgeBase(context.Context, *DeleteKnowledgeBaseReq) (*DeleteKnowledgeBaseResp, error)
+	GetKnowledgeBase(context.Context, *GetKnowledgeBaseReq) (*GetKnowledgeBaseResp, error)
+	ListKnowledgeBases(context.Context, *ListKnowledgeBasesReq) (*ListKnowledgeBasesResp, error)
+	AddDocuments(context.Context, *KnowledgeBaseDocumentsReq) (*KnowledgeBaseDocumentsResp, error)
+	RemoveDocuments(context.Context, *KnowledgeBaseDocumentsReq) (*KnowledgeBaseDocumentsResp, error)
+	mustEmbedUnimplementedKnowledgeBaseServiceServer()
+}
+
+// UnimplementedKnowledgeBaseServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKnowledgeBaseServiceServer struct{}
+
+func (UnimplementedKnowledgeBaseServiceServer) CreateKnowledgeBase(context.Context, *CreateKnowledgeBaseReq) (*CreateKnowledgeBaseResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateKnowledgeBase not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) UpdateKnowledgeBase(context.Context, *UpdateKnowledgeBaseReq) (*UpdateKnowledgeBaseResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateKnowledgeBase not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) DeleteKnowledgeBase(context.Context, *DeleteKnowledgeBaseReq) (*DeleteKnowledgeBaseResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteKnowledgeBase not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) GetKnowledgeBase(context.Context, *GetKnowledgeBaseReq) (*GetKnowledgeBaseResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKnowledgeBase not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) ListKnowledgeBases(context.Context, *ListKnowledgeBasesReq) (*ListKnowledgeBasesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKnowledgeBases not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) AddDocuments(context.Context, *KnowledgeBaseDocumentsReq) (*KnowledgeBaseDocumentsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDocuments not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) RemoveDocuments(context.Context, *KnowledgeBaseDocumentsReq) (*KnowledgeBaseDocumentsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDocuments not implemented")
+}
+func (UnimplementedKnowledgeBaseServiceServer) mustEmbedUnimplementedKnowledgeBaseServiceServer() {}
+func (UnimplementedKnowledgeBaseServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeKnowledgeBaseServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KnowledgeBaseServiceServer will
+// result in compilation errors.
+type UnsafeKnowledgeBaseServiceServer interface {
+	mustEmbedUnimplementedKnowledgeBaseServiceServer()
+}
+
+func RegisterKnowledgeBaseServiceServer(s grpc.ServiceRegistrar, srv KnowledgeBaseServiceServer) {
+	// If the following call pancis, it indicates UnimplementedKnowledgeBaseServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KnowledgeBaseService_ServiceDesc, srv)
+}
+
+func _KnowledgeBaseService_CreateKnowledgeBase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateKnowledgeBaseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).CreateKnowledgeBase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_CreateKnowledgeBase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).CreateKnowledgeBase(ctx, req.(*CreateKnowledgeBaseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeBaseService_UpdateKnowledgeBase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateKnowledgeBaseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).UpdateKnowledgeBase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_UpdateKnowledgeBase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).UpdateKnowledgeBase(ctx, req.(*UpdateKnowledgeBaseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeBaseService_DeleteKnowledgeBase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteKnowledgeBaseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).DeleteKnowledgeBase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_DeleteKnowledgeBase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).DeleteKnowledgeBase(ctx, req.(*DeleteKnowledgeBaseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeBaseService_GetKnowledgeBase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKnowledgeBaseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).GetKnowledgeBase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_GetKnowledgeBase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).GetKnowledgeBase(ctx, req.(*GetKnowledgeBaseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeBaseService_ListKnowledgeBases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKnowledgeBasesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).ListKnowledgeBases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_ListKnowledgeBases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).ListKnowledgeBases(ctx, req.(*ListKnowledgeBasesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeBaseService_AddDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KnowledgeBaseDocumentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).AddDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_AddDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).AddDocuments(ctx, req.(*KnowledgeBaseDocumentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeBaseService_RemoveDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KnowledgeBaseDocumentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeBaseServiceServer).RemoveDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeBaseService_RemoveDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeBaseServiceServer).RemoveDocuments(ctx, req.(*KnowledgeBaseDocumentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KnowledgeBaseService_ServiceDesc is the grpc.ServiceDesc for KnowledgeBaseService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KnowledgeBaseService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.KnowledgeBaseService",
+	HandlerType: (*KnowledgeBaseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateKnowledgeBase",
+			Handler:    _KnowledgeBaseService_CreateKnowledgeBase_Handler,
+		},
+		{
+			MethodName: "UpdateKnowledgeBase",
+			Handler:    _KnowledgeBaseService_UpdateKnowledgeBase_Handler,
+		},
+		{
+			MethodName: "DeleteKnowledgeBase",
+			Handler:    _KnowledgeBaseService_DeleteKnowledgeBase_Handler,
+		},
+		{
+			MethodName: "GetKnowledgeBase",
+			Handler:    _KnowledgeBaseService_GetKnowledgeBase_Handler,
+		},
+		{
+			MethodName: "ListKnowledgeBases",
+			Handler:    _KnowledgeBaseService_ListKnowledgeBases_Handler,
+		},
+		{
+			MethodName: "AddDocuments",
+			Handler:    _KnowledgeBaseService_AddDocuments_Handler,
+		},
+		{
+			MethodName: "RemoveDocuments",
+			Handler:    _KnowledgeBaseService_RemoveDocuments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rag.proto",
+}
@@ -6,6 +6,7 @@ import (
 
 	"go-zero-voice-agent/app/rag/cmd/rpc/internal/config"
 	docserviceServer "go-zero-voice-agent/app/rag/cmd/rpc/internal/server/docservice"
+	knowledgebaseserviceServer "go-zero-voice-agent/app/rag/cmd/rpc/internal/server/knowledgebaseservice"
 	ragserviceServer "go-zero-voice-agent/app/rag/cmd/rpc/internal/server/ragservice"
 	"go-zero-voice-agent/app/rag/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/rag/cmd/rpc/pb"
@@ -35,6 +36,7 @@ func main() {
 	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		pb.RegisterDocServiceServer(grpcServer, docserviceServer.NewDocServiceServer(ctx))
 		pb.RegisterRagServiceServer(grpcServer, ragserviceServer.NewRagServiceServer(ctx))
+		pb.RegisterKnowledgeBaseServiceServer(grpcServer, knowledgebaseserviceServer.NewKnowledgeBaseServiceServer(ctx))
 
 		if c.Mode == service.DevMode || c.Mode == service.TestMode {
 			reflection.Register(grpcServer)
@@ -1,6 +1,13 @@
 package model
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/globalkey"
+
+	"github.com/Masterminds/squirrel"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -12,6 +19,9 @@ type (
 	// and implement the added methods in customFileUploadModel.
 	FileUploadModel interface {
 		fileUploadModel
+		TransitStatus(ctx context.Context, data *FileUpload, status int64, errorMsg string, chunkCount int64) error
+		SetKnowledgeBase(ctx context.Context, session sqlx.Session, ids []int64, where squirrel.Eq, knowledgeBaseId int64) (int64, error)
+		CountByKnowledgeBase(ctx context.Context, knowledgeBaseIds []int64) (map[int64]int64, error)
 	}
 
 	customFileUploadModel struct {
@@ -25,3 +35,102 @@ func NewFileUploadModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Opti
 		defaultFileUploadModel: newFileUploadModel(conn, c, opts...),
 	}
 }
+
+// TransitStatus 按状态机切换处理状态并记录错误信息与切片数，以数据库中的当前状态为准，
+// 不允许切换（如已被删除或其他任务抢先切换）时返回 ErrNoRowsUpdate
+func (m *customFileUploadModel) TransitStatus(ctx context.Context, data *FileUpload, status int64, errorMsg string, chunkCount int64) error {
+	from := fileStatusFrom[status]
+	if len(from) == 0 {
+		return ErrNoRowsUpdate
+	}
+	query, args, err := squirrel.Update(m.table).
+		Set("status", status).
+		Set("error_msg", errorMsg).
+		Set("chunk_count", chunkCount).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": data.Id, "status": from, "del_state": globalkey.DelStateNo}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	gzvaRagFileUploadIdKey := fmt.Sprintf("%s%v", cacheGzvaRagFileUploadIdPrefix, data.Id)
+	result, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		return conn.ExecCtx(ctx, query, args...)
+	}, gzvaRagFileUploadIdKey)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRowsUpdate
+	}
+
+	data.Status = status
+	data.ErrorMsg = errorMsg
+	data.ChunkCount = chunkCount
+	data.Version++
+	return nil
+}
+
+// SetKnowledgeBase 把 ids 中满足 where 条件且未删除的文件移入知识库，knowledgeBaseId 为 0 时移出知识库，返回更新的行数
+func (m *customFileUploadModel) SetKnowledgeBase(ctx context.Context, session sqlx.Session, ids []int64, where squirrel.Eq, knowledgeBaseId int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	query, args, err := squirrel.Update(m.table).
+		Set("knowledge_base_id", knowledgeBaseId).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": ids, "del_state": globalkey.DelStateNo}).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheGzvaRagFileUploadIdPrefix, id))
+	}
+	result, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		if session != nil {
+			return session.ExecCtx(ctx, query, args...)
+		}
+		return conn.ExecCtx(ctx, query, args...)
+	}, keys...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// CountByKnowledgeBase 统计每个知识库中未删除的文件数，没有文件的知识库不在结果中
+func (m *customFileUploadModel) CountByKnowledgeBase(ctx context.Context, knowledgeBaseIds []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(knowledgeBaseIds))
+	if len(knowledgeBaseIds) == 0 {
+		return counts, nil
+	}
+	query, args, err := squirrel.Select("knowledge_base_id", "COUNT(id) AS count").
+		From(m.table).
+		Where(squirrel.Eq{"knowledge_base_id": knowledgeBaseIds, "del_state": globalkey.DelStateNo}).
+		GroupBy("knowledge_base_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		KnowledgeBaseId int64 `db:"knowledge_base_id"`
+		Count           int64 `db:"count"`
+	}
+	if err := m.QueryRowsNoCacheCtx(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.KnowledgeBaseId] = row.Count
+	}
+	return counts, nil
+}
@@ -57,19 +57,25 @@ type (
 	}
 
 	FileUpload struct {
-		Id         int64          `db:"id"`
-		CreateTime time.Time      `db:"create_time"`
-		UpdateTime time.Time      `db:"update_time"`
-		DeleteTime sql.NullTime   `db:"delete_time"`
-		DelState   int64          `db:"del_state"`
-		Version    int64          `db:"version"`
-		UserId     sql.NullInt64  `db:"user_id"`
-		FileName   string         `db:"file_name"`
-		FileFormat sql.NullString `db:"file_format"` // 文件的格式
-		BucketName sql.NullString `db:"bucket_name"`
-		FilePath   string         `db:"file_path"`  // 文件存储路径
-		StoreType  string         `db:"store_type"` // 存储方式：local,cors,minio
-		Status     int64          `db:"status"`     // 是否向量化：否0，是1
+		Id              int64          `db:"id"`
+		CreateTime      time.Time      `db:"create_time"`
+		UpdateTime      time.Time      `db:"update_time"`
+		DeleteTime      sql.NullTime   `db:"delete_time"`
+		DelState        int64          `db:"del_state"`
+		Version         int64          `db:"version"`
+		UserId          sql.NullInt64  `db:"user_id"`
+		FileName        string         `db:"file_name"`
+		FileFormat      sql.NullString `db:"file_format"` // 文件的格式
+		BucketName      sql.NullString `db:"bucket_name"`
+		FilePath        string         `db:"file_path"`         // 文件存储路径
+		StoreType       string         `db:"store_type"`        // 存储方式：local,cors,minio
+		Status          int64          `db:"status"`            // 处理状态：0已上传 1可检索 2排队中 3解析中 4向量化中 5失败
+		ErrorMsg        string         `db:"error_msg"`         // 处理失败的原因
+		ChunkCount      int64          `db:"chunk_count"`       // 向量化后的切片数
+		KnowledgeBaseId int64          `db:"knowledge_base_id"` // 所属知识库，0 表示不属于任何知识库
+		ChunkStrategy   string         `db:"chunk_strategy"`    // 上传时指定的切片策略，为空时使用知识库或默认策略
+		ChunkSize       int64          `db:"chunk_size"`        // 上传时指定的切片长度，0 表示使用知识库或默认值
+		ChunkOverlap    int64          `db:"chunk_overlap"`     // 上传时指定的相邻切片重叠长度
 	}
 )
 
@@ -112,11 +118,11 @@ func (m *defaultFileUploadModel) Insert(ctx context.Context, session sqlx.Sessio
 	data.DelState = globalkey.DelStateNo
 	gzvaRagFileUploadIdKey := fmt.Sprintf("%s%v", cacheGzvaRagFileUploadIdPrefix, data.Id)
 	ret, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
-		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, fileUploadRowsExpectAutoSet)
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, fileUploadRowsExpectAutoSet)
 		if session != nil {
-			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status)
+			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.ErrorMsg, data.ChunkCount, data.KnowledgeBaseId, data.ChunkStrategy, data.ChunkSize, data.ChunkOverlap)
 		}
-		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status)
+		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.ErrorMsg, data.ChunkCount, data.KnowledgeBaseId, data.ChunkStrategy, data.ChunkSize, data.ChunkOverlap)
 	}, gzvaRagFileUploadIdKey)
 	return ret, err
 }
@@ -126,9 +132,9 @@ func (m *defaultFileUploadModel) Update(ctx context.Context, session sqlx.Sessio
 	return m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, fileUploadRowsWithPlaceHolder)
 		if session != nil {
-			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.Id)
+			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.ErrorMsg, data.ChunkCount, data.KnowledgeBaseId, data.ChunkStrategy, data.ChunkSize, data.ChunkOverlap, data.Id)
 		}
-		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.Id)
+		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.ErrorMsg, data.ChunkCount, data.KnowledgeBaseId, data.ChunkStrategy, data.ChunkSize, data.ChunkOverlap, data.Id)
 	}, gzvaRagFileUploadIdKey)
 }
 
@@ -144,9 +150,9 @@ func (m *defaultFileUploadModel) UpdateWithVersion(ctx context.Context, session
 	sqlResult, err = m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("update %s set %s where `id` = ? and version = ? ", m.table, fileUploadRowsWithPlaceHolder)
 		if session != nil {
-			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.Id, oldVersion)
+			return session.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.ErrorMsg, data.ChunkCount, data.KnowledgeBaseId, data.ChunkStrategy, data.ChunkSize, data.ChunkOverlap, data.Id, oldVersion)
 		}
-		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.Id, oldVersion)
+		return conn.ExecCtx(ctx, query, data.DelState, data.Version, data.UserId, data.FileName, data.FileFormat, data.BucketName, data.FilePath, data.StoreType, data.Status, data.ErrorMsg, data.ChunkCount, data.KnowledgeBaseId, data.ChunkStrategy, data.ChunkSize, data.ChunkOverlap, data.Id, oldVersion)
 	}, gzvaRagFileUploadIdKey)
 	if err != nil {
 		return err
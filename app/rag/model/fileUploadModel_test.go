@@ -0,0 +1,72 @@
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+func newTestFileUploadModel(t *testing.T) (FileUploadModel, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	mr := miniredis.RunT(t)
+	c := cache.CacheConf{{RedisConf: redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType}, Weight: 100}}
+	return NewFileUploadModel(sqlx.NewSqlConnFromDB(db), c), mock
+}
+
+func TestTransitStatus(t *testing.T) {
+	for _, current := range allFileStatuses {
+		for _, to := range allFileStatuses {
+			name := FileStatusName(current) + "->" + FileStatusName(to)
+			t.Run(name, func(t *testing.T) {
+				m, mock := newTestFileUploadModel(t)
+				data := &FileUpload{Id: 7, Version: 3, Status: current, ErrorMsg: "old", ChunkCount: 1}
+
+				from := fileStatusFrom[to]
+				if len(from) > 0 {
+					args := []driver.Value{to, "msg", int64(5), int64(0), int64(7)}
+					for _, status := range from {
+						args = append(args, status)
+					}
+					// 模拟数据库按 where 中的来源状态过滤，当前状态不在其中时不更新任何行
+					var affected int64
+					if canTransit(current, to) {
+						affected = 1
+					}
+					mock.ExpectExec("UPDATE .* SET status = \\?, error_msg = \\?, chunk_count = \\?, version = version \\+ 1 WHERE del_state = \\? AND id = \\? AND status IN").
+						WithArgs(args...).
+						WillReturnResult(sqlmock.NewResult(0, affected))
+				}
+
+				err := m.TransitStatus(context.Background(), data, to, "msg", 5)
+				if canTransit(current, to) {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if data.Status != to || data.ErrorMsg != "msg" || data.ChunkCount != 5 || data.Version != 4 {
+						t.Fatalf("data not updated: %+v", data)
+					}
+				} else {
+					if err != ErrNoRowsUpdate {
+						t.Fatalf("expected ErrNoRowsUpdate, got %v", err)
+					}
+					if data.Status != current || data.ErrorMsg != "old" || data.ChunkCount != 1 || data.Version != 3 {
+						t.Fatalf("data changed on rejected transition: %+v", data)
+					}
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
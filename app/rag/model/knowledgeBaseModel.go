@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ KnowledgeBaseModel = (*customKnowledgeBaseModel)(nil)
+
+type (
+	// KnowledgeBaseModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customKnowledgeBaseModel.
+	KnowledgeBaseModel interface {
+		knowledgeBaseModel
+	}
+
+	customKnowledgeBaseModel struct {
+		*defaultKnowledgeBaseModel
+	}
+)
+
+// NewKnowledgeBaseModel returns a model for the database table.
+func NewKnowledgeBaseModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) KnowledgeBaseModel {
+	return &customKnowledgeBaseModel{
+		defaultKnowledgeBaseModel: newKnowledgeBaseModel(conn, c, opts...),
+	}
+}
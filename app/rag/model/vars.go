@@ -7,3 +7,40 @@ import (
 
 var ErrNotFound = sqlx.ErrNotFound
 var ErrNoRowsUpdate = errors.New("update db no rows change")
+
+// 文件的处理状态，1 沿用原来“已向量化”的取值
+const (
+	FileStatusUploaded  int64 = 0 // 已上传，等待向量化
+	FileStatusReady     int64 = 1 // 向量化完成，可以检索
+	FileStatusQueued    int64 = 2 // 已提交向量化任务
+	FileStatusParsing   int64 = 3 // 读取并校验文件
+	FileStatusEmbedding int64 = 4 // 切片并写入向量库
+	FileStatusFailed    int64 = 5 // 处理失败，error_msg 记录原因
+)
+
+var fileStatusNames = map[int64]string{
+	FileStatusUploaded:  "uploaded",
+	FileStatusReady:     "ready",
+	FileStatusQueued:    "queued",
+	FileStatusParsing:   "parsing",
+	FileStatusEmbedding: "embedding",
+	FileStatusFailed:    "failed",
+}
+
+// fileStatusFrom 每个状态允许从哪些状态切换过来：失败或已完成的文件可以重新排队，处理中失败后等待重试或卡住被清扫时回到排队中，
+// 处理中的任何阶段都可能失败
+var fileStatusFrom = map[int64][]int64{
+	FileStatusQueued:    {FileStatusUploaded, FileStatusReady, FileStatusFailed, FileStatusParsing, FileStatusEmbedding},
+	FileStatusParsing:   {FileStatusQueued},
+	FileStatusEmbedding: {FileStatusParsing},
+	FileStatusReady:     {FileStatusEmbedding},
+	FileStatusFailed:    {FileStatusUploaded, FileStatusQueued, FileStatusParsing, FileStatusEmbedding},
+}
+
+// FileStatusName 处理状态的名称，未知状态返回 unknown
+func FileStatusName(status int64) string {
+	if name, ok := fileStatusNames[status]; ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,68 @@
+package model
+
+import "testing"
+
+var allFileStatuses = []int64{
+	FileStatusUploaded, FileStatusReady, FileStatusQueued,
+	FileStatusParsing, FileStatusEmbedding, FileStatusFailed,
+}
+
+// fileTransitions 允许的状态切换，未列出的都不允许
+var fileTransitions = map[[2]int64]bool{
+	{FileStatusUploaded, FileStatusQueued}:   true,
+	{FileStatusReady, FileStatusQueued}:      true,
+	{FileStatusFailed, FileStatusQueued}:     true,
+	{FileStatusParsing, FileStatusQueued}:    true,
+	{FileStatusEmbedding, FileStatusQueued}:  true,
+	{FileStatusQueued, FileStatusParsing}:    true,
+	{FileStatusParsing, FileStatusEmbedding}: true,
+	{FileStatusEmbedding, FileStatusReady}:   true,
+	{FileStatusUploaded, FileStatusFailed}:   true,
+	{FileStatusQueued, FileStatusFailed}:     true,
+	{FileStatusParsing, FileStatusFailed}:    true,
+	{FileStatusEmbedding, FileStatusFailed}:  true,
+}
+
+func canTransit(from, to int64) bool {
+	for _, status := range fileStatusFrom[to] {
+		if status == from {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFileStatusFrom(t *testing.T) {
+	for _, from := range allFileStatuses {
+		for _, to := range allFileStatuses {
+			want := fileTransitions[[2]int64{from, to}]
+			if got := canTransit(from, to); got != want {
+				t.Errorf("%s -> %s: got %v, want %v", FileStatusName(from), FileStatusName(to), got, want)
+			}
+		}
+	}
+	// 上传是初始状态，不能从其他状态切换回来
+	if len(fileStatusFrom[FileStatusUploaded]) != 0 {
+		t.Errorf("uploaded should not be a transition target: %v", fileStatusFrom[FileStatusUploaded])
+	}
+}
+
+func TestFileStatusName(t *testing.T) {
+	tests := []struct {
+		status int64
+		want   string
+	}{
+		{FileStatusUploaded, "uploaded"},
+		{FileStatusReady, "ready"},
+		{FileStatusQueued, "queued"},
+		{FileStatusParsing, "parsing"},
+		{FileStatusEmbedding, "embedding"},
+		{FileStatusFailed, "failed"},
+		{99, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := FileStatusName(tt.status); got != tt.want {
+			t.Errorf("FileStatusName(%d) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
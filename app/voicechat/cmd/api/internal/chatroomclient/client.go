@@ -0,0 +1,79 @@
+package chatroomclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 聊天室房间成员的角色，与聊天室服务一致
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleMuted  = "muted"
+)
+
+// Client 调用聊天室服务的管理接口，语音房间通过它校验成员关系，不直接读取聊天室的库表与缓存
+type Client struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// RoomMember 用户在聊天室房间中的角色
+type RoomMember struct {
+	// RoomExists 房间不存在或已删除时为 false
+	RoomExists bool  `json:"roomExists"`
+	OwnerId    int64 `json:"ownerId"`
+	// Role owner/admin/member/muted，不是成员时为空
+	Role string `json:"role"`
+}
+
+// NewClient 创建聊天室服务客户端，token 为聊天室服务的 Admin.Token
+func NewClient(endpoint, token string, timeout time.Duration) *Client {
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	return &Client{
+		endpoint:   endpoint,
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// RoomMember 查询用户在房间中的角色
+func (c *Client) RoomMember(ctx context.Context, roomId, userId int64) (*RoomMember, error) {
+	url := fmt.Sprintf("%s/ws/v1/admin/room/%d/member/%d", c.endpoint, roomId, userId)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("chatroomclient: build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("chatroomclient: query room %d member %d: %w", roomId, userId, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("chatroomclient: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chatroomclient: query room %d member %d: status %d: %s",
+			roomId, userId, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var member RoomMember
+	if err := json.Unmarshal(body, &member); err != nil {
+		return nil, fmt.Errorf("chatroomclient: decode response: %w", err)
+	}
+	return &member, nil
+}
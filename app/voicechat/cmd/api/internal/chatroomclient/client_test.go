@@ -0,0 +1,47 @@
+package chatroomclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientRoomMember(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/ws/v1/admin/room/7/member/2":
+			_, _ = w.Write([]byte(`{"roomExists":true,"ownerId":1,"role":"muted"}`))
+		case "/ws/v1/admin/room/8/member/2":
+			_, _ = w.Write([]byte(`{"roomExists":false,"ownerId":0,"role":""}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(strings.TrimPrefix(server.URL, "http://")+"/", "secret", time.Second)
+	member, err := client.RoomMember(context.Background(), 7, 2)
+	if err != nil {
+		t.Fatalf("RoomMember failed: %v", err)
+	}
+	if !member.RoomExists || member.OwnerId != 1 || member.Role != RoleMuted {
+		t.Fatalf("unexpected member: %+v", member)
+	}
+	if member, err := client.RoomMember(context.Background(), 8, 2); err != nil || member.RoomExists {
+		t.Fatalf("unexpected member of deleted room: %+v %v", member, err)
+	}
+
+	if _, err := NewClient(server.URL, "wrong", time.Second).RoomMember(context.Background(), 7, 2); err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+	if _, err := client.RoomMember(context.Background(), 9, 2); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
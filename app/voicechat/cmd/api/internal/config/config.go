@@ -4,6 +4,11 @@
 package config
 
 import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -13,9 +18,194 @@ type Config struct {
 	LlmRpcConf    zrpc.RpcClientConf
 	VoicechatRpcConf zrpc.RpcClientConf
 	RustPBXConfig RustPBXConfig
+	CallConfig    CallConfig
+	MediaConfig   MediaConfig
+	MinioConfig   MinioConfig
+	RoomConfig    RoomConfig
+	SipConfig     SipConfig
+	// 语音房间的识别文字通过 Redis 投递到聊天室，SIP 外呼限频同样使用；Host 为空时不连接
+	Redis redis.RedisConf `json:",optional"`
+	// 语音房间即聊天室房间：加入前通过聊天室服务校验房间与成员关系，未配置时不开启语音房间
+	ChatroomConfig ChatroomConfig
+}
+
+// ChatroomConfig 聊天室服务的管理接口，语音房间不直接读取聊天室的库表
+type ChatroomConfig struct {
+	// 聊天室服务地址，如 http://chatroom-api:3083，为空时不开启语音房间
+	Url string `json:",optional"`
+	// 聊天室服务的管理员 token（Admin.Token），配置了 Url 时必填
+	AdminToken string `json:",optional"`
+	// 查询成员关系的超时时间
+	Timeout time.Duration `json:",default=5s"`
+}
+
+// Validate 启动时校验：开启语音房间必须配置管理员 token 与 Redis（识别文字经 Redis 投递到聊天室），
+// 外呼限频同样依赖 Redis
+func (c Config) Validate() error {
+	if c.ChatroomConfig.Url != "" {
+		if strings.TrimSpace(c.ChatroomConfig.AdminToken) == "" {
+			return errors.New("ChatroomConfig.AdminToken is required when ChatroomConfig.Url is set")
+		}
+		if c.Redis.Host == "" {
+			return errors.New("Redis.Host is required when ChatroomConfig.Url is set")
+		}
+	}
+	if c.SipConfig.CallUrl != "" && c.SipConfig.CallRateLimit.Quota > 0 && c.Redis.Host == "" {
+		return errors.New("Redis.Host is required when SipConfig.CallRateLimit is enabled, set Quota to 0 to disable it")
+	}
+	return nil
 }
 
 type RustPBXConfig struct {
 	Url          string
 	WebSocketUrl string
 }
+
+// MinioConfig 离线语音合成的音频存储，Endpoint 为空时不开启离线语音合成
+type MinioConfig struct {
+	Endpoint  string `json:",optional"`
+	AccessKey string `json:",optional"`
+	SecretKey string `json:",optional"`
+	UseSSL    bool   `json:",optional"`
+}
+
+type MediaConfig struct {
+	// 媒体后端：rustpbx（默认）或 fake（进程内模拟，按 FakeScript 产生识别结果，用于联调测试）
+	Backend    string   `json:",optional"`
+	FakeScript []string `json:",optional"`
+}
+
+type CallConfig struct {
+	// 信令连接断开后等待浏览器重连的时间，超时则挂断通话
+	ReconnectGracePeriod time.Duration `json:",default=30s"`
+	// 用户沉默超时：第一次超时语音提醒，再次超时挂断（0 表示不启用）
+	SilenceTimeout time.Duration `json:",optional"`
+	// 空闲超时：媒体后端长时间没有任何事件时挂断（0 表示不启用）
+	IdleTimeout time.Duration `json:",optional"`
+	// 单次通话默认的最长时长（0 表示不限制），可在 UserMaxDurations 中按用户单独设置
+	MaxDuration time.Duration `json:",optional"`
+	// 按用户设置的单次通话最长时长，优先于 MaxDuration
+	UserMaxDurations []UserCallLimit `json:",optional"`
+	// 距离最长时长多久时语音提醒
+	MaxDurationWarning time.Duration `json:",optional"`
+}
+
+// UserCallLimit 单个用户的通话时长上限（0 表示不限制）
+type UserCallLimit struct {
+	UserId      int64
+	MaxDuration time.Duration
+}
+
+// MaxDurationFor 返回用户单次通话的最长时长，未单独设置时使用 MaxDuration
+func (c CallConfig) MaxDurationFor(userId int64) time.Duration {
+	for _, limit := range c.UserMaxDurations {
+		if limit.UserId == userId {
+			return limit.MaxDuration
+		}
+	}
+	return c.MaxDuration
+}
+
+// RoomConfig 多人语音房间，均可不填使用默认值
+type RoomConfig struct {
+	// 房间内智能体的名字，说“@名字”视为提及（默认 奈奈）
+	AgentName string `json:",optional"`
+	// 以唤醒词开头的话交给智能体回答（默认为智能体名字）
+	WakeWords []string `json:",optional"`
+	// 房间最多人数（默认 8）
+	MaxMembers int `json:",optional"`
+	// 智能体回答时最多带上的最近对话条数（默认 20）
+	ContextSize int `json:",optional"`
+}
+
+// SipConfig 通过 RustPBX 接入 SIP 电话，未配置 CallUrl / AcceptUrl 时不启用对应方向
+type SipConfig struct {
+	// 外呼使用的 PBX websocket 地址，如 ws://rustpbx:8080/call/sip
+	CallUrl string `json:",optional"`
+	// 接听呼入使用的 PBX websocket 地址，如 ws://rustpbx:8080/call，按 dialogId 接管 PBX 转来的呼叫
+	AcceptUrl string `json:",optional"`
+	// 外呼的主叫号码
+	Caller string `json:",optional"`
+	// 被叫不是 SIP URI 时拼接的 SIP 域，如 127.0.0.1:15060
+	Domain string `json:",optional"`
+	// 外呼经过的 SIP 服务器认证信息
+	Username string `json:",optional"`
+	Password string `json:",optional"`
+	Realm    string `json:",optional"`
+	// 呼入 webhook 的校验 token，配置了 AcceptUrl 时必填。PBX 通过 Authorization: Bearer、
+	// X-Webhook-Token 请求头或 ?token= 传递，也可以用它对请求体做 HMAC-SHA256 签名
+	WebhookToken string `json:",optional"`
+	// 呼入号码到助手的路由
+	InboundRoutes []SipRoute `json:",optional"`
+	// 外呼被叫白名单，配置了 CallUrl 时必填。每项为“号码”（只匹配 Domain 下的号码）或“号码@主机”，
+	// 号码以 * 结尾表示前缀匹配，单独的 * 允许任意被叫
+	CalleeAllowList []string `json:",optional"`
+	// 每个用户的外呼频率限制
+	CallRateLimit SipCallRateLimit
+}
+
+// SipCallRateLimit 每个用户在 Period 内最多外呼 Quota 次，Quota 为 0 表示不限制
+type SipCallRateLimit struct {
+	Period time.Duration `json:",default=1h"`
+	Quota  int           `json:",default=20"`
+}
+
+// Validate 启动时校验：接听呼入必须配置 webhook token，外呼必须配置被叫白名单
+func (c SipConfig) Validate() error {
+	if c.AcceptUrl != "" && strings.TrimSpace(c.WebhookToken) == "" {
+		return errors.New("SipConfig.WebhookToken is required when SipConfig.AcceptUrl is set")
+	}
+	if c.CallUrl != "" && len(c.CalleeAllowList) == 0 {
+		return errors.New(`SipConfig.CalleeAllowList is required when SipConfig.CallUrl is set, use "*" to allow any callee`)
+	}
+	return nil
+}
+
+// CalleeAllowed 被叫是否在外呼白名单中，uri 为拼接 SIP 域之后的被叫，如 sip:1000@127.0.0.1:15060
+func (c SipConfig) CalleeAllowed(uri string) bool {
+	user, host := splitSipUri(uri)
+	for _, entry := range c.CalleeAllowList {
+		entry = strings.TrimSpace(entry)
+		if entry == "*" {
+			return true
+		}
+		pattern, entryHost, ok := strings.Cut(entry, "@")
+		if !ok {
+			entryHost = c.Domain
+		}
+		if user == "" || !strings.EqualFold(host, entryHost) {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(user, prefix) {
+				return true
+			}
+			continue
+		}
+		if user == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSipUri 拆出 SIP URI 的号码与主机，如 <sip:1000@127.0.0.1;transport=udp> -> 1000, 127.0.0.1
+func splitSipUri(uri string) (string, string) {
+	uri = strings.TrimPrefix(strings.Trim(strings.TrimSpace(uri), "<>"), "sip:")
+	if i := strings.Index(uri, ";"); i >= 0 {
+		uri = uri[:i]
+	}
+	user, host, _ := strings.Cut(uri, "@")
+	return user, host
+}
+
+// SipRoute 呼入号码对应的助手：使用该用户已保存的 ASR/TTS/LLM 配置接听
+type SipRoute struct {
+	// 被叫号码或分机号
+	Number       string
+	UserId       int64
+	AsrConfigId  int64
+	TtsConfigId  int64
+	LlmConfigId  int64
+	SystemPrompt string `json:",optional"`
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+func TestCallConfigMaxDurationFor(t *testing.T) {
+	c := CallConfig{
+		MaxDuration: 30 * time.Minute,
+		UserMaxDurations: []UserCallLimit{
+			{UserId: 1, MaxDuration: 2 * time.Hour},
+			{UserId: 2, MaxDuration: 0},
+		},
+	}
+	tests := []struct {
+		userId int64
+		want   time.Duration
+	}{
+		{1, 2 * time.Hour},
+		{2, 0}, // 单独设置为不限制
+		{3, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := c.MaxDurationFor(tt.userId); got != tt.want {
+			t.Errorf("MaxDurationFor(%d) = %s, want %s", tt.userId, got, tt.want)
+		}
+	}
+}
+
+func TestSipConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       SipConfig
+		wantErr bool
+	}{
+		{"disabled", SipConfig{}, false},
+		{"accept without token", SipConfig{AcceptUrl: "ws://pbx/call"}, true},
+		{"accept with blank token", SipConfig{AcceptUrl: "ws://pbx/call", WebhookToken: "  "}, true},
+		{"accept with token", SipConfig{AcceptUrl: "ws://pbx/call", WebhookToken: "secret"}, false},
+		{"call without allow list", SipConfig{CallUrl: "ws://pbx/call/sip"}, true},
+		{"call with allow list", SipConfig{CallUrl: "ws://pbx/call/sip", CalleeAllowList: []string{"*"}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSipConfigCalleeAllowed(t *testing.T) {
+	c := SipConfig{
+		Domain:          "127.0.0.1:15060",
+		CalleeAllowList: []string{"1000", "+86*", "2*@127.0.0.1:5070"},
+	}
+	tests := []struct {
+		uri  string
+		want bool
+	}{
+		{"sip:1000@127.0.0.1:15060", true},
+		{"<sip:1000@127.0.0.1:15060;transport=udp>", true},
+		{"sip:+8613800000000@127.0.0.1:15060", true},
+		{"sip:2000@127.0.0.1:5070", true},
+		{"sip:1001@127.0.0.1:15060", false},
+		// 号码相同但主机不同，不能绕过白名单呼叫其他域
+		{"sip:1000@evil.example.com", false},
+		{"sip:+8613800000000@evil.example.com", false},
+		{"sip:3000@127.0.0.1:5070", false},
+		{"sip:@127.0.0.1:15060", false},
+	}
+	for _, tt := range tests {
+		if got := c.CalleeAllowed(tt.uri); got != tt.want {
+			t.Errorf("CalleeAllowed(%q) = %v, want %v", tt.uri, got, tt.want)
+		}
+	}
+
+	allowAll := SipConfig{CalleeAllowList: []string{"*"}}
+	if !allowAll.CalleeAllowed("sip:1000@evil.example.com") {
+		t.Error(`"*" should allow any callee`)
+	}
+	if (SipConfig{}).CalleeAllowed("sip:1000@127.0.0.1") {
+		t.Error("empty allow list should reject every callee")
+	}
+}
+
+// TestExampleEnvConfig 使用 .env.example 中的变量加载 etc/voicechat.yaml，示例配置必须能通过启动校验
+func TestExampleEnvConfig(t *testing.T) {
+	f, err := os.Open("../../../../../../.env.example")
+	if err != nil {
+		t.Fatalf("open .env.example: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		t.Setenv(key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read .env.example: %v", err)
+	}
+
+	var c Config
+	if err := conf.Load("../../etc/voicechat.yaml", &c, conf.UseEnv()); err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := c.SipConfig.Validate(); err != nil {
+		t.Fatalf("example SipConfig is invalid: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("example config is invalid: %v", err)
+	}
+	if c.SipConfig.AcceptUrl != "" {
+		t.Fatalf("inbound SIP should be disabled by default, AcceptUrl = %q", c.SipConfig.AcceptUrl)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	withRedis := func(c Config) Config {
+		c.Redis.Host = "localhost:6379"
+		return c
+	}
+	room := Config{ChatroomConfig: ChatroomConfig{Url: "http://chatroom:3083", AdminToken: "secret"}}
+	call := Config{SipConfig: SipConfig{CallUrl: "ws://pbx/call/sip", CallRateLimit: SipCallRateLimit{Period: time.Hour, Quota: 20}}}
+	tests := []struct {
+		name    string
+		c       Config
+		wantErr bool
+	}{
+		{"calls only", Config{}, false},
+		{"room", withRedis(room), false},
+		{"room without token", withRedis(Config{ChatroomConfig: ChatroomConfig{Url: "http://chatroom:3083", AdminToken: " "}}), true},
+		{"room without redis", room, true},
+		{"call rate limit", withRedis(call), false},
+		{"call rate limit without redis", call, true},
+		{"call without rate limit", Config{SipConfig: SipConfig{CallUrl: "ws://pbx/call/sip"}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package consts
+
+const (
+	MINIO_BUCKETNAME_TTS_AUDIO = "tts-audio"
+
+	// 合成音频临时下载地址的有效期（秒）
+	TTS_AUDIO_URL_EXPIRE_SECONDS = 3600
+)
@@ -0,0 +1,39 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package asr
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/logic/asr"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+)
+
+// 上传音频文件,使用ASR配置离线识别
+func TranscribeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.TranscribeReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		defer file.Close()
+
+		l := asr.NewTranscribeLogic(r.Context(), svcCtx)
+		resp, err := l.Transcribe(&req, file, header)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
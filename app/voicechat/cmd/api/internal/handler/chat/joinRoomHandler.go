@@ -0,0 +1,30 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/logic/chat"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+)
+
+// 加入多人语音房间,每个成员独立识别,被唤醒词或提及时由智能体回答
+func JoinRoomHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.JoinVoiceRoomRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := chat.NewJoinRoomLogic(r.Context(), svcCtx)
+		_, err := l.JoinRoom(&req, r, w)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		}
+	}
+}
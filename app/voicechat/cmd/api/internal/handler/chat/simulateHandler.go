@@ -0,0 +1,30 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/logic/chat"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+)
+
+// 文本模拟语音通话,输入文字代替语音识别,返回将要播报的文本
+func SimulateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.StartVoiceRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := chat.NewSimulateLogic(r.Context(), svcCtx)
+		_, err := l.Simulate(&req, r, w)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		}
+	}
+}
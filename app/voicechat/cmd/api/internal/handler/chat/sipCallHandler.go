@@ -0,0 +1,32 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/logic/chat"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+)
+
+// 通过PBX外呼SIP号码,由助手与对方通话
+func SipCallHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.SipCallReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := chat.NewSipCallLogic(r.Context(), svcCtx)
+		resp, err := l.SipCall(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -47,6 +47,18 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/configs",
 				Handler: asr.ListAsrConfigHandler(serverCtx),
 			},
+			{
+				// 测试ASR配置,识别一段样例音频
+				Method:  http.MethodPost,
+				Path:    "/config/:id/test",
+				Handler: asr.TestAsrConfigHandler(serverCtx),
+			},
+			{
+				// 上传音频文件,使用ASR配置离线识别
+				Method:  http.MethodPost,
+				Path:    "/transcribe",
+				Handler: asr.TranscribeHandler(serverCtx),
+			},
 		},
 		rest.WithPrefix("/voice/v1/asr"),
 	)
@@ -59,10 +71,49 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/start",
 				Handler: chat.StartHandler(serverCtx),
 			},
+			{
+				// 文本模拟语音通话,输入文字代替语音识别,返回将要播报的文本
+				Method:  http.MethodGet,
+				Path:    "/simulate",
+				Handler: chat.SimulateHandler(serverCtx),
+			},
+			{
+				// 加入多人语音房间,每个成员独立识别,被唤醒词或提及时由智能体回答
+				Method:  http.MethodGet,
+				Path:    "/room/:roomId",
+				Handler: chat.JoinRoomHandler(serverCtx),
+			},
+			{
+				// 通过PBX外呼SIP号码,由助手与对方通话
+				Method:  http.MethodPost,
+				Path:    "/sip/call",
+				Handler: chat.SipCallHandler(serverCtx),
+			},
+			{
+				// 挂断SIP通话
+				Method:  http.MethodPost,
+				Path:    "/sip/call/:callId/hangup",
+				Handler: chat.SipHangupHandler(serverCtx),
+			},
 		},
 		rest.WithPrefix("/voice/v1/chat"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.SipWebhookAuth},
+			[]rest.Route{
+				{
+					// PBX呼入webhook,按被叫号码路由到助手接听
+					Method:  http.MethodPost,
+					Path:    "/sip/incoming",
+					Handler: chat.SipIncomingHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/voice/v1/chat"),
+	)
+
 	server.AddRoutes(
 		[]rest.Route{
 			{
@@ -95,6 +146,18 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/configs",
 				Handler: tts.ListTtsConfigHandler(serverCtx),
 			},
+			{
+				// 测试TTS配置,合成一个词
+				Method:  http.MethodPost,
+				Path:    "/config/:id/test",
+				Handler: tts.TestTtsConfigHandler(serverCtx),
+			},
+			{
+				// 使用TTS配置离线合成语音,音频存入MinIO
+				Method:  http.MethodPost,
+				Path:    "/synthesize",
+				Handler: tts.SynthesizeHandler(serverCtx),
+			},
 		},
 		rest.WithPrefix("/voice/v1/tts"),
 	)
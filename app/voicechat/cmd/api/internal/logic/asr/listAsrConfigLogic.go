@@ -9,6 +9,7 @@ import (
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/asrconfigservice"
+	"go-zero-voice-agent/pkg/secretbox"
 	"go-zero-voice-agent/pkg/xerr"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -49,7 +50,7 @@ func (l *ListAsrConfigLogic) ListAsrConfig(req *types.ListAsrConfigReq) (resp *t
 			Provider:  cfg.Provider,
 			AppId:     cfg.AppId,
 			SecretId:  cfg.SecretId,
-			SecretKey: cfg.SecretKey,
+			SecretKey: secretbox.Mask(cfg.SecretKey),
 			Language:  cfg.Language,
 		})
 	}
@@ -0,0 +1,74 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package asr
+
+import (
+	"context"
+	"time"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/asrconfigservice"
+	"go-zero-voice-agent/app/voicechat/pkg/speech"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 单次连通性测试的超时时间
+const testConfigTimeout = 15 * time.Second
+
+type TestAsrConfigLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 测试ASR配置,识别一段样例音频
+func NewTestAsrConfigLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TestAsrConfigLogic {
+	return &TestAsrConfigLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *TestAsrConfigLogic) TestAsrConfig(req *types.TestAsrConfigReq) (resp *types.TestConfigResp, err error) {
+	r, err := l.svcCtx.AsrConfigRpc.GetAsrConfig(l.ctx, &asrconfigservice.GetAsrConfigRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+	cfg := r.Config
+	if req.UserId <= 0 || cfg.UserId != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	start := time.Now()
+	transcriber, err := speech.NewTranscriber(cfg.Provider, speech.Credential{
+		AppId:     cfg.AppId,
+		SecretId:  cfg.SecretId,
+		SecretKey: cfg.SecretKey,
+	})
+	if err == nil {
+		ctx, cancel := context.WithTimeout(l.ctx, testConfigTimeout)
+		defer cancel()
+		_, err = transcriber.Transcribe(ctx, &speech.TranscribeRequest{
+			Audio:    speech.SampleAudio(),
+			Format:   speech.FORMAT_WAV,
+			Language: cfg.Language,
+		})
+	}
+
+	resp = &types.TestConfigResp{
+		Ok:             err == nil,
+		LatencyMs:      time.Since(start).Milliseconds(),
+		ModelAvailable: err == nil,
+		ErrorCategory:  speech.ClassifyError(err),
+	}
+	if err != nil {
+		resp.ErrorMessage = err.Error()
+		l.Infof("asr config test failed, id: %d, provider: %s, err: %v", cfg.Id, cfg.Provider, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package asr
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/asrconfigservice"
+	"go-zero-voice-agent/app/voicechat/pkg/speech"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 一句话识别最多支持 60 秒音频，限制上传大小
+const maxTranscribeAudioSize = 5 << 20
+
+type TranscribeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 上传音频文件,使用ASR配置离线识别
+func NewTranscribeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TranscribeLogic {
+	return &TranscribeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *TranscribeLogic) Transcribe(req *types.TranscribeReq, file multipart.File, header *multipart.FileHeader) (resp *types.TranscribeResp, err error) {
+	if req.UserId <= 0 || req.ConfigId <= 0 {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	if header.Size > maxTranscribeAudioSize {
+		return nil, xerr.NewErrCodeMsg(xerr.REQUEST_PARAM_ERROR, "音频文件过大")
+	}
+
+	r, err := l.svcCtx.AsrConfigRpc.GetAsrConfig(l.ctx, &asrconfigservice.GetAsrConfigRequest{Id: req.ConfigId})
+	if err != nil {
+		return nil, err
+	}
+	cfg := r.Config
+	if cfg.UserId != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	transcriber, err := speech.NewTranscriber(cfg.Provider, speech.Credential{
+		AppId:     cfg.AppId,
+		SecretId:  cfg.SecretId,
+		SecretKey: cfg.SecretKey,
+	})
+	if err != nil {
+		return nil, xerr.NewErrCodeMsg(xerr.REQUEST_PARAM_ERROR, err.Error())
+	}
+
+	audio, err := io.ReadAll(io.LimitReader(file, maxTranscribeAudioSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "read audio file failed")
+	}
+	if len(audio) == 0 || len(audio) > maxTranscribeAudioSize {
+		return nil, xerr.NewErrCodeMsg(xerr.REQUEST_PARAM_ERROR, "音频文件为空或过大")
+	}
+
+	format := req.Format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+	language := req.Language
+	if language == "" {
+		language = cfg.Language
+	}
+
+	result, err := transcriber.Transcribe(l.ctx, &speech.TranscribeRequest{
+		Audio:    audio,
+		Format:   format,
+		Language: language,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "transcribe failed, provider: %s", cfg.Provider)
+	}
+
+	return &types.TranscribeResp{
+		Text:       result.Text,
+		DurationMs: result.DurationMs,
+	}, nil
+}
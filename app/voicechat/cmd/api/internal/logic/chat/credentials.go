@@ -0,0 +1,78 @@
+package chat
+
+import (
+	"context"
+	"strings"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/asrconfigservice"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/ttsconfigservice"
+	"go-zero-voice-agent/pkg/xerr"
+)
+
+// resolveCredentials 按配置 id 从服务端补全 ASR/TTS/LLM 配置。
+// 配置接口只返回脱敏后的密钥，浏览器通过 asrConfigId / ttsConfigId / llmConfigId 引用已保存的配置。
+func resolveCredentials(ctx context.Context, svcCtx *svc.ServiceContext, userId int64, msg *webrtc.WebRTCMessage) error {
+	if msg.AsrConfigID > 0 {
+		r, err := svcCtx.AsrConfigRpc.GetAsrConfig(ctx, &asrconfigservice.GetAsrConfigRequest{Id: msg.AsrConfigID})
+		if err != nil {
+			return err
+		}
+		if r.Config.UserId != userId {
+			return xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+		}
+		msg.AsrConfig = webrtc.AsrConfig{
+			Provider:  r.Config.Provider,
+			AppId:     r.Config.AppId,
+			SecretId:  r.Config.SecretId,
+			SecretKey: r.Config.SecretKey,
+			Language:  r.Config.Language,
+		}
+	}
+
+	if msg.TtsConfigID > 0 {
+		r, err := svcCtx.TtsConfigRpc.GetTtsConfig(ctx, &ttsconfigservice.GetTtsConfigRequest{Id: msg.TtsConfigID})
+		if err != nil {
+			return err
+		}
+		if r.Config.UserId != userId {
+			return xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+		}
+		msg.TtsConfig = webrtc.TtsConfig{
+			Provider:  r.Config.Provider,
+			AppId:     r.Config.AppId,
+			SecretId:  r.Config.SecretId,
+			SecretKey: r.Config.SecretKey,
+		}
+	}
+
+	if msg.LlmConfigID > 0 {
+		r, err := svcCtx.LlmConfigRpc.GetConfig(ctx, &llmconfigservice.GetConfigReq{Id: msg.LlmConfigID})
+		if err != nil {
+			return err
+		}
+		cfg := r.Config
+		if cfg.UserId != userId {
+			return xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+		}
+		msg.LlmConfig = &llmchatservice.LlmConfig{
+			BaseUrl:           strings.TrimSpace(cfg.BaseUrl),
+			ApiKey:            strings.TrimSpace(cfg.ApiKey),
+			Model:             strings.TrimSpace(cfg.Model),
+			Temperature:       cfg.Temperature,
+			TopP:              cfg.TopP,
+			TopK:              cfg.TopK,
+			EnableThinking:    cfg.EnableThinking > 0,
+			RepetitionPenalty: cfg.RepetitionPenalty,
+			PresencePenalty:   cfg.PresencePenalty,
+			MaxTokens:         cfg.MaxTokens,
+			Seed:              cfg.Seed,
+			EnableSearch:      cfg.EnableSearch > 0,
+			ContentLength:     cfg.ContextLength,
+		}
+	}
+	return nil
+}
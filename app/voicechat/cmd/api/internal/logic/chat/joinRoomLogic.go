@@ -0,0 +1,204 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/chatroomclient"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/websocket"
+	"go-zero-voice-agent/pkg/chatbridge"
+	"go-zero-voice-agent/pkg/xerr"
+
+	wsTool "github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type JoinRoomLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 加入多人语音房间,每个成员独立识别,被唤醒词或提及时由智能体回答
+func NewJoinRoomLogic(ctx context.Context, svcCtx *svc.ServiceContext) *JoinRoomLogic {
+	return &JoinRoomLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// JoinRoom 语音房间即聊天室房间，只有房间成员（禁言成员除外）可以加入。
+// 信令与单人通话一致：offer 加入房间，ice-candidate 转发候选，hangup 或断开连接即离开房间。
+// 智能体使用聊天室房主的 LLM 配置，房主加入之前智能体不回答。识别文字与智能体回复作为聊天室消息投递给房间成员
+func (l *JoinRoomLogic) JoinRoom(req *types.JoinVoiceRoomRequest, r *http.Request, w http.ResponseWriter) (resp *types.Empty, err error) {
+	ownerId, err := l.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := websocket.NewConnection(w, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to establish websocket connection")
+	}
+	defer conn.Close()
+
+	l.handleRoomMsg(conn, req, ownerId)
+
+	return
+}
+
+// authorize 通过聊天室服务校验房间存在且用户是可发言的成员，返回房主 id。未配置聊天室服务时不开启语音房间
+func (l *JoinRoomLogic) authorize(req *types.JoinVoiceRoomRequest) (int64, error) {
+	if l.svcCtx.ChatroomClient == nil {
+		return 0, xerr.NewErrMsg("voice rooms are not enabled")
+	}
+	roomId, err := strconv.ParseInt(req.RoomId, 10, 64)
+	if err != nil || roomId <= 0 {
+		return 0, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	member, err := l.svcCtx.ChatroomClient.RoomMember(l.ctx, roomId, req.UserId)
+	if err != nil {
+		return 0, errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "query member of room %d failed: %v", roomId, err)
+	}
+	switch {
+	case !member.RoomExists:
+		return 0, xerr.NewErrCode(xerr.ROOM_NOT_FOUND_ERROR)
+	case member.Role == "":
+		return 0, xerr.NewErrCode(xerr.ROOM_MEMBER_NOT_FOUND_ERROR)
+	case member.Role == chatroomclient.RoleMuted:
+		return 0, xerr.NewErrCode(xerr.ROOM_MEMBER_MUTED_ERROR)
+	}
+	return member.OwnerId, nil
+}
+
+func (l *JoinRoomLogic) handleRoomMsg(conn *wsTool.Conn, req *types.JoinVoiceRoomRequest, ownerId int64) {
+	var room *webrtc.VoiceRoom
+	defer func() {
+		if room != nil {
+			room.Leave(req.UserId, webrtc.HANGUP_REASON_CLIENT)
+		}
+	}()
+
+	for {
+		typeVal, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if typeVal != wsTool.TextMessage {
+			continue
+		}
+		var msg webrtc.WebRTCMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			l.Logger.Errorf("Failed to unmarshal message: %v", err)
+			continue
+		}
+
+		switch msg.Type {
+		case webrtc.WEBRTC_SIGNALING_OFFER:
+			if room != nil {
+				if err := room.Renegotiate(req.UserId, msg.SDP); err != nil {
+					l.Logger.Errorf("Failed to renegotiate in room %s: %v", req.RoomId, err)
+				}
+				continue
+			}
+			joined, err := l.join(conn, req, ownerId, &msg)
+			if err != nil {
+				l.Logger.Errorf("Failed to join voice room %s: %v", req.RoomId, err)
+				l.writeError(conn, req.RoomId, err)
+				return
+			}
+			room = joined
+		case webrtc.WEBRTC_SIGNALING_ICE_CANDIDATE:
+			if room == nil {
+				continue
+			}
+			if err := room.AddIceCandidate(req.UserId, msg.Candidate); err != nil {
+				l.Logger.Errorf("Failed to add ICE candidate: %v", err)
+			}
+		case webrtc.WEBRTC_SIGNALING_HANGUP:
+			return
+		}
+	}
+}
+
+func (l *JoinRoomLogic) join(conn *wsTool.Conn, req *types.JoinVoiceRoomRequest, ownerId int64, msg *webrtc.WebRTCMessage) (*webrtc.VoiceRoom, error) {
+	if err := resolveCredentials(l.ctx, l.svcCtx, req.UserId, msg); err != nil {
+		return nil, err
+	}
+
+	backend, err := webrtc.NewMediaBackend(
+		l.svcCtx.Config.MediaConfig.Backend,
+		l.svcCtx.Config.RustPBXConfig.WebSocketUrl,
+		l.svcCtx.Config.MediaConfig.FakeScript,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roomConfig := l.svcCtx.Config.RoomConfig
+	return l.svcCtx.VoiceRooms.Join(webrtc.VoiceRoomParams{
+		Ctx:          l.ctx,
+		RoomID:       req.RoomId,
+		OwnerID:      ownerId,
+		LlmService:   l.svcCtx.LlmChatServiceRpc,
+		LlmConfig:    msg.LlmConfig,
+		SystemPrompt: msg.SystemPrompt,
+		Config: webrtc.RoomConfig{
+			AgentName:   roomConfig.AgentName,
+			WakeWords:   roomConfig.WakeWords,
+			MaxMembers:  roomConfig.MaxMembers,
+			ContextSize: roomConfig.ContextSize,
+		},
+		OnEvent: l.publishRoomEvent,
+	}, webrtc.RoomMemberParams{
+		UserID:  req.UserId,
+		OutConn: conn,
+		Backend: backend,
+		Option:  newCallOptions(msg),
+	})
+}
+
+// publishRoomEvent 房间事件逐个发给房间成员的聊天室连接。
+// 房间可能比创建它的请求存活更久，这里不使用请求的 ctx
+func (l *JoinRoomLogic) publishRoomEvent(evt webrtc.RoomEvent) {
+	ctx := context.WithoutCancel(l.ctx)
+	for _, userId := range evt.Members {
+		err := chatbridge.Publish(ctx, l.svcCtx.RedisClient, &chatbridge.Message{
+			Type:      evt.Type,
+			From:      strconv.FormatInt(evt.Speaker, 10),
+			To:        strconv.FormatInt(userId, 10),
+			Data:      evt,
+			Timestamp: evt.Timestamp,
+		})
+		if err != nil {
+			l.Logger.Errorf("Failed to publish voice room %s event to user %d: %v", evt.RoomID, userId, err)
+		}
+	}
+}
+
+// writeError 加入房间失败时直接写入当前连接
+func (l *JoinRoomLogic) writeError(conn *wsTool.Conn, roomId string, cause error) {
+	data, err := json.Marshal(webrtc.WebRTCMessage{
+		Type:   webrtc.WEBRTC_SIGNALING_ERROR,
+		RoomID: roomId,
+		Text:   cause.Error(),
+	})
+	if err != nil {
+		l.Logger.Errorf("Failed to marshal message: %v", err)
+		return
+	}
+	if err := conn.WriteMessage(wsTool.TextMessage, data); err != nil {
+		l.Logger.Errorf("Failed to write message: %v", err)
+	}
+}
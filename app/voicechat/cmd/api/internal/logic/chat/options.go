@@ -0,0 +1,39 @@
+package chat
+
+import (
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/config"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+)
+
+// newCallOptions 按信令消息中（已补全密钥）的 ASR/TTS 配置生成呼叫参数
+func newCallOptions(msg *webrtc.WebRTCMessage) *webrtc.CallOptions {
+	return &webrtc.CallOptions{
+		Asr: &webrtc.AsrConfig{
+			Language:  msg.AsrConfig.Language,
+			Provider:  msg.AsrConfig.Provider,
+			AppId:     msg.AsrConfig.AppId,
+			SecretId:  msg.AsrConfig.SecretId,
+			SecretKey: msg.AsrConfig.SecretKey,
+		},
+		Tts: &webrtc.TtsConfig{
+			Provider:  msg.TtsConfig.Provider,
+			Speaker:   "603004",
+			Speed:     1,
+			Volume:    5,
+			AppId:     msg.TtsConfig.AppId,
+			SecretId:  msg.TtsConfig.SecretId,
+			SecretKey: msg.TtsConfig.SecretKey,
+		},
+		Offer: msg.SDP,
+	}
+}
+
+// callTimeouts 按用户生成通话超时设置
+func callTimeouts(c config.CallConfig, userId int64) webrtc.CallTimeouts {
+	return webrtc.CallTimeouts{
+		SilenceTimeout:     c.SilenceTimeout,
+		IdleTimeout:        c.IdleTimeout,
+		MaxDuration:        c.MaxDurationFor(userId),
+		MaxDurationWarning: c.MaxDurationWarning,
+	}
+}
@@ -0,0 +1,135 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/websocket"
+
+	wsTool "github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SimulateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 文本模拟语音通话,输入文字代替语音识别,返回将要播报的文本
+func NewSimulateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SimulateLogic {
+	return &SimulateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Simulate 与语音通话走同一套对话流程，只是用文本后端替代 PBX：
+// 客户端先发送 start（携带 LLM 配置与提示词），之后每条 text 消息相当于一次 ASR 识别结果，
+// 将要播报的文本以 speak 消息返回（附带分句），工具确认同样以 text 回答
+func (l *SimulateLogic) Simulate(req *types.StartVoiceRequest, r *http.Request, w http.ResponseWriter) (resp *types.Empty, err error) {
+	conn, err := websocket.NewConnection(w, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to establish websocket connection")
+	}
+	defer conn.Close()
+
+	l.handleSimulateMsg(conn, req)
+
+	return
+}
+
+func (l *SimulateLogic) handleSimulateMsg(conn *wsTool.Conn, req *types.StartVoiceRequest) {
+	var client *webrtc.SignalingClient
+	var backend *webrtc.TextBackend
+	defer func() {
+		if client != nil {
+			client.Hangup(webrtc.HANGUP_REASON_CLIENT)
+		}
+	}()
+
+	for {
+		typeVal, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if typeVal != wsTool.TextMessage {
+			continue
+		}
+		var msg webrtc.WebRTCMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			l.Logger.Errorf("Failed to unmarshal message: %v", err)
+			continue
+		}
+
+		switch msg.Type {
+		case webrtc.WEBRTC_SIGNALING_START:
+			if client != nil {
+				l.Logger.Info("Simulation already started, ignoring start")
+				continue
+			}
+			if err := resolveCredentials(l.ctx, l.svcCtx, req.UserId, &msg); err != nil {
+				l.Logger.Errorf("Failed to resolve llm config: %v", err)
+				return
+			}
+			backend = webrtc.NewTextBackend(func(text string, sentences []string) {
+				if err := client.Send(webrtc.WebRTCMessage{
+					Type:      webrtc.WEBRTC_SIGNALING_SPEAK,
+					Text:      text,
+					Sentences: sentences,
+				}); err != nil {
+					l.Logger.Errorf("Failed to send speak message: %v", err)
+				}
+			})
+			created, err := webrtc.NewSignalingClient(webrtc.SignalingClientParams{
+				Ctx:               l.ctx,
+				LlmService:        l.svcCtx.LlmChatServiceRpc,
+				TurnMetricRpc:     l.svcCtx.TurnMetricRpc,
+				LlmConfig:         msg.LlmConfig,
+				LlmConversationID: msg.LlmConversationID,
+				SystemPrompt:      msg.SystemPrompt,
+				UserID:            req.UserId,
+				OutConn:           conn,
+				Backend:           backend,
+				Option: &webrtc.CallOptions{
+					Asr: &webrtc.AsrConfig{Provider: webrtc.MEDIA_BACKEND_TEXT},
+					Tts: &webrtc.TtsConfig{Provider: webrtc.MEDIA_BACKEND_TEXT},
+				},
+			})
+			if err != nil {
+				l.Logger.Errorf("Failed to create simulation client: %v", err)
+				return
+			}
+			client = created
+			go client.Listen(msg.KnowledgeInfo)
+			go client.HandleEvtMsg()
+		case webrtc.WEBRTC_SIGNALING_TEXT:
+			if backend == nil {
+				l.Logger.Info("Simulation not started, ignoring text")
+				continue
+			}
+			if err := backend.Input(msg.Text); err != nil {
+				l.Logger.Errorf("Failed to input text: %v", err)
+				return
+			}
+		case webrtc.WEBRTC_SIGNALING_TOOL_RESULT:
+			if client == nil {
+				continue
+			}
+			if err := client.SubmitToolResults(msg.ToolCalls); err != nil {
+				l.Logger.Errorf("Failed to submit tool results: %v", err)
+			}
+		case webrtc.WEBRTC_SIGNALING_HANGUP:
+			return
+		}
+	}
+}
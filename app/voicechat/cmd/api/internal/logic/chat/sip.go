@@ -0,0 +1,64 @@
+package chat
+
+import (
+	"context"
+	"strings"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/config"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+)
+
+const sipUriPrefix = "sip:"
+
+// startSipCall SIP 通话没有浏览器信令连接，其余与 WebRTC 通话走同一套 SignalingClient 流程，
+// 发给浏览器的消息会被暂存并丢弃；通话登记到 CallSessions，可按 callId 挂断
+func startSipCall(ctx context.Context, svcCtx *svc.ServiceContext, userId int64, msg *webrtc.WebRTCMessage,
+	backend webrtc.MediaBackend, option *webrtc.CallOptions) (*webrtc.SignalingClient, error) {
+	client, err := webrtc.NewSignalingClient(webrtc.SignalingClientParams{
+		Ctx:           ctx,
+		LlmService:    svcCtx.LlmChatServiceRpc,
+		TurnMetricRpc: svcCtx.TurnMetricRpc,
+		LlmConfig:     msg.LlmConfig,
+		SystemPrompt:  msg.SystemPrompt,
+		UserID:        userId,
+		Backend:       backend,
+		Timeouts:      callTimeouts(svcCtx.Config.CallConfig, userId),
+		Option:        option,
+	})
+	if err != nil {
+		return nil, err
+	}
+	svcCtx.CallSessions.Add(client)
+	go client.Listen("")
+	go client.HandleEvtMsg()
+	return client, nil
+}
+
+// sipCallee 被叫不是 SIP URI 时拼接配置的 SIP 域
+func sipCallee(callee, domain string) string {
+	if strings.HasPrefix(callee, sipUriPrefix) || domain == "" {
+		return callee
+	}
+	return sipUriPrefix + callee + "@" + domain
+}
+
+// sipUser 取 SIP URI 中的号码部分，如 sip:1000@127.0.0.1 -> 1000
+func sipUser(uri string) string {
+	uri = strings.TrimPrefix(strings.Trim(uri, "<>"), sipUriPrefix)
+	if i := strings.IndexAny(uri, "@;"); i >= 0 {
+		uri = uri[:i]
+	}
+	return uri
+}
+
+// findSipRoute 按被叫号码查找呼入路由
+func findSipRoute(routes []config.SipRoute, callee string) (config.SipRoute, bool) {
+	number := sipUser(callee)
+	for _, route := range routes {
+		if route.Number == number {
+			return route, true
+		}
+	}
+	return config.SipRoute{}, false
+}
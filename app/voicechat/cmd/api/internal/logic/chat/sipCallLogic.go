@@ -0,0 +1,98 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/limit"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SipCallLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 通过PBX外呼SIP号码,由助手与对方通话
+func NewSipCallLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SipCallLogic {
+	return &SipCallLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SipCallLogic) SipCall(req *types.SipCallReq) (resp *types.SipCallResp, err error) {
+	sipConf := l.svcCtx.Config.SipConfig
+	if sipConf.CallUrl == "" {
+		return nil, xerr.NewErrMsg("sip call is not enabled")
+	}
+	callee := strings.TrimSpace(req.Callee)
+	if callee == "" {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	calleeUri := sipCallee(callee, sipConf.Domain)
+	if !sipConf.CalleeAllowed(calleeUri) {
+		l.Infof("sip callee %s not in allow list, user: %d", calleeUri, req.UserId)
+		return nil, xerr.NewErrCode(xerr.SIP_CALLEE_NOT_ALLOWED_ERROR)
+	}
+	if err := l.checkRateLimit(req.UserId); err != nil {
+		return nil, err
+	}
+
+	msg := &webrtc.WebRTCMessage{
+		AsrConfigID:  req.AsrConfigId,
+		TtsConfigID:  req.TtsConfigId,
+		LlmConfigID:  req.LlmConfigId,
+		SystemPrompt: req.SystemPrompt,
+	}
+	if err := resolveCredentials(l.ctx, l.svcCtx, req.UserId, msg); err != nil {
+		return nil, err
+	}
+
+	option := newCallOptions(msg)
+	option.Caller = sipConf.Caller
+	option.Callee = calleeUri
+	if sipConf.Username != "" {
+		option.Sip = &webrtc.SipOption{
+			Username: sipConf.Username,
+			Password: sipConf.Password,
+			Realm:    sipConf.Realm,
+		}
+	}
+
+	client, err := startSipCall(l.ctx, l.svcCtx, req.UserId, msg, webrtc.NewRustPBXBackend(sipConf.CallUrl), option)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sip call %s failed", option.Callee)
+	}
+	l.Infof("sip call started, callId: %s, callee: %s", client.CallID(), option.Callee)
+
+	return &types.SipCallResp{CallId: client.CallID()}, nil
+}
+
+// checkRateLimit 按用户限制外呼次数，限流存储不可用时拒绝外呼
+func (l *SipCallLogic) checkRateLimit(userId int64) error {
+	if l.svcCtx.SipCallLimiter == nil {
+		return nil
+	}
+	code, err := l.svcCtx.SipCallLimiter.TakeCtx(l.ctx, strconv.FormatInt(userId, 10))
+	if err != nil {
+		return errors.Wrapf(xerr.NewErrCode(xerr.SERVER_COMMON_ERROR), "sip call rate limit failed, user: %d, err: %v", userId, err)
+	}
+	if code == limit.OverQuota {
+		return xerr.NewErrCode(xerr.SIP_CALL_RATE_LIMIT_ERROR)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SipHangupLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 挂断SIP通话
+func NewSipHangupLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SipHangupLogic {
+	return &SipHangupLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SipHangupLogic) SipHangup(req *types.SipHangupReq) (resp *types.Empty, err error) {
+	client, ok := l.svcCtx.CallSessions.Get(req.CallId)
+	if !ok {
+		return nil, xerr.NewErrMsg("call not found")
+	}
+	if client.UserID() != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+	client.Hangup(webrtc.HANGUP_REASON_CLIENT)
+
+	return &types.Empty{}, nil
+}
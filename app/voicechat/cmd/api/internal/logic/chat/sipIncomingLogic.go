@@ -0,0 +1,77 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package chat
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SipIncomingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// PBX呼入webhook,按被叫号码路由到助手接听
+func NewSipIncomingLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SipIncomingLogic {
+	return &SipIncomingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SipIncoming 找到被叫号码对应的助手后按 dialogId 接听；没有对应助手或配置无效时拒接
+func (l *SipIncomingLogic) SipIncoming(req *types.SipIncomingReq) (resp *types.Empty, err error) {
+	sipConf := l.svcCtx.Config.SipConfig
+	if sipConf.AcceptUrl == "" || req.DialogId == "" {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+
+	route, ok := findSipRoute(sipConf.InboundRoutes, req.Callee)
+	if !ok {
+		l.Infof("no assistant for sip callee %s, reject dialog %s", req.Callee, req.DialogId)
+		l.reject(req.DialogId, "no assistant for callee")
+		return &types.Empty{}, nil
+	}
+
+	msg := &webrtc.WebRTCMessage{
+		AsrConfigID:  route.AsrConfigId,
+		TtsConfigID:  route.TtsConfigId,
+		LlmConfigID:  route.LlmConfigId,
+		SystemPrompt: route.SystemPrompt,
+	}
+	if err := resolveCredentials(l.ctx, l.svcCtx, route.UserId, msg); err != nil {
+		l.Errorf("resolve sip route %s configs failed: %v", route.Number, err)
+		l.reject(req.DialogId, "assistant unavailable")
+		return nil, err
+	}
+
+	option := newCallOptions(msg)
+	option.Caller = req.Caller
+	option.Callee = req.Callee
+
+	backend := webrtc.NewRustPBXInboundBackend(sipConf.AcceptUrl, req.DialogId)
+	client, err := startSipCall(l.ctx, l.svcCtx, route.UserId, msg, backend, option)
+	if err != nil {
+		l.Errorf("accept sip dialog %s failed: %v", req.DialogId, err)
+		return nil, err
+	}
+	l.Infof("sip call accepted, callId: %s, dialogId: %s, caller: %s, callee: %s", client.CallID(), req.DialogId, req.Caller, req.Callee)
+
+	return &types.Empty{}, nil
+}
+
+func (l *SipIncomingLogic) reject(dialogId, reason string) {
+	if err := webrtc.RejectSipCall(l.svcCtx.Config.SipConfig.AcceptUrl, dialogId, reason); err != nil {
+		l.Errorf("reject sip dialog %s failed: %v", dialogId, err)
+	}
+}
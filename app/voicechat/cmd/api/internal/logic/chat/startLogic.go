@@ -53,7 +53,13 @@ func (l *StartLogic) handleWebsocketMsg(ctx context.Context, conn *wsTool.Conn,
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	var isClientCreated bool
+	// 当前连接绑定的通话，连接断开后通话保留一段时间等待重连
+	var client *webrtc.SignalingClient
+	defer func() {
+		if client != nil {
+			client.Detach(conn, l.svcCtx.Config.CallConfig.ReconnectGracePeriod)
+		}
+	}()
 
 	for {
 		select {
@@ -73,55 +79,137 @@ func (l *StartLogic) handleWebsocketMsg(ctx context.Context, conn *wsTool.Conn,
 				l.Logger.Errorf("Failed to unmarshal message: %v", err)
 				continue
 			}
-			if msg.Type == webrtc.WEBRTC_SIGNALING_OFFER {
-				if isClientCreated {
-					l.Logger.Info("Client already created, ignoring offer")
-					continue
+			switch msg.Type {
+			case webrtc.WEBRTC_SIGNALING_OFFER:
+				if client == nil && msg.CallID != "" {
+					resumed, err := l.resumeCall(conn, msg.CallID, req.UserId)
+					if err != nil {
+						l.Logger.Errorf("Failed to resume call %s: %v", msg.CallID, err)
+						l.writeError(conn, msg.CallID, err)
+						continue
+					}
+					client = resumed
 				}
 
-				signalingClientParams := webrtc.SignalingClientParams{
-					Ctx:               ctx,
-					LlmService:        l.svcCtx.LlmChatServiceRpc,
-					LlmConfig:         msg.LlmConfig,
-					LlmConversationID: msg.LlmConversationID,
-					SystemPrompt:      msg.SystemPrompt,
-					UserID:            req.UserId,
-					OutConn:           conn,
-					ServerAddr:        l.svcCtx.Config.RustPBXConfig.WebSocketUrl,
-					Initial: webrtc.PBXMessage{
-						Command: webrtc.WS_CALLBACK_EVENT_TYPE_INVITE,
-						Option: &webrtc.CallOptions{
-							Asr: &webrtc.AsrConfig{
-								Language:  msg.AsrConfig.Language,
-								Provider:  msg.AsrConfig.Provider,
-								AppId:     msg.AsrConfig.AppId,
-								SecretId:  msg.AsrConfig.SecretId,
-								SecretKey: msg.AsrConfig.SecretKey,
-							},
-							Tts: &webrtc.TtsConfig{
-								Provider:  msg.TtsConfig.Provider,
-								Speaker:   "603004",
-								Speed:     1,
-								Volume:    5,
-								AppId:     msg.TtsConfig.AppId,
-								SecretId:  msg.TtsConfig.SecretId,
-								SecretKey: msg.TtsConfig.SecretKey,
-							},
-							Offer: msg.SDP,
-						},
-					},
+				// 已有通话时视为重协商（如 ICE restart）
+				if client != nil {
+					if err := client.Renegotiate(msg.SDP); err != nil {
+						l.Logger.Errorf("Failed to renegotiate call %s: %v", client.CallID(), err)
+					}
+					continue
 				}
 
-				client, err := webrtc.NewSignalingClient(signalingClientParams)
+				created, err := l.newCall(ctx, conn, req, &msg)
 				if err != nil {
 					l.Logger.Errorf("Failed to create signaling client: %v", err)
+					l.writeError(conn, "", err)
 					cancel()
 					continue
 				}
-				isClientCreated = true
-				go client.Listen(msg.KnowledgeInfo)
-				go client.HandleEvtMsg()
+				client = created
+			case webrtc.WEBRTC_SIGNALING_ICE_CANDIDATE:
+				if client == nil {
+					l.Logger.Info("No call for ICE candidate, ignoring")
+					continue
+				}
+				if err := client.AddIceCandidate(msg.Candidate); err != nil {
+					l.Logger.Errorf("Failed to add ICE candidate: %v", err)
+				}
+			case webrtc.WEBRTC_SIGNALING_TOOL_RESULT:
+				if client == nil {
+					l.Logger.Info("No call for tool result, ignoring")
+					continue
+				}
+				if err := client.SubmitToolResults(msg.ToolCalls); err != nil {
+					l.Logger.Errorf("Failed to submit tool results: %v", err)
+				}
+			case webrtc.WEBRTC_SIGNALING_HANGUP:
+				if client == nil {
+					continue
+				}
+				// 挂断时会关闭当前连接，无需再等待重连
+				client.Hangup(webrtc.HANGUP_REASON_CLIENT)
+				return
+			case webrtc.WEBRTC_SIGNALING_RECONNECT:
+				if client != nil {
+					l.Logger.Info("Call already attached, ignoring reconnect")
+					continue
+				}
+				resumed, err := l.resumeCall(conn, msg.CallID, req.UserId)
+				if err != nil {
+					l.Logger.Errorf("Failed to resume call %s: %v", msg.CallID, err)
+					l.writeError(conn, msg.CallID, err)
+					continue
+				}
+				client = resumed
 			}
 		}
 	}
 }
+
+// newCall 创建通话并登记，供断线后重连
+func (l *StartLogic) newCall(ctx context.Context, conn *wsTool.Conn, req *types.StartVoiceRequest, msg *webrtc.WebRTCMessage) (*webrtc.SignalingClient, error) {
+	if err := resolveCredentials(ctx, l.svcCtx, req.UserId, msg); err != nil {
+		return nil, err
+	}
+
+	backend, err := webrtc.NewMediaBackend(
+		l.svcCtx.Config.MediaConfig.Backend,
+		l.svcCtx.Config.RustPBXConfig.WebSocketUrl,
+		l.svcCtx.Config.MediaConfig.FakeScript,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	signalingClientParams := webrtc.SignalingClientParams{
+		Ctx:               ctx,
+		LlmService:        l.svcCtx.LlmChatServiceRpc,
+		TurnMetricRpc:     l.svcCtx.TurnMetricRpc,
+		LlmConfig:         msg.LlmConfig,
+		LlmConversationID: msg.LlmConversationID,
+		SystemPrompt:      msg.SystemPrompt,
+		UserID:            req.UserId,
+		OutConn:           conn,
+		Backend:           backend,
+		Timeouts:          callTimeouts(l.svcCtx.Config.CallConfig, req.UserId),
+		Option:            newCallOptions(msg),
+	}
+
+	client, err := webrtc.NewSignalingClient(signalingClientParams)
+	if err != nil {
+		return nil, err
+	}
+	l.svcCtx.CallSessions.Add(client)
+	go client.Listen(msg.KnowledgeInfo)
+	go client.HandleEvtMsg()
+	return client, nil
+}
+
+// resumeCall 按 callId 找回断线前的通话，并绑定到当前连接
+func (l *StartLogic) resumeCall(conn *wsTool.Conn, callId string, userId int64) (*webrtc.SignalingClient, error) {
+	client, ok := l.svcCtx.CallSessions.Get(callId)
+	if !ok || client.UserID() != userId {
+		return nil, errors.New("call not found")
+	}
+	if err := client.Attach(conn); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// writeError 直接写入当前连接，仅在通话未绑定到该连接时使用
+func (l *StartLogic) writeError(conn *wsTool.Conn, callId string, cause error) {
+	data, err := json.Marshal(webrtc.WebRTCMessage{
+		Type:   webrtc.WEBRTC_SIGNALING_ERROR,
+		CallID: callId,
+		Text:   cause.Error(),
+	})
+	if err != nil {
+		l.Logger.Errorf("Failed to marshal message: %v", err)
+		return
+	}
+	if err := conn.WriteMessage(wsTool.TextMessage, data); err != nil {
+		l.Logger.Errorf("Failed to write message: %v", err)
+	}
+}
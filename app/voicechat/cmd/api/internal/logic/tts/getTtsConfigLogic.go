@@ -9,6 +9,7 @@ import (
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/ttsconfigservice"
+	"go-zero-voice-agent/pkg/secretbox"
 	"go-zero-voice-agent/pkg/xerr"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -44,6 +45,6 @@ func (l *GetTtsConfigLogic) GetTtsConfig(req *types.GetTtsConfigReq) (resp *type
 		Provider:  cfg.Provider,
 		AppId:     cfg.AppId,
 		SecretId:  cfg.SecretId,
-		SecretKey: cfg.SecretKey,
+		SecretKey: secretbox.Mask(cfg.SecretKey),
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/ttsconfigservice"
+	"go-zero-voice-agent/pkg/secretbox"
 	"go-zero-voice-agent/pkg/xerr"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -49,7 +50,7 @@ func (l *ListTtsConfigLogic) ListTtsConfig(req *types.ListTtsConfigReq) (resp *t
 			Provider:  cfg.Provider,
 			AppId:     cfg.AppId,
 			SecretId:  cfg.SecretId,
-			SecretKey: cfg.SecretKey,
+			SecretKey: secretbox.Mask(cfg.SecretKey),
 		})
 	}
 	return &types.ListTtsConfigResp{ConfigList: list, Total: r.Total}, nil
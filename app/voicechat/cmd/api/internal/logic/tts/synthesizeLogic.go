@@ -0,0 +1,115 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/consts"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/ttsconfigservice"
+	"go-zero-voice-agent/app/voicechat/pkg/speech"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 单次合成的最大文本长度
+const maxSynthesizeTextLength = 2000
+
+type SynthesizeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 使用TTS配置离线合成语音,音频存入MinIO
+func NewSynthesizeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SynthesizeLogic {
+	return &SynthesizeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SynthesizeLogic) Synthesize(req *types.SynthesizeReq) (resp *types.SynthesizeResp, err error) {
+	if req.UserId <= 0 || req.ConfigId <= 0 || req.Text == "" {
+		return nil, xerr.NewErrCode(xerr.REQUEST_PARAM_ERROR)
+	}
+	if len([]rune(req.Text)) > maxSynthesizeTextLength {
+		return nil, xerr.NewErrCodeMsg(xerr.REQUEST_PARAM_ERROR, "合成文本过长")
+	}
+	if l.svcCtx.MinioClient == nil {
+		return nil, xerr.NewErrMsg("tts audio storage is not configured")
+	}
+
+	r, err := l.svcCtx.TtsConfigRpc.GetTtsConfig(l.ctx, &ttsconfigservice.GetTtsConfigRequest{Id: req.ConfigId})
+	if err != nil {
+		return nil, err
+	}
+	cfg := r.Config
+	if cfg.UserId != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	synthesizer, err := speech.NewSynthesizer(cfg.Provider, speech.Credential{
+		AppId:     cfg.AppId,
+		SecretId:  cfg.SecretId,
+		SecretKey: cfg.SecretKey,
+	})
+	if err != nil {
+		return nil, xerr.NewErrCodeMsg(xerr.REQUEST_PARAM_ERROR, err.Error())
+	}
+
+	result, err := synthesizer.Synthesize(l.ctx, &speech.SynthesizeRequest{
+		Text:    req.Text,
+		Speaker: req.Speaker,
+		Format:  req.Format,
+		Speed:   req.Speed,
+		Volume:  req.Volume,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "synthesize failed, provider: %s", cfg.Provider)
+	}
+
+	if err := l.svcCtx.MinioClient.EnsureBucket(l.ctx, consts.MINIO_BUCKETNAME_TTS_AUDIO); err != nil {
+		return nil, err
+	}
+	objectName := fmt.Sprintf("tts/user_%d/%s.%s", req.UserId, uuid.NewString(), result.Format)
+	size := int64(len(result.Audio))
+	if _, err := l.svcCtx.MinioClient.Upload(
+		l.ctx,
+		consts.MINIO_BUCKETNAME_TTS_AUDIO,
+		objectName,
+		bytes.NewReader(result.Audio),
+		size,
+		speech.ContentType(result.Format),
+		map[string]string{"config-id": fmt.Sprint(req.ConfigId)},
+	); err != nil {
+		return nil, err
+	}
+
+	url, err := l.svcCtx.MinioClient.PresignedGet(
+		l.ctx,
+		consts.MINIO_BUCKETNAME_TTS_AUDIO,
+		objectName,
+		consts.TTS_AUDIO_URL_EXPIRE_SECONDS*time.Second,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SynthesizeResp{
+		ObjectName: objectName,
+		Url:        url,
+		Format:     result.Format,
+		Size:       size,
+	}, nil
+}
@@ -0,0 +1,73 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package tts
+
+import (
+	"context"
+	"time"
+
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/types"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/ttsconfigservice"
+	"go-zero-voice-agent/app/voicechat/pkg/speech"
+	"go-zero-voice-agent/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// 单次连通性测试的超时时间
+	testConfigTimeout = 15 * time.Second
+	testConfigText    = "你好"
+)
+
+type TestTtsConfigLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 测试TTS配置,合成一个词
+func NewTestTtsConfigLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TestTtsConfigLogic {
+	return &TestTtsConfigLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *TestTtsConfigLogic) TestTtsConfig(req *types.TestTtsConfigReq) (resp *types.TestConfigResp, err error) {
+	r, err := l.svcCtx.TtsConfigRpc.GetTtsConfig(l.ctx, &ttsconfigservice.GetTtsConfigRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+	cfg := r.Config
+	if req.UserId <= 0 || cfg.UserId != req.UserId {
+		return nil, xerr.NewErrCode(xerr.USER_PERMISSION_DENIED_ERROR)
+	}
+
+	start := time.Now()
+	synthesizer, err := speech.NewSynthesizer(cfg.Provider, speech.Credential{
+		AppId:     cfg.AppId,
+		SecretId:  cfg.SecretId,
+		SecretKey: cfg.SecretKey,
+	})
+	if err == nil {
+		ctx, cancel := context.WithTimeout(l.ctx, testConfigTimeout)
+		defer cancel()
+		_, err = synthesizer.Synthesize(ctx, &speech.SynthesizeRequest{Text: testConfigText})
+	}
+
+	resp = &types.TestConfigResp{
+		Ok:             err == nil,
+		LatencyMs:      time.Since(start).Milliseconds(),
+		ModelAvailable: err == nil,
+		ErrorCategory:  speech.ClassifyError(err),
+	}
+	if err != nil {
+		resp.ErrorMessage = err.Error()
+		l.Infof("tts config test failed, id: %d, provider: %s, err: %v", cfg.Id, cfg.Provider, err)
+	}
+	return resp, nil
+}
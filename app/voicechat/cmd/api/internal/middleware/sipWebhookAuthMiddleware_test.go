@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBody = `{"dialogId":"d-1","callee":"sip:1000@127.0.0.1"}`
+
+// serve 经过中间件处理请求，返回状态码与下游读到的请求体
+func serve(m *SipWebhookAuthMiddleware, r *http.Request) (int, string) {
+	var body string
+	handler := m.Handle(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w.Code, body
+}
+
+func signedRequest(token string, ts time.Time, body string) *http.Request {
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/voice/v1/chat/sip/incoming", strings.NewReader(body))
+	r.Header.Set("X-Webhook-Timestamp", timestamp)
+	r.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(SignSipWebhook(token, timestamp, []byte(testBody))))
+	return r
+}
+
+func TestSipWebhookAuthToken(t *testing.T) {
+	m := NewSipWebhookAuthMiddleware("secret")
+	tests := []struct {
+		name  string
+		setup func(r *http.Request)
+		want  int
+	}{
+		{"bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer secret") }, http.StatusOK},
+		{"header", func(r *http.Request) { r.Header.Set("X-Webhook-Token", "secret") }, http.StatusOK},
+		{"query", func(r *http.Request) { r.URL.RawQuery = "token=secret" }, http.StatusOK},
+		{"wrong bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer other") }, http.StatusUnauthorized},
+		{"missing", func(r *http.Request) {}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/voice/v1/chat/sip/incoming", strings.NewReader(testBody))
+		tt.setup(r)
+		if code, _ := serve(m, r); code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, code, tt.want)
+		}
+	}
+}
+
+func TestSipWebhookAuthRejectsWithoutToken(t *testing.T) {
+	m := NewSipWebhookAuthMiddleware("")
+	r := httptest.NewRequest(http.MethodPost, "/voice/v1/chat/sip/incoming", strings.NewReader(testBody))
+	r.Header.Set("Authorization", "Bearer ")
+	if code, _ := serve(m, r); code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401 when no token is configured", code)
+	}
+}
+
+func TestSipWebhookAuthSignature(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	m := NewSipWebhookAuthMiddleware("secret")
+	m.now = func() time.Time { return now }
+
+	code, body := serve(m, signedRequest("secret", now, testBody))
+	if code != http.StatusOK {
+		t.Fatalf("valid signature: status = %d, want 200", code)
+	}
+	// 校验后请求体需要放回给 handler 解析
+	if body != testBody {
+		t.Errorf("body after verification = %q, want %q", body, testBody)
+	}
+
+	tests := []struct {
+		name string
+		r    *http.Request
+	}{
+		{"wrong key", signedRequest("other", now, testBody)},
+		{"tampered body", signedRequest("secret", now, strings.Replace(testBody, "1000", "2000", 1))},
+		{"expired", signedRequest("secret", now.Add(-10*time.Minute), testBody)},
+		{"future", signedRequest("secret", now.Add(10*time.Minute), testBody)},
+	}
+	for _, tt := range tests {
+		if code, _ := serve(m, tt.r); code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", tt.name, code)
+		}
+	}
+
+	// 签名错误时不回退到 token 校验
+	r := signedRequest("other", now, testBody)
+	r.Header.Set("Authorization", "Bearer secret")
+	if code, _ := serve(m, r); code != http.StatusUnauthorized {
+		t.Errorf("bad signature with valid token: status = %d, want 401", code)
+	}
+}
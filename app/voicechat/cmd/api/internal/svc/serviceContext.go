@@ -4,13 +4,25 @@
 package svc
 
 import (
+	"fmt"
+	"time"
+
 	"go-zero-voice-agent/app/llm/cmd/rpc/client/chatmessageservice"
 	"go-zero-voice-agent/app/llm/cmd/rpc/client/chatsessionservice"
 	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmconfigservice"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/chatroomclient"
 	"go-zero-voice-agent/app/voicechat/cmd/api/internal/config"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/middleware"
+	"go-zero-voice-agent/app/voicechat/cmd/api/internal/webrtc"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/asrconfigservice"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/ttsconfigservice"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/turnmetricservice"
+	"go-zero-voice-agent/pkg/minioutil"
 
+	"github.com/zeromicro/go-zero/core/limit"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
 )
 
@@ -19,18 +31,80 @@ type ServiceContext struct {
 	LlmChatServiceRpc llmchatservice.LlmChatService
 	ChatSessionRpc    chatsessionservice.ChatSessionService
 	ChatMessageRpc    chatmessageservice.ChatMessageService
+	LlmConfigRpc      llmconfigservice.LlmConfigService
 
 	AsrConfigRpc asrconfigservice.AsrConfigService
 	TtsConfigRpc ttsconfigservice.TtsConfigService
+	TurnMetricRpc turnmetricservice.TurnMetricService
+
+	// ChatroomClient 校验语音房间的成员关系，未配置 ChatroomConfig.Url 时为 nil，不开启语音房间
+	ChatroomClient *chatroomclient.Client
+
+	CallSessions *webrtc.SessionManager
+	VoiceRooms   *webrtc.RoomManager
+	// MinioClient 离线语音合成的音频存储，未配置时为 nil
+	MinioClient *minioutil.MinioClient
+	// RedisClient 未配置 Redis.Host 时为 nil
+	RedisClient *redis.Redis
+	// SipCallLimiter 每个用户的外呼频率限制，未配置时为 nil
+	SipCallLimiter *limit.PeriodLimit
+
+	SipWebhookAuth rest.Middleware
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	if err := c.SipConfig.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid sip config: %v", err))
+	}
+	if err := c.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid config: %v", err))
+	}
+
+	// 单人通话不依赖 MinIO、Redis 与聊天室服务，只在配置后创建
+	var minioClient *minioutil.MinioClient
+	if c.MinioConfig.Endpoint != "" {
+		client, err := minioutil.NewMinioClient(minioutil.MinioConfig{
+			Endpoint:  c.MinioConfig.Endpoint,
+			AccessKey: c.MinioConfig.AccessKey,
+			SecretKey: c.MinioConfig.SecretKey,
+			UseSSL:    c.MinioConfig.UseSSL,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("init minio client failed: %v", err))
+		}
+		minioClient = client
+	}
+
+	var redisClient *redis.Redis
+	if c.Redis.Host != "" {
+		redisClient = redis.MustNewRedis(c.Redis)
+	}
+
+	var chatroomClient *chatroomclient.Client
+	if c.ChatroomConfig.Url != "" {
+		chatroomClient = chatroomclient.NewClient(c.ChatroomConfig.Url, c.ChatroomConfig.AdminToken, c.ChatroomConfig.Timeout)
+	}
+
+	var sipCallLimiter *limit.PeriodLimit
+	if rate := c.SipConfig.CallRateLimit; c.SipConfig.CallUrl != "" && rate.Quota > 0 {
+		sipCallLimiter = limit.NewPeriodLimit(int(rate.Period/time.Second), rate.Quota, redisClient, "voicechat:sip:call:limit:")
+	}
+
 	return &ServiceContext{
 		Config: c,
 		LlmChatServiceRpc: llmchatservice.NewLlmChatService(zrpc.MustNewClient(c.LlmRpcConf)),
 		ChatSessionRpc: chatsessionservice.NewChatSessionService(zrpc.MustNewClient(c.LlmRpcConf)),
 		ChatMessageRpc: chatmessageservice.NewChatMessageService(zrpc.MustNewClient(c.LlmRpcConf)),
+		LlmConfigRpc: llmconfigservice.NewLlmConfigService(zrpc.MustNewClient(c.LlmRpcConf)),
 		AsrConfigRpc: asrconfigservice.NewAsrConfigService(zrpc.MustNewClient(c.VoicechatRpcConf)),
 		TtsConfigRpc: ttsconfigservice.NewTtsConfigService(zrpc.MustNewClient(c.VoicechatRpcConf)),
+		TurnMetricRpc: turnmetricservice.NewTurnMetricService(zrpc.MustNewClient(c.VoicechatRpcConf)),
+		ChatroomClient: chatroomClient,
+		CallSessions: webrtc.NewSessionManager(),
+		VoiceRooms:   webrtc.NewRoomManager(),
+		MinioClient:  minioClient,
+		RedisClient:  redisClient,
+		SipCallLimiter: sipCallLimiter,
+		SipWebhookAuth: middleware.NewSipWebhookAuthMiddleware(c.SipConfig.WebhookToken).Handle,
 	}
 }
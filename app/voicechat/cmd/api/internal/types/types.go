@@ -76,6 +76,11 @@ type GetTtsConfigResp struct {
 	SecretKey string `json:"secretKey"`
 }
 
+type JoinVoiceRoomRequest struct {
+	UserId int64  `header:"X-User-Id"`
+	RoomId string `path:"roomId"`
+}
+
 type ListAsrConfigReq struct {
 	UserId   int64 `header:"X-User-Id"`
 	Page     int64 `json:"page"`
@@ -98,17 +103,88 @@ type ListTtsConfigResp struct {
 	Total      int64              `json:"total"`
 }
 
+type SipCallReq struct {
+	UserId       int64  `header:"X-User-Id"`
+	Callee       string `json:"callee"` // 被叫号码或 SIP URI
+	AsrConfigId  int64  `json:"asrConfigId"`
+	TtsConfigId  int64  `json:"ttsConfigId"`
+	LlmConfigId  int64  `json:"llmConfigId"`
+	SystemPrompt string `json:"systemPrompt,optional"`
+}
+
+type SipCallResp struct {
+	CallId string `json:"callId"`
+}
+
+type SipHangupReq struct {
+	UserId int64  `header:"X-User-Id"`
+	CallId string `path:"callId"`
+}
+
+type SipIncomingReq struct {
+	DialogId string `json:"dialogId"`
+	Caller   string `json:"caller,optional"`
+	Callee   string `json:"callee"`
+}
+
 type StartVoiceRequest struct {
 	UserId int64 `header:"X-User-Id"`
 }
 
+type SynthesizeReq struct {
+	UserId   int64   `header:"X-User-Id"`
+	ConfigId int64   `json:"configId"`
+	Text     string  `json:"text"`
+	Speaker  string  `json:"speaker,optional"`
+	Format   string  `json:"format,optional"`
+	Speed    float32 `json:"speed,optional"`
+	Volume   int     `json:"volume,optional"`
+}
+
+type SynthesizeResp struct {
+	ObjectName string `json:"objectName"`
+	Url        string `json:"url"`
+	Format     string `json:"format"`
+	Size       int64  `json:"size"`
+}
+
+type TestAsrConfigReq struct {
+	Id     int64 `path:"id"`
+	UserId int64 `header:"X-User-Id"`
+}
+
+type TestConfigResp struct {
+	Ok             bool   `json:"ok"`
+	LatencyMs      int64  `json:"latencyMs"`
+	ModelAvailable bool   `json:"modelAvailable"`
+	ErrorCategory  string `json:"errorCategory"` // auth_failed / not_found / rate_limited / timeout / network_error / invalid_config / provider_error
+	ErrorMessage   string `json:"errorMessage"`
+}
+
+type TestTtsConfigReq struct {
+	Id     int64 `path:"id"`
+	UserId int64 `header:"X-User-Id"`
+}
+
+type TranscribeReq struct {
+	UserId   int64  `header:"X-User-Id"`
+	ConfigId int64  `form:"configId"`
+	Format   string `form:"format,optional"`
+	Language string `form:"language,optional"`
+}
+
+type TranscribeResp struct {
+	Text       string `json:"text"`
+	DurationMs int64  `json:"durationMs"`
+}
+
 type UpdateAsrConfigReq struct {
 	Id        int64  `path:"id"`
 	UserId    int64  `header:"X-User-Id"`
 	Provider  string `json:"provider"`
 	AppId     string `json:"appId"`
 	SecretId  string `json:"secretId"`
-	SecretKey string `json:"secretKey"`
+	SecretKey string `json:"secretKey,optional"` // 留空或传回脱敏值表示不修改
 	Language  string `json:"language"`
 }
 
@@ -121,7 +197,7 @@ type UpdateTtsConfigReq struct {
 	Provider  string `json:"provider"`
 	AppId     string `json:"appId"`
 	SecretId  string `json:"secretId"`
-	SecretKey string `json:"secretKey"`
+	SecretKey string `json:"secretKey,optional"` // 留空或传回脱敏值表示不修改
 }
 
 type UpdateTtsConfigResp struct {
@@ -0,0 +1,45 @@
+package webrtc
+
+import (
+	"fmt"
+)
+
+const (
+	MEDIA_BACKEND_RUSTPBX = "rustpbx"
+	MEDIA_BACKEND_FAKE    = "fake"
+	MEDIA_BACKEND_TEXT    = "text" // 文本模拟通话专用，不可通过配置选择
+)
+
+// MediaBackend 媒体后端，负责与浏览器建立媒体连接并完成 ASR/TTS，
+// 通话过程中的状态（answer、asrFinal、trackStart 等）通过 Events 回报
+type MediaBackend interface {
+	// Invite 发起通话，answer 通过事件返回
+	Invite(option *CallOptions) error
+	// Renegotiate 在现有媒体会话上使用新的 offer 重新协商（ICE restart）
+	Renegotiate(offer string) error
+	// AddCandidate 添加对端的 trickle ICE 候选
+	AddCandidate(candidate string) error
+	// Speak 合成并播放文本。一轮回复可以分多段调用，end 为 true 表示最后一段，
+	// 整轮回复只上报一次 trackStart/trackEnd
+	Speak(text string, end bool) error
+	// Interrupt 打断正在播放的语音
+	Interrupt() error
+	// Hangup 挂断通话
+	Hangup(reason string) error
+	// Events 事件流，后端结束时关闭
+	Events() <-chan EventMessage
+	// Close 释放资源，可重复调用
+	Close() error
+}
+
+// NewMediaBackend 根据配置创建媒体后端，默认使用 RustPBX
+func NewMediaBackend(name, serverAddr string, script []string) (MediaBackend, error) {
+	switch name {
+	case "", MEDIA_BACKEND_RUSTPBX:
+		return NewRustPBXBackend(serverAddr), nil
+	case MEDIA_BACKEND_FAKE:
+		return NewFakeBackend(script), nil
+	default:
+		return nil, fmt.Errorf("unknown media backend: %s", name)
+	}
+}
@@ -2,11 +2,15 @@ package webrtc
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
 	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/client/turnmetricservice"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/zeromicro/go-zero/core/logx"
 	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
@@ -24,25 +28,64 @@ const defaultSystemPrompt = `你是一只名叫“奈奈”的猫娘，我是你
 
 type SignalingClient struct {
 	userId     int64
-	outConn    *websocket.Conn
-	inConn     *websocket.Conn
+	callId     string
+	backend    MediaBackend
 	ctx        context.Context
 	logx       logx.Logger
 	cancel     context.CancelFunc
+	closeOnce  sync.Once
+	onClose    func()
 	recvDone   chan struct{}
 	EvtMsgChan chan EventMessage
 
+	// 工具调用相关，仅在 HandleEvtMsg 协程中读写
+	toolResultChan chan []*llmchatservice.ToolCall // 浏览器回传的客户端工具执行结果
+	pendingConfirm []*llmchatservice.ToolCall      // 等待用户语音确认的工具调用
+
+	// 浏览器信令连接，断线重连时会被替换；断线期间的消息暂存在 pending 中
+	outMu   sync.Mutex
+	outConn *websocket.Conn
+	pending [][]byte
+
+	// 是否已收到过 answer，重协商时不再重复播放欢迎语
+	answered bool
+
+	// 挂断与超时控制，仅在 HandleEvtMsg 协程中读写
+	timeouts       CallTimeouts
+	startedAt      time.Time
+	lastEventAt    time.Time
+	silenceSince   time.Time // 机器人说完话的时间，为零表示机器人正在说话或正在生成回复
+	silenceWarned  bool
+	durationWarned bool
+	pendingHangup  string                     // 当前语音播放完后挂断的原因（end_call）
+	endCalls       []*llmchatservice.ToolCall // 挂断前需要补齐结果的 end_call 工具调用
+
+	// SIP 通话的 DTMF 按键缓冲，仅在 HandleEvtMsg 协程中读写
+	dtmfDigits strings.Builder
+	dtmfAt     time.Time
+
+	// 单轮延迟统计相关
+	asrProvider string
+	ttsProvider string
+	turnIndex   int64
+	currentTurn *turnTimer
+
 	LlmChatServiceRpc llmchatservice.LlmChatService
 	LlmConversationID string
-	LlmConfig         llmchatservice.LlmConfig
+	LlmConfig         *llmchatservice.LlmConfig
 	LlmSystemPromt    string
+	TurnMetricRpc     turnmetricservice.TurnMetricService
 }
 
 type PBXMessage struct {
-	Command string       `json:"command"` // 操作类型，如 'invite', 'tts'
-	Option  *CallOptions `json:"option,omitempty"`
-	Text    string       `json:"text,omitempty"`
-	PlayId  string       `json:"playId,omitempty"`
+	Command     string       `json:"command"` // 操作类型，如 'invite', 'tts', 'candidate'
+	Option      *CallOptions `json:"option,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	PlayId      string       `json:"playId,omitempty"`
+	Streaming   bool         `json:"streaming,omitempty"`   // 流式 tts，同一 playId 的多段文本连续播放
+	EndOfStream bool         `json:"endOfStream,omitempty"` // 流式 tts 的最后一段
+	Candidates  []string     `json:"candidates,omitempty"`  // trickle ICE 候选（仅 candidate 时有）
+	Reason      string       `json:"reason,omitempty"`      // 挂断原因（仅 hangup 时有）
 }
 
 // CallOptions 包含呼叫配置的详细信息
@@ -50,6 +93,18 @@ type CallOptions struct {
 	Asr   *AsrConfig `json:"asr,omitempty"`   // 自动语音识别配置
 	Tts   *TtsConfig `json:"tts,omitempty"`   // 语音合成配置
 	Offer string     `json:"offer,omitempty"` // SDP Offer 信息
+
+	// SIP 外呼（仅 SIP 通话时有）
+	Caller string     `json:"caller,omitempty"` // 主叫号码
+	Callee string     `json:"callee,omitempty"` // 被叫，如 sip:1000@127.0.0.1:5060
+	Sip    *SipOption `json:"sip,omitempty"`
+}
+
+// SipOption SIP 服务器认证信息
+type SipOption struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Realm    string `json:"realm,omitempty"`
 }
 
 // AsrConfig 自动语音识别配置
@@ -74,14 +129,17 @@ type TtsConfig struct {
 
 // EventMessage 表示服务端发送的事件通知
 type EventMessage struct {
-	Event     string                 `json:"event"`
-	TrackId   string                 `json:"trackId,omitempty"`
-	Timestamp *uint64                `json:"timestamp,omitempty"`
-	Key       string                 `json:"key,omitempty"`
-	Duration  uint32                 `json:"duration,omitempty"`
-	SDP       string                 `json:"sdp,omitempty"`
-	Data      map[string]interface{} `json:"data,omitempty"`
-	Text      string                 `json:"text,omitempty"`
+	Event      string                 `json:"event"`
+	TrackId    string                 `json:"trackId,omitempty"`
+	Timestamp  *uint64                `json:"timestamp,omitempty"`
+	Key        string                 `json:"key,omitempty"`
+	Duration   uint32                 `json:"duration,omitempty"`
+	SDP        string                 `json:"sdp,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+	Text       string                 `json:"text,omitempty"`
+	Candidates []string               `json:"candidates,omitempty"`
+	Reason     string                 `json:"reason,omitempty"`
+	Digit      string                 `json:"digit,omitempty"` // DTMF 按键（仅 dtmf 时有）
 }
 
 type WebRTCMessage struct {
@@ -92,129 +150,218 @@ type WebRTCMessage struct {
 	AssistantID   int64  `json:"assistantId,omitempty"`
 	SystemPrompt  string `json:"systemPrompt,omitempty"`
 	KnowledgeInfo string `json:"knowledgeInfo,omitempty"`
+	CallID        string `json:"callId,omitempty"` // 通话标识，断线重连时携带
+	RoomID        string `json:"roomId,omitempty"` // 多人语音房间标识（仅房间信令有）
 
-	AsrConfig         AsrConfig                `json:"asrConfig,omitempty"`
-	TtsConfig         TtsConfig                `json:"ttsConfig,omitempty"`
-	LlmConfig         llmchatservice.LlmConfig `json:"llmConfig,omitempty"`
-	LlmConversationID string                   `json:"llmConversationId,omitempty"`
+	AsrConfig         AsrConfig                 `json:"asrConfig,omitempty"`
+	TtsConfig         TtsConfig                 `json:"ttsConfig,omitempty"`
+	LlmConfig         *llmchatservice.LlmConfig `json:"llmConfig,omitempty"`
+	LlmConversationID string                    `json:"llmConversationId,omitempty"`
+	// 已保存配置的 id，由服务端补全密钥，优先于上面直接携带的配置
+	AsrConfigID int64 `json:"asrConfigId,omitempty"`
+	TtsConfigID int64 `json:"ttsConfigId,omitempty"`
+	LlmConfigID int64 `json:"llmConfigId,omitempty"`
+
+	Metrics   *TurnMetrics               `json:"metrics,omitempty"`   // 单轮延迟数据（仅 debug 时有）
+	ToolCalls []*llmchatservice.ToolCall `json:"toolCalls,omitempty"` // 工具调用（仅 tool-call / tool-result 时有）
+	Sentences []string                   `json:"sentences,omitempty"` // 播报文本的分句（仅 speak 时有）
 }
 
 type SignalingClientParams struct {
 	Ctx               context.Context
 	LlmService        llmchatservice.LlmChatService
-	LlmConfig         llmchatservice.LlmConfig
+	TurnMetricRpc     turnmetricservice.TurnMetricService
+	LlmConfig         *llmchatservice.LlmConfig
 	LlmConversationID string
 	SystemPrompt      string
 	UserID            int64
 	OutConn           *websocket.Conn
-	ServerAddr        string
-	Initial           PBXMessage
+	Backend           MediaBackend
+	Option            *CallOptions
+	Timeouts          CallTimeouts
 }
 
 func NewSignalingClient(params SignalingClientParams) (*SignalingClient, error) {
-	ctx, cancel := context.WithCancel(params.Ctx)
-
-	conn, _, err := websocket.DefaultDialer.Dial(params.ServerAddr, nil)
-	if err != nil {
-		cancel()
-		return nil, fmt.Errorf("websocket dial failed: %w", err)
-	}
-
-	msg, err := json.Marshal(params.Initial)
-	if err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to marshal initial PBXMessage: %w", err)
-	}
-	if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to send initial message: %w", err)
-	}
+	// 通话生命周期独立于浏览器的信令连接，断线后还要等待重连
+	ctx, cancel := context.WithCancel(context.WithoutCancel(params.Ctx))
 
 	client := &SignalingClient{
 		userId:     params.UserID,
+		callId:     uuid.NewString(),
+		backend:    params.Backend,
 		outConn:    params.OutConn,
-		inConn:     conn,
 		ctx:        ctx,
 		cancel:     cancel,
 		recvDone:   make(chan struct{}),
 		logx:       logx.WithContext(ctx),
 		EvtMsgChan: make(chan EventMessage, 1024),
+		timeouts:   params.Timeouts,
+
+		toolResultChan: make(chan []*llmchatservice.ToolCall, 16),
 
 		LlmChatServiceRpc: params.LlmService,
 		LlmConversationID: params.LlmConversationID,
 		LlmConfig:         params.LlmConfig,
 		LlmSystemPromt:    params.SystemPrompt,
+		TurnMetricRpc:     params.TurnMetricRpc,
+	}
+	if params.Option != nil {
+		if params.Option.Asr != nil {
+			client.asrProvider = params.Option.Asr.Provider
+		}
+		if params.Option.Tts != nil {
+			client.ttsProvider = params.Option.Tts.Provider
+		}
+	}
+
+	if err := client.backend.Invite(params.Option); err != nil {
+		cancel()
+		client.backend.Close()
+		return nil, err
 	}
-	client.logx.Info("Send invite command to RustPBX....")
 
 	return client, nil
 }
 
+func (s *SignalingClient) CallID() string {
+	return s.callId
+}
+
+func (s *SignalingClient) UserID() int64 {
+	return s.userId
+}
+
+// Send 发送信令消息给浏览器
+func (s *SignalingClient) Send(msg WebRTCMessage) error {
+	return s.writeOut(msg)
+}
+
 func (s *SignalingClient) Listen(knowledgeInfo string) {
 	defer close(s.recvDone)
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
-		default:
-			typeVal, data, err := s.inConn.ReadMessage()
-			if err != nil {
+		case evt, ok := <-s.backend.Events():
+			if !ok {
+				// 交给 HandleEvtMsg 处理完剩余事件后结束通话
+				s.logx.Infof("media backend closed, callId: %s", s.callId)
+				close(s.EvtMsgChan)
 				return
 			}
-			if typeVal != websocket.TextMessage {
-				continue
-			}
-			s.logx.Info("received:", string(data))
-			var evt EventMessage
-			if err := json.Unmarshal(data, &evt); err != nil {
-				continue
+			select {
+			case s.EvtMsgChan <- evt:
+			case <-s.ctx.Done():
+				return
 			}
-			s.EvtMsgChan <- evt
 		}
 	}
 }
 
 func (s *SignalingClient) HandleEvtMsg() {
+	ticker := time.NewTicker(timeoutCheckInterval)
+	defer ticker.Stop()
+
+	s.startedAt = time.Now()
+	s.lastEventAt = s.startedAt
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
-		case evt := <-s.EvtMsgChan:
+		case now := <-ticker.C:
+			s.flushDtmf(now)
+			s.checkTimeouts(now)
+		case toolCalls := <-s.toolResultChan:
+			s.handleToolResults(toolCalls)
+		case evt, ok := <-s.EvtMsgChan:
+			if !ok {
+				s.Hangup(HANGUP_REASON_MEDIA_CLOSED)
+				return
+			}
+			s.lastEventAt = time.Now()
 			switch evt.Event {
 			case WS_CALLBACK_EVENT_TYPE_ANSWER:
 				// 转发 WebRTC answer 事件
 				s.logx.Info("Received WebRTC answer event")
 				var message = WebRTCMessage{
-					SDP:  evt.SDP,
-					Type: WS_CALLBACK_EVENT_TYPE_ANSWER,
+					SDP:    evt.SDP,
+					Type:   WS_CALLBACK_EVENT_TYPE_ANSWER,
+					CallID: s.callId,
 				}
-				answerMsgBytes, err := json.Marshal(message)
-				if err != nil {
-					s.logx.Errorf("Failed to marshal WebRTC answer message: %v", err)
+				if err := s.writeOut(message); err != nil {
+					s.logx.Errorf("Failed to send WebRTC answer message: %v", err)
 					continue
 				}
-				if err := s.outConn.WriteMessage(websocket.TextMessage, answerMsgBytes); err != nil {
-					s.logx.Errorf("Failed to send WebRTC answer message: %v", err)
+
+				// 重协商产生的 answer 不再重复欢迎语
+				if s.answered {
 					continue
 				}
+				s.answered = true
 
 				// 发送 TTS 消息，让机器人说第一句话
 				if err := s.sendTTSMessage("嗯？你好啊，我是你的个人语音助手"); err != nil {
 					s.logx.Errorf("Failed to send TTS message: %v", err)
 					continue
 				}
+			case WS_CALLBACK_EVENT_TYPE_CANDIDATE:
+				// PBX 侧的 trickle ICE 候选，逐条转发给浏览器
+				for _, candidate := range evt.Candidates {
+					if err := s.writeOut(WebRTCMessage{
+						Type:      WEBRTC_SIGNALING_ICE_CANDIDATE,
+						Candidate: candidate,
+					}); err != nil {
+						s.logx.Errorf("Failed to send ICE candidate message: %v", err)
+					}
+				}
 			case WS_CALLBACK_EVENT_TYPE_ASRFINAL:
 				// 处理 ASR final 事件
 				s.logx.Infof("Received ASR final event: %s", evt.Text)
+				s.resetSilence()
 				s.handleAsrFinal(evt)
 			case WS_CALLBACK_EVENT_TYPE_TRACK_START:
 				s.logx.Infof("Track started: %s", evt.TrackId)
+				// LLM 完成后的第一个 track 即为本轮回复的 TTS 音频
+				if s.currentTurn != nil && !s.currentTurn.llmDone.IsZero() {
+					s.currentTurn.mark(&s.currentTurn.ttsFirstAudio)
+				}
 			case WS_CALLBACK_EVENT_TYPE_TRACK_END:
 				s.logx.Infof("Track ended: %s, duration: %d ms", evt.TrackId, evt.Duration)
+				if s.currentTurn != nil && !s.currentTurn.ttsFirstAudio.IsZero() {
+					s.currentTurn.mark(&s.currentTurn.ttsEnd)
+					s.finishTurn()
+				}
+				// 模型调用了 end_call，道别语播放完后挂断
+				if s.pendingHangup != "" {
+					s.hangupEndCall()
+					return
+				}
+				s.silenceSince = time.Now()
+			case WS_CALLBACK_EVENT_TYPE_HANGUP:
+				s.logx.Infof("Call hangup by media backend, callId: %s, reason: %s", s.callId, evt.Reason)
+				reason := evt.Reason
+				if reason == "" {
+					reason = HANGUP_REASON_REMOTE
+				}
+				s.Hangup(reason)
+				return
+			case WS_CALLBACK_EVENT_TYPE_SPEAKING:
+				s.resetSilence()
+			case WS_CALLBACK_EVENT_TYPE_DTMF:
+				s.logx.Infof("Received DTMF: %s", evt.Digit)
+				s.resetSilence()
+				s.handleDtmf(evt.Digit)
+			case WS_CALLBACK_EVENT_TYPE_RINGING:
+				s.logx.Infof("Callee ringing, callId: %s", s.callId)
+			case WS_CALLBACK_EVENT_TYPE_REJECT:
+				s.logx.Infof("Call rejected by callee, callId: %s, reason: %s", s.callId, evt.Reason)
+				s.Hangup(HANGUP_REASON_REJECTED)
+				return
 			case WS_CALLBACK_EVENT_TYPE_METRICS:
 				s.logx.Infof("Received metrics event: %v", evt.Data)
 			case WS_CALLBACK_EVENT_TYPE_ASRDELTA:
 				s.logx.Infof("Received ASR delta event: %s", evt.Text)
+				s.resetSilence()
 			default:
 				s.logx.Infof("warn: Unknown event type: %s", evt.Event)
 			}
@@ -222,18 +369,18 @@ func (s *SignalingClient) HandleEvtMsg() {
 	}
 }
 
-// 调用tts服务的方法
+// 调用tts服务的方法，text 作为一轮回复的最后一段
 func (s *SignalingClient) sendTTSMessage(text string) error {
-	sayHello := PBXMessage{
-		Command: WS_CALLBACK_EVENT_TYPE_TTS,
-		Text:    text,
-	}
-	sayHelloMsgBytes, err := json.Marshal(sayHello)
-	if err != nil {
-		return err
+	s.silenceSince = time.Time{}
+	return s.backend.Speak(text, true)
+}
+
+// speakSentence 回复生成过程中把已完整的句子先送去合成，缩短首句播报的等待
+func (s *SignalingClient) speakSentence(sentence string) {
+	s.silenceSince = time.Time{}
+	if err := s.backend.Speak(sentence, false); err != nil {
+		s.logx.Errorf("Failed to speak sentence: %v", err)
 	}
-	s.inConn.WriteMessage(websocket.TextMessage, sayHelloMsgBytes)
-	return nil
 }
 
 func (s *SignalingClient) handleAsrFinal(evt EventMessage) {
@@ -242,17 +389,27 @@ func (s *SignalingClient) handleAsrFinal(evt EventMessage) {
 		return
 	}
 
+	// 上一轮还没播放完用户就开口了，先结算上一轮再开始计时
+	if s.currentTurn != nil {
+		s.finishTurn()
+	}
+	s.turnIndex++
+	s.currentTurn = newTurnTimer(s.turnIndex)
+
 	// 将识别到的文字通过websocket连接发送到前端
 	asrMsg := WebRTCMessage{
 		Type: LLM_USER_MESSAGE_ROLE,
 		Text: evt.Text,
 	}
-	asrMsgBytes, err := json.Marshal(asrMsg)
-	if err != nil {
-		s.logx.Errorf("Failed to marshal ASR final message: %v", err)
+	if err := s.writeOut(asrMsg); err != nil {
+		s.logx.Errorf("Failed to send ASR final message: %v", err)
+	}
+
+	// 有等待确认的工具调用时，这句话作为确认回答
+	if len(s.pendingConfirm) > 0 {
+		s.handleConfirmReply(evt.Text)
 		return
 	}
-	s.outConn.WriteMessage(websocket.TextMessage, asrMsgBytes)
 
 	// 如果没有进行过对话，则填充系统提示词
 	chatMsgs := make([]*llmchatservice.ChatMsg, 0)
@@ -278,40 +435,176 @@ func (s *SignalingClient) handleAsrFinal(evt EventMessage) {
 		Content: evt.Text,
 	})
 
-	// 发送聊天请求到 LLM 服务
-	chatReq := &llmchatservice.ChatReq{
+	s.chat(chatMsgs)
+}
+
+// chat 以流式方式请求 LLM 服务（便于统计首 token 耗时），播报回复并处理返回的工具调用
+func (s *SignalingClient) chat(chatMsgs []*llmchatservice.ChatMsg) {
+	turn := s.currentTurn
+	chatReq := &llmchatservice.ChatStreamReq{
 		UserId:         s.userId,
 		ConversationId: s.LlmConversationID,
 		LlmConfig: &llmchatservice.LlmConfig{
-			BaseUrl: s.LlmConfig.BaseUrl,
-			ApiKey:  s.LlmConfig.ApiKey,
-			Model:   s.LlmConfig.Model,
+			BaseUrl: s.LlmConfig.GetBaseUrl(),
+			ApiKey:  s.LlmConfig.GetApiKey(),
+			Model:   s.LlmConfig.GetModel(),
 		},
 		Messages:        chatMsgs,
 		AutoFillHistory: true,
+		EnabledTools:    []string{chatconsts.TOOL_CALLING_END_CALL},
 	}
-	chatResp, err := s.LlmChatServiceRpc.Chat(s.ctx, chatReq)
+	chatStream, err := s.LlmChatServiceRpc.ChatStream(s.ctx, chatReq)
 	if err != nil {
-		s.logx.Errorf("LlmChatServiceRpc.Chat error: %v", err)
+		s.logx.Errorf("LlmChatServiceRpc.ChatStream error: %v", err)
+		s.currentTurn = nil
 		return
 	}
 
-	// 填充conversation-id
-	s.LlmConversationID = chatResp.ConversationId
+	var content strings.Builder
+	var toolCalls []*llmchatservice.ToolCall
+	// speech 为还没凑成整句的回复文本
+	speech, streamed := "", false
+	for {
+		chatResp, err := chatStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			s.logx.Errorf("LlmChatServiceRpc.ChatStream recv error: %v", err)
+			s.currentTurn = nil
+			// 已开始分段播报时结束本轮播报
+			if streamed {
+				s.sendTTSMessage(speech)
+			}
+			return
+		}
+
+		// 填充conversation-id
+		if chatResp.ConversationId != "" {
+			s.LlmConversationID = chatResp.ConversationId
+		}
+		if chatResp.Error != "" {
+			s.logx.Errorf("LlmChatServiceRpc.ChatStream resp error: %s", chatResp.Error)
+			continue
+		}
+
+		// 带工具调用的消息会重复携带已输出的文本，这里只累加文本分片
+		respMsg := chatResp.GetRespMsg()
+		if len(respMsg.GetToolCalls()) > 0 {
+			toolCalls = append(toolCalls, respMsg.GetToolCalls()...)
+			continue
+		}
+		if respMsg.GetContent() == "" {
+			continue
+		}
+		if turn != nil {
+			turn.mark(&turn.llmFirstToken)
+		}
+		content.WriteString(respMsg.GetContent())
+
+		sentences, rest := cutSentences(speech + respMsg.GetContent())
+		for _, sentence := range sentences {
+			s.speakSentence(sentence)
+			streamed = true
+		}
+		speech = rest
+	}
+	if turn != nil {
+		turn.mark(&turn.llmDone)
+	}
+
+	llmMsg := content.String()
+	endCalls, confirmCalls, clientCalls := splitToolCalls(toolCalls)
+
+	// 需要确认的工具调用，追加一句确认问题，等待用户下一句回答
+	if len(confirmCalls) > 0 {
+		s.pendingConfirm = confirmCalls
+		question := confirmQuestion(confirmCalls)
+		llmMsg += question
+		speech += question
+	}
+
+	// 客户端执行的工具调用交给浏览器，执行结果通过 tool-result 回传
+	if len(clientCalls) > 0 {
+		if err := s.writeOut(WebRTCMessage{
+			Type:      WEBRTC_SIGNALING_TOOL_CALL,
+			ToolCalls: clientCalls,
+		}); err != nil {
+			s.logx.Errorf("Failed to send client tool calls: %v", err)
+		}
+	}
 
-	// 发送 TTS 消息
-	llmMsg := chatResp.GetRespMsg().Content
-	s.sendTTSMessage(llmMsg)
+	if len(endCalls) > 0 {
+		s.logx.Infof("LLM requested end_call, callId: %s", s.callId)
+		s.endCalls = endCalls
+		if strings.TrimSpace(llmMsg) == "" {
+			s.hangupEndCall()
+			return
+		}
+		s.pendingHangup = HANGUP_REASON_END_CALL
+	}
+
+	if strings.TrimSpace(llmMsg) == "" {
+		return
+	}
+
+	// 剩余文本作为本轮回复的最后一段播报
+	s.sendTTSMessage(speech)
 
 	// 发送ai回复到前端
 	aiMsg := WebRTCMessage{
 		Type: LLM_ASSISTANT_MESSAGE_ROLE,
 		Text: llmMsg,
 	}
-	aiMsgBytes, err := json.Marshal(aiMsg)
-	if err != nil {
-		s.logx.Errorf("Failed to marshal AI message: %v", err)
+	if err := s.writeOut(aiMsg); err != nil {
+		s.logx.Errorf("Failed to send AI message: %v", err)
+	}
+}
+
+// finishTurn 结算当前轮次：写入 Prometheus、异步落库，并通过信令 websocket 下发 debug 事件
+func (s *SignalingClient) finishTurn() {
+	turn := s.currentTurn
+	s.currentTurn = nil
+	if turn == nil {
 		return
 	}
-	s.outConn.WriteMessage(websocket.TextMessage, aiMsgBytes)
+
+	metrics := turn.observe(s.callId, s.LlmConversationID, s.asrProvider, s.ttsProvider, s.LlmConfig.GetModel())
+	s.logx.Infof("Turn %d metrics: %+v", turn.index, metrics)
+	go s.saveTurnMetrics(metrics)
+
+	debugMsg := WebRTCMessage{
+		Type:    WEBRTC_SIGNALING_DEBUG,
+		Metrics: &metrics,
+	}
+	if err := s.writeOut(debugMsg); err != nil {
+		s.logx.Errorf("Failed to send turn metrics message: %v", err)
+	}
+}
+
+// saveTurnMetrics 将单轮延迟数据落库，通话结束后也要保证写入
+func (s *SignalingClient) saveTurnMetrics(m TurnMetrics) {
+	if s.TurnMetricRpc == nil {
+		return
+	}
+
+	_, err := s.TurnMetricRpc.CreateTurnMetric(context.WithoutCancel(s.ctx), &turnmetricservice.CreateTurnMetricRequest{
+		Metric: &turnmetricservice.TurnMetric{
+			UserId:          s.userId,
+			CallId:          m.CallId,
+			ConversationId:  m.ConversationId,
+			TurnIndex:       m.TurnIndex,
+			AsrProvider:     m.AsrProvider,
+			TtsProvider:     m.TtsProvider,
+			LlmModel:        m.LlmModel,
+			AsrFinalAt:      m.AsrFinalAt,
+			LlmFirstTokenMs: m.LlmFirstTokenMs,
+			LlmDoneMs:       m.LlmDoneMs,
+			TtsFirstAudioMs: m.TtsFirstAudioMs,
+			TtsEndMs:        m.TtsEndMs,
+		},
+	})
+	if err != nil {
+		s.logx.Errorf("TurnMetricRpc.CreateTurnMetric error, callId=%s, turn=%d, err=%v", m.CallId, m.TurnIndex, err)
+	}
 }
@@ -0,0 +1,257 @@
+package webrtc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/app/llm/cmd/rpc/pb"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+)
+
+// fakeLlmService 模拟流式输出，默认按用户输入回显
+type fakeLlmService struct {
+	reply func(in *llmchatservice.ChatStreamReq) []*llmchatservice.ChatMsg
+}
+
+func (f fakeLlmService) Chat(ctx context.Context, in *llmchatservice.ChatReq, opts ...grpc.CallOption) (*llmchatservice.ChatResp, error) {
+	return &llmchatservice.ChatResp{}, nil
+}
+
+func (f fakeLlmService) ChatStream(ctx context.Context, in *llmchatservice.ChatStreamReq, opts ...grpc.CallOption) (pb.LlmChatService_ChatStreamClient, error) {
+	if f.reply != nil {
+		return &fakeChatStream{msgs: f.reply(in)}, nil
+	}
+	text := in.Messages[len(in.Messages)-1].Content
+	return &fakeChatStream{msgs: []*llmchatservice.ChatMsg{{Content: "你说"}, {Content: "：" + text}}}, nil
+}
+
+type fakeChatStream struct {
+	grpc.ClientStream
+	msgs []*llmchatservice.ChatMsg
+}
+
+func (s *fakeChatStream) Recv() (*llmchatservice.ChatStreamResp, error) {
+	if len(s.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return &llmchatservice.ChatStreamResp{
+		ConversationId: "conv-1",
+		RespMsg:        msg,
+	}, nil
+}
+
+// newBrowserConn 建立一对 websocket 连接，分别作为服务端信令连接和浏览器
+func newBrowserConn(t *testing.T) (*websocket.Conn, *websocket.Conn) {
+	serverConn := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		serverConn <- conn
+	}))
+	t.Cleanup(srv.Close)
+
+	browser, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { browser.Close() })
+
+	out := <-serverConn
+	t.Cleanup(func() { out.Close() })
+	return out, browser
+}
+
+func TestSignalingClientWithFakeBackend(t *testing.T) {
+	out, browser := newBrowserConn(t)
+	backend := NewFakeBackend([]string{"你好", "今天天气怎么样"})
+
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: fakeLlmService{},
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		OutConn:    out,
+		Backend:    backend,
+		Option:     &CallOptions{Offer: "offer-sdp"},
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	go client.Listen("")
+	go client.HandleEvtMsg()
+
+	var got []WebRTCMessage
+	browser.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, data, err := browser.ReadMessage()
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		var msg WebRTCMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		got = append(got, msg)
+		if msg.Type == WEBRTC_SIGNALING_DEBUG && msg.Metrics.TurnIndex == 2 {
+			break
+		}
+	}
+
+	// 脚本结束后挂断：先收到 hangup 消息，再收到正常关闭
+	_, data, err := browser.ReadMessage()
+	if err != nil {
+		t.Fatalf("read hangup failed: %v", err)
+	}
+	var hangup WebRTCMessage
+	if err := json.Unmarshal(data, &hangup); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if hangup.Type != WEBRTC_SIGNALING_HANGUP || hangup.Text != "script_finished" {
+		t.Errorf("unexpected hangup message: %+v", hangup)
+	}
+	if _, _, err := browser.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("expected normal closure, got %v", err)
+	}
+
+	select {
+	case <-client.ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("call not closed after script finished")
+	}
+
+	if got[0].Type != WEBRTC_SIGNALING_ANSWER || got[0].SDP != FAKE_ANSWER_SDP || got[0].CallID != client.CallID() {
+		t.Errorf("unexpected answer: %+v", got[0])
+	}
+
+	var texts []string
+	for _, msg := range got {
+		if msg.Type == LLM_USER_MESSAGE_ROLE || msg.Type == LLM_ASSISTANT_MESSAGE_ROLE {
+			texts = append(texts, msg.Type+":"+msg.Text)
+		}
+	}
+	wantTexts := []string{
+		"llmUser:你好",
+		"llmAssistant:你说：你好",
+		"llmUser:今天天气怎么样",
+		"llmAssistant:你说：今天天气怎么样",
+	}
+	if strings.Join(texts, "|") != strings.Join(wantTexts, "|") {
+		t.Errorf("texts = %v, want %v", texts, wantTexts)
+	}
+
+	spoken := backend.Spoken()
+	if len(spoken) != 3 || spoken[1] != "你说：你好" || spoken[2] != "你说：今天天气怎么样" {
+		t.Errorf("spoken = %v", spoken)
+	}
+	if client.LlmConversationID != "conv-1" {
+		t.Errorf("conversation id = %q, want conv-1", client.LlmConversationID)
+	}
+}
+
+func TestSignalingClientVoiceConfirmation(t *testing.T) {
+	out, browser := newBrowserConn(t)
+	backend := NewFakeBackend([]string{"查一下汇率", "嗯，好的"})
+
+	decisions := make(chan string, 1)
+	llm := fakeLlmService{reply: func(in *llmchatservice.ChatStreamReq) []*llmchatservice.ChatMsg {
+		msg := in.Messages[len(in.Messages)-1]
+		if msg.Role == chatconsts.ChatMessageRoleTool {
+			decisions <- msg.ToolCalls[0].Status
+			return []*llmchatservice.ChatMsg{{Content: "查好了"}}
+		}
+		return []*llmchatservice.ChatMsg{{ToolCalls: []*llmchatservice.ToolCall{{
+			Info:   &llmchatservice.ToolCallInfo{Id: "call-1", Name: "currency_exchange"},
+			Status: chatconsts.TOOL_CALLING_WAITING_CONFIRMATION,
+		}}}}
+	}}
+
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: llm,
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		OutConn:    out,
+		Backend:    backend,
+		Option:     &CallOptions{Offer: "offer-sdp"},
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	go client.Listen("")
+	go client.HandleEvtMsg()
+
+	select {
+	case decision := <-decisions:
+		if decision != chatconsts.TOOL_CALLING_CONFIRMED {
+			t.Errorf("decision = %s, want %s", decision, chatconsts.TOOL_CALLING_CONFIRMED)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("confirmation not sent to llm")
+	}
+
+	select {
+	case <-client.ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("call not closed after script finished")
+	}
+	browser.Close()
+
+	spoken := backend.Spoken()
+	if len(spoken) != 3 || spoken[1] != confirmQuestion([]*llmchatservice.ToolCall{{Info: &llmchatservice.ToolCallInfo{Name: "currency_exchange"}}}) || spoken[2] != "查好了" {
+		t.Errorf("spoken = %v", spoken)
+	}
+}
+
+func TestSignalingClientDtmfInput(t *testing.T) {
+	backend := NewFakeBackend([]string{"dtmf:1234#"})
+	inputs := make(chan string, 4)
+	llm := fakeLlmService{reply: func(in *llmchatservice.ChatStreamReq) []*llmchatservice.ChatMsg {
+		inputs <- in.Messages[len(in.Messages)-1].Content
+		return []*llmchatservice.ChatMsg{{Content: "收到"}}
+	}}
+
+	// SIP 通话没有浏览器信令连接
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: llm,
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		Backend:    backend,
+		Option:     &CallOptions{Callee: "sip:1000@127.0.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	go client.Listen("")
+	go client.HandleEvtMsg()
+
+	select {
+	case input := <-inputs:
+		if want := "（用户通过电话按键输入：1234）"; input != want {
+			t.Errorf("input = %q, want %q", input, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("dtmf input not sent to llm")
+	}
+
+	select {
+	case <-client.ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("call not closed after script finished")
+	}
+}
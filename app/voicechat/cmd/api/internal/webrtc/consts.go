@@ -1,18 +1,44 @@
 package webrtc
 
 const (
-	WEBRTC_SIGNALING_OFFER  = "offer"
-	WEBRTC_SIGNALING_ANSWER = "answer"
+	WEBRTC_SIGNALING_OFFER         = "offer"
+	WEBRTC_SIGNALING_ANSWER        = "answer"
+	WEBRTC_SIGNALING_ICE_CANDIDATE = "ice-candidate"
+	WEBRTC_SIGNALING_RECONNECT     = "reconnect"
+	WEBRTC_SIGNALING_ERROR         = "error"
+	WEBRTC_SIGNALING_HANGUP        = "hangup"
+	WEBRTC_SIGNALING_TOOL_CALL     = "tool-call"
+	WEBRTC_SIGNALING_TOOL_RESULT   = "tool-result"
+	WEBRTC_SIGNALING_DEBUG         = "debug"
 
-	WS_CALLBACK_EVENT_TYPE_INVITE   = "invite"
-	WS_CALLBACK_EVENT_TYPE_TTS      = "tts"
-	WS_CALLBACK_EVENT_TYPE_ANSWER   = "answer"
-	WS_CALLBACK_EVENT_TYPE_ASRFINAL = "asrFinal"
+	// 文本模拟通话
+	WEBRTC_SIGNALING_START = "start"
+	WEBRTC_SIGNALING_TEXT  = "text"
+	WEBRTC_SIGNALING_SPEAK = "speak"
+
+	WS_CALLBACK_EVENT_TYPE_INVITE    = "invite"
+	WS_CALLBACK_EVENT_TYPE_CANDIDATE = "candidate"
+	WS_CALLBACK_EVENT_TYPE_TTS       = "tts"
+	WS_CALLBACK_EVENT_TYPE_ANSWER    = "answer"
+	WS_CALLBACK_EVENT_TYPE_ASRFINAL  = "asrFinal"
+	WS_CALLBACK_EVENT_TYPE_HANGUP    = "hangup"
 
 	WS_CALLBACK_EVENT_TYPE_TRACK_START = "trackStart"
 	WS_CALLBACK_EVENT_TYPE_TRACK_END   = "trackEnd"
 	WS_CALLBACK_EVENT_TYPE_METRICS     = "metrics"
 	WS_CALLBACK_EVENT_TYPE_ASRDELTA    = "asrDelta"
+	WS_CALLBACK_EVENT_TYPE_SPEAKING    = "speaking"
+
+	// SIP 通话
+	WS_CALLBACK_EVENT_TYPE_RINGING = "ringing"
+	WS_CALLBACK_EVENT_TYPE_REJECT  = "reject"
+	WS_CALLBACK_EVENT_TYPE_DTMF    = "dtmf"
+
+	PBX_COMMAND_INTERRUPT = "interrupt"
+	PBX_COMMAND_HANGUP    = "hangup"
+	PBX_COMMAND_ACCEPT    = "accept"
+	PBX_COMMAND_REJECT    = "reject"
+	PBX_COMMAND_REINVITE  = "reinvite" // 通话中携带新的 offer 重新协商媒体
 
 	LLM_USER_MESSAGE_ROLE      = "llmUser"
 	LLM_ASSISTANT_MESSAGE_ROLE = "llmAssistant"
@@ -0,0 +1,44 @@
+package webrtc
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DTMF 结束键，按下后立即把已按的号码交给 LLM
+	dtmfTerminator = "#"
+	// 最后一次按键后等待多久视为输入结束
+	dtmfFlushDelay = 2 * time.Second
+
+	dtmfInputFormat = "（用户通过电话按键输入：%s）"
+)
+
+// handleDtmf 缓冲按键，按下 # 或停顿后作为一句用户输入交给 LLM，便于输入多位号码
+func (s *SignalingClient) handleDtmf(digit string) {
+	if digit == dtmfTerminator {
+		s.submitDtmf()
+		return
+	}
+	s.dtmfDigits.WriteString(digit)
+	s.dtmfAt = time.Now()
+}
+
+// flushDtmf 停顿超过 dtmfFlushDelay 后提交已按的号码
+func (s *SignalingClient) flushDtmf(now time.Time) {
+	if s.dtmfDigits.Len() > 0 && now.Sub(s.dtmfAt) >= dtmfFlushDelay {
+		s.submitDtmf()
+	}
+}
+
+func (s *SignalingClient) submitDtmf() {
+	digits := s.dtmfDigits.String()
+	s.dtmfDigits.Reset()
+	if digits == "" {
+		return
+	}
+	s.handleAsrFinal(EventMessage{
+		Event: WS_CALLBACK_EVENT_TYPE_ASRFINAL,
+		Text:  fmt.Sprintf(dtmfInputFormat, digits),
+	})
+}
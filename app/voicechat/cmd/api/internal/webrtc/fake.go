@@ -0,0 +1,130 @@
+package webrtc
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	FAKE_ANSWER_SDP = "fake-answer-sdp"
+	// 以此开头的脚本行模拟电话按键，如 "dtmf:1234#"
+	FAKE_SCRIPT_DTMF_PREFIX = "dtmf:"
+)
+
+// FakeBackend 进程内的假媒体后端，不依赖 PBX，用于端到端测试语音链路。
+// 每轮回复播放完成后按脚本产生下一句 asrFinal（或一组 dtmf 按键），脚本用完后挂断
+type FakeBackend struct {
+	mu     sync.Mutex
+	script []string
+	next   int
+	spoken []string
+	reply  strings.Builder // 分段播报中尚未结束的回复
+	closed bool
+	events chan EventMessage
+}
+
+func NewFakeBackend(script []string) *FakeBackend {
+	return &FakeBackend{
+		script: script,
+		events: make(chan EventMessage, 1024),
+	}
+}
+
+func (b *FakeBackend) Invite(option *CallOptions) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_ANSWER, SDP: FAKE_ANSWER_SDP})
+	return nil
+}
+
+func (b *FakeBackend) Renegotiate(offer string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_ANSWER, SDP: FAKE_ANSWER_SDP})
+	return nil
+}
+
+func (b *FakeBackend) AddCandidate(candidate string) error {
+	return nil
+}
+
+func (b *FakeBackend) Speak(text string, end bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrCallClosed
+	}
+
+	b.reply.WriteString(text)
+	if !end {
+		return nil
+	}
+	b.spoken = append(b.spoken, b.reply.String())
+	b.reply.Reset()
+	b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_TRACK_START})
+	b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_TRACK_END})
+
+	if b.next < len(b.script) {
+		line := b.script[b.next]
+		b.next++
+		if digits, ok := strings.CutPrefix(line, FAKE_SCRIPT_DTMF_PREFIX); ok {
+			for _, digit := range digits {
+				b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_DTMF, Digit: string(digit)})
+			}
+			return nil
+		}
+		b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_ASRFINAL, Text: line})
+		return nil
+	}
+	b.hangup("script_finished")
+	return nil
+}
+
+func (b *FakeBackend) Interrupt() error {
+	return nil
+}
+
+func (b *FakeBackend) Hangup(reason string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hangup(reason)
+	return nil
+}
+
+func (b *FakeBackend) Events() <-chan EventMessage {
+	return b.events
+}
+
+func (b *FakeBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.events)
+	}
+	return nil
+}
+
+// Spoken 返回已播放的文本，分段播报的回复合并为一条
+func (b *FakeBackend) Spoken() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.spoken...)
+}
+
+func (b *FakeBackend) hangup(reason string) {
+	if b.closed {
+		return
+	}
+	b.emit(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_HANGUP, Reason: reason})
+	b.closed = true
+	close(b.events)
+}
+
+// emit 调用方需持有锁；事件通道容量足够，脚本不会写满
+func (b *FakeBackend) emit(evt EventMessage) {
+	if b.closed {
+		return
+	}
+	b.events <- evt
+}
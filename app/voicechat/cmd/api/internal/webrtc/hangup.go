@@ -0,0 +1,120 @@
+package webrtc
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	HANGUP_REASON_CLIENT            = "client"
+	HANGUP_REASON_REMOTE            = "remote"
+	HANGUP_REASON_END_CALL          = "end_call"
+	HANGUP_REASON_SILENCE_TIMEOUT   = "silence_timeout"
+	HANGUP_REASON_IDLE_TIMEOUT      = "idle_timeout"
+	HANGUP_REASON_MAX_DURATION      = "max_duration"
+	HANGUP_REASON_RECONNECT_TIMEOUT = "reconnect_timeout"
+	HANGUP_REASON_MEDIA_CLOSED      = "media_closed"
+	HANGUP_REASON_REJECTED          = "rejected"
+)
+
+const (
+	silenceWarningText     = "你还在吗？如果没有其他问题，我就先挂断啦"
+	maxDurationWarningText = "本次通话时长即将达到上限，马上就要结束了哦"
+
+	// 超时检查间隔
+	timeoutCheckInterval = time.Second
+)
+
+// CallTimeouts 通话超时控制，为 0 表示不启用
+type CallTimeouts struct {
+	// 机器人说完后用户持续沉默的时长，第一次超时语音提醒，再次超时挂断
+	SilenceTimeout time.Duration
+	// 媒体后端没有任何事件的时长，超时直接挂断
+	IdleTimeout time.Duration
+	// 单次通话最长时长
+	MaxDuration time.Duration
+	// 距离最长时长多久时语音提醒
+	MaxDurationWarning time.Duration
+}
+
+// Hangup 结束通话：通知媒体后端挂断、告知浏览器并关闭两端 websocket，可重复调用
+func (s *SignalingClient) Hangup(reason string) {
+	s.closeOnce.Do(func() {
+		s.logx.Infof("Hangup call, callId: %s, reason: %s", s.callId, reason)
+		if err := s.backend.Hangup(reason); err != nil {
+			s.logx.Infof("media backend hangup failed, callId: %s, err: %v", s.callId, err)
+		}
+
+		if err := s.writeOut(WebRTCMessage{
+			Type:   WEBRTC_SIGNALING_HANGUP,
+			Text:   reason,
+			CallID: s.callId,
+		}); err != nil {
+			s.logx.Errorf("Failed to send hangup message: %v", err)
+		}
+		s.closeOut(reason)
+
+		s.cancel()
+		s.backend.Close()
+		if s.onClose != nil {
+			s.onClose()
+		}
+	})
+}
+
+// closeOut 正常关闭浏览器信令连接
+func (s *SignalingClient) closeOut(reason string) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	if s.outConn == nil {
+		return
+	}
+
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason)
+	s.outConn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+	s.outConn.Close()
+	s.outConn = nil
+	s.pending = nil
+}
+
+// checkTimeouts 检查通话时长、空闲与沉默超时，只在 HandleEvtMsg 协程中调用
+func (s *SignalingClient) checkTimeouts(now time.Time) {
+	t := s.timeouts
+
+	if t.MaxDuration > 0 {
+		elapsed := now.Sub(s.startedAt)
+		if elapsed >= t.MaxDuration {
+			s.Hangup(HANGUP_REASON_MAX_DURATION)
+			return
+		}
+		if t.MaxDurationWarning > 0 && !s.durationWarned && elapsed >= t.MaxDuration-t.MaxDurationWarning {
+			s.durationWarned = true
+			if err := s.sendTTSMessage(maxDurationWarningText); err != nil {
+				s.logx.Errorf("Failed to send max duration warning: %v", err)
+			}
+		}
+	}
+
+	if t.IdleTimeout > 0 && now.Sub(s.lastEventAt) >= t.IdleTimeout {
+		s.Hangup(HANGUP_REASON_IDLE_TIMEOUT)
+		return
+	}
+
+	if t.SilenceTimeout > 0 && !s.silenceSince.IsZero() && now.Sub(s.silenceSince) >= t.SilenceTimeout {
+		if s.silenceWarned {
+			s.Hangup(HANGUP_REASON_SILENCE_TIMEOUT)
+			return
+		}
+		s.silenceWarned = true
+		if err := s.sendTTSMessage(silenceWarningText); err != nil {
+			s.logx.Errorf("Failed to send silence warning: %v", err)
+		}
+	}
+}
+
+// resetSilence 用户开口后重新计算沉默
+func (s *SignalingClient) resetSilence() {
+	s.silenceSince = time.Time{}
+	s.silenceWarned = false
+}
@@ -0,0 +1,170 @@
+package webrtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+)
+
+// newTimeoutClient 创建没有浏览器信令连接的通话，发给浏览器的消息暂存在 pending 中
+func newTimeoutClient(t *testing.T, timeouts CallTimeouts) (*SignalingClient, *FakeBackend) {
+	t.Helper()
+	backend := NewFakeBackend(nil)
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: fakeLlmService{},
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		Backend:    backend,
+		Timeouts:   timeouts,
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Hangup(HANGUP_REASON_CLIENT) })
+	return client, backend
+}
+
+// hangupReason 返回暂存消息中的挂断原因，未挂断时返回空字符串
+func hangupReason(client *SignalingClient) string {
+	for _, msg := range pendingMessages(client) {
+		if msg.Type == WEBRTC_SIGNALING_HANGUP {
+			return msg.Text
+		}
+	}
+	return ""
+}
+
+func TestCheckTimeoutsIdle(t *testing.T) {
+	client, _ := newTimeoutClient(t, CallTimeouts{IdleTimeout: time.Minute})
+	now := time.Now()
+	client.startedAt = now.Add(-5 * time.Minute)
+	client.lastEventAt = now.Add(-59 * time.Second)
+
+	client.checkTimeouts(now)
+	if client.ctx.Err() != nil {
+		t.Fatal("call hung up before idle timeout")
+	}
+
+	client.checkTimeouts(now.Add(time.Second))
+	if client.ctx.Err() == nil {
+		t.Fatal("call not hung up after idle timeout")
+	}
+	if reason := hangupReason(client); reason != HANGUP_REASON_IDLE_TIMEOUT {
+		t.Errorf("hangup reason = %q, want %s", reason, HANGUP_REASON_IDLE_TIMEOUT)
+	}
+}
+
+func TestCheckTimeoutsMaxDuration(t *testing.T) {
+	client, backend := newTimeoutClient(t, CallTimeouts{
+		MaxDuration:        30 * time.Minute,
+		MaxDurationWarning: time.Minute,
+	})
+	start := time.Now()
+	client.startedAt = start
+	client.lastEventAt = start
+
+	client.checkTimeouts(start.Add(28 * time.Minute))
+	if client.durationWarned || len(backend.Spoken()) != 0 {
+		t.Fatal("warned before the warning window")
+	}
+
+	client.checkTimeouts(start.Add(29 * time.Minute))
+	if !client.durationWarned {
+		t.Fatal("no warning inside the warning window")
+	}
+	client.checkTimeouts(start.Add(29*time.Minute + 30*time.Second))
+	if spoken := backend.Spoken(); len(spoken) != 1 || spoken[0] != maxDurationWarningText {
+		t.Errorf("spoken = %v, want one max duration warning", spoken)
+	}
+	if client.ctx.Err() != nil {
+		t.Fatal("call hung up before max duration")
+	}
+
+	client.checkTimeouts(start.Add(30 * time.Minute))
+	if client.ctx.Err() == nil {
+		t.Fatal("call not hung up at max duration")
+	}
+	if reason := hangupReason(client); reason != HANGUP_REASON_MAX_DURATION {
+		t.Errorf("hangup reason = %q, want %s", reason, HANGUP_REASON_MAX_DURATION)
+	}
+}
+
+func TestCheckTimeoutsDisabled(t *testing.T) {
+	client, backend := newTimeoutClient(t, CallTimeouts{})
+	start := time.Now()
+	client.startedAt = start
+	client.lastEventAt = start
+	client.silenceSince = start
+
+	client.checkTimeouts(start.Add(24 * time.Hour))
+	if client.ctx.Err() != nil {
+		t.Fatal("call hung up although all timeouts are disabled")
+	}
+	if spoken := backend.Spoken(); len(spoken) != 0 {
+		t.Errorf("spoken = %v, want nothing", spoken)
+	}
+}
+
+func TestEndCallRecordsToolResultBeforeHangup(t *testing.T) {
+	backend := NewFakeBackend([]string{"没事了，挂了吧"})
+	records := make(chan *llmchatservice.ChatStreamReq, 1)
+	llm := fakeLlmService{reply: func(in *llmchatservice.ChatStreamReq) []*llmchatservice.ChatMsg {
+		if in.RecordOnly {
+			records <- in
+			return nil
+		}
+		return []*llmchatservice.ChatMsg{
+			{Content: "好的，再见"},
+			{ToolCalls: []*llmchatservice.ToolCall{{
+				Info:   &llmchatservice.ToolCallInfo{Id: "call-end", Name: chatconsts.TOOL_CALLING_END_CALL, Scope: chatconsts.TOOL_CALLING_SCOPE_CLIENT},
+				Status: chatconsts.TOOL_CALLING_START,
+			}}},
+		}
+	}}
+
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: llm,
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		Backend:    backend,
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	go client.Listen("")
+	go client.HandleEvtMsg()
+
+	select {
+	case <-client.ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("call not closed after end_call")
+	}
+
+	select {
+	case in := <-records:
+		if in.ConversationId != "conv-1" || len(in.Messages) != 1 {
+			t.Fatalf("record request = %+v", in)
+		}
+		msg := in.Messages[0]
+		if msg.Role != chatconsts.ChatMessageRoleTool || msg.ToolCallId != "call-end" {
+			t.Errorf("tool message = %+v, want tool result for call-end", msg)
+		}
+		if tc := msg.ToolCalls[0]; tc.Status != chatconsts.TOOL_CALLING_FINISHED || tc.Result == "" {
+			t.Errorf("end_call tool call = %+v, want finished with result", tc)
+		}
+	default:
+		t.Fatal("end_call result not recorded before hangup")
+	}
+
+	if reason := hangupReason(client); reason != HANGUP_REASON_END_CALL {
+		t.Errorf("hangup reason = %q, want %s", reason, HANGUP_REASON_END_CALL)
+	}
+	if spoken := backend.Spoken(); len(spoken) != 2 || spoken[1] != "好的，再见" {
+		t.Errorf("spoken = %v", spoken)
+	}
+}
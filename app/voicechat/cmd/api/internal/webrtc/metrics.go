@@ -0,0 +1,106 @@
+package webrtc
+
+import (
+	"time"
+
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+const (
+	TURN_STAGE_LLM_FIRST_TOKEN = "llm_first_token"
+	TURN_STAGE_LLM_DONE        = "llm_done"
+	TURN_STAGE_TTS_FIRST_AUDIO = "tts_first_audio"
+	TURN_STAGE_TTS_END         = "tts_end"
+)
+
+// turnStageDuration 语音管线各阶段耗时，均以 ASR final 为起点
+var turnStageDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+	Namespace: "voicechat",
+	Subsystem: "turn",
+	Name:      "stage_duration_ms",
+	Help:      "voice turn stage latency since asr final in milliseconds.",
+	Labels:    []string{"stage", "asr_provider", "tts_provider", "llm_model"},
+	Buckets:   []float64{50, 100, 200, 300, 500, 750, 1000, 1500, 2000, 3000, 5000, 10000, 20000},
+})
+
+// TurnMetrics 单轮对话的延迟数据，同时作为 debug 事件下发给前端
+type TurnMetrics struct {
+	CallId          string `json:"callId"`
+	ConversationId  string `json:"conversationId"`
+	TurnIndex       int64  `json:"turnIndex"`
+	AsrProvider     string `json:"asrProvider"`
+	TtsProvider     string `json:"ttsProvider"`
+	LlmModel        string `json:"llmModel"`
+	AsrFinalAt      int64  `json:"asrFinalAt"`
+	LlmFirstTokenMs int64  `json:"llmFirstTokenMs"`
+	LlmDoneMs       int64  `json:"llmDoneMs"`
+	TtsFirstAudioMs int64  `json:"ttsFirstAudioMs"`
+	TtsEndMs        int64  `json:"ttsEndMs"`
+}
+
+// turnTimer 记录单轮对话各阶段的时间戳，只在 HandleEvtMsg 协程中读写
+type turnTimer struct {
+	index         int64
+	asrFinal      time.Time
+	llmFirstToken time.Time
+	llmDone       time.Time
+	ttsFirstAudio time.Time
+	ttsEnd        time.Time
+}
+
+func newTurnTimer(index int64) *turnTimer {
+	return &turnTimer{
+		index:    index,
+		asrFinal: time.Now(),
+	}
+}
+
+// mark 记录阶段时间戳，重复调用只保留第一次
+func (t *turnTimer) mark(stage *time.Time) {
+	if stage.IsZero() {
+		*stage = time.Now()
+	}
+}
+
+func (t *turnTimer) sinceAsrFinal(stage time.Time) int64 {
+	if stage.IsZero() {
+		return 0
+	}
+	return stage.Sub(t.asrFinal).Milliseconds()
+}
+
+// observe 将已记录的阶段写入 Prometheus 直方图，并生成本轮的指标数据
+func (t *turnTimer) observe(callId, conversationId, asrProvider, ttsProvider, llmModel string) TurnMetrics {
+	m := TurnMetrics{
+		CallId:          callId,
+		ConversationId:  conversationId,
+		TurnIndex:       t.index,
+		AsrProvider:     asrProvider,
+		TtsProvider:     ttsProvider,
+		LlmModel:        llmModel,
+		AsrFinalAt:      t.asrFinal.UnixMilli(),
+		LlmFirstTokenMs: t.sinceAsrFinal(t.llmFirstToken),
+		LlmDoneMs:       t.sinceAsrFinal(t.llmDone),
+		TtsFirstAudioMs: t.sinceAsrFinal(t.ttsFirstAudio),
+		TtsEndMs:        t.sinceAsrFinal(t.ttsEnd),
+	}
+
+	stages := []struct {
+		name string
+		at   time.Time
+		ms   int64
+	}{
+		{TURN_STAGE_LLM_FIRST_TOKEN, t.llmFirstToken, m.LlmFirstTokenMs},
+		{TURN_STAGE_LLM_DONE, t.llmDone, m.LlmDoneMs},
+		{TURN_STAGE_TTS_FIRST_AUDIO, t.ttsFirstAudio, m.TtsFirstAudioMs},
+		{TURN_STAGE_TTS_END, t.ttsEnd, m.TtsEndMs},
+	}
+	for _, stage := range stages {
+		if stage.at.IsZero() {
+			continue
+		}
+		turnStageDuration.Observe(stage.ms, stage.name, asrProvider, ttsProvider, llmModel)
+	}
+
+	return m
+}
@@ -0,0 +1,75 @@
+package webrtc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTurnTimerMarkKeepsFirst(t *testing.T) {
+	timer := newTurnTimer(1)
+
+	timer.mark(&timer.llmFirstToken)
+	first := timer.llmFirstToken
+	if first.IsZero() {
+		t.Fatal("mark did not record llmFirstToken")
+	}
+	time.Sleep(2 * time.Millisecond)
+	timer.mark(&timer.llmFirstToken)
+	if !timer.llmFirstToken.Equal(first) {
+		t.Errorf("second mark overwrote llmFirstToken: %v -> %v", first, timer.llmFirstToken)
+	}
+	if first.Before(timer.asrFinal) {
+		t.Errorf("llmFirstToken %v before asrFinal %v", first, timer.asrFinal)
+	}
+}
+
+func TestTurnTimerObserveStagesInOrder(t *testing.T) {
+	base := time.UnixMilli(1_700_000_000_000)
+	timer := &turnTimer{
+		index:         3,
+		asrFinal:      base,
+		llmFirstToken: base.Add(120 * time.Millisecond),
+		llmDone:       base.Add(480 * time.Millisecond),
+		ttsFirstAudio: base.Add(300 * time.Millisecond),
+		ttsEnd:        base.Add(1500 * time.Millisecond),
+	}
+
+	m := timer.observe("call-1", "conv-1", "paraformer", "cosyvoice", "qwen")
+	want := TurnMetrics{
+		CallId:          "call-1",
+		ConversationId:  "conv-1",
+		TurnIndex:       3,
+		AsrProvider:     "paraformer",
+		TtsProvider:     "cosyvoice",
+		LlmModel:        "qwen",
+		AsrFinalAt:      base.UnixMilli(),
+		LlmFirstTokenMs: 120,
+		LlmDoneMs:       480,
+		TtsFirstAudioMs: 300,
+		TtsEndMs:        1500,
+	}
+	if m != want {
+		t.Fatalf("observe() = %+v, want %+v", m, want)
+	}
+	if !(m.LlmFirstTokenMs < m.TtsFirstAudioMs && m.TtsFirstAudioMs < m.TtsEndMs && m.LlmFirstTokenMs < m.LlmDoneMs) {
+		t.Errorf("stage latencies out of order: %+v", m)
+	}
+}
+
+func TestTurnTimerObserveSkipsUnmarkedStages(t *testing.T) {
+	base := time.UnixMilli(1_700_000_000_000)
+	timer := &turnTimer{
+		index:         1,
+		asrFinal:      base,
+		llmFirstToken: base.Add(90 * time.Millisecond),
+		llmDone:       base.Add(200 * time.Millisecond),
+	}
+
+	m := timer.observe("call-1", "conv-1", "asr", "tts", "model")
+	if m.LlmFirstTokenMs != 90 || m.LlmDoneMs != 200 {
+		t.Errorf("llm stages = %d/%d, want 90/200", m.LlmFirstTokenMs, m.LlmDoneMs)
+	}
+	if m.TtsFirstAudioMs != 0 || m.TtsEndMs != 0 {
+		t.Errorf("unmarked tts stages = %d/%d, want 0/0", m.TtsFirstAudioMs, m.TtsEndMs)
+	}
+}
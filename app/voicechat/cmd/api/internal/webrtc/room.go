@@ -0,0 +1,575 @@
+package webrtc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+	"go-zero-voice-agent/pkg/chatbridge"
+
+	"github.com/gorilla/websocket"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 多人语音房间的默认配置
+const (
+	defaultRoomAgentName   = "奈奈"
+	defaultRoomMaxMembers  = 8
+	defaultRoomContextSize = 20
+
+	// 智能体发言时的 speaker
+	ROOM_SPEAKER_AGENT int64 = 0
+)
+
+const defaultRoomSystemPrompt = `你是多人语音房间里的助手“%s”，房间里有多位用户在交谈。
+【对话格式】
+1. 每句话前标注了说话人，如“用户1001：”。
+2. 只有被叫到名字时你才会收到消息，请回答最后一位叫你的用户，可以参考之前其他人说的话。
+【语音交互限制】
+1. 回答必须简短（30字以内），口语化。
+2. 绝对不要使用Markdown、列表或表情符号。`
+
+var (
+	ErrRoomFull      = errors.New("voice room is full")
+	ErrAlreadyInRoom = errors.New("already in voice room")
+)
+
+// RoomConfig 语音房间的人数限制与智能体唤醒方式，为空时使用默认值
+type RoomConfig struct {
+	// 智能体名字，说“@名字”视为提及
+	AgentName string
+	// 以唤醒词开头的话会交给智能体回答，为空时使用智能体名字
+	WakeWords []string
+	// 房间最多人数
+	MaxMembers int
+	// 智能体回答时最多带上的最近对话条数
+	ContextSize int
+}
+
+func (c RoomConfig) withDefaults() RoomConfig {
+	if c.AgentName == "" {
+		c.AgentName = defaultRoomAgentName
+	}
+	if len(c.WakeWords) == 0 {
+		c.WakeWords = []string{c.AgentName}
+	}
+	if c.MaxMembers <= 0 {
+		c.MaxMembers = defaultRoomMaxMembers
+	}
+	if c.ContextSize <= 0 {
+		c.ContextSize = defaultRoomContextSize
+	}
+	return c
+}
+
+// addressed 判断这句话是否在叫智能体：以唤醒词开头，或提及了智能体
+func (c RoomConfig) addressed(text string) bool {
+	if strings.Contains(text, "@"+c.AgentName) {
+		return true
+	}
+	text = strings.ToLower(strings.TrimLeftFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}))
+	for _, word := range c.WakeWords {
+		if word != "" && strings.HasPrefix(text, strings.ToLower(word)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoomEvent 房间事件，通过 OnEvent 投递到聊天室
+type RoomEvent struct {
+	Type        string  `json:"type"` // chatbridge.MESSAGE_TYPE_VOICE_*
+	RoomID      string  `json:"roomId"`
+	Speaker     int64   `json:"speaker"` // 说话人 userId，智能体为 0
+	SpeakerName string  `json:"speakerName,omitempty"`
+	Text        string  `json:"text,omitempty"`
+	Members     []int64 `json:"members"` // 事件发生时的房间成员，即消息接收人
+	Timestamp   int64   `json:"timestamp"`
+}
+
+type VoiceRoomParams struct {
+	Ctx    context.Context
+	RoomID string
+	// 房主（聊天室房间的 owner），智能体只使用房主加入时带上的 LLM 配置和提示词
+	OwnerID    int64
+	LlmService llmchatservice.LlmChatService
+	// 加入者的 LLM 配置与提示词，加入者不是房主时忽略
+	LlmConfig    *llmchatservice.LlmConfig
+	SystemPrompt string
+	Config       RoomConfig
+	OnEvent      func(evt RoomEvent)
+}
+
+// RoomMemberParams 成员加入房间时的媒体连接
+type RoomMemberParams struct {
+	UserID  int64
+	OutConn *websocket.Conn
+	Backend MediaBackend
+	Option  *CallOptions
+}
+
+// VoiceRoom 多人语音房间：每个成员一路独立的媒体连接和 ASR，识别结果按 userId 区分说话人；
+// 房间内有一个智能体，被唤醒词或提及时回答，回答通过每个成员自己的媒体连接播放
+type VoiceRoom struct {
+	roomId  string
+	ownerId int64
+	cfg     RoomConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	logx    logx.Logger
+	onEvent func(evt RoomEvent)
+	onClose func()
+
+	mu      sync.Mutex
+	members map[int64]*roomMember
+	closed  bool
+
+	utterances chan roomUtterance
+
+	// 智能体配置，房主加入时设置，由 mu 保护；房主加入之前智能体不回答
+	llmConfig    *llmchatservice.LlmConfig
+	systemPrompt string
+
+	// 智能体相关，仅在 run 协程中读写
+	llmService     llmchatservice.LlmChatService
+	conversationId string
+	history        []string // 智能体上次回答之后的对话
+}
+
+type roomMember struct {
+	userId  int64
+	backend MediaBackend
+
+	outMu   sync.Mutex
+	outConn *websocket.Conn
+}
+
+type roomUtterance struct {
+	userId int64
+	text   string
+}
+
+func newVoiceRoom(params VoiceRoomParams) *VoiceRoom {
+	// 房间生命周期独立于创建者的信令连接，最后一个成员离开时结束
+	ctx, cancel := context.WithCancel(context.WithoutCancel(params.Ctx))
+	cfg := params.Config.withDefaults()
+
+	systemPrompt := params.SystemPrompt
+	if strings.TrimSpace(systemPrompt) == "" {
+		systemPrompt = fmt.Sprintf(defaultRoomSystemPrompt, cfg.AgentName)
+	}
+
+	return &VoiceRoom{
+		roomId:       params.RoomID,
+		ownerId:      params.OwnerID,
+		cfg:          cfg,
+		ctx:          ctx,
+		cancel:       cancel,
+		logx:         logx.WithContext(ctx),
+		onEvent:      params.OnEvent,
+		members:      make(map[int64]*roomMember),
+		utterances:   make(chan roomUtterance, 256),
+		llmService:   params.LlmService,
+		systemPrompt: systemPrompt,
+	}
+}
+
+// setAgent 房主加入时设置智能体的 LLM 配置，其他成员的配置被忽略，只设置一次
+func (r *VoiceRoom) setAgent(userId int64, llmConfig *llmchatservice.LlmConfig, systemPrompt string) {
+	if userId != r.ownerId || llmConfig == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.llmConfig != nil {
+		return
+	}
+	r.llmConfig = llmConfig
+	if strings.TrimSpace(systemPrompt) != "" {
+		r.systemPrompt = systemPrompt
+	}
+}
+
+func (r *VoiceRoom) agent() (*llmchatservice.LlmConfig, string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.llmConfig, r.systemPrompt
+}
+
+func (r *VoiceRoom) RoomID() string {
+	return r.roomId
+}
+
+// Members 当前房间成员
+func (r *VoiceRoom) Members() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.memberIds()
+}
+
+// AddIceCandidate 将成员浏览器的 trickle ICE 候选转发给其媒体后端
+func (r *VoiceRoom) AddIceCandidate(userId int64, candidate string) error {
+	member, ok := r.member(userId)
+	if !ok || candidate == "" {
+		return nil
+	}
+	return member.backend.AddCandidate(candidate)
+}
+
+// Renegotiate 使用新的 offer 重新协商成员的媒体连接
+func (r *VoiceRoom) Renegotiate(userId int64, offer string) error {
+	member, ok := r.member(userId)
+	if !ok {
+		return ErrCallClosed
+	}
+	return member.backend.Renegotiate(offer)
+}
+
+// Leave 成员离开房间，最后一个成员离开后房间结束
+func (r *VoiceRoom) Leave(userId int64, reason string) {
+	if member, ok := r.member(userId); ok {
+		r.leave(member, reason)
+	}
+}
+
+// reserve 登记成员，调用方需持有 RoomManager 的锁，保证不会加入正在关闭的房间
+func (r *VoiceRoom) reserve(member *roomMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return ErrCallClosed
+	}
+	if _, ok := r.members[member.userId]; ok {
+		return ErrAlreadyInRoom
+	}
+	if len(r.members) >= r.cfg.MaxMembers {
+		return ErrRoomFull
+	}
+	r.members[member.userId] = member
+	return nil
+}
+
+func (r *VoiceRoom) member(userId int64) (*roomMember, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	member, ok := r.members[userId]
+	return member, ok
+}
+
+func (r *VoiceRoom) isClosed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closed
+}
+
+// memberIds 调用方需持有锁
+func (r *VoiceRoom) memberIds() []int64 {
+	ids := make([]int64, 0, len(r.members))
+	for id := range r.members {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r *VoiceRoom) snapshot() []*roomMember {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	members := make([]*roomMember, 0, len(r.members))
+	for _, m := range r.members {
+		members = append(members, m)
+	}
+	return members
+}
+
+// leave 只移除仍在房间中的同一个成员，避免旧连接的清理误踢掉重新加入的成员
+func (r *VoiceRoom) leave(member *roomMember, reason string) {
+	r.mu.Lock()
+	if r.members[member.userId] != member {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.members, member.userId)
+	remaining := r.memberIds()
+	empty := len(r.members) == 0
+	if empty {
+		r.closed = true
+	}
+	r.mu.Unlock()
+
+	r.logx.Infof("user %d left voice room %s, reason: %s", member.userId, r.roomId, reason)
+	if err := member.backend.Hangup(reason); err != nil {
+		r.logx.Infof("media backend hangup failed, roomId: %s, userId: %d, err: %v", r.roomId, member.userId, err)
+	}
+	member.backend.Close()
+	if err := member.write(WebRTCMessage{Type: WEBRTC_SIGNALING_HANGUP, Text: reason, RoomID: r.roomId}); err != nil {
+		r.logx.Errorf("Failed to send hangup message: %v", err)
+	}
+	member.closeOut(reason)
+
+	if empty {
+		r.cancel()
+		if r.onClose != nil {
+			r.onClose()
+		}
+		return
+	}
+	r.emit(RoomEvent{Type: chatbridge.MESSAGE_TYPE_VOICE_LEAVE, Speaker: member.userId, Members: remaining})
+}
+
+// listen 转发成员媒体后端的事件：信令回给该成员，识别结果交给房间
+func (r *VoiceRoom) listen(member *roomMember) {
+	for evt := range member.backend.Events() {
+		switch evt.Event {
+		case WS_CALLBACK_EVENT_TYPE_ANSWER:
+			if err := member.write(WebRTCMessage{Type: WS_CALLBACK_EVENT_TYPE_ANSWER, SDP: evt.SDP, RoomID: r.roomId}); err != nil {
+				r.logx.Errorf("Failed to send WebRTC answer message: %v", err)
+			}
+		case WS_CALLBACK_EVENT_TYPE_CANDIDATE:
+			for _, candidate := range evt.Candidates {
+				if err := member.write(WebRTCMessage{Type: WEBRTC_SIGNALING_ICE_CANDIDATE, Candidate: candidate}); err != nil {
+					r.logx.Errorf("Failed to send ICE candidate message: %v", err)
+				}
+			}
+		case WS_CALLBACK_EVENT_TYPE_ASRFINAL:
+			if strings.TrimSpace(evt.Text) == "" {
+				continue
+			}
+			select {
+			case r.utterances <- roomUtterance{userId: member.userId, text: evt.Text}:
+			case <-r.ctx.Done():
+				return
+			}
+		case WS_CALLBACK_EVENT_TYPE_HANGUP:
+			reason := evt.Reason
+			if reason == "" {
+				reason = HANGUP_REASON_REMOTE
+			}
+			r.leave(member, reason)
+			return
+		}
+	}
+	r.leave(member, HANGUP_REASON_MEDIA_CLOSED)
+}
+
+// run 按顺序处理所有成员的发言，智能体一次只回答一句
+func (r *VoiceRoom) run() {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case u := <-r.utterances:
+			r.handleUtterance(u)
+		}
+	}
+}
+
+func (r *VoiceRoom) handleUtterance(u roomUtterance) {
+	r.emit(RoomEvent{Type: chatbridge.MESSAGE_TYPE_VOICE_TRANSCRIPT, Speaker: u.userId, Text: u.text})
+
+	r.history = append(r.history, fmt.Sprintf("用户%d：%s", u.userId, u.text))
+	if len(r.history) > r.cfg.ContextSize {
+		r.history = r.history[len(r.history)-r.cfg.ContextSize:]
+	}
+	if !r.cfg.addressed(u.text) {
+		return
+	}
+	llmConfig, systemPrompt := r.agent()
+	if llmConfig == nil {
+		r.logx.Infof("voice room %s agent not ready, owner %d has not joined", r.roomId, r.ownerId)
+		return
+	}
+
+	reply, err := r.askAgent(llmConfig, systemPrompt)
+	if err != nil {
+		r.logx.Errorf("voice room %s agent chat error: %v", r.roomId, err)
+		return
+	}
+	r.history = nil
+	if strings.TrimSpace(reply) == "" {
+		return
+	}
+
+	for _, member := range r.snapshot() {
+		if err := member.backend.Speak(reply, true); err != nil {
+			r.logx.Errorf("Failed to speak to user %d in room %s: %v", member.userId, r.roomId, err)
+		}
+	}
+	r.emit(RoomEvent{
+		Type:        chatbridge.MESSAGE_TYPE_VOICE_TRANSCRIPT,
+		Speaker:     ROOM_SPEAKER_AGENT,
+		SpeakerName: r.cfg.AgentName,
+		Text:        reply,
+	})
+}
+
+// askAgent 把智能体上次回答之后的对话一并交给 LLM，历史对话由 LLM 服务按会话补全
+func (r *VoiceRoom) askAgent(llmConfig *llmchatservice.LlmConfig, systemPrompt string) (string, error) {
+	chatMsgs := make([]*llmchatservice.ChatMsg, 0, 2)
+	if r.conversationId == "" {
+		chatMsgs = append(chatMsgs, &llmchatservice.ChatMsg{
+			Role:    chatconsts.ChatMessageRoleSystem,
+			Content: systemPrompt,
+		})
+	}
+	chatMsgs = append(chatMsgs, &llmchatservice.ChatMsg{
+		Role:    chatconsts.ChatMessageRoleUser,
+		Content: strings.Join(r.history, "\n"),
+	})
+
+	stream, err := r.llmService.ChatStream(r.ctx, &llmchatservice.ChatStreamReq{
+		UserId:         r.ownerId,
+		ConversationId: r.conversationId,
+		LlmConfig: &llmchatservice.LlmConfig{
+			BaseUrl: llmConfig.GetBaseUrl(),
+			ApiKey:  llmConfig.GetApiKey(),
+			Model:   llmConfig.GetModel(),
+		},
+		Messages:        chatMsgs,
+		AutoFillHistory: true,
+		// 房间里多人同时在说话，无法逐个征求工具调用确认，智能体只做问答
+		DisableTools: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var content strings.Builder
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if resp.ConversationId != "" {
+			r.conversationId = resp.ConversationId
+		}
+		if resp.Error != "" {
+			r.logx.Errorf("voice room %s chat resp error: %s", r.roomId, resp.Error)
+			continue
+		}
+		// 已禁用工具，仍返回工具调用时只记录，不能把带工具调用的重复文本再播报一遍
+		if len(resp.GetRespMsg().GetToolCalls()) > 0 {
+			r.logx.Errorf("voice room %s got unexpected tool calls, ignored", r.roomId)
+			continue
+		}
+		content.WriteString(resp.GetRespMsg().GetContent())
+	}
+	return content.String(), nil
+}
+
+// emit 补全房间信息后投递事件，Members 为空时发给当前所有成员
+func (r *VoiceRoom) emit(evt RoomEvent) {
+	if r.onEvent == nil {
+		return
+	}
+	evt.RoomID = r.roomId
+	if evt.Members == nil {
+		evt.Members = r.Members()
+	}
+	evt.Timestamp = time.Now().Unix()
+	r.onEvent(evt)
+}
+
+func (m *roomMember) write(msg WebRTCMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	m.outMu.Lock()
+	defer m.outMu.Unlock()
+	if m.outConn == nil {
+		return nil
+	}
+	return m.outConn.WriteMessage(websocket.TextMessage, data)
+}
+
+// closeOut 正常关闭成员的信令连接
+func (m *roomMember) closeOut(reason string) {
+	m.outMu.Lock()
+	defer m.outMu.Unlock()
+	if m.outConn == nil {
+		return
+	}
+
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason)
+	m.outConn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+	m.outConn.Close()
+	m.outConn = nil
+}
+
+// RoomManager 维护本节点上的语音房间
+type RoomManager struct {
+	mu    sync.Mutex
+	rooms map[string]*VoiceRoom
+}
+
+func NewRoomManager() *RoomManager {
+	return &RoomManager{
+		rooms: make(map[string]*VoiceRoom),
+	}
+}
+
+// Join 加入房间，房间不存在时以 params 创建。房主由调用方按聊天室房间的 owner 指定，
+// 与谁先加入无关；只有房主加入时 params 中的 LLM 配置才会成为智能体的配置
+func (m *RoomManager) Join(params VoiceRoomParams, memberParams RoomMemberParams) (*VoiceRoom, error) {
+	member := &roomMember{
+		userId:  memberParams.UserID,
+		backend: memberParams.Backend,
+		outConn: memberParams.OutConn,
+	}
+
+	m.mu.Lock()
+	room, ok := m.rooms[params.RoomID]
+	if !ok || room.isClosed() {
+		room = newVoiceRoom(params)
+		room.onClose = func() {
+			m.remove(room)
+		}
+		m.rooms[params.RoomID] = room
+		go room.run()
+	}
+	err := room.reserve(member)
+	m.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	room.setAgent(member.userId, params.LlmConfig, params.SystemPrompt)
+
+	if err := member.backend.Invite(memberParams.Option); err != nil {
+		room.leave(member, HANGUP_REASON_MEDIA_CLOSED)
+		return nil, err
+	}
+	go room.listen(member)
+
+	room.logx.Infof("user %d joined voice room %s", member.userId, room.roomId)
+	room.emit(RoomEvent{Type: chatbridge.MESSAGE_TYPE_VOICE_JOIN, Speaker: member.userId})
+	return room, nil
+}
+
+func (m *RoomManager) Get(roomId string) (*VoiceRoom, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	room, ok := m.rooms[roomId]
+	return room, ok
+}
+
+func (m *RoomManager) remove(room *VoiceRoom) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rooms[room.roomId] == room {
+		delete(m.rooms, room.roomId)
+	}
+}
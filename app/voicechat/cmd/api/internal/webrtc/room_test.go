@@ -0,0 +1,170 @@
+package webrtc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	"go-zero-voice-agent/pkg/chatbridge"
+)
+
+func TestRoomConfigAddressed(t *testing.T) {
+	cfg := RoomConfig{WakeWords: []string{"奈奈", "Hey Nana"}}.withDefaults()
+	cases := map[string]bool{
+		"奈奈，今天天气怎么样":         true,
+		"  ，奈奈在吗":            true,
+		"hey nana what's up": true,
+		"我们问问 @奈奈 吧":         true,
+		"我觉得奈奈说得对":           false,
+		"大家好":                false,
+	}
+	for text, want := range cases {
+		if got := cfg.addressed(text); got != want {
+			t.Errorf("addressed(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestVoiceRoomAgentAnswersWhenAddressed(t *testing.T) {
+	events := make(chan RoomEvent, 16)
+	spoken := make(chan string, 4)
+	onSpeak := func(text string, sentences []string) { spoken <- text }
+
+	manager := NewRoomManager()
+	params := VoiceRoomParams{
+		Ctx:        context.Background(),
+		RoomID:     "room-1",
+		OwnerID:    1,
+		LlmService: fakeLlmService{},
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "owner-model"},
+		OnEvent:    func(evt RoomEvent) { events <- evt },
+	}
+	b1, b2 := NewTextBackend(onSpeak), NewTextBackend(onSpeak)
+	room, err := manager.Join(params, RoomMemberParams{UserID: 1, Backend: b1})
+	if err != nil {
+		t.Fatalf("join failed: %v", err)
+	}
+	if _, err := manager.Join(params, RoomMemberParams{UserID: 2, Backend: b2}); err != nil {
+		t.Fatalf("join failed: %v", err)
+	}
+	if _, err := manager.Join(params, RoomMemberParams{UserID: 2, Backend: NewTextBackend(nil)}); err != ErrAlreadyInRoom {
+		t.Fatalf("err = %v, want ErrAlreadyInRoom", err)
+	}
+
+	next := func() RoomEvent {
+		select {
+		case evt := <-events:
+			return evt
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for room event")
+			return RoomEvent{}
+		}
+	}
+	if evt := next(); evt.Type != chatbridge.MESSAGE_TYPE_VOICE_JOIN || evt.Speaker != 1 {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+	if evt := next(); evt.Type != chatbridge.MESSAGE_TYPE_VOICE_JOIN || evt.Speaker != 2 || len(evt.Members) != 2 {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+
+	b2.Input("大家好")
+	if evt := next(); evt.Type != chatbridge.MESSAGE_TYPE_VOICE_TRANSCRIPT || evt.Speaker != 2 || evt.Text != "大家好" {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+
+	b1.Input("奈奈，帮我们总结一下")
+	if evt := next(); evt.Speaker != 1 {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+	evt := next()
+	if evt.Speaker != ROOM_SPEAKER_AGENT || evt.SpeakerName != defaultRoomAgentName {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+	// 智能体收到的是上次回答之后所有人的发言
+	if !strings.Contains(evt.Text, "用户2：大家好\n用户1：奈奈，帮我们总结一下") {
+		t.Fatalf("agent reply = %q, want room context", evt.Text)
+	}
+	for i := 0; i < 2; i++ {
+		if text := <-spoken; text != evt.Text {
+			t.Fatalf("spoken = %q, want %q", text, evt.Text)
+		}
+	}
+
+	room.Leave(1, HANGUP_REASON_CLIENT)
+	if evt := next(); evt.Type != chatbridge.MESSAGE_TYPE_VOICE_LEAVE || len(evt.Members) != 1 || evt.Members[0] != 2 {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+	room.Leave(2, HANGUP_REASON_CLIENT)
+	if _, ok := manager.Get("room-1"); ok {
+		t.Fatal("room should be removed after the last member left")
+	}
+}
+
+func TestVoiceRoomAgentUsesOwnerConfig(t *testing.T) {
+	requests := make(chan *llmchatservice.ChatStreamReq, 4)
+	llm := fakeLlmService{reply: func(in *llmchatservice.ChatStreamReq) []*llmchatservice.ChatMsg {
+		requests <- in
+		return []*llmchatservice.ChatMsg{
+			{Content: "好的"},
+			{Content: "好的", ToolCalls: []*llmchatservice.ToolCall{{Info: &llmchatservice.ToolCallInfo{Id: "call-1", Name: "currency_exchange"}}}},
+		}
+	}}
+	spoken := make(chan string, 4)
+	onSpeak := func(text string, sentences []string) { spoken <- text }
+
+	manager := NewRoomManager()
+	params := func(llmConfig *llmchatservice.LlmConfig) VoiceRoomParams {
+		return VoiceRoomParams{
+			Ctx:        context.Background(),
+			RoomID:     "room-1",
+			OwnerID:    1,
+			LlmService: llm,
+			LlmConfig:  llmConfig,
+		}
+	}
+
+	// 非房主先加入创建房间，其 LLM 配置不会被智能体使用
+	guest := NewTextBackend(onSpeak)
+	room, err := manager.Join(params(&llmchatservice.LlmConfig{Model: "guest-model"}), RoomMemberParams{UserID: 2, Backend: guest})
+	if err != nil {
+		t.Fatalf("join failed: %v", err)
+	}
+	t.Cleanup(func() {
+		room.Leave(1, HANGUP_REASON_CLIENT)
+		room.Leave(2, HANGUP_REASON_CLIENT)
+	})
+	guest.Input("奈奈，在吗")
+	select {
+	case in := <-requests:
+		t.Fatalf("agent answered before the owner joined, model: %s", in.LlmConfig.GetModel())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if _, err := manager.Join(params(&llmchatservice.LlmConfig{Model: "owner-model"}), RoomMemberParams{UserID: 1, Backend: NewTextBackend(nil)}); err != nil {
+		t.Fatalf("owner join failed: %v", err)
+	}
+	guest.Input("奈奈，现在呢")
+	select {
+	case in := <-requests:
+		if in.UserId != 1 || in.LlmConfig.GetModel() != "owner-model" {
+			t.Errorf("agent request user %d model %s, want owner 1 owner-model", in.UserId, in.LlmConfig.GetModel())
+		}
+		if !in.DisableTools {
+			t.Error("room agent request should disable tools")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("agent did not answer after the owner joined")
+	}
+
+	// 带工具调用的重复文本不播报
+	select {
+	case text := <-spoken:
+		if text != "好的" {
+			t.Errorf("spoken = %q, want 好的", text)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("agent reply not spoken")
+	}
+}
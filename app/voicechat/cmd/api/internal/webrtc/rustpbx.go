@@ -0,0 +1,245 @@
+package webrtc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// RustPBXBackend 通过 RustPBX 的 websocket JSON 指令协议完成媒体处理
+type RustPBXBackend struct {
+	serverAddr string
+	ctx        context.Context
+	cancel     context.CancelFunc
+	logx       logx.Logger
+	closeOnce  sync.Once
+	events     chan EventMessage
+	// 建立通话的指令：invite 发起呼叫（WebRTC 或 SIP 外呼），accept 接听 SIP 呼入
+	command string
+
+	// PBX 连接，整个通话期间只有一条，重协商也在这条连接上完成
+	mu     sync.Mutex
+	conn   *websocket.Conn
+	option CallOptions
+	// 正在分段播报的回复，为空表示没有未结束的流式 tts
+	playId string
+}
+
+func NewRustPBXBackend(serverAddr string) *RustPBXBackend {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RustPBXBackend{
+		serverAddr: serverAddr,
+		ctx:        ctx,
+		cancel:     cancel,
+		logx:       logx.WithContext(ctx),
+		events:     make(chan EventMessage, 1024),
+		command:    WS_CALLBACK_EVENT_TYPE_INVITE,
+	}
+}
+
+// NewRustPBXInboundBackend 接听 PBX 转来的 SIP 呼入，serverAddr 为 PBX 的 /call 地址，Invite 时以 accept 指令应答
+func NewRustPBXInboundBackend(serverAddr, dialogId string) *RustPBXBackend {
+	b := NewRustPBXBackend(dialogURL(serverAddr, dialogId))
+	b.command = PBX_COMMAND_ACCEPT
+	return b
+}
+
+// RejectSipCall 拒接 PBX 转来的 SIP 呼入（如号码没有对应的助手）
+func RejectSipCall(serverAddr, dialogId, reason string) error {
+	conn, err := dialPBX(dialogURL(serverAddr, dialogId), PBXMessage{
+		Command: PBX_COMMAND_REJECT,
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func dialogURL(serverAddr, dialogId string) string {
+	u, err := url.Parse(serverAddr)
+	if err != nil {
+		return serverAddr
+	}
+	q := u.Query()
+	q.Set("id", dialogId)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (b *RustPBXBackend) Invite(option *CallOptions) error {
+	conn, err := dialPBX(b.serverAddr, PBXMessage{
+		Command: b.command,
+		Option:  option,
+	})
+	if err != nil {
+		return err
+	}
+	b.logx.Infof("Send %s command to RustPBX....", b.command)
+
+	b.mu.Lock()
+	b.conn = conn
+	if option != nil {
+		b.option = *option
+	}
+	b.mu.Unlock()
+
+	go b.listen()
+	return nil
+}
+
+// Renegotiate 在现有 PBX 连接上发送 reinvite 重新协商（ICE restart），
+// 媒体会话与 ASR/TTS 状态保持不变，新的 answer 仍通过事件返回
+func (b *RustPBXBackend) Renegotiate(offer string) error {
+	b.mu.Lock()
+	b.option.Offer = offer
+	b.mu.Unlock()
+
+	if err := b.send(PBXMessage{
+		Command: PBX_COMMAND_REINVITE,
+		Option:  &CallOptions{Offer: offer},
+	}); err != nil {
+		return fmt.Errorf("renegotiate failed: %w", err)
+	}
+	return nil
+}
+
+func (b *RustPBXBackend) AddCandidate(candidate string) error {
+	return b.send(PBXMessage{
+		Command:    WS_CALLBACK_EVENT_TYPE_CANDIDATE,
+		Candidates: []string{candidate},
+	})
+}
+
+// Speak 整段播报直接发送 tts；分段播报时同一轮回复共用一个 playId 以流式 tts 发送，
+// PBX 在 endOfStream 那段播放完成后才上报 trackEnd
+func (b *RustPBXBackend) Speak(text string, end bool) error {
+	b.mu.Lock()
+	playId := b.playId
+	if playId == "" && !end {
+		playId = uuid.NewString()
+		b.playId = playId
+	}
+	if end {
+		b.playId = ""
+	}
+	b.mu.Unlock()
+
+	msg := PBXMessage{
+		Command: WS_CALLBACK_EVENT_TYPE_TTS,
+		Text:    text,
+	}
+	if playId != "" {
+		msg.PlayId = playId
+		msg.Streaming = true
+		msg.EndOfStream = end
+	}
+	return b.send(msg)
+}
+
+func (b *RustPBXBackend) Interrupt() error {
+	b.mu.Lock()
+	b.playId = ""
+	b.mu.Unlock()
+	return b.send(PBXMessage{
+		Command: PBX_COMMAND_INTERRUPT,
+	})
+}
+
+func (b *RustPBXBackend) Hangup(reason string) error {
+	return b.send(PBXMessage{
+		Command: PBX_COMMAND_HANGUP,
+		Reason:  reason,
+	})
+}
+
+func (b *RustPBXBackend) Events() <-chan EventMessage {
+	return b.events
+}
+
+func (b *RustPBXBackend) Close() error {
+	b.closeOnce.Do(func() {
+		b.cancel()
+		b.mu.Lock()
+		if b.conn != nil {
+			b.conn.Close()
+		}
+		b.mu.Unlock()
+	})
+	return nil
+}
+
+func (b *RustPBXBackend) listen() {
+	defer close(b.events)
+	for {
+		if b.ctx.Err() != nil {
+			return
+		}
+
+		typeVal, data, err := b.currentConn().ReadMessage()
+		if err != nil {
+			b.logx.Infof("PBX connection closed: %v", err)
+			return
+		}
+		if typeVal != websocket.TextMessage {
+			continue
+		}
+		b.logx.Info("received:", string(data))
+		var evt EventMessage
+		if err := json.Unmarshal(data, &evt); err != nil {
+			continue
+		}
+		select {
+		case b.events <- evt:
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
+// send 发送指令给 PBX
+func (b *RustPBXBackend) send(msg PBXMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == nil {
+		return ErrCallClosed
+	}
+	return b.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (b *RustPBXBackend) currentConn() *websocket.Conn {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.conn
+}
+
+// dialPBX 建立到 PBX 的 websocket 连接并发送 invite 指令
+func dialPBX(serverAddr string, initial PBXMessage) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(serverAddr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("websocket dial failed: %w", err)
+	}
+
+	msg, err := json.Marshal(initial)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to marshal initial PBXMessage: %w", err)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send initial message: %w", err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,137 @@
+package webrtc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newFakePBX 模拟 PBX 的 websocket 指令接口，收到的指令写入 commands，收到 reinvite 时回复 answer
+func newFakePBX(t *testing.T) (string, <-chan PBXMessage, *atomic.Int32) {
+	t.Helper()
+	commands := make(chan PBXMessage, 16)
+	var dials atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		dials.Add(1)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg PBXMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Errorf("unmarshal failed: %v", err)
+				return
+			}
+			commands <- msg
+			if msg.Command == PBX_COMMAND_REINVITE {
+				answer, _ := json.Marshal(EventMessage{Event: WS_CALLBACK_EVENT_TYPE_ANSWER, SDP: "answer-" + msg.Option.Offer})
+				conn.WriteMessage(websocket.TextMessage, answer)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http"), commands, &dials
+}
+
+func nextCommand(t *testing.T, commands <-chan PBXMessage) PBXMessage {
+	t.Helper()
+	select {
+	case msg := <-commands:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no command received by PBX")
+		return PBXMessage{}
+	}
+}
+
+func TestRustPBXRenegotiateReusesConnection(t *testing.T) {
+	addr, commands, dials := newFakePBX(t)
+	backend := NewRustPBXBackend(addr)
+	t.Cleanup(func() { backend.Close() })
+
+	if err := backend.Invite(&CallOptions{Offer: "offer-1", Asr: &AsrConfig{Provider: "aliyun"}}); err != nil {
+		t.Fatalf("Invite failed: %v", err)
+	}
+	if msg := nextCommand(t, commands); msg.Command != WS_CALLBACK_EVENT_TYPE_INVITE || msg.Option.Offer != "offer-1" {
+		t.Fatalf("first command = %+v, want invite", msg)
+	}
+
+	if err := backend.Renegotiate("offer-2"); err != nil {
+		t.Fatalf("Renegotiate failed: %v", err)
+	}
+	msg := nextCommand(t, commands)
+	if msg.Command != PBX_COMMAND_REINVITE || msg.Option == nil || msg.Option.Offer != "offer-2" {
+		t.Fatalf("renegotiate command = %+v, want reinvite with offer-2", msg)
+	}
+
+	select {
+	case evt := <-backend.Events():
+		if evt.Event != WS_CALLBACK_EVENT_TYPE_ANSWER || evt.SDP != "answer-offer-2" {
+			t.Errorf("event = %+v, want answer for offer-2", evt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no answer after renegotiate")
+	}
+
+	if n := dials.Load(); n != 1 {
+		t.Errorf("PBX dialed %d times, want 1", n)
+	}
+	if backend.option.Offer != "offer-2" || backend.option.Asr == nil {
+		t.Errorf("option after renegotiate = %+v", backend.option)
+	}
+}
+
+func TestRustPBXRenegotiateBeforeInvite(t *testing.T) {
+	backend := NewRustPBXBackend("ws://127.0.0.1:0")
+	t.Cleanup(func() { backend.Close() })
+
+	if err := backend.Renegotiate("offer"); err == nil {
+		t.Fatal("Renegotiate without a PBX connection should fail")
+	}
+}
+
+func TestRustPBXSpeakStreamsSegmentsWithOnePlayId(t *testing.T) {
+	addr, commands, _ := newFakePBX(t)
+	backend := NewRustPBXBackend(addr)
+	t.Cleanup(func() { backend.Close() })
+
+	if err := backend.Invite(&CallOptions{Offer: "offer-1"}); err != nil {
+		t.Fatalf("Invite failed: %v", err)
+	}
+	nextCommand(t, commands)
+
+	// 整段播报不使用流式 tts
+	backend.Speak("你好", true)
+	if msg := nextCommand(t, commands); msg.Command != WS_CALLBACK_EVENT_TYPE_TTS || msg.Streaming || msg.PlayId != "" {
+		t.Fatalf("single tts = %+v, want plain tts", msg)
+	}
+
+	backend.Speak("第一句。", false)
+	backend.Speak("第二句。", true)
+	first, last := nextCommand(t, commands), nextCommand(t, commands)
+	if !first.Streaming || first.EndOfStream || first.PlayId == "" || first.Text != "第一句。" {
+		t.Errorf("first segment = %+v", first)
+	}
+	if !last.Streaming || !last.EndOfStream || last.PlayId != first.PlayId || last.Text != "第二句。" {
+		t.Errorf("last segment = %+v, want endOfStream with playId %s", last, first.PlayId)
+	}
+
+	// 下一轮回复使用新的 playId
+	backend.Speak("新的一轮。", false)
+	if msg := nextCommand(t, commands); msg.PlayId == "" || msg.PlayId == first.PlayId {
+		t.Errorf("next reply playId = %q, want a new one", msg.PlayId)
+	}
+}
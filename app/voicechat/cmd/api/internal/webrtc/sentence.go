@@ -0,0 +1,48 @@
+package webrtc
+
+import (
+	"strings"
+)
+
+// 句末标点，TTS 按句切分播报
+const sentenceDelimiters = "。！？；!?;…\n"
+
+// splitSentences 按句末标点切分文本，标点保留在句尾，连续标点归入同一句
+func splitSentences(text string) []string {
+	var sentences []string
+	var current strings.Builder
+	runes := []rune(text)
+	for i, r := range runes {
+		current.WriteRune(r)
+		if !strings.ContainsRune(sentenceDelimiters, r) {
+			continue
+		}
+		if i+1 < len(runes) && strings.ContainsRune(sentenceDelimiters, runes[i+1]) {
+			continue
+		}
+		if sentence := strings.TrimSpace(current.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		current.Reset()
+	}
+	if sentence := strings.TrimSpace(current.String()); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// cutSentences 从流式输出的文本中切出已经完整的句子，剩余部分等待后续分片。
+// 句末标点后出现其他字符才算一句结束，避免连续标点被拆到两段
+func cutSentences(text string) ([]string, string) {
+	runes := []rune(text)
+	end := -1
+	for i := 0; i+1 < len(runes); i++ {
+		if strings.ContainsRune(sentenceDelimiters, runes[i]) && !strings.ContainsRune(sentenceDelimiters, runes[i+1]) {
+			end = i
+		}
+	}
+	if end < 0 {
+		return nil, text
+	}
+	return splitSentences(string(runes[:end+1])), string(runes[end+1:])
+}
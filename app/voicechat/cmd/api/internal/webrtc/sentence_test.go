@@ -0,0 +1,46 @@
+package webrtc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"你好", []string{"你好"}},
+		{"你好！今天天气不错。要出去玩吗？", []string{"你好！", "今天天气不错。", "要出去玩吗？"}},
+		{"真的吗？！那太好了喵~", []string{"真的吗？！", "那太好了喵~"}},
+		{"第一行\n第二行", []string{"第一行", "第二行"}},
+	}
+	for _, tt := range tests {
+		if got := splitSentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitSentences(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCutSentences(t *testing.T) {
+	tests := []struct {
+		text      string
+		sentences []string
+		rest      string
+	}{
+		{"", nil, ""},
+		{"你好", nil, "你好"},
+		// 句末标点后还没有新字符，可能还有连续标点，暂不切出
+		{"你好！", nil, "你好！"},
+		{"你好！今天", []string{"你好！"}, "今天"},
+		{"真的吗？！那太", []string{"真的吗？！"}, "那太"},
+		{"第一句。第二句。第三", []string{"第一句。", "第二句。"}, "第三"},
+	}
+	for _, tt := range tests {
+		sentences, rest := cutSentences(tt.text)
+		if !reflect.DeepEqual(sentences, tt.sentences) || rest != tt.rest {
+			t.Errorf("cutSentences(%q) = %q, %q, want %q, %q", tt.text, sentences, rest, tt.sentences, tt.rest)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package webrtc
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 浏览器断线期间最多暂存的信令消息数，超出后丢弃最早的消息
+const maxPendingMessages = 256
+
+var ErrCallClosed = errors.New("call already closed")
+
+// SessionManager 维护本节点上进行中的通话，供断线重连时按 callId 找回
+type SessionManager struct {
+	mu       sync.RWMutex
+	sessions map[string]*SignalingClient
+}
+
+func NewSessionManager() *SessionManager {
+	return &SessionManager{
+		sessions: make(map[string]*SignalingClient),
+	}
+}
+
+// Add 登记通话，通话结束时自动移除
+func (m *SessionManager) Add(client *SignalingClient) {
+	callId := client.CallID()
+	client.onClose = func() {
+		m.Remove(callId)
+	}
+
+	m.mu.Lock()
+	m.sessions[callId] = client
+	m.mu.Unlock()
+}
+
+func (m *SessionManager) Get(callId string) (*SignalingClient, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	client, ok := m.sessions[callId]
+	return client, ok
+}
+
+func (m *SessionManager) Remove(callId string) {
+	m.mu.Lock()
+	delete(m.sessions, callId)
+	m.mu.Unlock()
+}
+
+// Attach 绑定新的浏览器信令连接，回复 reconnect 后补发断线期间暂存的消息
+func (s *SignalingClient) Attach(conn *websocket.Conn) error {
+	if s.ctx.Err() != nil {
+		return ErrCallClosed
+	}
+
+	ack, err := json.Marshal(WebRTCMessage{
+		Type:   WEBRTC_SIGNALING_RECONNECT,
+		CallID: s.callId,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	s.outConn = conn
+	if err := conn.WriteMessage(websocket.TextMessage, ack); err != nil {
+		return err
+	}
+	for _, data := range s.pending {
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			return err
+		}
+	}
+	s.pending = nil
+	return nil
+}
+
+// Detach 解绑浏览器信令连接，grace 时间内未重连则结束通话
+func (s *SignalingClient) Detach(conn *websocket.Conn, grace time.Duration) {
+	s.outMu.Lock()
+	if s.outConn != conn {
+		// 已被新的连接接管
+		s.outMu.Unlock()
+		return
+	}
+	s.outConn = nil
+	s.outMu.Unlock()
+
+	if grace <= 0 {
+		s.Hangup(HANGUP_REASON_RECONNECT_TIMEOUT)
+		return
+	}
+
+	time.AfterFunc(grace, func() {
+		s.outMu.Lock()
+		detached := s.outConn == nil
+		s.outMu.Unlock()
+		if detached && s.ctx.Err() == nil {
+			s.logx.Infof("signaling not reconnected within %s, hangup callId: %s", grace, s.callId)
+			s.Hangup(HANGUP_REASON_RECONNECT_TIMEOUT)
+		}
+	})
+}
+
+// AddIceCandidate 将浏览器的 trickle ICE 候选转发给媒体后端
+func (s *SignalingClient) AddIceCandidate(candidate string) error {
+	if candidate == "" {
+		return nil
+	}
+	return s.backend.AddCandidate(candidate)
+}
+
+// Renegotiate 使用新的 offer 重新协商媒体连接（如 ICE restart）
+func (s *SignalingClient) Renegotiate(offer string) error {
+	if s.ctx.Err() != nil {
+		return ErrCallClosed
+	}
+	if err := s.backend.Renegotiate(offer); err != nil {
+		return err
+	}
+
+	s.logx.Infof("renegotiated media session, callId: %s", s.callId)
+	return nil
+}
+
+// writeOut 发送消息给浏览器，浏览器断线期间暂存
+func (s *SignalingClient) writeOut(msg WebRTCMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	if s.outConn == nil {
+		if len(s.pending) >= maxPendingMessages {
+			s.pending = s.pending[1:]
+		}
+		s.pending = append(s.pending, data)
+		return nil
+	}
+	return s.outConn.WriteMessage(websocket.TextMessage, data)
+}
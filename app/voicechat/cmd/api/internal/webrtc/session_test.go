@@ -0,0 +1,184 @@
+package webrtc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+
+	"github.com/gorilla/websocket"
+)
+
+func newTestSession(t *testing.T) (*SignalingClient, *websocket.Conn, *websocket.Conn) {
+	t.Helper()
+	out, browser := newBrowserConn(t)
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: fakeLlmService{},
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		OutConn:    out,
+		Backend:    NewFakeBackend(nil),
+		Option:     &CallOptions{Offer: "offer-sdp"},
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Hangup(HANGUP_REASON_CLIENT) })
+	return client, out, browser
+}
+
+func readSignal(t *testing.T, conn *websocket.Conn) WebRTCMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	var msg WebRTCMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	return msg
+}
+
+// pendingMessages 在锁内读取断线期间暂存的消息
+func pendingMessages(client *SignalingClient) []WebRTCMessage {
+	client.outMu.Lock()
+	defer client.outMu.Unlock()
+	msgs := make([]WebRTCMessage, 0, len(client.pending))
+	for _, data := range client.pending {
+		var msg WebRTCMessage
+		json.Unmarshal(data, &msg)
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func TestSessionDetachBuffersAndAttachReplays(t *testing.T) {
+	client, out, _ := newTestSession(t)
+
+	client.Detach(out, time.Minute)
+	for _, text := range []string{"第一句", "第二句"} {
+		if err := client.Send(WebRTCMessage{Type: LLM_ASSISTANT_MESSAGE_ROLE, Text: text}); err != nil {
+			t.Fatalf("send while detached failed: %v", err)
+		}
+	}
+
+	out2, browser2 := newBrowserConn(t)
+	if err := client.Attach(out2); err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+
+	if msg := readSignal(t, browser2); msg.Type != WEBRTC_SIGNALING_RECONNECT || msg.CallID != client.CallID() {
+		t.Fatalf("first message = %+v, want reconnect ack", msg)
+	}
+	for _, want := range []string{"第一句", "第二句"} {
+		if msg := readSignal(t, browser2); msg.Text != want {
+			t.Errorf("replayed text = %q, want %q", msg.Text, want)
+		}
+	}
+	if pending := pendingMessages(client); len(pending) != 0 {
+		t.Errorf("pending not cleared after attach: %d", len(pending))
+	}
+
+	// 重连后消息直接发送
+	if err := client.Send(WebRTCMessage{Type: LLM_ASSISTANT_MESSAGE_ROLE, Text: "第三句"}); err != nil {
+		t.Fatalf("send after attach failed: %v", err)
+	}
+	if msg := readSignal(t, browser2); msg.Text != "第三句" {
+		t.Errorf("text after attach = %q, want 第三句", msg.Text)
+	}
+}
+
+func TestSessionPendingDropsOldest(t *testing.T) {
+	client, out, _ := newTestSession(t)
+	client.Detach(out, time.Minute)
+
+	for i := 0; i < maxPendingMessages+3; i++ {
+		client.Send(WebRTCMessage{Type: WEBRTC_SIGNALING_DEBUG, Text: string(rune('a' + i%26))})
+	}
+	pending := pendingMessages(client)
+	if len(pending) != maxPendingMessages {
+		t.Fatalf("pending = %d, want %d", len(pending), maxPendingMessages)
+	}
+	if pending[0].Text != "d" {
+		t.Errorf("oldest pending text = %q, want d", pending[0].Text)
+	}
+}
+
+func TestSessionDetachStaleConnIgnored(t *testing.T) {
+	client, out, _ := newTestSession(t)
+	out2, _ := newBrowserConn(t)
+	if err := client.Attach(out2); err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+
+	// 旧连接的断开不影响已接管的新连接
+	client.Detach(out, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	if client.ctx.Err() != nil {
+		t.Fatal("call closed after stale connection detached")
+	}
+	client.outMu.Lock()
+	current := client.outConn
+	client.outMu.Unlock()
+	if current != out2 {
+		t.Error("stale detach replaced the current connection")
+	}
+}
+
+func TestSessionGraceExpiryHangsUp(t *testing.T) {
+	client, out, _ := newTestSession(t)
+	closed := make(chan struct{})
+	client.onClose = func() { close(closed) }
+
+	client.Detach(out, 20*time.Millisecond)
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("call not hung up after grace period")
+	}
+
+	// 断线期间挂断，hangup 消息暂存等待重连
+	pending := pendingMessages(client)
+	if len(pending) == 0 {
+		t.Fatal("hangup message not buffered")
+	}
+	hangup := pending[len(pending)-1]
+	if hangup.Type != WEBRTC_SIGNALING_HANGUP || hangup.Text != HANGUP_REASON_RECONNECT_TIMEOUT {
+		t.Errorf("hangup = %+v, want reason %s", hangup, HANGUP_REASON_RECONNECT_TIMEOUT)
+	}
+
+	out2, _ := newBrowserConn(t)
+	if err := client.Attach(out2); !errors.Is(err, ErrCallClosed) {
+		t.Errorf("Attach after hangup = %v, want ErrCallClosed", err)
+	}
+}
+
+func TestSessionReattachWithinGraceKeepsCall(t *testing.T) {
+	client, out, _ := newTestSession(t)
+
+	client.Detach(out, 30*time.Millisecond)
+	out2, _ := newBrowserConn(t)
+	if err := client.Attach(out2); err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if client.ctx.Err() != nil {
+		t.Fatal("call hung up although reconnected within grace period")
+	}
+}
+
+func TestSessionDetachWithoutGraceHangsUpImmediately(t *testing.T) {
+	client, out, _ := newTestSession(t)
+
+	client.Detach(out, 0)
+	if client.ctx.Err() == nil {
+		t.Fatal("call not hung up when grace period is disabled")
+	}
+}
@@ -0,0 +1,97 @@
+package webrtc
+
+import (
+	"strings"
+	"sync"
+)
+
+// TextBackend 文本模拟媒体后端：输入的文字代替 ASR 识别结果，
+// 需要播报的文本通过 onSpeak 回调交给调用方，用于不依赖浏览器和 PBX 的对话测试
+type TextBackend struct {
+	mu        sync.Mutex
+	closed    bool
+	events    chan EventMessage
+	onSpeak   func(text string, sentences []string)
+	reply     strings.Builder // 分段播报中尚未结束的回复
+	sentences []string
+}
+
+func NewTextBackend(onSpeak func(text string, sentences []string)) *TextBackend {
+	return &TextBackend{
+		events:  make(chan EventMessage, 1024),
+		onSpeak: onSpeak,
+	}
+}
+
+// Input 输入一句文字，相当于用户说完一句话
+func (b *TextBackend) Input(text string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrCallClosed
+	}
+	b.events <- EventMessage{Event: WS_CALLBACK_EVENT_TYPE_ASRFINAL, Text: text}
+	return nil
+}
+
+func (b *TextBackend) Invite(option *CallOptions) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events <- EventMessage{Event: WS_CALLBACK_EVENT_TYPE_ANSWER}
+	return nil
+}
+
+func (b *TextBackend) Renegotiate(offer string) error {
+	return nil
+}
+
+func (b *TextBackend) AddCandidate(candidate string) error {
+	return nil
+}
+
+// Speak 不做语音合成，一轮回复的最后一段到达后回调完整文本与分句，并视为立即播放完成
+func (b *TextBackend) Speak(text string, end bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrCallClosed
+	}
+
+	b.reply.WriteString(text)
+	b.sentences = append(b.sentences, splitSentences(text)...)
+	if !end {
+		return nil
+	}
+	reply, sentences := b.reply.String(), b.sentences
+	b.reply.Reset()
+	b.sentences = nil
+
+	if b.onSpeak != nil {
+		b.onSpeak(reply, sentences)
+	}
+	b.events <- EventMessage{Event: WS_CALLBACK_EVENT_TYPE_TRACK_START}
+	b.events <- EventMessage{Event: WS_CALLBACK_EVENT_TYPE_TRACK_END}
+	return nil
+}
+
+func (b *TextBackend) Interrupt() error {
+	return nil
+}
+
+func (b *TextBackend) Hangup(reason string) error {
+	return b.Close()
+}
+
+func (b *TextBackend) Events() <-chan EventMessage {
+	return b.events
+}
+
+func (b *TextBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.events)
+	}
+	return nil
+}
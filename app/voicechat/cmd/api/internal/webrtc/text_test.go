@@ -0,0 +1,133 @@
+package webrtc
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+)
+
+type spokenReply struct {
+	text      string
+	sentences []string
+}
+
+// streamingReplyLlm 把一句回复拆成多个流式分片返回，分片边界与句子边界不对齐
+var streamingReplyLlm = fakeLlmService{reply: func(in *llmchatservice.ChatStreamReq) []*llmchatservice.ChatMsg {
+	return []*llmchatservice.ChatMsg{{Content: "你好！今天"}, {Content: "天气不错。要出"}, {Content: "去玩吗？"}}
+}}
+
+func newTextClient(t *testing.T, backend MediaBackend) *SignalingClient {
+	t.Helper()
+	client, err := NewSignalingClient(SignalingClientParams{
+		Ctx:        context.Background(),
+		LlmService: streamingReplyLlm,
+		LlmConfig:  &llmchatservice.LlmConfig{Model: "fake-model"},
+		UserID:     1,
+		Backend:    backend,
+		Option: &CallOptions{
+			Asr: &AsrConfig{Provider: MEDIA_BACKEND_TEXT},
+			Tts: &TtsConfig{Provider: MEDIA_BACKEND_TEXT},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSignalingClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Hangup(HANGUP_REASON_CLIENT) })
+	go client.Listen("")
+	go client.HandleEvtMsg()
+	return client
+}
+
+func TestTextBackendSimulateFlow(t *testing.T) {
+	replies := make(chan spokenReply, 4)
+	backend := NewTextBackend(func(text string, sentences []string) {
+		replies <- spokenReply{text: text, sentences: sentences}
+	})
+	client := newTextClient(t, backend)
+
+	next := func() spokenReply {
+		t.Helper()
+		select {
+		case reply := <-replies:
+			return reply
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for speak")
+			return spokenReply{}
+		}
+	}
+
+	welcome := next()
+	if want := []string{"嗯？", "你好啊，我是你的个人语音助手"}; !reflect.DeepEqual(welcome.sentences, want) {
+		t.Errorf("welcome sentences = %q, want %q", welcome.sentences, want)
+	}
+
+	if err := backend.Input("在吗"); err != nil {
+		t.Fatalf("Input failed: %v", err)
+	}
+	reply := next()
+	if reply.text != "你好！今天天气不错。要出去玩吗？" {
+		t.Errorf("reply text = %q", reply.text)
+	}
+	if want := []string{"你好！", "今天天气不错。", "要出去玩吗？"}; !reflect.DeepEqual(reply.sentences, want) {
+		t.Errorf("reply sentences = %q, want %q", reply.sentences, want)
+	}
+
+	// 整轮回复只回调一次，不会按分片重复播报
+	select {
+	case extra := <-replies:
+		t.Errorf("unexpected extra speak: %+v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	client.Hangup(HANGUP_REASON_CLIENT)
+	if err := backend.Input("还在吗"); err != ErrCallClosed {
+		t.Errorf("Input after hangup = %v, want ErrCallClosed", err)
+	}
+}
+
+// segmentBackend 记录每次 Speak 收到的分段
+type segmentBackend struct {
+	*TextBackend
+	mu       sync.Mutex
+	segments []string
+	ends     []bool
+}
+
+func (b *segmentBackend) Speak(text string, end bool) error {
+	b.mu.Lock()
+	b.segments = append(b.segments, text)
+	b.ends = append(b.ends, end)
+	b.mu.Unlock()
+	return b.TextBackend.Speak(text, end)
+}
+
+func TestSignalingClientStreamsSentencesToTTS(t *testing.T) {
+	replied := make(chan string, 4)
+	backend := &segmentBackend{TextBackend: NewTextBackend(func(text string, sentences []string) {
+		replied <- text
+	})}
+	newTextClient(t, backend)
+
+	<-replied // 欢迎语
+	backend.Input("在吗")
+	select {
+	case <-replied:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for reply")
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	// 第一段是欢迎语，之后是逐句送出的回复
+	segments, ends := backend.segments[1:], backend.ends[1:]
+	if want := []string{"你好！", "今天天气不错。", "要出去玩吗？"}; !reflect.DeepEqual(segments, want) {
+		t.Errorf("segments = %q, want %q", segments, want)
+	}
+	if want := []bool{false, false, true}; !reflect.DeepEqual(ends, want) {
+		t.Errorf("ends = %v, want %v", ends, want)
+	}
+}
@@ -0,0 +1,199 @@
+package webrtc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+)
+
+const (
+	confirmRetryText = "抱歉，我没有听清，请回答“是”或者“不是”"
+	endCallResult    = "Call ended by client."
+
+	// 挂断前补齐 end_call 结果的最长等待时间
+	recordToolResultTimeout = 5 * time.Second
+)
+
+var (
+	// 反问句式（“可不可以”“好不好”）既不是同意也不是拒绝，匹配前先去掉
+	confirmNeutralPhrases = []string{"可不可以", "好不好", "行不行", "是不是", "对不对", "要不要", "能不能"}
+	// 含否定字但表示同意的说法，先于否定词匹配，避免“不错”“没问题”被识别为拒绝
+	confirmAcceptPhrases = []string{"不错", "没问题", "没有问题", "没什么问题", "不要紧", "没关系"}
+	// 否定说法优先于同意词匹配，避免“不可以”被识别为“可以”
+	confirmRejectWords = []string{"不要", "不用", "不行", "不可以", "不是", "不好", "不对", "不需要", "不执行", "不了", "别", "取消", "算了", "否"}
+	confirmAcceptWords = []string{"是", "好", "可以", "确认", "确定", "行", "对", "嗯", "执行"}
+	// 整句只有一个否定字时视为拒绝
+	confirmRejectReplies = []string{"不", "没有"}
+	confirmTrimReplacer  = strings.NewReplacer("，", "", "。", "", "！", "", "？", "", ",", "", ".", "", "!", "", "?", "", " ", "", "吧", "", "啊", "", "呀", "", "的", "")
+)
+
+// SubmitToolResults 提交浏览器执行客户端工具后的结果，交给 HandleEvtMsg 协程继续对话
+func (s *SignalingClient) SubmitToolResults(toolCalls []*llmchatservice.ToolCall) error {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+	select {
+	case s.toolResultChan <- toolCalls:
+		return nil
+	case <-s.ctx.Done():
+		return ErrCallClosed
+	}
+}
+
+// splitToolCalls 按处理方式拆分模型返回的工具调用
+func splitToolCalls(toolCalls []*llmchatservice.ToolCall) (endCalls, confirmCalls, clientCalls []*llmchatservice.ToolCall) {
+	for _, toolCall := range toolCalls {
+		switch {
+		case toolCall.GetInfo().GetName() == chatconsts.TOOL_CALLING_END_CALL:
+			endCalls = append(endCalls, toolCall)
+		case toolCall.GetStatus() == chatconsts.TOOL_CALLING_WAITING_CONFIRMATION:
+			confirmCalls = append(confirmCalls, toolCall)
+		case toolCall.GetInfo().GetScope() == chatconsts.TOOL_CALLING_SCOPE_CLIENT:
+			clientCalls = append(clientCalls, toolCall)
+		}
+	}
+	return
+}
+
+// confirmQuestion 生成播报给用户的确认问题
+func confirmQuestion(toolCalls []*llmchatservice.ToolCall) string {
+	names := make([]string, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		names = append(names, toolCall.GetInfo().GetName())
+	}
+	return "我需要调用" + strings.Join(names, "、") + "，可以吗？"
+}
+
+// parseConfirmation 从识别文本中判断用户是否同意，无法判断时返回空字符串
+func parseConfirmation(text string) string {
+	text = confirmTrimReplacer.Replace(text)
+	if slices.Contains(confirmRejectReplies, text) {
+		return chatconsts.TOOL_CALLING_REJECTED
+	}
+
+	for _, phrase := range confirmNeutralPhrases {
+		text = strings.ReplaceAll(text, phrase, "|")
+	}
+	accepted := false
+	for _, phrase := range confirmAcceptPhrases {
+		if strings.Contains(text, phrase) {
+			accepted = true
+			text = strings.ReplaceAll(text, phrase, "|")
+		}
+	}
+
+	for _, word := range confirmRejectWords {
+		if strings.Contains(text, word) {
+			return chatconsts.TOOL_CALLING_REJECTED
+		}
+	}
+	if accepted {
+		return chatconsts.TOOL_CALLING_CONFIRMED
+	}
+	for _, word := range confirmAcceptWords {
+		if strings.Contains(text, word) {
+			return chatconsts.TOOL_CALLING_CONFIRMED
+		}
+	}
+	return ""
+}
+
+// handleConfirmReply 处理用户对工具调用的语音确认，并把结果交回 LLM
+func (s *SignalingClient) handleConfirmReply(text string) {
+	decision := parseConfirmation(text)
+	if decision == "" {
+		s.currentTurn = nil
+		if err := s.sendTTSMessage(confirmRetryText); err != nil {
+			s.logx.Errorf("Failed to send confirm retry message: %v", err)
+		}
+		return
+	}
+
+	toolCalls := s.pendingConfirm
+	s.pendingConfirm = nil
+	for _, toolCall := range toolCalls {
+		toolCall.Status = decision
+	}
+	s.logx.Infof("User replied %s for %d tool calls, callId: %s", decision, len(toolCalls), s.callId)
+
+	s.chat(toolResultMsgs(toolCalls))
+}
+
+// handleToolResults 将浏览器回传的客户端工具执行结果交回 LLM
+func (s *SignalingClient) handleToolResults(toolCalls []*llmchatservice.ToolCall) {
+	for _, toolCall := range toolCalls {
+		if toolCall.GetStatus() == "" {
+			toolCall.Status = chatconsts.TOOL_CALLING_FINISHED
+		}
+	}
+	s.logx.Infof("Received %d client tool results, callId: %s", len(toolCalls), s.callId)
+
+	s.chat(toolResultMsgs(toolCalls))
+}
+
+// toolResultMsgs 为每个工具调用生成一条 tool 消息，保证历史中每个 tool_call id 都有对应结果
+func toolResultMsgs(toolCalls []*llmchatservice.ToolCall) []*llmchatservice.ChatMsg {
+	msgs := make([]*llmchatservice.ChatMsg, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		msgs = append(msgs, &llmchatservice.ChatMsg{
+			Role:       chatconsts.ChatMessageRoleTool,
+			ToolCalls:  []*llmchatservice.ToolCall{toolCall},
+			ToolCallId: toolCall.GetInfo().GetId(),
+		})
+	}
+	return msgs
+}
+
+// hangupEndCall 将 end_call 标记为已完成并写入对话历史后挂断，
+// 否则历史中留下没有 tool 结果的 tool_calls，同一会话的下一轮请求会被模型服务拒绝
+func (s *SignalingClient) hangupEndCall() {
+	endCalls := s.endCalls
+	s.endCalls = nil
+	for _, toolCall := range endCalls {
+		toolCall.Status = chatconsts.TOOL_CALLING_FINISHED
+		toolCall.Result = endCallResult
+	}
+	if err := s.recordToolResults(endCalls); err != nil {
+		s.logx.Errorf("Failed to record end_call result, callId: %s, err: %v", s.callId, err)
+	}
+	s.Hangup(HANGUP_REASON_END_CALL)
+}
+
+// recordToolResults 只把工具调用结果写入对话历史，不触发新一轮回复
+func (s *SignalingClient) recordToolResults(toolCalls []*llmchatservice.ToolCall) error {
+	if len(toolCalls) == 0 || s.LlmConversationID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, recordToolResultTimeout)
+	defer cancel()
+	stream, err := s.LlmChatServiceRpc.ChatStream(ctx, &llmchatservice.ChatStreamReq{
+		UserId:         s.userId,
+		ConversationId: s.LlmConversationID,
+		LlmConfig: &llmchatservice.LlmConfig{
+			BaseUrl: s.LlmConfig.GetBaseUrl(),
+			ApiKey:  s.LlmConfig.GetApiKey(),
+			Model:   s.LlmConfig.GetModel(),
+		},
+		Messages:        toolResultMsgs(toolCalls),
+		AutoFillHistory: true,
+		RecordOnly:      true,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
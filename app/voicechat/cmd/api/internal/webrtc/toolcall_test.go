@@ -0,0 +1,65 @@
+package webrtc
+
+import (
+	"testing"
+
+	"go-zero-voice-agent/app/llm/cmd/rpc/client/llmchatservice"
+	chatconsts "go-zero-voice-agent/app/llm/pkg/consts"
+)
+
+func TestParseConfirmation(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"好的", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"嗯，可以", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"确认执行", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"没问题", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"没有问题，查吧", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"不错，执行吧", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"是的。", chatconsts.TOOL_CALLING_CONFIRMED},
+		{"不", chatconsts.TOOL_CALLING_REJECTED},
+		{"不。", chatconsts.TOOL_CALLING_REJECTED},
+		{"没有", chatconsts.TOOL_CALLING_REJECTED},
+		{"不要", chatconsts.TOOL_CALLING_REJECTED},
+		{"不可以", chatconsts.TOOL_CALLING_REJECTED},
+		{"不用了，谢谢", chatconsts.TOOL_CALLING_REJECTED},
+		{"算了吧", chatconsts.TOOL_CALLING_REJECTED},
+		{"取消", chatconsts.TOOL_CALLING_REJECTED},
+		{"别查了", chatconsts.TOOL_CALLING_REJECTED},
+		{"不错，但还是算了", chatconsts.TOOL_CALLING_REJECTED},
+		// 反问句无法判断，需要重新询问
+		{"可不可以", ""},
+		{"好不好", ""},
+		{"能不能先说下汇率", ""},
+		{"今天天气怎么样", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := parseConfirmation(tt.text); got != tt.want {
+			t.Errorf("parseConfirmation(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestToolResultMsgsOnePerCall(t *testing.T) {
+	toolCalls := []*llmchatservice.ToolCall{
+		{Info: &llmchatservice.ToolCallInfo{Id: "call-1", Name: "currency_exchange"}, Status: chatconsts.TOOL_CALLING_CONFIRMED},
+		{Info: &llmchatservice.ToolCallInfo{Id: "call-2", Name: "weather"}, Status: chatconsts.TOOL_CALLING_REJECTED},
+	}
+
+	msgs := toolResultMsgs(toolCalls)
+	if len(msgs) != len(toolCalls) {
+		t.Fatalf("got %d tool messages, want %d", len(msgs), len(toolCalls))
+	}
+	for i, msg := range msgs {
+		id := toolCalls[i].GetInfo().GetId()
+		if msg.Role != chatconsts.ChatMessageRoleTool || msg.ToolCallId != id {
+			t.Errorf("msgs[%d] = %+v, want tool result for %s", i, msg, id)
+		}
+		if len(msg.ToolCalls) != 1 || msg.ToolCalls[0] != toolCalls[i] {
+			t.Errorf("msgs[%d] carries %d tool calls, want only %s", i, len(msg.ToolCalls), id)
+		}
+	}
+}
@@ -14,29 +14,34 @@ import (
 )
 
 type (
-	AsrConfig               = voicechatpb.AsrConfig
-	CreateAsrConfigRequest  = voicechatpb.CreateAsrConfigRequest
-	CreateAsrConfigResponse = voicechatpb.CreateAsrConfigResponse
-	CreateTtsConfigRequest  = voicechatpb.CreateTtsConfigRequest
-	CreateTtsConfigResponse = voicechatpb.CreateTtsConfigResponse
-	DeleteAsrConfigRequest  = voicechatpb.DeleteAsrConfigRequest
-	DeleteAsrConfigResponse = voicechatpb.DeleteAsrConfigResponse
-	DeleteTtsConfigRequest  = voicechatpb.DeleteTtsConfigRequest
-	DeleteTtsConfigResponse = voicechatpb.DeleteTtsConfigResponse
-	GetAsrConfigRequest     = voicechatpb.GetAsrConfigRequest
-	GetAsrConfigResponse    = voicechatpb.GetAsrConfigResponse
-	GetTtsConfigRequest     = voicechatpb.GetTtsConfigRequest
-	GetTtsConfigResponse    = voicechatpb.GetTtsConfigResponse
-	ListAsrConfigRequest    = voicechatpb.ListAsrConfigRequest
-	ListAsrConfigResponse   = voicechatpb.ListAsrConfigResponse
-	ListTtsConfigRequest    = voicechatpb.ListTtsConfigRequest
-	ListTtsConfigResponse   = voicechatpb.ListTtsConfigResponse
-	PageQuery               = voicechatpb.PageQuery
-	TtsConfig               = voicechatpb.TtsConfig
-	UpdateAsrConfigRequest  = voicechatpb.UpdateAsrConfigRequest
-	UpdateAsrConfigResponse = voicechatpb.UpdateAsrConfigResponse
-	UpdateTtsConfigRequest  = voicechatpb.UpdateTtsConfigRequest
-	UpdateTtsConfigResponse = voicechatpb.UpdateTtsConfigResponse
+	AsrConfig                = voicechatpb.AsrConfig
+	CreateAsrConfigRequest   = voicechatpb.CreateAsrConfigRequest
+	CreateAsrConfigResponse  = voicechatpb.CreateAsrConfigResponse
+	CreateTtsConfigRequest   = voicechatpb.CreateTtsConfigRequest
+	CreateTtsConfigResponse  = voicechatpb.CreateTtsConfigResponse
+	CreateTurnMetricRequest  = voicechatpb.CreateTurnMetricRequest
+	CreateTurnMetricResponse = voicechatpb.CreateTurnMetricResponse
+	DeleteAsrConfigRequest   = voicechatpb.DeleteAsrConfigRequest
+	DeleteAsrConfigResponse  = voicechatpb.DeleteAsrConfigResponse
+	DeleteTtsConfigRequest   = voicechatpb.DeleteTtsConfigRequest
+	DeleteTtsConfigResponse  = voicechatpb.DeleteTtsConfigResponse
+	GetAsrConfigRequest      = voicechatpb.GetAsrConfigRequest
+	GetAsrConfigResponse     = voicechatpb.GetAsrConfigResponse
+	GetTtsConfigRequest      = voicechatpb.GetTtsConfigRequest
+	GetTtsConfigResponse     = voicechatpb.GetTtsConfigResponse
+	ListAsrConfigRequest     = voicechatpb.ListAsrConfigRequest
+	ListAsrConfigResponse    = voicechatpb.ListAsrConfigResponse
+	ListTtsConfigRequest     = voicechatpb.ListTtsConfigRequest
+	ListTtsConfigResponse    = voicechatpb.ListTtsConfigResponse
+	ListTurnMetricRequest    = voicechatpb.ListTurnMetricRequest
+	ListTurnMetricResponse   = voicechatpb.ListTurnMetricResponse
+	PageQuery                = voicechatpb.PageQuery
+	TtsConfig                = voicechatpb.TtsConfig
+	TurnMetric               = voicechatpb.TurnMetric
+	UpdateAsrConfigRequest   = voicechatpb.UpdateAsrConfigRequest
+	UpdateAsrConfigResponse  = voicechatpb.UpdateAsrConfigResponse
+	UpdateTtsConfigRequest   = voicechatpb.UpdateTtsConfigRequest
+	UpdateTtsConfigResponse  = voicechatpb.UpdateTtsConfigResponse
 
 	AsrConfigService interface {
 		CreateAsrConfig(ctx context.Context, in *CreateAsrConfigRequest, opts ...grpc.CallOption) (*CreateAsrConfigResponse, error)
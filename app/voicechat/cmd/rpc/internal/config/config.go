@@ -1,6 +1,8 @@
 package config
 
 import (
+	"go-zero-voice-agent/pkg/secretbox"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -11,4 +13,6 @@ type Config struct {
 		DataSource string
 	}
 	Cache cache.CacheConf
+	// ASR/TTS 密钥加密的主密钥
+	SecretConfig secretbox.Config
 }
@@ -7,6 +7,7 @@ import (
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/voicechatpb"
 	"go-zero-voice-agent/app/voicechat/model"
+	"go-zero-voice-agent/pkg/secretbox"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -36,6 +37,15 @@ func (l *UpdateAsrConfigLogic) UpdateAsrConfig(in *voicechatpb.UpdateAsrConfigRe
 		Language:  sql.NullString{String: in.Config.Language, Valid: in.Config.Language != ""},
 	}
 
+	// 密钥只写不读：未传或传回脱敏值时沿用已保存的密钥
+	if secretbox.Unchanged(in.Config.SecretKey) {
+		old, err := l.svcCtx.AsrConfigModel.FindOne(l.ctx, in.Config.Id)
+		if err != nil {
+			return nil, err
+		}
+		data.SecretKey = old.SecretKey
+	}
+
 	_, err := l.svcCtx.AsrConfigModel.Update(l.ctx, nil, data)
 	if err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/voicechatpb"
 	"go-zero-voice-agent/app/voicechat/model"
+	"go-zero-voice-agent/pkg/secretbox"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -35,6 +36,15 @@ func (l *UpdateTtsConfigLogic) UpdateTtsConfig(in *voicechatpb.UpdateTtsConfigRe
 		SecretKey: sql.NullString{String: in.Config.SecretKey, Valid: in.Config.SecretKey != ""},
 	}
 
+	// 密钥只写不读：未传或传回脱敏值时沿用已保存的密钥
+	if secretbox.Unchanged(in.Config.SecretKey) {
+		old, err := l.svcCtx.TtsConfigModel.FindOne(l.ctx, in.Config.Id)
+		if err != nil {
+			return nil, err
+		}
+		data.SecretKey = old.SecretKey
+	}
+
 	_, err := l.svcCtx.TtsConfigModel.Update(l.ctx, nil, data)
 	if err != nil {
 		return nil, err
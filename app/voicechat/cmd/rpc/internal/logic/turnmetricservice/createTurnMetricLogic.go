@@ -0,0 +1,63 @@
+package turnmetricservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/voicechatpb"
+	"go-zero-voice-agent/app/voicechat/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CreateTurnMetricLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateTurnMetricLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateTurnMetricLogic {
+	return &CreateTurnMetricLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *CreateTurnMetricLogic) CreateTurnMetric(in *voicechatpb.CreateTurnMetricRequest) (*voicechatpb.CreateTurnMetricResponse, error) {
+	m := in.GetMetric()
+	if m == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing turn metric")
+	}
+
+	data := &model.TurnMetric{
+		UserId:          m.UserId,
+		CallId:          m.CallId,
+		ConversationId:  m.ConversationId,
+		TurnIndex:       m.TurnIndex,
+		AsrProvider:     m.AsrProvider,
+		TtsProvider:     m.TtsProvider,
+		LlmModel:        m.LlmModel,
+		AsrFinalAt:      m.AsrFinalAt,
+		LlmFirstTokenMs: m.LlmFirstTokenMs,
+		LlmDoneMs:       m.LlmDoneMs,
+		TtsFirstAudioMs: m.TtsFirstAudioMs,
+		TtsEndMs:        m.TtsEndMs,
+	}
+
+	res, err := l.svcCtx.TurnMetricModel.Insert(l.ctx, nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	lastId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &voicechatpb.CreateTurnMetricResponse{
+		Id: lastId,
+	}, nil
+}
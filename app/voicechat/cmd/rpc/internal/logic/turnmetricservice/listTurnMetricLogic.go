@@ -0,0 +1,78 @@
+package turnmetricservicelogic
+
+import (
+	"context"
+
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/svc"
+	"go-zero-voice-agent/app/voicechat/cmd/rpc/voicechatpb"
+	"go-zero-voice-agent/app/voicechat/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListTurnMetricLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListTurnMetricLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListTurnMetricLogic {
+	return &ListTurnMetricLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *ListTurnMetricLogic) ListTurnMetric(in *voicechatpb.ListTurnMetricRequest) (*voicechatpb.ListTurnMetricResponse, error) {
+	builder := l.svcCtx.TurnMetricModel.SelectBuilder()
+	if in.UserId != 0 {
+		builder = builder.Where("user_id = ?", in.UserId)
+	}
+	if in.CallId != "" {
+		builder = builder.Where("call_id = ?", in.CallId)
+	}
+	if in.ConversationId != "" {
+		builder = builder.Where("conversation_id = ?", in.ConversationId)
+	}
+
+	var metrics []*model.TurnMetric
+	var total int64
+	var err error
+
+	if in.Page != nil && in.Page.PageSize > 0 {
+		metrics, total, err = l.svcCtx.TurnMetricModel.FindPageListByPageWithTotal(l.ctx, builder, in.Page.Page, in.Page.PageSize, in.Page.OrderBy)
+	} else {
+		metrics, err = l.svcCtx.TurnMetricModel.FindAll(l.ctx, builder, "turn_index ASC")
+		if err == nil {
+			total = int64(len(metrics))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	respList := make([]*voicechatpb.TurnMetric, 0, len(metrics))
+	for _, m := range metrics {
+		respList = append(respList, &voicechatpb.TurnMetric{
+			Id:              m.Id,
+			UserId:          m.UserId,
+			CallId:          m.CallId,
+			ConversationId:  m.ConversationId,
+			TurnIndex:       m.TurnIndex,
+			AsrProvider:     m.AsrProvider,
+			TtsProvider:     m.TtsProvider,
+			LlmModel:        m.LlmModel,
+			AsrFinalAt:      m.AsrFinalAt,
+			LlmFirstTokenMs: m.LlmFirstTokenMs,
+			LlmDoneMs:       m.LlmDoneMs,
+			TtsFirstAudioMs: m.TtsFirstAudioMs,
+			TtsEndMs:        m.TtsEndMs,
+		})
+	}
+
+	return &voicechatpb.ListTurnMetricResponse{
+		Metrics: respList,
+		Total:   total,
+	}, nil
+}
@@ -3,6 +3,7 @@ package svc
 import (
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/config"
 	"go-zero-voice-agent/app/voicechat/model"
+	"go-zero-voice-agent/pkg/secretbox"
 
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
@@ -14,10 +15,12 @@ type ServiceContext struct {
 
 	AsrConfigModel model.AsrConfigModel
 	TtsConfigModel model.TtsConfigModel
+	TurnMetricModel model.TurnMetricModel
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	sqlConn := sqlx.NewMysql(c.DB.DataSource)
+	secrets := secretbox.MustNewKeyring(c.SecretConfig)
 
 	return &ServiceContext{
 		Config: c,
@@ -25,7 +28,8 @@ func NewServiceContext(c config.Config) *ServiceContext {
 			r.Type = c.Redis.Type
 			r.Pass = c.Redis.Pass
 		}),
-		AsrConfigModel: model.NewAsrConfigModel(sqlConn, c.Cache),
-		TtsConfigModel: model.NewTtsConfigModel(sqlConn, c.Cache),
+		AsrConfigModel: model.NewAsrConfigModel(sqlConn, c.Cache, secrets),
+		TtsConfigModel: model.NewTtsConfigModel(sqlConn, c.Cache, secrets),
+		TurnMetricModel: model.NewTurnMetricModel(sqlConn, c.Cache),
 	}
 }
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/config"
 	asrconfigserviceServer "go-zero-voice-agent/app/voicechat/cmd/rpc/internal/server/asrconfigservice"
 	ttsconfigserviceServer "go-zero-voice-agent/app/voicechat/cmd/rpc/internal/server/ttsconfigservice"
+	turnmetricserviceServer "go-zero-voice-agent/app/voicechat/cmd/rpc/internal/server/turnmetricservice"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/internal/svc"
 	"go-zero-voice-agent/app/voicechat/cmd/rpc/voicechatpb"
 
@@ -20,6 +22,7 @@ import (
 )
 
 var configFile = flag.String("f", "etc/voicechat.yaml", "the config file")
+var rotateSecrets = flag.Bool("rotate-secrets", false, "re-encrypt stored secrets with the current master key and exit")
 
 func main() {
 	flag.Parse()
@@ -32,9 +35,24 @@ func main() {
 	conf.MustLoad(*configFile, &c, conf.UseEnv())
 	ctx := svc.NewServiceContext(c)
 
+	if *rotateSecrets {
+		n, err := ctx.AsrConfigModel.RotateSecrets(context.Background())
+		if err != nil {
+			logx.Must(fmt.Errorf("rotate asr_config secrets failed after %d rows: %w", n, err))
+		}
+		fmt.Printf("rotated %d asr_config rows\n", n)
+		n, err = ctx.TtsConfigModel.RotateSecrets(context.Background())
+		if err != nil {
+			logx.Must(fmt.Errorf("rotate tts_config secrets failed after %d rows: %w", n, err))
+		}
+		fmt.Printf("rotated %d tts_config rows\n", n)
+		return
+	}
+
 	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		voicechatpb.RegisterAsrConfigServiceServer(grpcServer, asrconfigserviceServer.NewAsrConfigServiceServer(ctx))
 		voicechatpb.RegisterTtsConfigServiceServer(grpcServer, ttsconfigserviceServer.NewTtsConfigServiceServer(ctx))
+		voicechatpb.RegisterTurnMetricServiceServer(grpcServer, turnmetricserviceServer.NewTurnMetricServiceServer(ctx))
 
 		if c.Mode == service.DevMode || c.Mode == service.TestMode {
 			reflection.Register(grpcServer)
@@ -1247,6 +1247,356 @@ func (x *ListTtsConfigResponse) GetTotal() int64 {
 	return 0
 }
 
+// 语音对话单轮延迟指标，耗时均以 ASR final 为起点，单位毫秒
+type TurnMetric struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	UserId          int64                  `protobuf:"varint,2,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	CallId          string                 `protobuf:"bytes,3,opt,name=CallId,proto3" json:"CallId,omitempty"`
+	ConversationId  string                 `protobuf:"bytes,4,opt,name=ConversationId,proto3" json:"ConversationId,omitempty"`
+	TurnIndex       int64                  `protobuf:"varint,5,opt,name=TurnIndex,proto3" json:"TurnIndex,omitempty"`
+	AsrProvider     string                 `protobuf:"bytes,6,opt,name=AsrProvider,proto3" json:"AsrProvider,omitempty"`
+	TtsProvider     string                 `protobuf:"bytes,7,opt,name=TtsProvider,proto3" json:"TtsProvider,omitempty"`
+	LlmModel        string                 `protobuf:"bytes,8,opt,name=LlmModel,proto3" json:"LlmModel,omitempty"`
+	AsrFinalAt      int64                  `protobuf:"varint,9,opt,name=AsrFinalAt,proto3" json:"AsrFinalAt,omitempty"`            // ASR final 到达时间 (unix 毫秒)
+	LlmFirstTokenMs int64                  `protobuf:"varint,10,opt,name=LlmFirstTokenMs,proto3" json:"LlmFirstTokenMs,omitempty"` // LLM 首个 token
+	LlmDoneMs       int64                  `protobuf:"varint,11,opt,name=LlmDoneMs,proto3" json:"LlmDoneMs,omitempty"`             // LLM 输出完成
+	TtsFirstAudioMs int64                  `protobuf:"varint,12,opt,name=TtsFirstAudioMs,proto3" json:"TtsFirstAudioMs,omitempty"` // TTS 首帧音频 (trackStart)
+	TtsEndMs        int64                  `protobuf:"varint,13,opt,name=TtsEndMs,proto3" json:"TtsEndMs,omitempty"`               // TTS 播放结束 (trackEnd)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TurnMetric) Reset() {
+	*x = TurnMetric{}
+	mi := &file_voicechat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnMetric) ProtoMessage() {}
+
+func (x *TurnMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_voicechat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnMetric.ProtoReflect.Descriptor instead.
+func (*TurnMetric) Descriptor() ([]byte, []int) {
+	return file_voicechat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TurnMetric) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *TurnMetric) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *TurnMetric) GetTurnIndex() int64 {
+	if x != nil {
+		return x.TurnIndex
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetAsrProvider() string {
+	if x != nil {
+		return x.AsrProvider
+	}
+	return ""
+}
+
+func (x *TurnMetric) GetTtsProvider() string {
+	if x != nil {
+		return x.TtsProvider
+	}
+	return ""
+}
+
+func (x *TurnMetric) GetLlmModel() string {
+	if x != nil {
+		return x.LlmModel
+	}
+	return ""
+}
+
+func (x *TurnMetric) GetAsrFinalAt() int64 {
+	if x != nil {
+		return x.AsrFinalAt
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetLlmFirstTokenMs() int64 {
+	if x != nil {
+		return x.LlmFirstTokenMs
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetLlmDoneMs() int64 {
+	if x != nil {
+		return x.LlmDoneMs
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetTtsFirstAudioMs() int64 {
+	if x != nil {
+		return x.TtsFirstAudioMs
+	}
+	return 0
+}
+
+func (x *TurnMetric) GetTtsEndMs() int64 {
+	if x != nil {
+		return x.TtsEndMs
+	}
+	return 0
+}
+
+// TurnMetric Create & List
+type CreateTurnMetricRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        *TurnMetric            `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTurnMetricRequest) Reset() {
+	*x = CreateTurnMetricRequest{}
+	mi := &file_voicechat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTurnMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTurnMetricRequest) ProtoMessage() {}
+
+func (x *CreateTurnMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voicechat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTurnMetricRequest.ProtoReflect.Descriptor instead.
+func (*CreateTurnMetricRequest) Descriptor() ([]byte, []int) {
+	return file_voicechat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateTurnMetricRequest) GetMetric() *TurnMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return nil
+}
+
+type CreateTurnMetricResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTurnMetricResponse) Reset() {
+	*x = CreateTurnMetricResponse{}
+	mi := &file_voicechat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTurnMetricResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTurnMetricResponse) ProtoMessage() {}
+
+func (x *CreateTurnMetricResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voicechat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTurnMetricResponse.ProtoReflect.Descriptor instead.
+func (*CreateTurnMetricResponse) Descriptor() ([]byte, []int) {
+	return file_voicechat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateTurnMetricResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTurnMetricRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           *PageQuery             `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CallId         string                 `protobuf:"bytes,3,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,4,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListTurnMetricRequest) Reset() {
+	*x = ListTurnMetricRequest{}
+	mi := &file_voicechat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTurnMetricRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTurnMetricRequest) ProtoMessage() {}
+
+func (x *ListTurnMetricRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_voicechat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTurnMetricRequest.ProtoReflect.Descriptor instead.
+func (*ListTurnMetricRequest) Descriptor() ([]byte, []int) {
+	return file_voicechat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTurnMetricRequest) GetPage() *PageQuery {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListTurnMetricRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListTurnMetricRequest) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *ListTurnMetricRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ListTurnMetricResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metrics       []*TurnMetric          `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTurnMetricResponse) Reset() {
+	*x = ListTurnMetricResponse{}
+	mi := &file_voicechat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTurnMetricResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTurnMetricResponse) ProtoMessage() {}
+
+func (x *ListTurnMetricResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_voicechat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTurnMetricResponse.ProtoReflect.Descriptor instead.
+func (*ListTurnMetricResponse) Descriptor() ([]byte, []int) {
+	return file_voicechat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTurnMetricResponse) GetMetrics() []*TurnMetric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *ListTurnMetricResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 var File_voicechat_proto protoreflect.FileDescriptor
 
 const file_voicechat_proto_rawDesc = "" +
@@ -1323,6 +1673,36 @@ const file_voicechat_proto_rawDesc = "" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\"_\n" +
 	"\x15ListTtsConfigResponse\x120\n" +
 	"\aconfigs\x18\x01 \x03(\v2\x16.voicechatpb.TtsConfigR\aconfigs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xa0\x03\n" +
+	"\n" +
+	"TurnMetric\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12\x16\n" +
+	"\x06UserId\x18\x02 \x01(\x03R\x06UserId\x12\x16\n" +
+	"\x06CallId\x18\x03 \x01(\tR\x06CallId\x12&\n" +
+	"\x0eConversationId\x18\x04 \x01(\tR\x0eConversationId\x12\x1c\n" +
+	"\tTurnIndex\x18\x05 \x01(\x03R\tTurnIndex\x12 \n" +
+	"\vAsrProvider\x18\x06 \x01(\tR\vAsrProvider\x12 \n" +
+	"\vTtsProvider\x18\a \x01(\tR\vTtsProvider\x12\x1a\n" +
+	"\bLlmModel\x18\b \x01(\tR\bLlmModel\x12\x1e\n" +
+	"\n" +
+	"AsrFinalAt\x18\t \x01(\x03R\n" +
+	"AsrFinalAt\x12(\n" +
+	"\x0fLlmFirstTokenMs\x18\n" +
+	" \x01(\x03R\x0fLlmFirstTokenMs\x12\x1c\n" +
+	"\tLlmDoneMs\x18\v \x01(\x03R\tLlmDoneMs\x12(\n" +
+	"\x0fTtsFirstAudioMs\x18\f \x01(\x03R\x0fTtsFirstAudioMs\x12\x1a\n" +
+	"\bTtsEndMs\x18\r \x01(\x03R\bTtsEndMs\"J\n" +
+	"\x17CreateTurnMetricRequest\x12/\n" +
+	"\x06metric\x18\x01 \x01(\v2\x17.voicechatpb.TurnMetricR\x06metric\"*\n" +
+	"\x18CreateTurnMetricResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x9e\x01\n" +
+	"\x15ListTurnMetricRequest\x12*\n" +
+	"\x04page\x18\x01 \x01(\v2\x16.voicechatpb.PageQueryR\x04page\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x17\n" +
+	"\acall_id\x18\x03 \x01(\tR\x06callId\x12'\n" +
+	"\x0fconversation_id\x18\x04 \x01(\tR\x0econversationId\"a\n" +
+	"\x16ListTurnMetricResponse\x121\n" +
+	"\ametrics\x18\x01 \x03(\v2\x17.voicechatpb.TurnMetricR\ametrics\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total2\xd9\x03\n" +
 	"\x10AsrConfigService\x12\\\n" +
 	"\x0fCreateAsrConfig\x12#.voicechatpb.CreateAsrConfigRequest\x1a$.voicechatpb.CreateAsrConfigResponse\x12S\n" +
@@ -1335,7 +1715,10 @@ const file_voicechat_proto_rawDesc = "" +
 	"\fGetTtsConfig\x12 .voicechatpb.GetTtsConfigRequest\x1a!.voicechatpb.GetTtsConfigResponse\x12\\\n" +
 	"\x0fUpdateTtsConfig\x12#.voicechatpb.UpdateTtsConfigRequest\x1a$.voicechatpb.UpdateTtsConfigResponse\x12\\\n" +
 	"\x0fDeleteTtsConfig\x12#.voicechatpb.DeleteTtsConfigRequest\x1a$.voicechatpb.DeleteTtsConfigResponse\x12V\n" +
-	"\rListTtsConfig\x12!.voicechatpb.ListTtsConfigRequest\x1a\".voicechatpb.ListTtsConfigResponseB\x0fZ\r./voicechatpbb\x06proto3"
+	"\rListTtsConfig\x12!.voicechatpb.ListTtsConfigRequest\x1a\".voicechatpb.ListTtsConfigResponse2\xcf\x01\n" +
+	"\x11TurnMetricService\x12_\n" +
+	"\x10CreateTurnMetric\x12$.voicechatpb.CreateTurnMetricRequest\x1a%.voicechatpb.CreateTurnMetricResponse\x12Y\n" +
+	"\x0eListTurnMetric\x12\".voicechatpb.ListTurnMetricRequest\x1a#.voicechatpb.ListTurnMetricResponseB\x0fZ\r./voicechatpbb\x06proto3"
 
 var (
 	file_voicechat_proto_rawDescOnce sync.Once
@@ -1349,31 +1732,36 @@ func file_voicechat_proto_rawDescGZIP() []byte {
 	return file_voicechat_proto_rawDescData
 }
 
-var file_voicechat_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_voicechat_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_voicechat_proto_goTypes = []any{
-	(*PageQuery)(nil),               // 0: voicechatpb.PageQuery
-	(*AsrConfig)(nil),               // 1: voicechatpb.AsrConfig
-	(*TtsConfig)(nil),               // 2: voicechatpb.TtsConfig
-	(*CreateAsrConfigRequest)(nil),  // 3: voicechatpb.CreateAsrConfigRequest
-	(*CreateAsrConfigResponse)(nil), // 4: voicechatpb.CreateAsrConfigResponse
-	(*GetAsrConfigRequest)(nil),     // 5: voicechatpb.GetAsrConfigRequest
-	(*GetAsrConfigResponse)(nil),    // 6: voicechatpb.GetAsrConfigResponse
-	(*UpdateAsrConfigRequest)(nil),  // 7: voicechatpb.UpdateAsrConfigRequest
-	(*UpdateAsrConfigResponse)(nil), // 8: voicechatpb.UpdateAsrConfigResponse
-	(*DeleteAsrConfigRequest)(nil),  // 9: voicechatpb.DeleteAsrConfigRequest
-	(*DeleteAsrConfigResponse)(nil), // 10: voicechatpb.DeleteAsrConfigResponse
-	(*ListAsrConfigRequest)(nil),    // 11: voicechatpb.ListAsrConfigRequest
-	(*ListAsrConfigResponse)(nil),   // 12: voicechatpb.ListAsrConfigResponse
-	(*CreateTtsConfigRequest)(nil),  // 13: voicechatpb.CreateTtsConfigRequest
-	(*CreateTtsConfigResponse)(nil), // 14: voicechatpb.CreateTtsConfigResponse
-	(*GetTtsConfigRequest)(nil),     // 15: voicechatpb.GetTtsConfigRequest
-	(*GetTtsConfigResponse)(nil),    // 16: voicechatpb.GetTtsConfigResponse
-	(*UpdateTtsConfigRequest)(nil),  // 17: voicechatpb.UpdateTtsConfigRequest
-	(*UpdateTtsConfigResponse)(nil), // 18: voicechatpb.UpdateTtsConfigResponse
-	(*DeleteTtsConfigRequest)(nil),  // 19: voicechatpb.DeleteTtsConfigRequest
-	(*DeleteTtsConfigResponse)(nil), // 20: voicechatpb.DeleteTtsConfigResponse
-	(*ListTtsConfigRequest)(nil),    // 21: voicechatpb.ListTtsConfigRequest
-	(*ListTtsConfigResponse)(nil),   // 22: voicechatpb.ListTtsConfigResponse
+	(*PageQuery)(nil),                // 0: voicechatpb.PageQuery
+	(*AsrConfig)(nil),                // 1: voicechatpb.AsrConfig
+	(*TtsConfig)(nil),                // 2: voicechatpb.TtsConfig
+	(*CreateAsrConfigRequest)(nil),   // 3: voicechatpb.CreateAsrConfigRequest
+	(*CreateAsrConfigResponse)(nil),  // 4: voicechatpb.CreateAsrConfigResponse
+	(*GetAsrConfigRequest)(nil),      // 5: voicechatpb.GetAsrConfigRequest
+	(*GetAsrConfigResponse)(nil),     // 6: voicechatpb.GetAsrConfigResponse
+	(*UpdateAsrConfigRequest)(nil),   // 7: voicechatpb.UpdateAsrConfigRequest
+	(*UpdateAsrConfigResponse)(nil),  // 8: voicechatpb.UpdateAsrConfigResponse
+	(*DeleteAsrConfigRequest)(nil),   // 9: voicechatpb.DeleteAsrConfigRequest
+	(*DeleteAsrConfigResponse)(nil),  // 10: voicechatpb.DeleteAsrConfigResponse
+	(*ListAsrConfigRequest)(nil),     // 11: voicechatpb.ListAsrConfigRequest
+	(*ListAsrConfigResponse)(nil),    // 12: voicechatpb.ListAsrConfigResponse
+	(*CreateTtsConfigRequest)(nil),   // 13: voicechatpb.CreateTtsConfigRequest
+	(*CreateTtsConfigResponse)(nil),  // 14: voicechatpb.CreateTtsConfigResponse
+	(*GetTtsConfigRequest)(nil),      // 15: voicechatpb.GetTtsConfigRequest
+	(*GetTtsConfigResponse)(nil),     // 16: voicechatpb.GetTtsConfigResponse
+	(*UpdateTtsConfigRequest)(nil),   // 17: voicechatpb.UpdateTtsConfigRequest
+	(*UpdateTtsConfigResponse)(nil),  // 18: voicechatpb.UpdateTtsConfigResponse
+	(*DeleteTtsConfigRequest)(nil),   // 19: voicechatpb.DeleteTtsConfigRequest
+	(*DeleteTtsConfigResponse)(nil),  // 20: voicechatpb.DeleteTtsConfigResponse
+	(*ListTtsConfigRequest)(nil),     // 21: voicechatpb.ListTtsConfigRequest
+	(*ListTtsConfigResponse)(nil),    // 22: voicechatpb.ListTtsConfigResponse
+	(*TurnMetric)(nil),               // 23: voicechatpb.TurnMetric
+	(*CreateTurnMetricRequest)(nil),  // 24: voicechatpb.CreateTurnMetricRequest
+	(*CreateTurnMetricResponse)(nil), // 25: voicechatpb.CreateTurnMetricResponse
+	(*ListTurnMetricRequest)(nil),    // 26: voicechatpb.ListTurnMetricRequest
+	(*ListTurnMetricResponse)(nil),   // 27: voicechatpb.ListTurnMetricResponse
 }
 var file_voicechat_proto_depIdxs = []int32{
 	1,  // 0: voicechatpb.CreateAsrConfigResponse.config:type_name -> voicechatpb.AsrConfig
@@ -1388,31 +1776,38 @@ var file_voicechat_proto_depIdxs = []int32{
 	2,  // 9: voicechatpb.UpdateTtsConfigResponse.config:type_name -> voicechatpb.TtsConfig
 	0,  // 10: voicechatpb.ListTtsConfigRequest.page:type_name -> voicechatpb.PageQuery
 	2,  // 11: voicechatpb.ListTtsConfigResponse.configs:type_name -> voicechatpb.TtsConfig
-	3,  // 12: voicechatpb.AsrConfigService.CreateAsrConfig:input_type -> voicechatpb.CreateAsrConfigRequest
-	5,  // 13: voicechatpb.AsrConfigService.GetAsrConfig:input_type -> voicechatpb.GetAsrConfigRequest
-	7,  // 14: voicechatpb.AsrConfigService.UpdateAsrConfig:input_type -> voicechatpb.UpdateAsrConfigRequest
-	9,  // 15: voicechatpb.AsrConfigService.DeleteAsrConfig:input_type -> voicechatpb.DeleteAsrConfigRequest
-	11, // 16: voicechatpb.AsrConfigService.ListAsrConfig:input_type -> voicechatpb.ListAsrConfigRequest
-	13, // 17: voicechatpb.TtsConfigService.CreateTtsConfig:input_type -> voicechatpb.CreateTtsConfigRequest
-	15, // 18: voicechatpb.TtsConfigService.GetTtsConfig:input_type -> voicechatpb.GetTtsConfigRequest
-	17, // 19: voicechatpb.TtsConfigService.UpdateTtsConfig:input_type -> voicechatpb.UpdateTtsConfigRequest
-	19, // 20: voicechatpb.TtsConfigService.DeleteTtsConfig:input_type -> voicechatpb.DeleteTtsConfigRequest
-	21, // 21: voicechatpb.TtsConfigService.ListTtsConfig:input_type -> voicechatpb.ListTtsConfigRequest
-	4,  // 22: voicechatpb.AsrConfigService.CreateAsrConfig:output_type -> voicechatpb.CreateAsrConfigResponse
-	6,  // 23: voicechatpb.AsrConfigService.GetAsrConfig:output_type -> voicechatpb.GetAsrConfigResponse
-	8,  // 24: voicechatpb.AsrConfigService.UpdateAsrConfig:output_type -> voicechatpb.UpdateAsrConfigResponse
-	10, // 25: voicechatpb.AsrConfigService.DeleteAsrConfig:output_type -> voicechatpb.DeleteAsrConfigResponse
-	12, // 26: voicechatpb.AsrConfigService.ListAsrConfig:output_type -> voicechatpb.ListAsrConfigResponse
-	14, // 27: voicechatpb.TtsConfigService.CreateTtsConfig:output_type -> voicechatpb.CreateTtsConfigResponse
-	16, // 28: voicechatpb.TtsConfigService.GetTtsConfig:output_type -> voicechatpb.GetTtsConfigResponse
-	18, // 29: voicechatpb.TtsConfigService.UpdateTtsConfig:output_type -> voicechatpb.UpdateTtsConfigResponse
-	20, // 30: voicechatpb.TtsConfigService.DeleteTtsConfig:output_type -> voicechatpb.DeleteTtsConfigResponse
-	22, // 31: voicechatpb.TtsConfigService.ListTtsConfig:output_type -> voicechatpb.ListTtsConfigResponse
-	22, // [22:32] is the sub-list for method output_type
-	12, // [12:22] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	23, // 12: voicechatpb.CreateTurnMetricRequest.metric:type_name -> voicechatpb.TurnMetric
+	0,  // 13: voicechatpb.ListTurnMetricRequest.page:type_name -> voicechatpb.PageQuery
+	23, // 14: voicechatpb.ListTurnMetricResponse.metrics:type_name -> voicechatpb.TurnMetric
+	3,  // 15: voicechatpb.AsrConfigService.CreateAsrConfig:input_type -> voicechatpb.CreateAsrConfigRequest
+	5,  // 16: voicechatpb.AsrConfigService.GetAsrConfig:input_type -> voicechatpb.GetAsrConfigRequest
+	7,  // 17: voicechatpb.AsrConfigService.UpdateAsrConfig:input_type -> voicechatpb.UpdateAsrConfigRequest
+	9,  // 18: voicechatpb.AsrConfigService.DeleteAsrConfig:input_type -> voicechatpb.DeleteAsrConfigRequest
+	11, // 19: voicechatpb.AsrConfigService.ListAsrConfig:input_type -> voicechatpb.ListAsrConfigRequest
+	13, // 20: voicechatpb.TtsConfigService.CreateTtsConfig:input_type -> voicechatpb.CreateTtsConfigRequest
+	15, // 21: voicechatpb.TtsConfigService.GetTtsConfig:input_type -> voicechatpb.GetTtsConfigRequest
+	17, // 22: voicechatpb.TtsConfigService.UpdateTtsConfig:input_type -> voicechatpb.UpdateTtsConfigRequest
+	19, // 23: voicechatpb.TtsConfigService.DeleteTtsConfig:input_type -> voicechatpb.DeleteTtsConfigRequest
+	21, // 24: voicechatpb.TtsConfigService.ListTtsConfig:input_type -> voicechatpb.ListTtsConfigRequest
+	24, // 25: voicechatpb.TurnMetricService.CreateTurnMetric:input_type -> voicechatpb.CreateTurnMetricRequest
+	26, // 26: voicechatpb.TurnMetricService.ListTurnMetric:input_type -> voicechatpb.ListTurnMetricRequest
+	4,  // 27: voicechatpb.AsrConfigService.CreateAsrConfig:output_type -> voicechatpb.CreateAsrConfigResponse
+	6,  // 28: voicechatpb.AsrConfigService.GetAsrConfig:output_type -> voicechatpb.GetAsrConfigResponse
+	8,  // 29: voicechatpb.AsrConfigService.UpdateAsrConfig:output_type -> voicechatpb.UpdateAsrConfigResponse
+	10, // 30: voicechatpb.AsrConfigService.DeleteAsrConfig:output_type -> voicechatpb.DeleteAsrConfigResponse
+	12, // 31: voicechatpb.AsrConfigService.ListAsrConfig:output_type -> voicechatpb.ListAsrConfigResponse
+	14, // 32: voicechatpb.TtsConfigService.CreateTtsConfig:output_type -> voicechatpb.CreateTtsConfigResponse
+	16, // 33: voicechatpb.TtsConfigService.GetTtsConfig:output_type -> voicechatpb.GetTtsConfigResponse
+	18, // 34: voicechatpb.TtsConfigService.UpdateTtsConfig:output_type -> voicechatpb.UpdateTtsConfigResponse
+	20, // 35: voicechatpb.TtsConfigService.DeleteTtsConfig:output_type -> voicechatpb.DeleteTtsConfigResponse
+	22, // 36: voicechatpb.TtsConfigService.ListTtsConfig:output_type -> voicechatpb.ListTtsConfigResponse
+	25, // 37: voicechatpb.TurnMetricService.CreateTurnMetric:output_type -> voicechatpb.CreateTurnMetricResponse
+	27, // 38: voicechatpb.TurnMetricService.ListTurnMetric:output_type -> voicechatpb.ListTurnMetricResponse
+	27, // [27:39] is the sub-list for method output_type
+	15, // [15:27] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_voicechat_proto_init() }
@@ -1426,9 +1821,9 @@ func file_voicechat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_voicechat_proto_rawDesc), len(file_voicechat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   28,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_voicechat_proto_goTypes,
 		DependencyIndexes: file_voicechat_proto_depIdxs,
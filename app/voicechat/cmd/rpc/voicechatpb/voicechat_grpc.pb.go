@@ -531,3 +531,143 @@ var TtsConfigService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "voicechat.proto",
 }
+
+const (
+	TurnMetricService_CreateTurnMetric_FullMethodName = "/voicechatpb.TurnMetricService/CreateTurnMetric"
+	TurnMetricService_ListTurnMetric_FullMethodName   = "/voicechatpb.TurnMetricService/ListTurnMetric"
+)
+
+// TurnMetricServiceClient is the client API for TurnMetricService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TurnMetricServiceClient interface {
+	CreateTurnMetric(ctx context.Context, in *CreateTurnMetricRequest, opts ...grpc.CallOption) (*CreateTurnMetricResponse, error)
+	ListTurnMetric(ctx context.Context, in *ListTurnMetricRequest, opts ...grpc.CallOption) (*ListTurnMetricResponse, error)
+}
+
+type turnMetricServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTurnMetricServiceClient(cc grpc.ClientConnInterface) TurnMetricServiceClient {
+	return &turnMetricServiceClient{cc}
+}
+
+func (c *turnMetricServiceClient) CreateTurnMetric(ctx context.Context, in *CreateTurnMetricRequest, opts ...grpc.CallOption) (*CreateTurnMetricResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTurnMetricResponse)
+	err := c.cc.Invoke(ctx, TurnMetricService_CreateTurnMetric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *turnMetricServiceClient) ListTurnMetric(ctx context.Context, in *ListTurnMetricRequest, opts ...grpc.CallOption) (*ListTurnMetricResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTurnMetricResponse)
+	err := c.cc.Invoke(ctx, TurnMetricService_ListTurnMetric_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TurnMetricServiceServer is the server API for TurnMetricService service.
+// All implementations must embed UnimplementedTurnMetricServiceServer
+// for forward compatibility.
+type TurnMetricServiceServer interface {
+	CreateTurnMetric(context.Context, *CreateTurnMetricRequest) (*CreateTurnMetricResponse, error)
+	ListTurnMetric(context.Context, *ListTurnMetricRequest) (*ListTurnMetricResponse, error)
+	mustEmbedUnimplementedTurnMetricServiceServer()
+}
+
+// UnimplementedTurnMetricServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTurnMetricServiceServer struct{}
+
+func (UnimplementedTurnMetricServiceServer) CreateTurnMetric(context.Context, *CreateTurnMetricRequest) (*CreateTurnMetricResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTurnMetric not implemented")
+}
+func (UnimplementedTurnMetricServiceServer) ListTurnMetric(context.Context, *ListTurnMetricRequest) (*ListTurnMetricResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTurnMetric not implemented")
+}
+func (UnimplementedTurnMetricServiceServer) mustEmbedUnimplementedTurnMetricServiceServer() {}
+func (UnimplementedTurnMetricServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeTurnMetricServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TurnMetricServiceServer will
+// result in compilation errors.
+type UnsafeTurnMetricServiceServer interface {
+	mustEmbedUnimplementedTurnMetricServiceServer()
+}
+
+func RegisterTurnMetricServiceServer(s grpc.ServiceRegistrar, srv TurnMetricServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTurnMetricServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TurnMetricService_ServiceDesc, srv)
+}
+
+func _TurnMetricService_CreateTurnMetric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTurnMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TurnMetricServiceServer).CreateTurnMetric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TurnMetricService_CreateTurnMetric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TurnMetricServiceServer).CreateTurnMetric(ctx, req.(*CreateTurnMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TurnMetricService_ListTurnMetric_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTurnMetricRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TurnMetricServiceServer).ListTurnMetric(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TurnMetricService_ListTurnMetric_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TurnMetricServiceServer).ListTurnMetric(ctx, req.(*ListTurnMetricRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TurnMetricService_ServiceDesc is the grpc.ServiceDesc for TurnMetricService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TurnMetricService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "voicechatpb.TurnMetricService",
+	HandlerType: (*TurnMetricServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTurnMetric",
+			Handler:    _TurnMetricService_CreateTurnMetric_Handler,
+		},
+		{
+			MethodName: "ListTurnMetric",
+			Handler:    _TurnMetricService_ListTurnMetric_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "voicechat.proto",
+}
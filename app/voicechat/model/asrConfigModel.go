@@ -1,6 +1,13 @@
 package model
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/secretbox"
+
+	"github.com/Masterminds/squirrel"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -12,16 +19,99 @@ type (
 	// and implement the added methods in customAsrConfigModel.
 	AsrConfigModel interface {
 		asrConfigModel
+		// RotateSecrets 使用当前主密钥重新加密所有行（包括历史明文），返回更新的行数
+		RotateSecrets(ctx context.Context) (int, error)
 	}
 
+	// customAsrConfigModel 写入前加密 asr_config.secret_key，读取后解密，上层只接触明文
 	customAsrConfigModel struct {
 		*defaultAsrConfigModel
+		secret secretbox.Column[AsrConfig]
 	}
 )
 
 // NewAsrConfigModel returns a model for the database table.
-func NewAsrConfigModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) AsrConfigModel {
+func NewAsrConfigModel(conn sqlx.SqlConn, c cache.CacheConf, secrets *secretbox.Keyring, opts ...cache.Option) AsrConfigModel {
 	return &customAsrConfigModel{
 		defaultAsrConfigModel: newAsrConfigModel(conn, c, opts...),
+		secret: secretbox.Column[AsrConfig]{
+			Keyring: secrets,
+			Name:    "asr_config.secret_key",
+			Field:   func(row *AsrConfig) *sql.NullString { return &row.SecretKey },
+			Id:      func(row *AsrConfig) int64 { return row.Id },
+		},
+	}
+}
+
+func (m *customAsrConfigModel) Insert(ctx context.Context, session sqlx.Session, data *AsrConfig) (sql.Result, error) {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return m.defaultAsrConfigModel.Insert(ctx, session, data)
+}
+
+func (m *customAsrConfigModel) Update(ctx context.Context, session sqlx.Session, data *AsrConfig) (sql.Result, error) {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return m.defaultAsrConfigModel.Update(ctx, session, data)
+}
+
+func (m *customAsrConfigModel) UpdateWithVersion(ctx context.Context, session sqlx.Session, data *AsrConfig) error {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return m.defaultAsrConfigModel.UpdateWithVersion(ctx, session, data)
+}
+
+func (m *customAsrConfigModel) FindOne(ctx context.Context, id int64) (*AsrConfig, error) {
+	data, err := m.defaultAsrConfigModel.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return data, m.secret.Open(data)
+}
+
+func (m *customAsrConfigModel) FindAll(ctx context.Context, builder squirrel.SelectBuilder, orderBy string) ([]*AsrConfig, error) {
+	return m.secret.OpenList(m.defaultAsrConfigModel.FindAll(ctx, builder, orderBy))
+}
+
+func (m *customAsrConfigModel) FindPageListByPage(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*AsrConfig, error) {
+	return m.secret.OpenList(m.defaultAsrConfigModel.FindPageListByPage(ctx, builder, page, pageSize, orderBy))
+}
+
+func (m *customAsrConfigModel) FindPageListByPageWithTotal(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*AsrConfig, int64, error) {
+	list, total, err := m.defaultAsrConfigModel.FindPageListByPageWithTotal(ctx, builder, page, pageSize, orderBy)
+	if err != nil {
+		return nil, 0, err
+	}
+	list, err = m.secret.OpenList(list, nil)
+	return list, total, err
+}
+
+func (m *customAsrConfigModel) FindPageListByIdDESC(ctx context.Context, builder squirrel.SelectBuilder, preMinId, pageSize int64) ([]*AsrConfig, error) {
+	return m.secret.OpenList(m.defaultAsrConfigModel.FindPageListByIdDESC(ctx, builder, preMinId, pageSize))
+}
+
+func (m *customAsrConfigModel) FindPageListByIdASC(ctx context.Context, builder squirrel.SelectBuilder, preMaxId, pageSize int64) ([]*AsrConfig, error) {
+	return m.secret.OpenList(m.defaultAsrConfigModel.FindPageListByIdASC(ctx, builder, preMaxId, pageSize))
+}
+
+func (m *customAsrConfigModel) RotateSecrets(ctx context.Context) (int, error) {
+	// 直接读取原始密文，已软删除的行也一并处理
+	var rows []*AsrConfig
+	query := fmt.Sprintf("select %s from %s", asrConfigRows, m.table)
+	if err := m.QueryRowsNoCacheCtx(ctx, &rows, query); err != nil {
+		return 0, err
 	}
+	return m.secret.Rotate(ctx, rows, func(ctx context.Context, row *AsrConfig) error {
+		_, err := m.Update(ctx, nil, row)
+		return err
+	})
 }
@@ -1,6 +1,13 @@
 package model
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go-zero-voice-agent/pkg/secretbox"
+
+	"github.com/Masterminds/squirrel"
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -12,16 +19,99 @@ type (
 	// and implement the added methods in customTtsConfigModel.
 	TtsConfigModel interface {
 		ttsConfigModel
+		// RotateSecrets 使用当前主密钥重新加密所有行（包括历史明文），返回更新的行数
+		RotateSecrets(ctx context.Context) (int, error)
 	}
 
+	// customTtsConfigModel 写入前加密 tts_config.secret_key，读取后解密，上层只接触明文
 	customTtsConfigModel struct {
 		*defaultTtsConfigModel
+		secret secretbox.Column[TtsConfig]
 	}
 )
 
 // NewTtsConfigModel returns a model for the database table.
-func NewTtsConfigModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) TtsConfigModel {
+func NewTtsConfigModel(conn sqlx.SqlConn, c cache.CacheConf, secrets *secretbox.Keyring, opts ...cache.Option) TtsConfigModel {
 	return &customTtsConfigModel{
 		defaultTtsConfigModel: newTtsConfigModel(conn, c, opts...),
+		secret: secretbox.Column[TtsConfig]{
+			Keyring: secrets,
+			Name:    "tts_config.secret_key",
+			Field:   func(row *TtsConfig) *sql.NullString { return &row.SecretKey },
+			Id:      func(row *TtsConfig) int64 { return row.Id },
+		},
+	}
+}
+
+func (m *customTtsConfigModel) Insert(ctx context.Context, session sqlx.Session, data *TtsConfig) (sql.Result, error) {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return m.defaultTtsConfigModel.Insert(ctx, session, data)
+}
+
+func (m *customTtsConfigModel) Update(ctx context.Context, session sqlx.Session, data *TtsConfig) (sql.Result, error) {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	return m.defaultTtsConfigModel.Update(ctx, session, data)
+}
+
+func (m *customTtsConfigModel) UpdateWithVersion(ctx context.Context, session sqlx.Session, data *TtsConfig) error {
+	restore, err := m.secret.Seal(data)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return m.defaultTtsConfigModel.UpdateWithVersion(ctx, session, data)
+}
+
+func (m *customTtsConfigModel) FindOne(ctx context.Context, id int64) (*TtsConfig, error) {
+	data, err := m.defaultTtsConfigModel.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return data, m.secret.Open(data)
+}
+
+func (m *customTtsConfigModel) FindAll(ctx context.Context, builder squirrel.SelectBuilder, orderBy string) ([]*TtsConfig, error) {
+	return m.secret.OpenList(m.defaultTtsConfigModel.FindAll(ctx, builder, orderBy))
+}
+
+func (m *customTtsConfigModel) FindPageListByPage(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*TtsConfig, error) {
+	return m.secret.OpenList(m.defaultTtsConfigModel.FindPageListByPage(ctx, builder, page, pageSize, orderBy))
+}
+
+func (m *customTtsConfigModel) FindPageListByPageWithTotal(ctx context.Context, builder squirrel.SelectBuilder, page, pageSize int64, orderBy string) ([]*TtsConfig, int64, error) {
+	list, total, err := m.defaultTtsConfigModel.FindPageListByPageWithTotal(ctx, builder, page, pageSize, orderBy)
+	if err != nil {
+		return nil, 0, err
+	}
+	list, err = m.secret.OpenList(list, nil)
+	return list, total, err
+}
+
+func (m *customTtsConfigModel) FindPageListByIdDESC(ctx context.Context, builder squirrel.SelectBuilder, preMinId, pageSize int64) ([]*TtsConfig, error) {
+	return m.secret.OpenList(m.defaultTtsConfigModel.FindPageListByIdDESC(ctx, builder, preMinId, pageSize))
+}
+
+func (m *customTtsConfigModel) FindPageListByIdASC(ctx context.Context, builder squirrel.SelectBuilder, preMaxId, pageSize int64) ([]*TtsConfig, error) {
+	return m.secret.OpenList(m.defaultTtsConfigModel.FindPageListByIdASC(ctx, builder, preMaxId, pageSize))
+}
+
+func (m *customTtsConfigModel) RotateSecrets(ctx context.Context) (int, error) {
+	// 直接读取原始密文，已软删除的行也一并处理
+	var rows []*TtsConfig
+	query := fmt.Sprintf("select %s from %s", ttsConfigRows, m.table)
+	if err := m.QueryRowsNoCacheCtx(ctx, &rows, query); err != nil {
+		return 0, err
 	}
+	return m.secret.Rotate(ctx, rows, func(ctx context.Context, row *TtsConfig) error {
+		_, err := m.Update(ctx, nil, row)
+		return err
+	})
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ TurnMetricModel = (*customTurnMetricModel)(nil)
+
+type (
+	// TurnMetricModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customTurnMetricModel.
+	TurnMetricModel interface {
+		turnMetricModel
+	}
+
+	customTurnMetricModel struct {
+		*defaultTurnMetricModel
+	}
+)
+
+// NewTurnMetricModel returns a model for the database table.
+func NewTurnMetricModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) TurnMetricModel {
+	return &customTurnMetricModel{
+		defaultTurnMetricModel: newTurnMetricModel(conn, c, opts...),
+	}
+}
@@ -0,0 +1,307 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	aliyunDashScopeWsUrl = "wss://dashscope.aliyuncs.com/api-ws/v1/inference/"
+
+	aliyunAsrModel          = "paraformer-realtime-v2"
+	aliyunTtsModel          = "cosyvoice-v1"
+	aliyunDefaultTtsVoice   = "longxiaochun"
+	aliyunAudioChunkSize    = 3200
+	aliyunTaskTimeout       = 60 * time.Second
+	aliyunEventTaskStarted  = "task-started"
+	aliyunEventResult       = "result-generated"
+	aliyunEventTaskFinished = "task-finished"
+	aliyunEventTaskFailed   = "task-failed"
+)
+
+// 阿里云使用 DashScope 语音服务，secretKey 即 DashScope API Key
+type aliyunHeader struct {
+	Action       string `json:"action,omitempty"`
+	TaskId       string `json:"task_id"`
+	Streaming    string `json:"streaming,omitempty"`
+	Event        string `json:"event,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+type aliyunMessage struct {
+	Header  aliyunHeader   `json:"header"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+type aliyunEvent struct {
+	Header  aliyunHeader `json:"header"`
+	Payload struct {
+		Output struct {
+			Sentence struct {
+				BeginTime   int64  `json:"begin_time"`
+				EndTime     *int64 `json:"end_time"`
+				Text        string `json:"text"`
+				SentenceEnd bool   `json:"sentence_end"`
+			} `json:"sentence"`
+		} `json:"output"`
+	} `json:"payload"`
+}
+
+// aliyunTask 一次 DashScope 双工任务
+type aliyunTask struct {
+	conn   *websocket.Conn
+	taskId string
+}
+
+func startAliyunTask(ctx context.Context, cred Credential, runPayload map[string]any) (*aliyunTask, error) {
+	if cred.SecretKey == "" {
+		return nil, errEmptyCredential
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+cred.SecretKey)
+	if cred.AppId != "" {
+		header.Set("X-DashScope-WorkSpace", cred.AppId)
+	}
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, aliyunDashScopeWsUrl, header)
+	if err != nil {
+		if resp != nil {
+			return nil, &ProviderError{Provider: PROVIDER_ALIYUN, StatusCode: resp.StatusCode, Message: err.Error()}
+		}
+		return nil, fmt.Errorf("dial dashscope failed: %w", err)
+	}
+
+	deadline := time.Now().Add(aliyunTaskTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetReadDeadline(deadline)
+	conn.SetWriteDeadline(deadline)
+
+	task := &aliyunTask{conn: conn, taskId: strings.ReplaceAll(uuid.NewString(), "-", "")}
+	if err := task.send("run-task", runPayload); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	for {
+		evt, _, err := task.next()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if evt != nil && evt.Header.Event == aliyunEventTaskStarted {
+			return task, nil
+		}
+	}
+}
+
+func (t *aliyunTask) send(action string, payload map[string]any) error {
+	return t.conn.WriteJSON(aliyunMessage{
+		Header: aliyunHeader{
+			Action:    action,
+			TaskId:    t.taskId,
+			Streaming: "duplex",
+		},
+		Payload: payload,
+	})
+}
+
+// next 读取下一帧，文本帧解析为事件，二进制帧原样返回
+func (t *aliyunTask) next() (*aliyunEvent, []byte, error) {
+	msgType, data, err := t.conn.ReadMessage()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read dashscope message failed: %w", err)
+	}
+	if msgType == websocket.BinaryMessage {
+		return nil, data, nil
+	}
+
+	var evt aliyunEvent
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return nil, nil, fmt.Errorf("invalid dashscope message: %w", err)
+	}
+	if evt.Header.Event == aliyunEventTaskFailed {
+		return nil, nil, &ProviderError{
+			Provider: PROVIDER_ALIYUN,
+			Code:     evt.Header.ErrorCode,
+			Message:  evt.Header.ErrorMessage,
+		}
+	}
+	return &evt, nil, nil
+}
+
+func (t *aliyunTask) finish() error {
+	return t.send("finish-task", map[string]any{"input": map[string]any{}})
+}
+
+func (t *aliyunTask) Close() error {
+	return t.conn.Close()
+}
+
+// AliyunTranscriber 使用 paraformer 实时识别模型，整段音频分片推流后收集所有句子
+type AliyunTranscriber struct {
+	cred Credential
+}
+
+func NewAliyunTranscriber(cred Credential) *AliyunTranscriber {
+	return &AliyunTranscriber{cred: cred}
+}
+
+func (a *AliyunTranscriber) Transcribe(ctx context.Context, req *TranscribeRequest) (*TranscribeResult, error) {
+	format := req.Format
+	if format == "" {
+		format = FORMAT_WAV
+	}
+	sampleRate := req.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 16000
+	}
+	parameters := map[string]any{
+		"format":      format,
+		"sample_rate": sampleRate,
+	}
+	if req.Language != "" {
+		parameters["language_hints"] = []string{strings.SplitN(req.Language, "-", 2)[0]}
+	}
+
+	task, err := startAliyunTask(ctx, a.cred, map[string]any{
+		"task_group": "audio",
+		"task":       "asr",
+		"function":   "recognition",
+		"model":      aliyunAsrModel,
+		"parameters": parameters,
+		"input":      map[string]any{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer task.Close()
+
+	for offset := 0; offset < len(req.Audio); offset += aliyunAudioChunkSize {
+		end := min(offset+aliyunAudioChunkSize, len(req.Audio))
+		if err := task.conn.WriteMessage(websocket.BinaryMessage, req.Audio[offset:end]); err != nil {
+			return nil, fmt.Errorf("send audio failed: %w", err)
+		}
+	}
+	if err := task.finish(); err != nil {
+		return nil, err
+	}
+
+	// 同一句话会多次返回中间结果，按开始时间保留最终结果
+	sentences := make(map[int64]string)
+	var durationMs int64
+	for {
+		evt, _, err := task.next()
+		if err != nil {
+			return nil, err
+		}
+		if evt == nil {
+			continue
+		}
+		switch evt.Header.Event {
+		case aliyunEventResult:
+			sentence := evt.Payload.Output.Sentence
+			sentences[sentence.BeginTime] = sentence.Text
+			if sentence.EndTime != nil && *sentence.EndTime > durationMs {
+				durationMs = *sentence.EndTime
+			}
+		case aliyunEventTaskFinished:
+			begins := make([]int64, 0, len(sentences))
+			for begin := range sentences {
+				begins = append(begins, begin)
+			}
+			sort.Slice(begins, func(i, j int) bool { return begins[i] < begins[j] })
+
+			var text strings.Builder
+			for _, begin := range begins {
+				text.WriteString(sentences[begin])
+			}
+			return &TranscribeResult{Text: text.String(), DurationMs: durationMs}, nil
+		}
+	}
+}
+
+// AliyunSynthesizer 使用 cosyvoice 语音合成模型，音频以二进制帧返回
+type AliyunSynthesizer struct {
+	cred Credential
+}
+
+func NewAliyunSynthesizer(cred Credential) *AliyunSynthesizer {
+	return &AliyunSynthesizer{cred: cred}
+}
+
+func (a *AliyunSynthesizer) Synthesize(ctx context.Context, req *SynthesizeRequest) (*SynthesizeResult, error) {
+	voice := req.Speaker
+	if voice == "" {
+		voice = aliyunDefaultTtsVoice
+	}
+	format := req.Format
+	if format == "" {
+		format = FORMAT_MP3
+	}
+	sampleRate := req.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 16000
+	}
+	parameters := map[string]any{
+		"text_type":   "PlainText",
+		"voice":       voice,
+		"format":      format,
+		"sample_rate": sampleRate,
+	}
+	if req.Speed > 0 {
+		parameters["rate"] = req.Speed
+	}
+	// DashScope 音量范围 [0, 100]，默认 50
+	if req.Volume > 0 {
+		parameters["volume"] = req.Volume * 10
+	}
+
+	task, err := startAliyunTask(ctx, a.cred, map[string]any{
+		"task_group": "audio",
+		"task":       "tts",
+		"function":   "SpeechSynthesizer",
+		"model":      aliyunTtsModel,
+		"parameters": parameters,
+		"input":      map[string]any{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer task.Close()
+
+	if err := task.send("continue-task", map[string]any{
+		"input": map[string]any{"text": req.Text},
+	}); err != nil {
+		return nil, err
+	}
+	if err := task.finish(); err != nil {
+		return nil, err
+	}
+
+	var audio bytes.Buffer
+	for {
+		evt, data, err := task.next()
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			audio.Write(data)
+			continue
+		}
+		if evt.Header.Event == aliyunEventTaskFinished {
+			return &SynthesizeResult{Audio: audio.Bytes(), Format: format}, nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package speech
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"go-zero-voice-agent/pkg/consts"
+)
+
+// ProviderError 服务商返回的错误
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s speech error: status=%d code=%s message=%s", e.Provider, e.StatusCode, e.Code, e.Message)
+}
+
+// ClassifyError 将识别/合成错误归一化为 consts.CREDENTIAL_ERROR_*
+func ClassifyError(err error) string {
+	if err == nil {
+		return consts.CREDENTIAL_ERROR_NONE
+	}
+	if errors.Is(err, ErrUnsupportedProvider) || errors.Is(err, errEmptyCredential) {
+		return consts.CREDENTIAL_ERROR_INVALID_CONFIG
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return consts.CREDENTIAL_ERROR_TIMEOUT
+	}
+
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return classifyProviderError(providerErr)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return consts.CREDENTIAL_ERROR_TIMEOUT
+		}
+		return consts.CREDENTIAL_ERROR_NETWORK
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return consts.CREDENTIAL_ERROR_NETWORK
+	}
+	return consts.CREDENTIAL_ERROR_PROVIDER
+}
+
+func classifyProviderError(e *ProviderError) string {
+	code := strings.ToLower(e.Code)
+	switch {
+	// 腾讯云 AuthFailure.*，DashScope InvalidApiKey / AccessDenied
+	case strings.HasPrefix(code, "authfailure"), strings.Contains(code, "apikey"), strings.Contains(code, "accessdenied"),
+		e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return consts.CREDENTIAL_ERROR_AUTH_FAILED
+	// 腾讯云 RequestLimitExceeded / LimitExceeded / 欠费，DashScope Throttling
+	case strings.Contains(code, "limitexceeded"), strings.Contains(code, "throttling"), strings.Contains(code, "arrears"),
+		e.StatusCode == http.StatusTooManyRequests:
+		return consts.CREDENTIAL_ERROR_RATE_LIMITED
+	case strings.Contains(code, "notfound"), e.StatusCode == http.StatusNotFound:
+		return consts.CREDENTIAL_ERROR_NOT_FOUND
+	default:
+		return consts.CREDENTIAL_ERROR_PROVIDER
+	}
+}
@@ -0,0 +1,67 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+)
+
+// MOCK_TRANSCRIPTION 本地模拟识别固定返回的文本
+const MOCK_TRANSCRIPTION = "这是一段模拟识别结果"
+
+// MockTranscriber 本地模拟识别，不调用任何云服务，用于开发和测试
+type MockTranscriber struct{}
+
+func NewMockTranscriber() *MockTranscriber {
+	return &MockTranscriber{}
+}
+
+func (m *MockTranscriber) Transcribe(ctx context.Context, req *TranscribeRequest) (*TranscribeResult, error) {
+	if len(req.Audio) == 0 {
+		return nil, fmt.Errorf("empty audio")
+	}
+	return &TranscribeResult{Text: MOCK_TRANSCRIPTION}, nil
+}
+
+// MockSynthesizer 本地模拟合成，按文本长度生成静音 wav
+type MockSynthesizer struct{}
+
+func NewMockSynthesizer() *MockSynthesizer {
+	return &MockSynthesizer{}
+}
+
+func (m *MockSynthesizer) Synthesize(ctx context.Context, req *SynthesizeRequest) (*SynthesizeResult, error) {
+	sampleRate := req.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 16000
+	}
+	// 每个字 200ms
+	samples := len([]rune(req.Text)) * sampleRate / 5
+	return &SynthesizeResult{Audio: silentWav(sampleRate, samples), Format: FORMAT_WAV}, nil
+}
+
+// SampleAudio 用于配置连通性测试的样例音频：1 秒 16k 静音 wav
+func SampleAudio() []byte {
+	return silentWav(16000, 16000)
+}
+
+// silentWav 生成 16bit 单声道静音 wav
+func silentWav(sampleRate, samples int) []byte {
+	dataLen := samples * 2
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataLen))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // 单声道
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2))
+	binary.Write(&buf, binary.LittleEndian, uint16(2))
+	binary.Write(&buf, binary.LittleEndian, uint16(16))
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataLen))
+	buf.Write(make([]byte, dataLen))
+	return buf.Bytes()
+}
@@ -0,0 +1,109 @@
+package speech
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 提供商名称，与 ASR/TTS 配置中的 provider 保持一致
+const (
+	PROVIDER_TENCENT = "tencent"
+	PROVIDER_ALIYUN  = "aliyun"
+	PROVIDER_MOCK    = "mock"
+)
+
+// 音频格式
+const (
+	FORMAT_WAV = "wav"
+	FORMAT_MP3 = "mp3"
+	FORMAT_PCM = "pcm"
+)
+
+var (
+	ErrUnsupportedProvider = errors.New("unsupported speech provider")
+	errEmptyCredential     = errors.New("speech credential is empty")
+)
+
+// Credential 提供商凭证，对应 ASR/TTS 配置中的 appId/secretId/secretKey
+type Credential struct {
+	AppId     string
+	SecretId  string
+	SecretKey string
+}
+
+type TranscribeRequest struct {
+	Audio      []byte
+	Format     string // wav / mp3 / pcm 等
+	SampleRate int
+	Language   string
+}
+
+type TranscribeResult struct {
+	Text       string
+	DurationMs int64
+}
+
+// Transcriber 离线语音识别：整段音频转文字
+type Transcriber interface {
+	Transcribe(ctx context.Context, req *TranscribeRequest) (*TranscribeResult, error)
+}
+
+type SynthesizeRequest struct {
+	Text       string
+	Speaker    string
+	Format     string
+	SampleRate int
+	Speed      float32 // 1 为正常语速
+	Volume     int     // 0-10，5 为正常音量
+}
+
+type SynthesizeResult struct {
+	Audio  []byte
+	Format string
+}
+
+// Synthesizer 离线语音合成：文字转整段音频
+type Synthesizer interface {
+	Synthesize(ctx context.Context, req *SynthesizeRequest) (*SynthesizeResult, error)
+}
+
+func NewTranscriber(provider string, cred Credential) (Transcriber, error) {
+	switch provider {
+	case PROVIDER_TENCENT:
+		return NewTencentTranscriber(cred), nil
+	case PROVIDER_ALIYUN:
+		return NewAliyunTranscriber(cred), nil
+	case PROVIDER_MOCK:
+		return NewMockTranscriber(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, provider)
+	}
+}
+
+func NewSynthesizer(provider string, cred Credential) (Synthesizer, error) {
+	switch provider {
+	case PROVIDER_TENCENT:
+		return NewTencentSynthesizer(cred), nil
+	case PROVIDER_ALIYUN:
+		return NewAliyunSynthesizer(cred), nil
+	case PROVIDER_MOCK:
+		return NewMockSynthesizer(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, provider)
+	}
+}
+
+// ContentType 返回音频格式对应的 MIME 类型
+func ContentType(format string) string {
+	switch format {
+	case FORMAT_MP3:
+		return "audio/mpeg"
+	case FORMAT_WAV:
+		return "audio/wav"
+	case FORMAT_PCM:
+		return "audio/L16"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,82 @@
+package speech
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+
+	"go-zero-voice-agent/pkg/consts"
+)
+
+func TestNewTranscriberUnsupported(t *testing.T) {
+	if _, err := NewTranscriber("unknown", Credential{}); !errors.Is(err, ErrUnsupportedProvider) {
+		t.Fatalf("err = %v, want ErrUnsupportedProvider", err)
+	}
+	if _, err := NewSynthesizer("unknown", Credential{}); !errors.Is(err, ErrUnsupportedProvider) {
+		t.Fatalf("err = %v, want ErrUnsupportedProvider", err)
+	}
+}
+
+func TestMockSynthesizer(t *testing.T) {
+	s, err := NewSynthesizer(PROVIDER_MOCK, Credential{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := s.Synthesize(context.Background(), &SynthesizeRequest{Text: "你好"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 个字 * 200ms * 16000Hz * 2 字节
+	wantData := 2 * 3200 * 2
+	if result.Format != FORMAT_WAV || len(result.Audio) != 44+wantData {
+		t.Fatalf("format = %s, len = %d", result.Format, len(result.Audio))
+	}
+	if string(result.Audio[:4]) != "RIFF" || binary.LittleEndian.Uint32(result.Audio[40:44]) != uint32(wantData) {
+		t.Errorf("invalid wav header: %v", result.Audio[:44])
+	}
+
+	tr, _ := NewTranscriber(PROVIDER_MOCK, Credential{})
+	text, err := tr.Transcribe(context.Background(), &TranscribeRequest{Audio: result.Audio})
+	if err != nil || text.Text != MOCK_TRANSCRIPTION {
+		t.Errorf("transcribe = %+v, %v", text, err)
+	}
+}
+
+func TestTencentEngineType(t *testing.T) {
+	cases := map[string]string{
+		"":       "16k_zh",
+		"zh-CN":  "16k_zh",
+		"en":     "16k_en",
+		"8k_zh":  "8k_zh",
+		"16k_ca": "16k_ca",
+	}
+	for language, want := range cases {
+		if got := tencentEngineType(language); got != want {
+			t.Errorf("tencentEngineType(%q) = %s, want %s", language, got, want)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{nil, consts.CREDENTIAL_ERROR_NONE},
+		{errEmptyCredential, consts.CREDENTIAL_ERROR_INVALID_CONFIG},
+		{context.DeadlineExceeded, consts.CREDENTIAL_ERROR_TIMEOUT},
+		{&ProviderError{Provider: PROVIDER_TENCENT, Code: "AuthFailure.SignatureFailure"}, consts.CREDENTIAL_ERROR_AUTH_FAILED},
+		{&ProviderError{Provider: PROVIDER_ALIYUN, StatusCode: 401}, consts.CREDENTIAL_ERROR_AUTH_FAILED},
+		{&ProviderError{Provider: PROVIDER_TENCENT, Code: "RequestLimitExceeded"}, consts.CREDENTIAL_ERROR_RATE_LIMITED},
+		{&ProviderError{Provider: PROVIDER_TENCENT, Code: "InternalError"}, consts.CREDENTIAL_ERROR_PROVIDER},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, consts.CREDENTIAL_ERROR_NETWORK},
+	}
+	for _, c := range cases {
+		if got := ClassifyError(c.err); got != c.want {
+			t.Errorf("ClassifyError(%v) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
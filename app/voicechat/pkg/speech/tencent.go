@@ -0,0 +1,241 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	tencentAsrHost    = "asr.tencentcloudapi.com"
+	tencentAsrVersion = "2019-06-14"
+	tencentTtsHost    = "tts.tencentcloudapi.com"
+	tencentTtsVersion = "2019-08-23"
+	tencentTtsRegion  = "ap-guangzhou"
+
+	// 默认音色，与实时通话保持一致
+	tencentDefaultVoiceType = 603004
+)
+
+// tencentClient 腾讯云 API 3.0 调用，签名方式为 TC3-HMAC-SHA256
+type tencentClient struct {
+	cred       Credential
+	httpClient *http.Client
+}
+
+type tencentError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+func (c *tencentClient) call(ctx context.Context, host, version, region, action string, payload, out any) error {
+	if c.cred.SecretId == "" || c.cred.SecretKey == "" {
+		return errEmptyCredential
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	service := strings.SplitN(host, ".", 2)[0]
+	authorization := c.sign(host, service, body, now)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Host", host)
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Version", version)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	if region != "" {
+		req.Header.Set("X-TC-Region", region)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("tencent %s request failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Response json.RawMessage `json:"Response"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("tencent %s invalid response: %w", action, err)
+	}
+	var errResp struct {
+		Error *tencentError `json:"Error"`
+	}
+	if err := json.Unmarshal(envelope.Response, &errResp); err == nil && errResp.Error != nil {
+		return &ProviderError{
+			Provider:   PROVIDER_TENCENT,
+			StatusCode: resp.StatusCode,
+			Code:       errResp.Error.Code,
+			Message:    errResp.Error.Message,
+		}
+	}
+	return json.Unmarshal(envelope.Response, out)
+}
+
+func (c *tencentClient) sign(host, service string, body []byte, now time.Time) string {
+	date := now.Format("2006-01-02")
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:application/json; charset=utf-8\nhost:" + host + "\n",
+		"content-type;host",
+		sha256Hex(body),
+	}, "\n")
+
+	credentialScope := date + "/" + service + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(now.Unix(), 10),
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	secretDate := hmacSha256([]byte("TC3"+c.cred.SecretKey), date)
+	secretService := hmacSha256(secretDate, service)
+	secretSigning := hmacSha256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSha256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		c.cred.SecretId, credentialScope, signature)
+}
+
+// TencentTranscriber 腾讯云一句话识别，适用于 60 秒以内的音频
+type TencentTranscriber struct {
+	client *tencentClient
+}
+
+func NewTencentTranscriber(cred Credential) *TencentTranscriber {
+	return &TencentTranscriber{
+		client: &tencentClient{cred: cred, httpClient: &http.Client{Timeout: 60 * time.Second}},
+	}
+}
+
+func (t *TencentTranscriber) Transcribe(ctx context.Context, req *TranscribeRequest) (*TranscribeResult, error) {
+	format := req.Format
+	if format == "" {
+		format = FORMAT_WAV
+	}
+	payload := map[string]any{
+		"EngSerViceType": tencentEngineType(req.Language),
+		"SourceType":     1,
+		"VoiceFormat":    format,
+		"Data":           base64.StdEncoding.EncodeToString(req.Audio),
+		"DataLen":        len(req.Audio),
+	}
+
+	var out struct {
+		Result        string `json:"Result"`
+		AudioDuration int64  `json:"AudioDuration"`
+	}
+	if err := t.client.call(ctx, tencentAsrHost, tencentAsrVersion, "", "SentenceRecognition", payload, &out); err != nil {
+		return nil, err
+	}
+	return &TranscribeResult{Text: out.Result, DurationMs: out.AudioDuration}, nil
+}
+
+// TencentSynthesizer 腾讯云基础语音合成
+type TencentSynthesizer struct {
+	client *tencentClient
+}
+
+func NewTencentSynthesizer(cred Credential) *TencentSynthesizer {
+	return &TencentSynthesizer{
+		client: &tencentClient{cred: cred, httpClient: &http.Client{Timeout: 60 * time.Second}},
+	}
+}
+
+func (t *TencentSynthesizer) Synthesize(ctx context.Context, req *SynthesizeRequest) (*SynthesizeResult, error) {
+	voiceType := tencentDefaultVoiceType
+	if req.Speaker != "" {
+		v, err := strconv.Atoi(req.Speaker)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tencent voice type: %s", req.Speaker)
+		}
+		voiceType = v
+	}
+	format := req.Format
+	if format == "" {
+		format = FORMAT_MP3
+	}
+	sampleRate := req.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 16000
+	}
+
+	payload := map[string]any{
+		"Text":       req.Text,
+		"SessionId":  uuid.NewString(),
+		"VoiceType":  voiceType,
+		"Codec":      format,
+		"SampleRate": sampleRate,
+	}
+	// 腾讯云语速范围 [-2, 6]，0 为正常；音量范围 [-10, 10]，0 为正常
+	if req.Speed > 0 {
+		payload["Speed"] = req.Speed - 1
+	}
+	if req.Volume > 0 {
+		payload["Volume"] = (req.Volume - 5) * 2
+	}
+
+	var out struct {
+		Audio string `json:"Audio"`
+	}
+	if err := t.client.call(ctx, tencentTtsHost, tencentTtsVersion, tencentTtsRegion, "TextToVoice", payload, &out); err != nil {
+		return nil, err
+	}
+	audio, err := base64.StdEncoding.DecodeString(out.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("tencent TextToVoice invalid audio: %w", err)
+	}
+	return &SynthesizeResult{Audio: audio, Format: format}, nil
+}
+
+// tencentEngineType 将语言转换为识别引擎类型，已是引擎类型（如 16k_zh）时原样返回
+func tencentEngineType(language string) string {
+	switch {
+	case language == "":
+		return "16k_zh"
+	case strings.HasPrefix(language, "8k_") || strings.HasPrefix(language, "16k_"):
+		return language
+	default:
+		return "16k_" + strings.SplitN(language, "-", 2)[0]
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,105 @@
+create table gzva_chatroom.room
+(
+    id          bigint auto_increment
+        primary key,
+    create_time timestamp    default CURRENT_TIMESTAMP not null,
+    update_time timestamp    default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time timestamp    default CURRENT_TIMESTAMP not null,
+    del_state   smallint     default 0                 not null,
+    version     bigint       default 0                 not null comment '版本号',
+    name        varchar(64)  default ''                not null comment '房间名',
+    description varchar(255) default ''                not null comment '房间简介',
+    owner_id    bigint       default 0                 not null comment '房主用户id',
+    key idx_owner_id (owner_id)
+)
+    comment '聊天室房间表';
+
+create table gzva_chatroom.room_member
+(
+    id          bigint auto_increment
+        primary key,
+    create_time timestamp   default CURRENT_TIMESTAMP not null,
+    update_time timestamp   default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time timestamp   default CURRENT_TIMESTAMP not null,
+    del_state   smallint    default 0                 not null,
+    version     bigint      default 0                 not null comment '版本号',
+    room_id     bigint      default 0                 not null comment '房间id',
+    user_id     bigint      default 0                 not null comment '成员用户id',
+    role        varchar(16) default 'member'          not null comment '成员角色 owner/admin/member/muted',
+    unique key uk_room_user (room_id, user_id),
+    key idx_user_id (user_id)
+)
+    comment '聊天室房间成员表';
+
+create table gzva_chatroom.chat_message
+(
+    id           bigint auto_increment
+        primary key,
+    create_time  timestamp   default CURRENT_TIMESTAMP not null,
+    update_time  timestamp   default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time  timestamp   default CURRENT_TIMESTAMP not null,
+    del_state    smallint    default 0                 not null,
+    version      bigint      default 0                 not null comment '版本号',
+    type         varchar(32) default ''                not null comment '消息类型 chat/notification',
+    from_user_id bigint      default 0                 not null comment '发送者用户id',
+    to_user_id   bigint      default 0                 not null comment '私聊接收者用户id，房间消息为0',
+    room_id      bigint      default 0                 not null comment '房间id，私聊消息为0',
+    data         text                                  not null comment '消息内容json',
+    send_time    bigint      default 0                 not null comment '发送时间戳(秒)',
+    seq          bigint      default 0                 not null comment '房间消息在房间内的序号，私聊消息为0',
+    key idx_room_id (room_id, id),
+    key idx_room_seq (room_id, seq),
+    key idx_from_to (from_user_id, to_user_id, id)
+)
+    comment '聊天室消息表';
+
+create table gzva_chatroom.user_message
+(
+    id          bigint auto_increment
+        primary key,
+    create_time timestamp default CURRENT_TIMESTAMP not null,
+    update_time timestamp default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time timestamp default CURRENT_TIMESTAMP not null,
+    del_state   smallint  default 0                 not null,
+    version     bigint    default 0                 not null comment '版本号',
+    user_id     bigint    default 0                 not null comment '接收者用户id',
+    seq         bigint    default 0                 not null comment '接收者的消息序号，按用户递增',
+    message_id  bigint    default 0                 not null comment '消息id',
+    unique key uk_user_seq (user_id, seq)
+)
+    comment '聊天室用户收件箱表，离线消息按序号补发';
+
+create table gzva_chatroom.bot
+(
+    id            bigint auto_increment
+        primary key,
+    create_time   timestamp    default CURRENT_TIMESTAMP not null,
+    update_time   timestamp    default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time   timestamp    default CURRENT_TIMESTAMP not null,
+    del_state     smallint     default 0                 not null,
+    version       bigint       default 0                 not null comment '版本号',
+    owner_id      bigint       default 0                 not null comment '创建者用户id',
+    name          varchar(64)  default ''                not null comment '机器人名称，在房间中用 @名称 提及',
+    llm_config_id bigint       default 0                 not null comment '使用的 LLM 配置id（属于创建者）',
+    system_prompt text                                   not null comment '系统提示词',
+    key idx_owner_id (owner_id)
+)
+    comment '聊天室机器人表，加入房间时以 -id 作为成员用户id';
+
+create table gzva_chatroom.read_cursor
+(
+    id          bigint auto_increment
+        primary key,
+    create_time timestamp default CURRENT_TIMESTAMP not null,
+    update_time timestamp default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time timestamp default CURRENT_TIMESTAMP not null,
+    del_state   smallint  default 0                 not null,
+    version     bigint    default 0                 not null comment '版本号',
+    user_id     bigint    default 0                 not null comment '用户id',
+    room_id     bigint    default 0                 not null comment '房间id，私聊为0',
+    peer_id     bigint    default 0                 not null comment '私聊对方用户id，房间为0',
+    message_id  bigint    default 0                 not null comment '已读到的消息id',
+    unique key uk_user_conversation (user_id, room_id, peer_id),
+    key idx_room (room_id)
+)
+    comment '聊天室已读位置表，每个用户在每个房间或私聊中一条';
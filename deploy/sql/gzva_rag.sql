@@ -0,0 +1,65 @@
+create table gzva_rag.file_upload
+(
+    id          bigint auto_increment
+        primary key,
+    create_time timestamp    default CURRENT_TIMESTAMP not null,
+    update_time timestamp    default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time timestamp                              null,
+    del_state   smallint     default 0                 not null,
+    version     bigint       default 0                 not null comment '版本号',
+    user_id     bigint                                 null,
+    file_name   varchar(255) default ''                not null,
+    file_format varchar(32)                            null comment '文件的格式',
+    bucket_name varchar(64)                            null,
+    file_path   varchar(512) default ''                not null comment '文件存储路径',
+    store_type  varchar(16)  default ''                not null comment '存储方式：local,cors,minio',
+    status      smallint     default 0                 not null comment '处理状态：0已上传 1可检索 2排队中 3解析中 4向量化中 5失败',
+    error_msg   varchar(512) default ''                not null comment '处理失败的原因',
+    chunk_count bigint       default 0                 not null comment '向量化后的切片数',
+    knowledge_base_id bigint default 0                 not null comment '所属知识库，0 表示不属于任何知识库',
+    chunk_strategy varchar(32) default ''              not null comment '上传时指定的切片策略，为空时使用知识库或默认策略',
+    chunk_size  int          default 0                 not null comment '上传时指定的切片长度，0 表示使用知识库或默认值',
+    chunk_overlap int        default 0                 not null comment '上传时指定的相邻切片重叠长度',
+    key idx_user_id (user_id),
+    key idx_knowledge_base_id (knowledge_base_id)
+)
+    comment 'rag上传文件表';
+
+-- 已有部署升级：status 原来只有 0/1，取值含义不变
+-- alter table gzva_rag.file_upload
+--     modify status smallint default 0 not null comment '处理状态：0已上传 1可检索 2排队中 3解析中 4向量化中 5失败',
+--     add error_msg varchar(512) default '' not null comment '处理失败的原因',
+--     add chunk_count bigint default 0 not null comment '向量化后的切片数';
+
+-- 已有部署升级：文件归属知识库
+-- alter table gzva_rag.file_upload
+--     add knowledge_base_id bigint default 0 not null comment '所属知识库，0 表示不属于任何知识库',
+--     add key idx_knowledge_base_id (knowledge_base_id);
+
+-- 已有部署升级：切片策略
+-- alter table gzva_rag.file_upload
+--     add chunk_strategy varchar(32) default '' not null comment '上传时指定的切片策略，为空时使用知识库或默认策略',
+--     add chunk_size int default 0 not null comment '上传时指定的切片长度，0 表示使用知识库或默认值',
+--     add chunk_overlap int default 0 not null comment '上传时指定的相邻切片重叠长度';
+-- alter table gzva_rag.knowledge_base
+--     add chunk_strategy varchar(32) default '' not null comment '切片策略：fixed_tokens,recursive,markdown,sentence，为空时使用默认策略';
+
+create table gzva_rag.knowledge_base
+(
+    id              bigint auto_increment
+        primary key,
+    create_time     timestamp    default CURRENT_TIMESTAMP not null,
+    update_time     timestamp    default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time     timestamp                              null,
+    del_state       smallint     default 0                 not null,
+    version         bigint       default 0                 not null comment '版本号',
+    user_id         bigint                                 not null,
+    name            varchar(64)  default ''                not null,
+    description     varchar(512) default ''                not null,
+    embedding_model varchar(128) default ''                not null comment '向量化使用的模型，为空时使用 RAG 服务的默认模型',
+    chunk_size      int          default 0                 not null comment '切片长度，0 表示使用默认值',
+    chunk_overlap   int          default 0                 not null comment '相邻切片的重叠长度',
+    chunk_strategy  varchar(32)  default ''                not null comment '切片策略：fixed_tokens,recursive,markdown,sentence，为空时使用默认策略',
+    key idx_user_id (user_id)
+)
+    comment 'rag知识库表';
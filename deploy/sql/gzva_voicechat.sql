@@ -0,0 +1,25 @@
+create table gzva_voicechat.turn_metric
+(
+    id                 bigint auto_increment
+        primary key,
+    create_time        timestamp    default CURRENT_TIMESTAMP not null,
+    update_time        timestamp    default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP,
+    delete_time        timestamp    default CURRENT_TIMESTAMP not null,
+    del_state          smallint     default 0                 not null,
+    version            bigint       default 0                 not null comment '版本号',
+    user_id            bigint       default 0                 not null,
+    call_id            varchar(64)  default ''                not null comment '通话id',
+    conversation_id    varchar(64)  default ''                not null comment 'llm会话id',
+    turn_index         bigint       default 0                 not null comment '通话内轮次序号',
+    asr_provider       varchar(64)  default ''                not null,
+    tts_provider       varchar(64)  default ''                not null,
+    llm_model          varchar(128) default ''                not null,
+    asr_final_at       bigint       default 0                 not null comment 'ASR final 到达时间(unix毫秒)',
+    llm_first_token_ms bigint       default 0                 not null comment 'ASR final 到 LLM 首 token 耗时',
+    llm_done_ms        bigint       default 0                 not null comment 'ASR final 到 LLM 输出完成耗时',
+    tts_first_audio_ms bigint       default 0                 not null comment 'ASR final 到 TTS 首帧音频耗时',
+    tts_end_ms         bigint       default 0                 not null comment 'ASR final 到 TTS 播放结束耗时',
+    key idx_call_id (call_id),
+    key idx_user_id (user_id)
+)
+    comment '语音对话单轮延迟指标表';
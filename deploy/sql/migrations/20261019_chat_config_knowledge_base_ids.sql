@@ -0,0 +1,4 @@
+-- 对话配置绑定的知识库，保存为逗号分隔的知识库 id（最多 20 个），为空时不限定知识库。
+-- 需在支持知识库的 llm 服务上线前执行，否则读写 chat_config 会因缺少该列失败。
+alter table gzva_llmservice.chat_config
+    add knowledge_base_ids varchar(512) null comment '绑定的知识库id，逗号分隔';
@@ -0,0 +1,5 @@
+-- 房间消息改为按房间分配序号，同一条消息的所有成员收到同一个序号，不再为每个成员写收件记录。
+-- 历史房间消息的 seq 为 0，仍可从成员的收件记录中补发。
+alter table gzva_chatroom.chat_message
+    add seq bigint default 0 not null comment '房间消息在房间内的序号，私聊消息为0',
+    add key idx_room_seq (room_id, seq);
@@ -0,0 +1,11 @@
+-- 配置密钥改为信封加密落库（enc:v1:<主密钥id>:<加密的数据密钥>:<密文>），
+-- 密文比明文长约 100 字节，原有字段放不下，统一放宽到 1024。
+-- 需在开启加密的版本上线前执行，之后再用 -rotate-secrets 加密历史明文。
+alter table gzva_voicechat.asr_config
+    modify secret_key varchar(1024) null comment '密钥（加密存储）';
+
+alter table gzva_voicechat.tts_config
+    modify secret_key varchar(1024) null comment '密钥（加密存储）';
+
+alter table gzva_llmservice.chat_config
+    modify api_key varchar(1024) null comment 'API Key（加密存储）';
@@ -0,0 +1,77 @@
+package chatbridge
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	red "github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// CHANNEL 其他服务投递聊天室消息的 Redis Pub/Sub 频道
+const CHANNEL = "chatroom:bridge"
+
+// 语音房间投递到聊天室的消息类型
+const (
+	MESSAGE_TYPE_VOICE_TRANSCRIPT = "voice_transcript" // 语音识别或智能体回复的文字
+	MESSAGE_TYPE_VOICE_JOIN       = "voice_join"       // 成员加入语音房间
+	MESSAGE_TYPE_VOICE_LEAVE      = "voice_leave"      // 成员离开语音房间
+)
+
+// MESSAGE_TYPE_DOC_STATUS RAG 文档处理状态变化，发给上传者
+const MESSAGE_TYPE_DOC_STATUS = "doc_status"
+
+// Message 与聊天室 websocket 消息格式一致，Room 不为空时发给房间成员，To 与 Room 都为空时广播给所有连接
+type Message struct {
+	Type      string      `json:"type"`
+	From      string      `json:"from,omitempty"`
+	To        string      `json:"to,omitempty"`
+	Room      string      `json:"room,omitempty"`
+	Data      interface{} `json:"data"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+// Publish 投递一条聊天室消息
+func Publish(ctx context.Context, rds *redis.Redis, msg *Message) error {
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().Unix()
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = rds.PublishCtx(ctx, CHANNEL, string(data))
+	return err
+}
+
+// Subscribe 订阅投递的消息并交给 handler 处理，ctx 结束前一直阻塞，断线后由 go-redis 自动重连
+func Subscribe(ctx context.Context, conf redis.RedisConf, handler func(msg *Message)) {
+	client := red.NewClient(&red.Options{
+		Addr:     conf.Host,
+		Password: conf.Pass,
+	})
+	defer client.Close()
+
+	sub := client.Subscribe(ctx, CHANNEL)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			var msg Message
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				logx.Errorf("chatbridge: invalid message: %v", err)
+				continue
+			}
+			handler(&msg)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package consts
+
+// 配置连通性测试的归一化错误类型
+const (
+	CREDENTIAL_ERROR_NONE           = ""
+	CREDENTIAL_ERROR_AUTH_FAILED    = "auth_failed"    // 密钥错误或无权限
+	CREDENTIAL_ERROR_NOT_FOUND      = "not_found"      // 地址错误或模型不存在
+	CREDENTIAL_ERROR_RATE_LIMITED   = "rate_limited"   // 限流或额度不足
+	CREDENTIAL_ERROR_TIMEOUT        = "timeout"        // 请求超时
+	CREDENTIAL_ERROR_NETWORK        = "network_error"  // 无法连接服务商
+	CREDENTIAL_ERROR_INVALID_CONFIG = "invalid_config" // 配置缺失或不支持的服务商
+	CREDENTIAL_ERROR_PROVIDER       = "provider_error" // 服务商返回的其他错误
+)
@@ -0,0 +1,71 @@
+package secretbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Column 模型中一个加密存储的字段。模型写入前调用 Seal、读取后调用 Open，上层只接触明文
+type Column[T any] struct {
+	Keyring *Keyring
+	// Name 表名.列名，用于错误信息，如 asr_config.secret_key
+	Name  string
+	Field func(row *T) *sql.NullString
+	Id    func(row *T) int64
+}
+
+// Seal 原地加密，返回恢复明文的函数，避免调用方拿到密文
+func (c Column[T]) Seal(row *T) (func(), error) {
+	field := c.Field(row)
+	plaintext := *field
+	ciphertext, err := c.Keyring.Encrypt(plaintext.String)
+	if err != nil {
+		return nil, err
+	}
+	field.String = ciphertext
+	return func() { *field = plaintext }, nil
+}
+
+// Open 原地解密，历史明文原样保留
+func (c Column[T]) Open(row *T) error {
+	field := c.Field(row)
+	plaintext, err := c.Keyring.Decrypt(field.String)
+	if err != nil {
+		return fmt.Errorf("decrypt %s failed, id: %d: %w", c.Name, c.Id(row), err)
+	}
+	field.String = plaintext
+	return nil
+}
+
+// OpenList 解密查询结果，查询出错时直接返回错误，便于包裹 FindXxx 的返回值
+func (c Column[T]) OpenList(list []*T, err error) ([]*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range list {
+		if err := c.Open(row); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// Rotate 把 rows 中的明文或旧主密钥密文用当前主密钥重新加密，update 负责写回（写回前会再次 Seal），
+// 返回更新的行数
+func (c Column[T]) Rotate(ctx context.Context, rows []*T, update func(ctx context.Context, row *T) error) (int, error) {
+	rotated := 0
+	for _, row := range rows {
+		if !c.Keyring.NeedsRotation(c.Field(row).String) {
+			continue
+		}
+		if err := c.Open(row); err != nil {
+			return rotated, err
+		}
+		if err := update(ctx, row); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	return rotated, nil
+}
@@ -0,0 +1,79 @@
+package secretbox
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+type testRow struct {
+	Id     int64
+	Secret sql.NullString
+}
+
+func newTestColumn(k *Keyring) Column[testRow] {
+	return Column[testRow]{
+		Keyring: k,
+		Name:    "test.secret",
+		Field:   func(row *testRow) *sql.NullString { return &row.Secret },
+		Id:      func(row *testRow) int64 { return row.Id },
+	}
+}
+
+func TestColumnSealOpen(t *testing.T) {
+	k, _ := newTestKeyring(t)
+	col := newTestColumn(k)
+	row := &testRow{Id: 1, Secret: sql.NullString{String: "sk-secret", Valid: true}}
+
+	restore, err := col.Seal(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed := row.Secret
+	if !IsEncrypted(sealed.String) || !sealed.Valid {
+		t.Fatalf("sealed = %+v", sealed)
+	}
+	restore()
+	if row.Secret.String != "sk-secret" {
+		t.Fatalf("restore = %q", row.Secret.String)
+	}
+
+	list, err := col.OpenList([]*testRow{{Id: 2, Secret: sealed}, {Id: 3, Secret: sql.NullString{String: "legacy"}}}, nil)
+	if err != nil || list[0].Secret.String != "sk-secret" || list[1].Secret.String != "legacy" {
+		t.Fatalf("OpenList = %+v, %v", list, err)
+	}
+
+	other, _ := newTestKeyring(t)
+	if err := newTestColumn(other).Open(&testRow{Id: 4, Secret: sealed}); err == nil {
+		t.Error("Open with unknown master key should fail")
+	}
+}
+
+func TestColumnRotate(t *testing.T) {
+	oldKeyring, oldMaster := newTestKeyring(t)
+	oldCipher, _ := oldKeyring.Encrypt("old-secret")
+	k, _ := newTestKeyring(t, oldMaster)
+	current, _ := k.Encrypt("current-secret")
+
+	rows := []*testRow{
+		{Id: 1, Secret: sql.NullString{String: oldCipher}},
+		{Id: 2, Secret: sql.NullString{String: "plain"}},
+		{Id: 3, Secret: sql.NullString{String: current}},
+		{Id: 4},
+	}
+	var updated []int64
+	n, err := newTestColumn(k).Rotate(context.Background(), rows, func(ctx context.Context, row *testRow) error {
+		updated = append(updated, row.Id)
+		return nil
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("Rotate = %d, %v", n, err)
+	}
+	if len(updated) != 2 || updated[0] != 1 || updated[1] != 2 {
+		t.Errorf("updated ids = %v, want [1 2]", updated)
+	}
+	// update 收到的是明文，由模型写回时再次加密
+	if rows[0].Secret.String != "old-secret" {
+		t.Errorf("row 1 passed to update = %q, want plaintext", rows[0].Secret.String)
+	}
+}
@@ -0,0 +1,27 @@
+package secretbox
+
+import "strings"
+
+const maskPlaceholder = "****"
+
+// Mask 脱敏展示密钥，只保留前 3 位和后 4 位，如 sk-****abcd
+func Mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	runes := []rune(secret)
+	if len(runes) <= 8 {
+		return maskPlaceholder
+	}
+	return string(runes[:3]) + maskPlaceholder + string(runes[len(runes)-4:])
+}
+
+// IsMasked 是否为 Mask 返回的脱敏值
+func IsMasked(secret string) bool {
+	return strings.Contains(secret, maskPlaceholder)
+}
+
+// Unchanged 更新时密钥为空或为脱敏值，表示沿用已保存的密钥（密钥只写不读）
+func Unchanged(secret string) bool {
+	return secret == "" || IsMasked(secret)
+}
@@ -0,0 +1,223 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 密文格式：enc:v1:<主密钥id>:<主密钥加密后的数据密钥>:<数据密钥加密后的明文>
+// 每次加密生成新的数据密钥（即每行一个），主密钥只用于加解密数据密钥。
+const (
+	cipherPrefix = "enc:v1:"
+	keySize      = 32
+	keyIdLength  = 8
+)
+
+var (
+	ErrMissingMasterKey = errors.New("secretbox: master key is required, generate one with `openssl rand -base64 32`")
+	ErrWeakMasterKey    = errors.New("secretbox: master key is not random, generate one with `openssl rand -base64 32`")
+	ErrInvalidMasterKey = errors.New("secretbox: master key must be base64 encoded 32 bytes")
+	ErrUnknownKey       = errors.New("secretbox: unknown master key id")
+	ErrMalformed        = errors.New("secretbox: malformed ciphertext")
+)
+
+// Config 主密钥配置，轮换时把旧的 MasterKey 移到 PreviousMasterKeys
+type Config struct {
+	MasterKey          string
+	PreviousMasterKeys []string `json:",optional"`
+}
+
+// Keyring 持有当前主密钥及仍需解密的历史主密钥
+type Keyring struct {
+	primaryId string
+	keys      map[string][]byte
+}
+
+// NewKeyring 校验并加载主密钥。当前主密钥不能为空，也不能是全零、
+// 或由可打印字符拼成的口令（如示例配置里的值）；历史主密钥只用于解密，不做强度校验
+func NewKeyring(c Config) (*Keyring, error) {
+	if strings.TrimSpace(c.MasterKey) == "" {
+		return nil, ErrMissingMasterKey
+	}
+	primary, err := decodeKey(c.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+	if weakKey(primary) {
+		return nil, ErrWeakMasterKey
+	}
+
+	k := &Keyring{
+		primaryId: keyId(primary),
+		keys:      make(map[string][]byte),
+	}
+	k.keys[k.primaryId] = primary
+	for _, raw := range c.PreviousMasterKeys {
+		key, err := decodeKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[keyId(key)] = key
+	}
+	return k, nil
+}
+
+func MustNewKeyring(c Config) *Keyring {
+	k, err := NewKeyring(c)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// GenerateMasterKey 生成新的主密钥（base64）
+func GenerateMasterKey() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt 使用当前主密钥加密，空字符串原样返回
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := seal(k.keys[k.primaryId], dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return cipherPrefix + k.primaryId + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密，未加密的历史明文原样返回
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, cipherPrefix), ":")
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+	master, ok := k.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, parts[0])
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	dataKey, err := open(master, wrapped)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataKey, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation 明文或非当前主密钥加密的值需要重新加密
+func (k *Keyring) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	return !strings.HasPrefix(value, cipherPrefix+k.primaryId+":")
+}
+
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, cipherPrefix)
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("secretbox: decrypt failed: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func decodeKey(raw string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw))
+	if err != nil || len(key) != keySize {
+		return nil, ErrInvalidMasterKey
+	}
+	return key, nil
+}
+
+// weakKey 所有字节相同（如全零），或全部是可打印 ASCII 字符（随机生成的概率可以忽略）
+func weakKey(key []byte) bool {
+	same, printable := true, true
+	for _, b := range key {
+		if b != key[0] {
+			same = false
+		}
+		if b < 0x20 || b > 0x7e {
+			printable = false
+		}
+	}
+	return same || printable
+}
+
+func keyId(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])[:keyIdLength]
+}
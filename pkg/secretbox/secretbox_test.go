@@ -0,0 +1,117 @@
+package secretbox
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T, previous ...string) (*Keyring, string) {
+	t.Helper()
+	master, err := GenerateMasterKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return MustNewKeyring(Config{MasterKey: master, PreviousMasterKeys: previous}), master
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	k, _ := newTestKeyring(t)
+
+	a, err := k.Encrypt("sk-1234567890abcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := k.Encrypt("sk-1234567890abcd")
+	if a == b || !IsEncrypted(a) || strings.Contains(a, "1234567890") {
+		t.Fatalf("unexpected ciphertext: %s / %s", a, b)
+	}
+
+	plain, err := k.Decrypt(a)
+	if err != nil || plain != "sk-1234567890abcd" {
+		t.Fatalf("decrypt = %q, %v", plain, err)
+	}
+
+	// 历史明文原样返回
+	if plain, _ := k.Decrypt("legacy-plain"); plain != "legacy-plain" {
+		t.Errorf("legacy decrypt = %q", plain)
+	}
+	if empty, _ := k.Encrypt(""); empty != "" {
+		t.Errorf("empty encrypt = %q", empty)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	oldKeyring, oldMaster := newTestKeyring(t)
+	ciphertext, _ := oldKeyring.Encrypt("secret-value")
+
+	newKeyring, _ := newTestKeyring(t, oldMaster)
+	if !newKeyring.NeedsRotation(ciphertext) || !newKeyring.NeedsRotation("plain") {
+		t.Fatal("old ciphertext and plaintext should need rotation")
+	}
+	plain, err := newKeyring.Decrypt(ciphertext)
+	if err != nil || plain != "secret-value" {
+		t.Fatalf("decrypt with previous key = %q, %v", plain, err)
+	}
+	rotated, _ := newKeyring.Encrypt(plain)
+	if newKeyring.NeedsRotation(rotated) {
+		t.Error("rotated value should not need rotation")
+	}
+
+	withoutOld, _ := newTestKeyring(t)
+	if _, err := withoutOld.Decrypt(ciphertext); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("err = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestInvalidMasterKey(t *testing.T) {
+	if _, err := NewKeyring(Config{MasterKey: "short"}); !errors.Is(err, ErrInvalidMasterKey) {
+		t.Errorf("err = %v, want ErrInvalidMasterKey", err)
+	}
+}
+
+func TestMask(t *testing.T) {
+	cases := map[string]string{
+		"":                  "",
+		"abcd":              "****",
+		"sk-1234567890abcd": "sk-****abcd",
+	}
+	for in, want := range cases {
+		if got := Mask(in); got != want {
+			t.Errorf("Mask(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if !Unchanged(Mask("sk-1234567890abcd")) || !Unchanged("") || Unchanged("sk-new") {
+		t.Error("Unchanged mismatch")
+	}
+}
+
+func TestNewKeyringRejectsWeakMasterKey(t *testing.T) {
+	zero := base64.StdEncoding.EncodeToString(make([]byte, keySize))
+	// 曾出现在示例配置中的值：32 个可打印字符
+	ascii := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	tests := []struct {
+		name string
+		key  string
+		want error
+	}{
+		{"empty", "", ErrMissingMasterKey},
+		{"blank", "  ", ErrMissingMasterKey},
+		{"not base64", "not-a-key", ErrInvalidMasterKey},
+		{"short", base64.StdEncoding.EncodeToString(make([]byte, 16)), ErrInvalidMasterKey},
+		{"all zero", zero, ErrWeakMasterKey},
+		{"printable", ascii, ErrWeakMasterKey},
+	}
+	for _, tt := range tests {
+		if _, err := NewKeyring(Config{MasterKey: tt.key}); !errors.Is(err, tt.want) {
+			t.Errorf("%s: NewKeyring() err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// 弱密钥仍可作为历史主密钥参与解密，便于轮换
+	master, _ := GenerateMasterKey()
+	if _, err := NewKeyring(Config{MasterKey: master, PreviousMasterKeys: []string{ascii}}); err != nil {
+		t.Errorf("weak previous master key rejected: %v", err)
+	}
+}
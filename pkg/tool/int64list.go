@@ -0,0 +1,33 @@
+package tool
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// Int64sToNullString 把 id 列表保存为逗号分隔的字符串，列表为空时为 NULL
+func Int64sToNullString(ids []int64) sql.NullString {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return StringToNullString(strings.Join(parts, ","))
+}
+
+// NullStringToInt64s 解析逗号分隔的 id 列表，忽略无法解析的项
+func NullStringToInt64s(ns sql.NullString) []int64 {
+	if !ns.Valid || ns.String == "" {
+		return nil
+	}
+	parts := strings.Split(ns.String, ",")
+	ids := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -12,6 +12,7 @@ type SnPrefix string
 const (
 	SN_PREFIX_THIRD_PAYMENT SnPrefix = "PMT" //第三方支付流水记录前缀 looklook_payment/third_payment
 	SN_PREFIX_WEBSOCKET     SnPrefix = "WS"
+	SN_PREFIX_CHATROOM_BOT  SnPrefix = "BOT" //聊天室机器人回复流与工具确认
 )
 
 // 生成单号
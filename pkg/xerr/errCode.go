@@ -23,3 +23,22 @@ const (
 	USER_PERMISSION_DENIED_ERROR uint32 = 200004 // 用户无权限
 	USER_ALREADY_EXISTS_ERROR    uint32 = 200005 // 用户已存在
 )
+
+// 聊天室模块
+const (
+	ROOM_NOT_FOUND_ERROR         uint32 = 300001 // 房间不存在
+	ROOM_MEMBER_NOT_FOUND_ERROR  uint32 = 300002 // 不是房间成员
+	ROOM_MEMBER_MUTED_ERROR      uint32 = 300003 // 已被禁言
+	WS_MESSAGE_TOO_LARGE_ERROR   uint32 = 300004 // 消息超过大小限制
+	WS_RATE_LIMIT_ERROR          uint32 = 300005 // 发送过于频繁
+	BOT_NOT_FOUND_ERROR          uint32 = 300006 // 机器人不存在
+	BOT_CONFIRM_EXPIRED_ERROR    uint32 = 300007 // 工具调用确认已过期
+	CHAT_MESSAGE_NOT_FOUND_ERROR uint32 = 300008 // 消息不存在或不属于该会话
+	WS_KICKED_ERROR              uint32 = 300009 // 连接被管理员断开
+)
+
+// 语音模块
+const (
+	SIP_CALLEE_NOT_ALLOWED_ERROR uint32 = 400001 // 被叫不在外呼白名单中
+	SIP_CALL_RATE_LIMIT_ERROR    uint32 = 400002 // 外呼过于频繁
+)
@@ -19,6 +19,21 @@ func init() {
 	msg[USER_REGISTER_ERROR] = "用户注册失败"
 	msg[USER_PERMISSION_DENIED_ERROR] = "用户无权限"
 	msg[USER_ALREADY_EXISTS_ERROR] = "用户已存在"
+
+	// 聊天室模块
+	msg[ROOM_NOT_FOUND_ERROR] = "房间不存在"
+	msg[ROOM_MEMBER_NOT_FOUND_ERROR] = "不是房间成员"
+	msg[ROOM_MEMBER_MUTED_ERROR] = "已被禁言"
+	msg[WS_MESSAGE_TOO_LARGE_ERROR] = "消息过大"
+	msg[WS_RATE_LIMIT_ERROR] = "发送过于频繁,请稍后再试"
+	msg[BOT_NOT_FOUND_ERROR] = "机器人不存在"
+	msg[BOT_CONFIRM_EXPIRED_ERROR] = "确认已过期或已处理"
+	msg[CHAT_MESSAGE_NOT_FOUND_ERROR] = "消息不存在"
+	msg[WS_KICKED_ERROR] = "连接已被管理员断开"
+
+	// 语音模块
+	msg[SIP_CALLEE_NOT_ALLOWED_ERROR] = "该号码不允许外呼"
+	msg[SIP_CALL_RATE_LIMIT_ERROR] = "外呼过于频繁,请稍后再试"
 }
 
 func MapErrMsg(errcode uint32) string {
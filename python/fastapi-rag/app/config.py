@@ -60,6 +60,11 @@ COLLECTION_NAME = get_env_variable("COLLECTION_NAME", "documents")
 CHUNK_SIZE = int(get_env_variable("CHUNK_SIZE", "1500"))
 CHUNK_OVERLAP = int(get_env_variable("CHUNK_OVERLAP", "100"))
 
+# 关键词索引缓存与混合检索候选数量
+KEYWORD_INDEX_TTL = float(get_env_variable("KEYWORD_INDEX_TTL", "300"))
+KEYWORD_INDEX_MAX_FILES = int(get_env_variable("KEYWORD_INDEX_MAX_FILES", "1024"))
+HYBRID_CANDIDATE_FACTOR = int(get_env_variable("HYBRID_CANDIDATE_FACTOR", "4"))
+
 pg_connection_suffix = f"{quote_plus(POSTGRES_USER)}:{quote_plus(POSTGRES_PASSWORD)}@{DB_HOST}:{DB_PORT}/{quote_plus(POSTGRES_DB)}"
 PG_CONNECTION_STRING = f"postgresql+psycopg2://{pg_connection_suffix}"
 PG_DSN = f"postgresql://{pg_connection_suffix}"
@@ -215,6 +220,9 @@ if VECTOR_DB_TYPE == VectorDBType.PGVECTOR:
         chunk_size=CHUNK_SIZE,
         chunk_overlap=CHUNK_OVERLAP,
         logger=logger,
+        keyword_index_ttl=KEYWORD_INDEX_TTL,
+        keyword_index_max_files=KEYWORD_INDEX_MAX_FILES,
+        hybrid_candidate_factor=HYBRID_CANDIDATE_FACTOR,
     )
 else:
     raise ValueError(f"Unsupported VECTOR_DB_TYPE: {VECTOR_DB_TYPE}")
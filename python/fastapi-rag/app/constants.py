@@ -7,4 +7,6 @@ class PGVector(str, Enum):
     """pgvector 表结构名称及常见索引常量。"""
     TABLE_NAME = "langchain_pg_embedding"
     COLUMN_NAME = "custom_id"
-    INDEX_NAME = f"idx_{TABLE_NAME}_{COLUMN_NAME}"
\ No newline at end of file
+    INDEX_NAME = f"idx_{TABLE_NAME}_{COLUMN_NAME}"
+    # 按文件记录切片版本号，各副本据此判断进程内关键词索引是否过期
+    KEYWORD_GENERATION_TABLE = "rag_keyword_generation"
\ No newline at end of file
@@ -25,7 +25,7 @@ class PSQLDatabase:
 
 # 确保向量数据库的索引存在。
 async def ensure_vector_indexes():
-    """在 pgvector 表上创建常用索引，避免重复创建异常。"""
+    """在 pgvector 表上创建常用索引，并创建关键词索引版本表，避免重复创建异常。"""
     pool = await PSQLDatabase.get_pool()
     async with pool.acquire() as conn:
         await conn.execute(
@@ -42,6 +42,16 @@ async def ensure_vector_indexes():
             """
         )
 
+        await conn.execute(
+            f"""
+            CREATE TABLE IF NOT EXISTS {PGVector.KEYWORD_GENERATION_TABLE.value} (
+                file_id TEXT PRIMARY KEY,
+                generation BIGINT NOT NULL DEFAULT 0,
+                updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+            );
+            """
+        )
+
         logger.info("Vector database indexes ensured.")
 
 # 检查 PostgreSQL 数据库的健康状态。
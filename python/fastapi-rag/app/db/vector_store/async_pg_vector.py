@@ -110,6 +110,45 @@ class AsyncPgVector(ExtendedPgVector):
             file_id,
         )
 
+    async def aget_chunks_by_file_id(
+        self,
+        file_id: str,
+        executor=None,
+    ) -> List[Dict[str, Any]]:
+        """异步获取指定文件 ID 下的全部切片。"""
+        executor = executor or self._get_thread_pool()
+        return await run_in_executor(
+            executor,
+            super().get_chunks_by_file_id,
+            file_id,
+        )
+
+    async def abump_keyword_generations(
+        self,
+        file_ids: List[str],
+        executor=None,
+    ) -> None:
+        """异步递增文件的关键词索引版本号。"""
+        executor = executor or self._get_thread_pool()
+        await run_in_executor(
+            executor,
+            super().bump_keyword_generations,
+            file_ids,
+        )
+
+    async def aget_keyword_generations(
+        self,
+        file_ids: List[str],
+        executor=None,
+    ) -> Dict[str, int]:
+        """异步获取文件的关键词索引版本号。"""
+        executor = executor or self._get_thread_pool()
+        return await run_in_executor(
+            executor,
+            super().get_keyword_generations,
+            file_ids,
+        )
+
     async def aget_chunks_paginated(
         self,
         *,
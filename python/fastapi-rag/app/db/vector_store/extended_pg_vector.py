@@ -4,13 +4,15 @@ import logging
 import os
 import time
 from typing import Optional, Any, Dict, List, Union
-from sqlalchemy import event, delete, select, asc, desc, cast
+from sqlalchemy import event, delete, select, asc, desc, cast, text
 from sqlalchemy.orm import Session
 from sqlalchemy.engine import Engine
 from sqlalchemy.types import Integer
 from langchain_core.documents import Document
 from langchain_community.vectorstores.pgvector import PGVector
 
+from app.constants import PGVector as PGVectorConstants
+
 
 class ExtendedPgVector(PGVector):
     """在原有 PGVector 基础上新增查询日志、自定义删除等能力。"""
@@ -208,6 +210,54 @@ class ExtendedPgVector(PGVector):
                     digests[custom_id] = digest
             return digests
 
+    def get_chunks_by_file_id(self, file_id: str) -> List[Dict[str, Any]]:
+        """返回指定文件 ID 下的全部切片文本及元数据，供关键词索引构建使用。"""
+        if not file_id:
+            return []
+
+        with Session(self._bind) as session:
+            stmt = select(
+                self.EmbeddingStore.custom_id,
+                self.EmbeddingStore.document,
+                self.EmbeddingStore.cmetadata,
+            ).where(self.EmbeddingStore.cmetadata["file_id"].astext == file_id)
+            rows = session.execute(stmt).all()
+
+            return [
+                {
+                    "custom_id": custom_id,
+                    "page_content": document or "",
+                    "metadata": metadata if isinstance(metadata, dict) else {},
+                }
+                for custom_id, document, metadata in rows
+                if custom_id
+            ]
+
+    def bump_keyword_generations(self, file_ids: List[str]) -> None:
+        """切片变化后递增文件的关键词索引版本号，使所有副本在下次检索时重建索引。"""
+        if not file_ids:
+            return
+
+        table = PGVectorConstants.KEYWORD_GENERATION_TABLE.value
+        stmt = text(
+            f"INSERT INTO {table} (file_id, generation) VALUES (:file_id, 1) "
+            f"ON CONFLICT (file_id) DO UPDATE SET generation = {table}.generation + 1, updated_at = now()"
+        )
+        with Session(self._bind) as session:
+            session.execute(stmt, [{"file_id": file_id} for file_id in dict.fromkeys(file_ids)])
+            session.commit()
+
+    def get_keyword_generations(self, file_ids: List[str]) -> Dict[str, int]:
+        """返回文件的关键词索引版本号，未记录的文件不在结果中。"""
+        if not file_ids:
+            return {}
+
+        table = PGVectorConstants.KEYWORD_GENERATION_TABLE.value
+        stmt = text(f"SELECT file_id, generation FROM {table} WHERE file_id = ANY(:file_ids)")
+        with Session(self._bind) as session:
+            rows = session.execute(stmt, {"file_ids": list(file_ids)}).all()
+            return {file_id: generation for file_id, generation in rows}
+
     def get_chunks_paginated(
         self,
         *,
@@ -2,7 +2,7 @@
 
 from enum import Enum
 import hashlib
-from pydantic import BaseModel
+from pydantic import BaseModel, Field
 from typing import Optional
 
 
@@ -28,8 +28,21 @@ class StoreDocument(BaseModel):
     file_content_type: str
     file_id: str
 
-class QueryRequestBody(BaseModel):
-    """单文件范围内的向量检索请求体。"""
+class RetrievalMode(str, Enum):
+    """检索方式。"""
+    vector = "vector"  # 仅向量相似度
+    keyword = "keyword"  # 仅 BM25 关键词
+    hybrid = "hybrid"  # 向量与关键词结果按倒数排名融合
+
+class RetrievalOptions(BaseModel):
+    """检索方式与融合权重，未指定时使用向量检索。"""
+    mode: RetrievalMode = RetrievalMode.vector
+    vector_weight: float = Field(1.0, ge=0)
+    keyword_weight: float = Field(1.0, ge=0)
+    rrf_k: int = Field(60, ge=1)
+
+class QueryRequestBody(RetrievalOptions):
+    """单文件范围内的检索请求体。"""
     query: str
     file_id: str
     top_k: int = 4
@@ -40,7 +53,14 @@ class CleanupMethod(str, Enum):
     incremental = "incremental"
     full = "full"
 
-class QueryMultipleBody(BaseModel):
+class ChunkStrategy(str, Enum):
+    """文本切片策略。"""
+    fixed_tokens = "fixed_tokens"  # 固定 token 数
+    recursive = "recursive"  # 按段落、换行、空格递归拆分
+    markdown = "markdown"  # 按 Markdown 标题拆分
+    sentence = "sentence"  # 按中英文句子边界拆分
+
+class QueryMultipleBody(RetrievalOptions):
     """多文件检索请求体。"""
     query: str
     file_ids: list[str]
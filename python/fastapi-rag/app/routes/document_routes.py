@@ -5,7 +5,12 @@ from typing import List
 from fastapi import APIRouter, Body, Form, HTTPException, Query, Request
 
 from app.db.database import pg_health_check
-from app.models.document import CleanupMethod, QueryMultipleBody, QueryRequestBody
+from app.models.document import (
+    ChunkStrategy,
+    CleanupMethod,
+    QueryMultipleBody,
+    QueryRequestBody,
+)
 from app.services import (
     delete_documents,
     embed_file,
@@ -87,6 +92,9 @@ async def embed_file_upload(
     file_content_type: str = Form(None),
     entity_id: str = Form(None),
     cleanup_method: CleanupMethod = Form(CleanupMethod.incremental),
+    chunk_strategy: ChunkStrategy = Form(ChunkStrategy.recursive),
+    chunk_size: int | None = Form(None, ge=1),
+    chunk_overlap: int | None = Form(None, ge=0),
 ):
     """从 Minio 下载文件并完成向量化写入。"""
     user_id = _require_user_id(request)
@@ -99,19 +107,22 @@ async def embed_file_upload(
         entity_id=entity_id,
         user_id=user_id,
         cleanup_method=cleanup_method,
+        chunk_strategy=chunk_strategy,
+        chunk_size=chunk_size,
+        chunk_overlap=chunk_overlap,
         executor=_get_executor(request),
     )
 
 
 @router.post("/query")
 async def query_document(request: Request, body: QueryRequestBody):
-    """在单文件范围内执行向量检索。"""
+    """在单文件范围内执行向量、关键词或混合检索。"""
     _require_user_id(request)
     return await query_documents(body=body, executor=_get_executor(request))
 
 
 @router.post("/query-multiple")
 async def query_documents_multiple(request: Request, body: QueryMultipleBody):
-    """在多个文件范围内执行向量检索。"""
+    """在多个文件范围内执行向量、关键词或混合检索。"""
     _require_user_id(request)
     return await query_multiple_documents(body=body, executor=_get_executor(request))
\ No newline at end of file
@@ -0,0 +1,105 @@
+"""按切片策略把文本拆分为向量化切片。"""
+
+from typing import Any, Dict, List, Tuple
+
+from langchain_text_splitters import (
+    MarkdownHeaderTextSplitter,
+    RecursiveCharacterTextSplitter,
+    TokenTextSplitter,
+)
+
+from app.models.document import ChunkStrategy
+
+
+# fixed_tokens 策略使用的 tiktoken 编码
+TOKEN_ENCODING = "cl100k_base"
+
+# markdown 策略按三级以内的标题拆分
+_MARKDOWN_HEADERS = [("#", "h1"), ("##", "h2"), ("###", "h3")]
+
+# sentence 策略的分隔符：段落、换行后优先在中英文句末标点处断开，最后才退化为逗号、空格和单字
+_SENTENCE_SEPARATORS = [
+    "\n\n",
+    "\n",
+    "。",
+    "！",
+    "？",
+    "；",
+    "…",
+    ". ",
+    "! ",
+    "? ",
+    "; ",
+    "，",
+    ", ",
+    " ",
+    "",
+]
+
+
+def validate_chunking(strategy: ChunkStrategy | str, chunk_size: int, chunk_overlap: int) -> ChunkStrategy:
+    """校验切片参数，返回解析后的策略；未知策略、非正的长度或重叠不小于长度时抛出 ValueError。"""
+    try:
+        strategy = ChunkStrategy(strategy)
+    except ValueError:
+        raise ValueError(f"unknown chunk strategy {strategy!r}") from None
+    if chunk_size <= 0:
+        raise ValueError("chunk_size must be positive")
+    if chunk_overlap < 0:
+        raise ValueError("chunk_overlap must not be negative")
+    if chunk_overlap >= chunk_size:
+        raise ValueError("chunk_overlap must be smaller than chunk_size")
+    return strategy
+
+
+def split_text(
+    text: str,
+    *,
+    strategy: ChunkStrategy,
+    chunk_size: int,
+    chunk_overlap: int,
+) -> List[Tuple[str, Dict[str, Any]]]:
+    """返回切片文本及该切片额外的元数据。
+
+    fixed_tokens 的 chunk_size 与 chunk_overlap 以 token 计，其余策略以字符计。
+    参数不合法时抛出 ValueError，见 validate_chunking。
+    """
+    strategy = validate_chunking(strategy, chunk_size, chunk_overlap)
+    if strategy == ChunkStrategy.fixed_tokens:
+        splitter = TokenTextSplitter(
+            encoding_name=TOKEN_ENCODING,
+            chunk_size=chunk_size,
+            chunk_overlap=chunk_overlap,
+        )
+        return [(chunk, {}) for chunk in splitter.split_text(text)]
+
+    if strategy == ChunkStrategy.sentence:
+        splitter = RecursiveCharacterTextSplitter(
+            chunk_size=chunk_size,
+            chunk_overlap=chunk_overlap,
+            separators=_SENTENCE_SEPARATORS,
+            keep_separator="end",
+        )
+        return [(chunk, {}) for chunk in splitter.split_text(text)]
+
+    splitter = RecursiveCharacterTextSplitter(
+        chunk_size=chunk_size,
+        chunk_overlap=chunk_overlap,
+    )
+    if strategy != ChunkStrategy.markdown:
+        return [(chunk, {}) for chunk in splitter.split_text(text)]
+
+    # 先按标题拆成章节并保留标题行，过长的章节再递归拆分；切片记录所在章节的标题路径
+    sections = MarkdownHeaderTextSplitter(
+        headers_to_split_on=_MARKDOWN_HEADERS,
+        strip_headers=False,
+    ).split_text(text)
+    chunks: List[Tuple[str, Dict[str, Any]]] = []
+    for section in sections:
+        headings = " > ".join(
+            section.metadata[key] for _, key in _MARKDOWN_HEADERS if key in section.metadata
+        )
+        extra = {"headings": headings} if headings else {}
+        for chunk in splitter.split_text(section.page_content):
+            chunks.append((chunk, dict(extra)))
+    return chunks
@@ -7,17 +7,21 @@ from typing import Any, Dict, List, Optional
 from fastapi import HTTPException
 from langchain_core.documents import Document
 from langchain_core.embeddings import Embeddings
-from langchain_text_splitters import RecursiveCharacterTextSplitter
 from minio import Minio
 from minio.error import S3Error
 
 from app.db.vector_store.async_pg_vector import AsyncPgVector
 from app.models.document import (
+    ChunkStrategy,
     CleanupMethod,
     DocumentResponse,
     QueryMultipleBody,
     QueryRequestBody,
+    RetrievalMode,
+    RetrievalOptions,
 )
+from app.services.chunking import split_text, validate_chunking
+from app.services.keyword_search import KeywordIndex, fuse_results
 
 
 _vector_store: AsyncPgVector | None = None
@@ -25,6 +29,8 @@ _embeddings: Embeddings | None = None
 _minio_client: Minio | None = None
 _chunk_size: int = 1500
 _chunk_overlap: int = 100
+_keyword_index = KeywordIndex()
+_hybrid_candidate_factor: int = 4
 _logger: logging.Logger = logging.getLogger("app.services.document")
 
 
@@ -36,6 +42,9 @@ def configure_document_service(
     chunk_size: int,
     chunk_overlap: int,
     logger: logging.Logger,
+    keyword_index_ttl: float = 300,
+    keyword_index_max_files: int = 1024,
+    hybrid_candidate_factor: int = 4,
 ) -> None:
     """在应用启动阶段注入文档服务所需的运行时依赖。
 
@@ -43,12 +52,15 @@ def configure_document_service(
     便于后续的业务函数直接复用这些对象而无需重复传参。
     """
     global _vector_store, _embeddings, _minio_client, _chunk_size, _chunk_overlap, _logger
+    global _hybrid_candidate_factor
     _vector_store = vector_store
     _embeddings = embeddings
     _minio_client = minio_client
     _chunk_size = chunk_size
     _chunk_overlap = chunk_overlap
     _logger = logger
+    _keyword_index.configure(ttl_seconds=keyword_index_ttl, max_files=keyword_index_max_files)
+    _hybrid_candidate_factor = max(hybrid_candidate_factor, 1)
 
 
 def _require_vector_store() -> AsyncPgVector:
@@ -128,6 +140,9 @@ def _build_metadata(
     vector_id: str,
     size_bytes: int,
     last_modified: Optional[str],
+    chunk_strategy: ChunkStrategy,
+    chunk_size: int,
+    chunk_overlap: int,
 ) -> Dict[str, Any]:
     """构建写入向量存储的元数据，用于描述每个文本分片。"""
     return {
@@ -143,10 +158,19 @@ def _build_metadata(
         "vector_id": vector_id,
         "size_bytes": size_bytes,
         "last_modified": last_modified,
+        "chunk_strategy": chunk_strategy.value,
+        "chunk_size": chunk_size,
+        "chunk_overlap": chunk_overlap,
         "source": f"minio://{bucket_name}/{object_path}",
     }
 
 
+async def _invalidate_keyword_index(store: AsyncPgVector, file_ids: List[str], *, executor=None) -> None:
+    """切片变化后递增文件版本号并丢弃本副本的缓存，其他副本在下次检索时按版本号重建。"""
+    await store.abump_keyword_generations(file_ids, executor=executor)
+    _keyword_index.invalidate(file_ids)
+
+
 async def embed_file(
     *,
     file_id: str,
@@ -157,10 +181,24 @@ async def embed_file(
     entity_id: Optional[str],
     user_id: str,
     cleanup_method: CleanupMethod,
+    chunk_strategy: ChunkStrategy = ChunkStrategy.recursive,
+    chunk_size: Optional[int] = None,
+    chunk_overlap: Optional[int] = None,
     executor=None,
 ) -> Dict[str, Any]:
-    """从 Minio 拉取文件、完成文本切片并写入向量库。"""
+    """从 Minio 拉取文件、按切片策略完成文本切片并写入向量库。
+
+    未指定 chunk_size 时切片长度与重叠长度都使用服务配置的默认值。
+    """
     store = _require_vector_store()
+    if chunk_size is None:
+        chunk_size, chunk_overlap = _chunk_size, _chunk_overlap
+    elif chunk_overlap is None:
+        chunk_overlap = 0
+    try:
+        validate_chunking(chunk_strategy, chunk_size, chunk_overlap)
+    except ValueError as exc:
+        raise HTTPException(status_code=400, detail=str(exc)) from exc
 
     object_info = await _stat_object(bucket_name, object_path)
     raw_bytes = await _download_object(bucket_name, object_path)
@@ -176,11 +214,15 @@ async def embed_file(
             "bucket": bucket_name,
             "object_path": object_path,
             "cleanup_method": cleanup_method.value,
+            "chunk_strategy": chunk_strategy.value,
+            "chunk_size": chunk_size,
+            "chunk_overlap": chunk_overlap,
         },
     )
 
     if cleanup_method == CleanupMethod.full:
         deleted = await store.adelete_by_file_ids([file_id], executor=executor)
+        await _invalidate_keyword_index(store, [file_id], executor=executor)
         _logger.debug(
             "Removed previous vectors",
             extra={"file_id": file_id, "deleted": deleted},
@@ -193,11 +235,12 @@ async def embed_file(
         )
         known_digests = set(existing.values())
 
-    splitter = RecursiveCharacterTextSplitter(
-        chunk_size=_chunk_size,
-        chunk_overlap=_chunk_overlap,
+    chunks = split_text(
+        text,
+        strategy=chunk_strategy,
+        chunk_size=chunk_size,
+        chunk_overlap=chunk_overlap,
     )
-    chunks = splitter.split_text(text)
     if not chunks:
         raise HTTPException(status_code=400, detail="No chunks generated from object")
 
@@ -205,7 +248,7 @@ async def embed_file(
     doc_ids: List[str] = []
     skipped = 0
 
-    for index, chunk in enumerate(chunks):
+    for index, (chunk, chunk_metadata) in enumerate(chunks):
         normalized = chunk.strip()
         if not normalized:
             continue
@@ -230,7 +273,11 @@ async def embed_file(
             last_modified=object_info.last_modified.isoformat()
             if object_info.last_modified
             else None,
+            chunk_strategy=chunk_strategy,
+            chunk_size=chunk_size,
+            chunk_overlap=chunk_overlap,
         )
+        metadata.update(chunk_metadata)
         documents.append(Document(page_content=normalized, metadata=metadata))
         doc_ids.append(vector_id)
 
@@ -247,6 +294,7 @@ async def embed_file(
         ids=doc_ids,
         executor=executor,
     )
+    await _invalidate_keyword_index(store, [file_id], executor=executor)
 
     return {
         "file_id": file_id,
@@ -291,6 +339,7 @@ async def delete_documents(
     # 修改：直接根据 file_id 删除，而不是根据 vector_id 删除
     # 假设 store 实现了 adelete_by_file_ids 方法（在 embed_file 中有用到）
     deleted_count = await store.adelete_by_file_ids(ids, executor=executor)
+    await _invalidate_keyword_index(store, ids, executor=executor)
     
     return {"deleted_count": deleted_count}
 
@@ -330,35 +379,100 @@ def _build_filter(file_id: Optional[str], entity_id: Optional[str]) -> Optional[
     return payload or None
 
 
-async def query_documents(
+async def _retrieve(
     *,
-    body: QueryRequestBody,
+    query: str,
+    options: RetrievalOptions,
+    top_k: int,
+    file_ids: List[str],
+    vector_filter: Optional[Dict[str, Any]],
+    entity_id: Optional[str] = None,
     executor=None,
-) -> Dict[str, Any]:
-    """对查询语句进行向量化，并在指定文件范围内返回最相似的结果。"""
+) -> List[Dict[str, Any]]:
+    """按检索方式执行向量检索、BM25 关键词检索或两者的倒数排名融合。
+
+    单路检索时 score 为该路原始得分（向量为距离，越小越相似；BM25 越大越相关），
+    混合检索时 score 为融合得分，越大越相关。metadata 中附带各路名次与得分。
+    """
     store = _require_vector_store()
-    embeddings = _require_embeddings()
-    if not body.query.strip():
-        raise HTTPException(status_code=400, detail="Query cannot be empty")
+    mode = options.mode
+    if mode == RetrievalMode.hybrid and options.vector_weight == 0 and options.keyword_weight == 0:
+        raise HTTPException(status_code=400, detail="vector_weight and keyword_weight cannot both be 0")
+
+    # 混合检索时两路各取更多候选再融合，避免只在一路中排名靠后的切片被截断
+    candidates = top_k * _hybrid_candidate_factor if mode == RetrievalMode.hybrid else top_k
+
+    vector_hits = []
+    if mode != RetrievalMode.keyword:
+        embeddings = _require_embeddings()
+        embedding_vector = await embeddings.aembed_query(query)
+        vector_hits = await store.asimilarity_search_with_score_by_vector(
+            embedding_vector,
+            k=candidates,
+            filter=vector_filter,
+            executor=executor,
+        )
 
-    embedding_vector = await embeddings.aembed_query(body.query)
-    results = await store.asimilarity_search_with_score_by_vector(
-        embedding_vector,
-        k=body.top_k,
-        filter=_build_filter(body.file_id, body.entity_id),
-        executor=executor,
-    )
+    keyword_hits = []
+    if mode != RetrievalMode.vector:
+        keyword_hits = await _keyword_index.search(
+            query,
+            file_ids=file_ids,
+            k=candidates,
+            loader=lambda file_id: store.aget_chunks_by_file_id(file_id, executor=executor),
+            generations=lambda ids: store.aget_keyword_generations(ids, executor=executor),
+            entity_id=entity_id,
+        )
 
-    return {
-        "results": [
+    if mode == RetrievalMode.hybrid:
+        results = fuse_results(
+            vector_hits,
+            keyword_hits,
+            vector_weight=options.vector_weight,
+            keyword_weight=options.keyword_weight,
+            rrf_k=options.rrf_k,
+            top_k=top_k,
+        )
+    else:
+        prefix = "vector" if mode == RetrievalMode.vector else "keyword"
+        hits = vector_hits if mode == RetrievalMode.vector else keyword_hits
+        results = [
             {
                 "page_content": document.page_content,
-                "metadata": document.metadata,
+                "metadata": {
+                    **(document.metadata or {}),
+                    f"{prefix}_rank": rank,
+                    f"{prefix}_score": score,
+                },
                 "score": score,
             }
-            for document, score in results
+            for rank, (document, score) in enumerate(hits, start=1)
         ]
-    }
+
+    for result in results:
+        result["metadata"]["retrieval_mode"] = mode.value
+    return results
+
+
+async def query_documents(
+    *,
+    body: QueryRequestBody,
+    executor=None,
+) -> Dict[str, Any]:
+    """在指定文件范围内按请求的检索方式返回最相关的结果。"""
+    if not body.query.strip():
+        raise HTTPException(status_code=400, detail="Query cannot be empty")
+
+    results = await _retrieve(
+        query=body.query,
+        options=body,
+        top_k=body.top_k,
+        file_ids=[body.file_id],
+        vector_filter=_build_filter(body.file_id, body.entity_id),
+        entity_id=body.entity_id,
+        executor=executor,
+    )
+    return {"results": results}
 
 
 async def query_multiple_documents(
@@ -366,29 +480,18 @@ async def query_multiple_documents(
     body: QueryMultipleBody,
     executor=None,
 ) -> Dict[str, Any]:
-    """向量化查询语句，并在多个文件范围内检索最相似内容。"""
-    store = _require_vector_store()
-    embeddings = _require_embeddings()
+    """在多个文件范围内按请求的检索方式返回最相关的结果。"""
     if not body.query.strip():
         raise HTTPException(status_code=400, detail="Query cannot be empty")
     if not body.file_ids:
         raise HTTPException(status_code=400, detail="file_ids cannot be empty")
 
-    embedding_vector = await embeddings.aembed_query(body.query)
-    results = await store.asimilarity_search_with_score_by_vector(
-        embedding_vector,
-        k=body.top_k,
-        filter={"file_id": {"$in": body.file_ids}},
+    results = await _retrieve(
+        query=body.query,
+        options=body,
+        top_k=body.top_k,
+        file_ids=body.file_ids,
+        vector_filter={"file_id": {"$in": body.file_ids}},
         executor=executor,
     )
-
-    return {
-        "results": [
-            {
-                "page_content": document.page_content,
-                "metadata": document.metadata,
-                "score": score,
-            }
-            for document, score in results
-        ]
-    }
+    return {"results": results}
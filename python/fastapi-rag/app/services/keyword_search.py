@@ -0,0 +1,264 @@
+"""基于 BM25 的关键词检索，以及与向量检索结果的倒数排名融合（RRF）。
+
+关键词索引按文件缓存在进程内存中：首次检索某个文件时从 pgvector 表读取该文件的全部切片并分词。
+重新向量化或删除文件时会在 PostgreSQL 中递增该文件的版本号（generation）。
+每次检索前先读取版本号，与缓存构建时的版本号不一致就重建，
+因此任一副本写入的变更都会被所有副本感知；TTL 仅作为兜底。
+"""
+
+import asyncio
+import math
+import re
+import time
+from collections import Counter
+from dataclasses import dataclass, field
+from typing import Any, Awaitable, Callable, Dict, Iterable, List, Optional, Tuple
+
+from langchain_core.documents import Document
+
+
+# BM25 参数
+BM25_K1 = 1.5
+BM25_B = 0.75
+
+# 英文单词、数字以及由 . _ - / : 连接的产品编码、版本号、错误码等
+_ASCII_TOKEN = re.compile(r"[a-z0-9]+(?:[._\-/:][a-z0-9]+)*")
+_ASCII_PART = re.compile(r"[a-z0-9]+")
+# 中日韩统一表意文字
+_CJK_RUN = re.compile(r"[\u3400-\u4dbf\u4e00-\u9fff\uf900-\ufaff]+")
+
+
+def tokenize(text: str) -> List[str]:
+    """把文本切分为关键词检索使用的词项。
+
+    英文与编码整体保留并额外拆出各组成部分，保证 "ERR-1024" 既能整体命中也能按 "1024" 命中；
+    中文没有空格分词，按相邻两字切分（单字片段保留单字）。
+    """
+    if not text:
+        return []
+
+    lowered = text.lower()
+    tokens: List[str] = []
+    for match in _ASCII_TOKEN.finditer(lowered):
+        token = match.group()
+        tokens.append(token)
+        parts = _ASCII_PART.findall(token)
+        if len(parts) > 1:
+            tokens.extend(parts)
+
+    for match in _CJK_RUN.finditer(lowered):
+        run = match.group()
+        if len(run) == 1:
+            tokens.append(run)
+            continue
+        tokens.extend(run[i : i + 2] for i in range(len(run) - 1))
+
+    return tokens
+
+
+@dataclass
+class _Entry:
+    """索引中的单个切片。"""
+    custom_id: str
+    page_content: str
+    metadata: Dict[str, Any]
+    term_freqs: Counter
+    length: int
+
+
+@dataclass
+class _FileIndex:
+    """单个文件的词频统计。"""
+    entries: List[_Entry]
+    doc_freqs: Counter = field(default_factory=Counter)
+    total_length: int = 0
+    built_at: float = 0.0
+    generation: int = 0
+
+    @classmethod
+    def build(cls, chunks: Iterable[Dict[str, Any]], generation: int = 0) -> "_FileIndex":
+        """从切片记录构建文件索引，generation 为读取切片前的文件版本号。"""
+        index = cls(entries=[], built_at=time.monotonic(), generation=generation)
+        for chunk in chunks:
+            terms = Counter(tokenize(chunk.get("page_content") or ""))
+            length = sum(terms.values())
+            index.entries.append(
+                _Entry(
+                    custom_id=chunk["custom_id"],
+                    page_content=chunk.get("page_content") or "",
+                    metadata=chunk.get("metadata") or {},
+                    term_freqs=terms,
+                    length=length,
+                )
+            )
+            index.doc_freqs.update(terms.keys())
+            index.total_length += length
+        return index
+
+
+ChunkLoader = Callable[[str], Awaitable[List[Dict[str, Any]]]]
+# 批量读取文件版本号，未记录的文件视为版本 0
+GenerationLoader = Callable[[List[str]], Awaitable[Dict[str, int]]]
+
+
+class KeywordIndex:
+    """按文件缓存的 BM25 索引，检索时把多个文件的统计量合并为一个语料。"""
+
+    def __init__(self, ttl_seconds: float = 300, max_files: int = 1024):
+        self._ttl = ttl_seconds
+        self._max_files = max_files
+        self._files: Dict[str, _FileIndex] = {}
+        self._locks: Dict[str, asyncio.Lock] = {}
+
+    def configure(self, *, ttl_seconds: float, max_files: int) -> None:
+        """更新缓存有效期与容量。"""
+        self._ttl = ttl_seconds
+        self._max_files = max_files
+
+    def invalidate(self, file_ids: Iterable[str]) -> None:
+        """切片变化后立即丢弃本副本中对应文件的缓存索引，其他副本依赖版本号重建。"""
+        for file_id in file_ids:
+            self._files.pop(file_id, None)
+
+    def _is_fresh(self, cached: Optional[_FileIndex], generation: int) -> bool:
+        """缓存版本号与当前版本号一致且未超过 TTL 时可直接复用。"""
+        return (
+            cached is not None
+            and cached.generation == generation
+            and time.monotonic() - cached.built_at < self._ttl
+        )
+
+    async def _get_file_index(self, file_id: str, loader: ChunkLoader, generation: int) -> _FileIndex:
+        """返回文件索引，缓存缺失、版本号变化或过期时重新加载。"""
+        cached = self._files.get(file_id)
+        if self._is_fresh(cached, generation):
+            return cached
+
+        lock = self._locks.setdefault(file_id, asyncio.Lock())
+        async with lock:
+            cached = self._files.get(file_id)
+            if self._is_fresh(cached, generation):
+                return cached
+            index = _FileIndex.build(await loader(file_id), generation)
+            if len(self._files) >= self._max_files:
+                # 淘汰最早构建的文件索引
+                oldest = min(self._files, key=lambda key: self._files[key].built_at)
+                self._files.pop(oldest, None)
+            self._files[file_id] = index
+            return index
+
+    async def search(
+        self,
+        query: str,
+        *,
+        file_ids: List[str],
+        k: int,
+        loader: ChunkLoader,
+        generations: GenerationLoader,
+        entity_id: Optional[str] = None,
+    ) -> List[Tuple[Document, float]]:
+        """在给定文件范围内按 BM25 得分返回前 k 个切片，没有任何词项命中的切片不返回。
+
+        版本号必须先于切片读取：并发写入时最多缓存旧版本号下的新切片，下次检索仍会重建。
+        """
+        query_terms = set(tokenize(query))
+        if not query_terms or k <= 0:
+            return []
+
+        unique_ids = list(dict.fromkeys(file_ids))
+        current = await generations(unique_ids) if unique_ids else {}
+        indexes = [
+            await self._get_file_index(file_id, loader, current.get(file_id, 0))
+            for file_id in unique_ids
+        ]
+        entries = [
+            entry
+            for index in indexes
+            for entry in index.entries
+            if not entity_id or entry.metadata.get("entity_id") == entity_id
+        ]
+        if not entries:
+            return []
+
+        doc_count = sum(len(index.entries) for index in indexes)
+        avg_length = sum(index.total_length for index in indexes) / max(doc_count, 1) or 1.0
+        idf: Dict[str, float] = {}
+        for term in query_terms:
+            df = sum(index.doc_freqs.get(term, 0) for index in indexes)
+            if df:
+                idf[term] = math.log(1 + (doc_count - df + 0.5) / (df + 0.5))
+        if not idf:
+            return []
+
+        scored: List[Tuple[float, _Entry]] = []
+        for entry in entries:
+            score = 0.0
+            norm = BM25_K1 * (1 - BM25_B + BM25_B * entry.length / avg_length)
+            for term, weight in idf.items():
+                tf = entry.term_freqs.get(term, 0)
+                if tf:
+                    score += weight * tf * (BM25_K1 + 1) / (tf + norm)
+            if score > 0:
+                scored.append((score, entry))
+
+        scored.sort(key=lambda item: item[0], reverse=True)
+        return [
+            (
+                Document(page_content=entry.page_content, metadata=entry.metadata),
+                score,
+            )
+            for score, entry in scored[:k]
+        ]
+
+
+def _result_key(document: Document) -> str:
+    """返回用于合并两路结果的切片标识。"""
+    vector_id = document.metadata.get("vector_id") if document.metadata else None
+    return vector_id or document.page_content
+
+
+def fuse_results(
+    vector_hits: List[Tuple[Document, float]],
+    keyword_hits: List[Tuple[Document, float]],
+    *,
+    vector_weight: float,
+    keyword_weight: float,
+    rrf_k: int,
+    top_k: int,
+) -> List[Dict[str, Any]]:
+    """按加权倒数排名融合两路结果。
+
+    fused_score = vector_weight / (rrf_k + vector_rank) + keyword_weight / (rrf_k + keyword_rank)，
+    只在一路中出现的切片只累加该路得分。每条结果的 metadata 中附带两路的名次、原始得分与融合得分。
+    """
+    merged: Dict[str, Dict[str, Any]] = {}
+
+    def _collect(hits: List[Tuple[Document, float]], prefix: str, weight: float) -> None:
+        for rank, (document, score) in enumerate(hits, start=1):
+            key = _result_key(document)
+            item = merged.get(key)
+            if item is None:
+                item = {"document": document, "fused": 0.0, "breakdown": {}}
+                merged[key] = item
+            item["fused"] += weight / (rrf_k + rank)
+            item["breakdown"][f"{prefix}_rank"] = rank
+            item["breakdown"][f"{prefix}_score"] = score
+
+    _collect(vector_hits, "vector", vector_weight)
+    _collect(keyword_hits, "keyword", keyword_weight)
+
+    ranked = sorted(merged.values(), key=lambda item: item["fused"], reverse=True)
+    results: List[Dict[str, Any]] = []
+    for item in ranked[:top_k]:
+        document: Document = item["document"]
+        metadata = dict(document.metadata or {})
+        metadata.update(item["breakdown"])
+        metadata["fused_score"] = item["fused"]
+        results.append(
+            {
+                "page_content": document.page_content,
+                "metadata": metadata,
+                "score": item["fused"],
+            }
+        )
+    return results
@@ -0,0 +1,90 @@
+"""切片策略与切片参数校验的测试，在 python/fastapi-rag 下执行 python -m unittest discover tests。"""
+
+import unittest
+
+from app.models.document import ChunkStrategy
+from app.services.chunking import split_text, validate_chunking
+
+
+def _token_encoding_available() -> bool:
+    """fixed_tokens 需要 tiktoken 的编码文件，离线且没有缓存时跳过。"""
+    try:
+        import tiktoken
+
+        tiktoken.get_encoding("cl100k_base")
+    except Exception:
+        return False
+    return True
+
+
+class ValidateChunkingTest(unittest.TestCase):
+    def test_valid(self):
+        self.assertEqual(validate_chunking("markdown", 500, 50), ChunkStrategy.markdown)
+        self.assertEqual(validate_chunking(ChunkStrategy.recursive, 1, 0), ChunkStrategy.recursive)
+
+    def test_invalid(self):
+        cases = [
+            ("unknown", 500, 50, "unknown chunk strategy"),
+            ("", 500, 50, "unknown chunk strategy"),
+            ("recursive", 0, 0, "chunk_size"),
+            ("recursive", -1, 0, "chunk_size"),
+            ("recursive", 100, -1, "chunk_overlap"),
+            ("recursive", 100, 100, "smaller than chunk_size"),
+            ("sentence", 100, 150, "smaller than chunk_size"),
+        ]
+        for strategy, size, overlap, message in cases:
+            with self.subTest(strategy=strategy, size=size, overlap=overlap):
+                with self.assertRaisesRegex(ValueError, message):
+                    validate_chunking(strategy, size, overlap)
+                with self.assertRaisesRegex(ValueError, message):
+                    split_text("text", strategy=strategy, chunk_size=size, chunk_overlap=overlap)
+
+
+class SplitTextTest(unittest.TestCase):
+    def test_recursive(self):
+        text = "\n\n".join(["段落" + str(i) + "。" + "内容" * 20 for i in range(5)])
+        chunks = split_text(text, strategy=ChunkStrategy.recursive, chunk_size=60, chunk_overlap=10)
+        self.assertGreater(len(chunks), 1)
+        for chunk, extra in chunks:
+            self.assertLessEqual(len(chunk), 60)
+            self.assertEqual(extra, {})
+
+    def test_sentence_breaks_at_sentence_end(self):
+        text = "第一句话比较长一些。第二句话也不短！第三句话结束了？Fourth sentence here. Fifth one."
+        chunks = split_text(text, strategy=ChunkStrategy.sentence, chunk_size=20, chunk_overlap=0)
+        self.assertGreater(len(chunks), 1)
+        self.assertTrue(chunks[0][0].endswith("。"), chunks)
+        for chunk, _ in chunks:
+            self.assertLessEqual(len(chunk), 20)
+        self.assertEqual("".join(chunk for chunk, _ in chunks).replace(" ", ""), text.replace(" ", ""))
+
+    def test_markdown_records_headings(self):
+        text = "# 指南\n\n简介。\n\n## 安装\n\n执行安装命令。\n\n### 依赖\n\n需要 Go。\n\n## 使用\n\n启动服务。"
+        chunks = split_text(text, strategy=ChunkStrategy.markdown, chunk_size=200, chunk_overlap=0)
+        headings = [extra.get("headings") for _, extra in chunks]
+        self.assertEqual(headings, ["指南", "指南 > 安装", "指南 > 安装 > 依赖", "指南 > 使用"])
+        # 保留标题行
+        self.assertTrue(chunks[1][0].startswith("## 安装"), chunks[1][0])
+
+    def test_markdown_splits_long_sections(self):
+        text = "# 长章节\n\n" + "内容很长。" * 100
+        chunks = split_text(text, strategy=ChunkStrategy.markdown, chunk_size=100, chunk_overlap=10)
+        self.assertGreater(len(chunks), 1)
+        for chunk, extra in chunks:
+            self.assertLessEqual(len(chunk), 100)
+            self.assertEqual(extra, {"headings": "长章节"})
+
+    @unittest.skipUnless(_token_encoding_available(), "tiktoken encoding cl100k_base unavailable")
+    def test_fixed_tokens(self):
+        import tiktoken
+
+        encoding = tiktoken.get_encoding("cl100k_base")
+        text = " ".join(f"word{i}" for i in range(200))
+        chunks = split_text(text, strategy=ChunkStrategy.fixed_tokens, chunk_size=50, chunk_overlap=5)
+        self.assertGreater(len(chunks), 1)
+        for chunk, _ in chunks:
+            self.assertLessEqual(len(encoding.encode(chunk)), 50)
+
+
+if __name__ == "__main__":
+    unittest.main()
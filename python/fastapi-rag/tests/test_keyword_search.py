@@ -0,0 +1,216 @@
+"""关键词分词、BM25 索引与倒数排名融合的测试，在 python/fastapi-rag 下执行 python -m unittest discover tests。"""
+
+import asyncio
+import unittest
+
+from langchain_core.documents import Document
+
+from app.services.keyword_search import KeywordIndex, fuse_results, tokenize
+
+
+def _doc(vector_id: str, content: str = "") -> Document:
+    return Document(page_content=content or vector_id, metadata={"vector_id": vector_id})
+
+
+class TokenizeTest(unittest.TestCase):
+    def test_ascii(self):
+        self.assertEqual(tokenize("Hello World 42"), ["hello", "world", "42"])
+
+    def test_codes_keep_whole_token_and_parts(self):
+        self.assertEqual(tokenize("ERR-1024"), ["err-1024", "err", "1024"])
+        self.assertEqual(tokenize("v1.2.3"), ["v1.2.3", "v1", "2", "3"])
+        self.assertEqual(tokenize("a/b:c_d"), ["a/b:c_d", "a", "b", "c", "d"])
+
+    def test_cjk_bigrams(self):
+        self.assertEqual(tokenize("向量检索"), ["向量", "量检", "检索"])
+
+    def test_single_cjk_character(self):
+        self.assertEqual(tokenize("是 ok"), ["ok", "是"])
+
+    def test_mixed(self):
+        self.assertEqual(tokenize("错误码 E42 重试"), ["e42", "错误", "误码", "重试"])
+
+    def test_empty(self):
+        self.assertEqual(tokenize(""), [])
+        self.assertEqual(tokenize("  ，。!"), [])
+
+
+class FuseResultsTest(unittest.TestCase):
+    def test_weighted_rrf(self):
+        vector_hits = [(_doc("a"), 0.1), (_doc("b"), 0.2)]
+        keyword_hits = [(_doc("b"), 5.0), (_doc("c"), 3.0)]
+
+        results = fuse_results(
+            vector_hits,
+            keyword_hits,
+            vector_weight=1.0,
+            keyword_weight=2.0,
+            rrf_k=60,
+            top_k=10,
+        )
+
+        self.assertEqual([item["page_content"] for item in results], ["b", "c", "a"])
+        scores = {item["page_content"]: item["score"] for item in results}
+        self.assertAlmostEqual(scores["b"], 1.0 / 62 + 2.0 / 61)
+        self.assertAlmostEqual(scores["c"], 2.0 / 62)
+        self.assertAlmostEqual(scores["a"], 1.0 / 61)
+
+        b = results[0]["metadata"]
+        self.assertEqual(b["vector_rank"], 2)
+        self.assertEqual(b["vector_score"], 0.2)
+        self.assertEqual(b["keyword_rank"], 1)
+        self.assertEqual(b["keyword_score"], 5.0)
+        self.assertAlmostEqual(b["fused_score"], scores["b"])
+        self.assertEqual(b["vector_id"], "b")
+
+        a = results[2]["metadata"]
+        self.assertNotIn("keyword_rank", a)
+        self.assertNotIn("keyword_score", a)
+
+    def test_zero_weight_keeps_ranking_of_other_side(self):
+        results = fuse_results(
+            [(_doc("a"), 0.1), (_doc("b"), 0.2)],
+            [(_doc("b"), 5.0)],
+            vector_weight=1.0,
+            keyword_weight=0.0,
+            rrf_k=60,
+            top_k=10,
+        )
+        self.assertEqual([item["page_content"] for item in results], ["a", "b"])
+        self.assertEqual(results[1]["metadata"]["keyword_rank"], 1)
+
+    def test_top_k(self):
+        hits = [(_doc(name), 0.0) for name in ("a", "b", "c")]
+        results = fuse_results(hits, [], vector_weight=1.0, keyword_weight=1.0, rrf_k=60, top_k=2)
+        self.assertEqual([item["page_content"] for item in results], ["a", "b"])
+
+    def test_falls_back_to_content_without_vector_id(self):
+        results = fuse_results(
+            [(Document(page_content="same"), 0.1)],
+            [(Document(page_content="same"), 1.0)],
+            vector_weight=1.0,
+            keyword_weight=1.0,
+            rrf_k=1,
+            top_k=10,
+        )
+        self.assertEqual(len(results), 1)
+        self.assertAlmostEqual(results[0]["score"], 1.0)
+
+    def test_does_not_mutate_input_metadata(self):
+        document = _doc("a")
+        fuse_results([(document, 0.1)], [], vector_weight=1.0, keyword_weight=1.0, rrf_k=60, top_k=1)
+        self.assertEqual(document.metadata, {"vector_id": "a"})
+
+
+class _FakeStore:
+    """模拟 pgvector 中的切片与版本号表，记录切片加载次数。"""
+
+    def __init__(self):
+        self.chunks = {}
+        self.generations = {}
+        self.loads = []
+
+    def write(self, file_id, contents):
+        self.chunks[file_id] = [
+            {"custom_id": f"{file_id}:{i}", "page_content": content, "metadata": {"file_id": file_id}}
+            for i, content in enumerate(contents)
+        ]
+        self.generations[file_id] = self.generations.get(file_id, 0) + 1
+
+    async def load(self, file_id):
+        self.loads.append(file_id)
+        return list(self.chunks.get(file_id, []))
+
+    async def get_generations(self, file_ids):
+        return {file_id: self.generations[file_id] for file_id in file_ids if file_id in self.generations}
+
+
+class KeywordIndexTest(unittest.TestCase):
+    def _search(self, index, store, query, file_ids, **kwargs):
+        return asyncio.run(
+            index.search(
+                query,
+                file_ids=file_ids,
+                k=kwargs.pop("k", 10),
+                loader=store.load,
+                generations=store.get_generations,
+                **kwargs,
+            )
+        )
+
+    def test_ranks_matching_chunks(self):
+        store = _FakeStore()
+        store.write("f1", ["error ERR-1024 occurred", "nothing relevant here", "ERR-1024 ERR-1024 retry"])
+        index = KeywordIndex()
+
+        hits = self._search(index, store, "ERR-1024", ["f1"])
+
+        self.assertEqual([doc.page_content for doc, _ in hits], ["ERR-1024 ERR-1024 retry", "error ERR-1024 occurred"])
+        self.assertGreater(hits[0][1], hits[1][1])
+
+    def test_reuses_index_while_generation_unchanged(self):
+        store = _FakeStore()
+        store.write("f1", ["alpha"])
+        index = KeywordIndex()
+
+        self._search(index, store, "alpha", ["f1"])
+        self._search(index, store, "alpha", ["f1", "f1"])
+
+        self.assertEqual(store.loads, ["f1"])
+
+    def test_rebuilds_when_another_replica_bumps_generation(self):
+        store = _FakeStore()
+        store.write("f1", ["alpha"])
+        replica_a, replica_b = KeywordIndex(), KeywordIndex()
+
+        self.assertEqual(len(self._search(replica_b, store, "alpha", ["f1"])), 1)
+
+        # 副本 A 重新向量化文件：切片变化并递增版本号，副本 B 的缓存未被主动失效
+        store.write("f1", ["beta"])
+        replica_a.invalidate(["f1"])
+
+        self.assertEqual(self._search(replica_b, store, "alpha", ["f1"]), [])
+        self.assertEqual([doc.page_content for doc, _ in self._search(replica_b, store, "beta", ["f1"])], ["beta"])
+        self.assertEqual(store.loads, ["f1", "f1"])
+
+    def test_rebuilds_after_ttl(self):
+        store = _FakeStore()
+        store.write("f1", ["alpha"])
+        index = KeywordIndex(ttl_seconds=0)
+
+        self._search(index, store, "alpha", ["f1"])
+        self._search(index, store, "alpha", ["f1"])
+
+        self.assertEqual(store.loads, ["f1", "f1"])
+
+    def test_unrecorded_file_uses_generation_zero(self):
+        store = _FakeStore()
+        store.chunks["f1"] = [{"custom_id": "f1:0", "page_content": "alpha", "metadata": {}}]
+        index = KeywordIndex()
+
+        self.assertEqual(len(self._search(index, store, "alpha", ["f1"])), 1)
+        self.assertEqual(len(self._search(index, store, "alpha", ["f1"])), 1)
+        self.assertEqual(store.loads, ["f1"])
+
+    def test_entity_filter_and_eviction(self):
+        store = _FakeStore()
+        store.write("f1", ["alpha"])
+        store.write("f2", ["alpha"])
+        store.chunks["f2"][0]["metadata"]["entity_id"] = "e1"
+        index = KeywordIndex(max_files=1)
+
+        hits = self._search(index, store, "alpha", ["f1", "f2"], entity_id="e1")
+
+        self.assertEqual([doc.metadata["file_id"] for doc, _ in hits], ["f2"])
+        self.assertEqual(len(index._files), 1)
+
+    def test_empty_query(self):
+        store = _FakeStore()
+        store.write("f1", ["alpha"])
+        self.assertEqual(self._search(KeywordIndex(), store, "  ", ["f1"]), [])
+        self.assertEqual(self._search(KeywordIndex(), store, "alpha", ["f1"], k=0), [])
+        self.assertEqual(store.loads, [])
+
+
+if __name__ == "__main__":
+    unittest.main()